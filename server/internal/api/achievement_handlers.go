@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// handleListSeasonAchievements returns the hole-in-ones, eagles and other achievements recorded
+// in a season, most recent first
+func (s *APIServer) handleListSeasonAchievements(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	achievements, err := s.firestoreClient.ListSeasonAchievements(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list achievements: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(achievements)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
+)
+
+// handleIntegrityCheck reports orphaned records in a league (league admin only).
+// It only reads data; nothing is repaired.
+func (s *APIServer) handleIntegrityCheck(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.URL.Query().Get("leagueId")
+	if leagueID == "" {
+		http.Error(w, "leagueId is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	var data services.IntegrityData
+	var err error
+	if data.Matches, err = s.firestoreClient.ListMatches(ctx, leagueID, ""); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if data.Courses, err = s.firestoreClient.ListCourses(ctx, leagueID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if data.Players, err = s.firestoreClient.ListPlayers(ctx, false); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if data.Seasons, err = s.firestoreClient.ListSeasons(ctx, leagueID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if data.SeasonPlayers, err = s.firestoreClient.ListLeagueSeasonPlayers(ctx, leagueID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if data.MatchDays, err = s.firestoreClient.ListMatchDays(ctx, leagueID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list match days: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Scores are checked as they stream in rather than held for the whole league
+	checker := services.NewIntegrityChecker(leagueID, data)
+	err = s.firestoreClient.IterateLeagueScores(ctx, leagueID, func(score models.Score) error {
+		checker.CheckScore(score)
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	report := checker.Report()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// AddAttachmentRequest is the request body for attaching an uploaded file to a match
+type AddAttachmentRequest struct {
+	URL string `json:"url"`
+}
+
+// MatchWithAttachments is a match along with the photos and scans attached to it
+type MatchWithAttachments struct {
+	models.Match
+	Attachments []models.Attachment `json:"attachments"`
+}
+
+// handleAddAttachment attaches an uploaded photo or scan to a match (match players and admins)
+func (s *APIServer) handleAddAttachment(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+
+	var req AddAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	player, isAdmin, ok := s.authorizeAttachments(w, r, *match)
+	if !ok {
+		return
+	}
+
+	attachment, err := services.NewAttachment(*match, player.ID, isAdmin, req.URL, time.Now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid attachment: %v", err), http.StatusBadRequest)
+		return
+	}
+	attachment.ID = uuid.New().String()
+
+	if err := s.firestoreClient.CreateAttachment(ctx, attachment); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create attachment: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// handleListAttachments returns a match's attachments, oldest first (match players and admins)
+func (s *APIServer) handleListAttachments(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	if _, _, ok := s.authorizeAttachments(w, r, *match); !ok {
+		return
+	}
+
+	attachments, err := s.firestoreClient.ListMatchAttachments(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list attachments: %v", err), http.StatusInternalServerError)
+		return
+	}
+	services.SortAttachments(attachments)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachments)
+}
+
+// handleDeleteAttachment removes an attachment from a match (match players and admins)
+func (s *APIServer) handleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+	attachmentID := r.PathValue("attachment_id")
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	attachment, err := s.firestoreClient.GetAttachment(ctx, attachmentID)
+	if err != nil || attachment.MatchID != matchID {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+
+	if _, _, ok := s.authorizeAttachments(w, r, *match); !ok {
+		return
+	}
+
+	if err := s.firestoreClient.DeleteAttachment(ctx, attachmentID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete attachment: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeAttachments returns the authenticated player, and whether they are a league admin,
+// when they may access the match's attachments. It writes the error response and returns false
+// otherwise.
+func (s *APIServer) authorizeAttachments(w http.ResponseWriter, r *http.Request, match models.Match) (*models.Player, bool, bool) {
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false, false
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return nil, false, false
+	}
+
+	isAdmin, err := s.isAttachmentAdmin(ctx, match, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return nil, false, false
+	}
+	if err := services.CheckAttachmentAccess(match, player.ID, isAdmin); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, false, false
+	}
+	return player, isAdmin, true
+}
+
+// isAttachmentAdmin checks admin status only for players outside the match, since the match's
+// own players already have access
+func (s *APIServer) isAttachmentAdmin(ctx context.Context, match models.Match, playerID string) (bool, error) {
+	if playerID == match.PlayerAID || playerID == match.PlayerBID {
+		return false, nil
+	}
+	return s.firestoreClient.IsLeagueAdmin(ctx, match.LeagueID, playerID)
+}
+
+// visibleAttachments returns a match's attachments when the authenticated player may see them,
+// and an empty list otherwise or when they can't be loaded
+func (s *APIServer) visibleAttachments(ctx context.Context, match models.Match) []models.Attachment {
+	none := make([]models.Attachment, 0)
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return none
+	}
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		return none
+	}
+	isAdmin, err := s.isAttachmentAdmin(ctx, match, player.ID)
+	if err != nil || services.CheckAttachmentAccess(match, player.ID, isAdmin) != nil {
+		return none
+	}
+
+	attachments, err := s.firestoreClient.ListMatchAttachments(ctx, match.ID)
+	if err != nil {
+		return none
+	}
+	services.SortAttachments(attachments)
+	return attachments
+}
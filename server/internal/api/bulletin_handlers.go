@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 )
 
 // CreateBulletinMessageRequest represents the request body for creating a bulletin message
@@ -58,6 +59,21 @@ func (s *APIServer) checkBulletinAccess(ctx context.Context, leagueID, seasonID
 	return &bulletinAccessResult{player: player, hasAccess: isAdmin}, nil
 }
 
+// checkBulletinSeasonWritable responds with a conflict and returns false when the bulletin's
+// season is archived, since an archived season's board is read-only
+func (s *APIServer) checkBulletinSeasonWritable(ctx context.Context, w http.ResponseWriter, seasonID string) bool {
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return false
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return false
+	}
+	return true
+}
+
 // handleCreateBulletinMessage creates a new bulletin message for a season
 func (s *APIServer) handleCreateBulletinMessage(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
@@ -87,20 +103,18 @@ func (s *APIServer) handleCreateBulletinMessage(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	var req CreateBulletinMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+	if !s.checkBulletinSeasonWritable(ctx, w, seasonID) {
 		return
 	}
 
-	if req.Content == "" {
-		http.Error(w, "Message content is required", http.StatusBadRequest)
+	var req CreateBulletinMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	// Limit message length
-	if len(req.Content) > 1000 {
-		http.Error(w, "Message content must be 1000 characters or less", http.StatusBadRequest)
+	if err := services.ValidateBulletinContent(req.Content); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid message: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -167,8 +181,209 @@ func (s *APIServer) handleListBulletinMessages(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Pinned messages are always shown, even when older than the latest page
+	pinned, err := s.firestoreClient.ListPinnedBulletinMessages(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list pinned bulletin messages: %v", err), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(messages)
+	json.NewEncoder(w).Encode(services.OrderBulletinMessages(pinned, messages))
+}
+
+// handleListLeagueBulletinMessages lists bulletin messages across all of a league's seasons
+// for admin moderation
+func (s *APIServer) handleListLeagueBulletinMessages(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return
+	}
+
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !isAdmin {
+		http.Error(w, "Access denied: league admin required", http.StatusForbidden)
+		return
+	}
+
+	// Parse limit from query params (default 50)
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
+			limit = parsedLimit
+		}
+	}
+
+	messages, nextPageToken, err := s.firestoreClient.ListLeagueBulletinMessages(ctx, leagueID, limit, r.URL.Query().Get("pageToken"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list bulletin messages: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	seasons, err := s.firestoreClient.ListSeasons(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"messages":      services.AttachSeasonNames(messages, seasons),
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// handleUpdateBulletinMessage edits the content of a bulletin message. Authors can edit
+// within the league's edit window; admins can edit at any time.
+func (s *APIServer) handleUpdateBulletinMessage(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	messageID := r.PathValue("message_id")
+	if leagueID == "" || messageID == "" {
+		http.Error(w, "League ID and Message ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return
+	}
+
+	var req CreateBulletinMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := services.ValidateBulletinContent(req.Content); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid message: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	message, err := s.firestoreClient.GetBulletinMessage(ctx, messageID)
+	if err != nil || message.LeagueID != leagueID {
+		http.Error(w, "Message not found", http.StatusNotFound)
+		return
+	}
+	if !s.checkBulletinSeasonWritable(ctx, w, message.SeasonID) {
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	if err := services.CanEditBulletinMessage(*message, player.ID, isAdmin, services.BulletinEditWindow(*league), now); err != nil {
+		http.Error(w, fmt.Sprintf("Access denied: %v", err), http.StatusForbidden)
+		return
+	}
+
+	message.Content = req.Content
+	message.EditedAt = &now
+
+	if err := s.firestoreClient.UpdateBulletinMessage(ctx, *message); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update bulletin message: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message)
+}
+
+// handlePinBulletinMessage pins (POST) or unpins (DELETE) a bulletin message. Admin only.
+func (s *APIServer) handlePinBulletinMessage(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	messageID := r.PathValue("message_id")
+	if leagueID == "" || messageID == "" {
+		http.Error(w, "League ID and Message ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return
+	}
+
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !isAdmin {
+		http.Error(w, "Access denied: league admin required", http.StatusForbidden)
+		return
+	}
+
+	message, err := s.firestoreClient.GetBulletinMessage(ctx, messageID)
+	if err != nil || message.LeagueID != leagueID {
+		http.Error(w, "Message not found", http.StatusNotFound)
+		return
+	}
+	if !s.checkBulletinSeasonWritable(ctx, w, message.SeasonID) {
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		message.Pinned = false
+		message.PinnedAt = nil
+	} else {
+		now := time.Now()
+		message.Pinned = true
+		message.PinnedAt = &now
+	}
+
+	if err := s.firestoreClient.UpdateBulletinMessage(ctx, *message); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update bulletin message: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message)
 }
 
 // handleDeleteBulletinMessage deletes a bulletin message
@@ -202,6 +417,9 @@ func (s *APIServer) handleDeleteBulletinMessage(w http.ResponseWriter, r *http.R
 		http.Error(w, fmt.Sprintf("Message not found: %v", err), http.StatusNotFound)
 		return
 	}
+	if !s.checkBulletinSeasonWritable(ctx, w, message.SeasonID) {
+		return
+	}
 
 	// Only allow deletion by the message author or league admin
 	if message.PlayerID != player.ID {
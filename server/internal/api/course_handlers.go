@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -22,6 +23,11 @@ func (s *APIServer) handleCreateCourse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := services.ValidateCourse(course); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	course.ID = uuid.New().String()
 	course.LeagueID = leagueID
 
@@ -36,6 +42,8 @@ func (s *APIServer) handleCreateCourse(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(course)
 }
 
+// handleListCourses returns the league's courses with their full hole detail (pars and stroke
+// indexes), so clients don't need to fetch each course again
 func (s *APIServer) handleListCourses(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
 	if leagueID == "" {
@@ -85,6 +93,11 @@ func (s *APIServer) handleUpdateCourse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := services.ValidateCourse(course); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	course.ID = courseID
 
 	ctx := r.Context()
@@ -95,4 +108,25 @@ func (s *APIServer) handleUpdateCourse(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(course)
-}
\ No newline at end of file
+}
+
+// handleGetCourseCard returns a course's hole-by-hole card (hole, par and stroke index) ready to
+// render, with any missing hole data filled in and flagged
+func (s *APIServer) handleGetCourseCard(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	courseID := r.PathValue("id")
+	if leagueID == "" || courseID == "" {
+		http.Error(w, "League ID and Course ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	course, err := s.firestoreClient.GetCourse(ctx, courseID)
+	if err != nil || course.LeagueID != leagueID {
+		http.Error(w, "Course not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.BuildCourseCard(*course))
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// RaiseDisputeRequest is the request body for disputing a match result
+type RaiseDisputeRequest struct {
+	Reason    string `json:"reason"`
+	FlagMatch bool   `json:"flagMatch"` // Leave the match out of standings until the dispute is resolved
+}
+
+// ResolveDisputeRequest is the request body for resolving a dispute
+type ResolveDisputeRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+// handleRaiseDispute lets a player in a completed match dispute its result
+func (s *APIServer) handleRaiseDispute(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+
+	ctx := r.Context()
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return
+	}
+
+	var req RaiseDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	dispute, err := services.RaiseDispute(match, player.ID, req.Reason, req.FlagMatch, time.Now())
+	if errors.Is(err, services.ErrDisputeNotParticipant) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid dispute: %v", err), http.StatusBadRequest)
+		return
+	}
+	dispute.ID = uuid.New().String()
+
+	if err := s.firestoreClient.CreateDispute(ctx, dispute); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create dispute: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if match.Disputed {
+		if err := s.firestoreClient.UpdateMatch(ctx, *match); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to flag match as disputed: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dispute)
+}
+
+// handleListDisputes returns a league's disputes, newest first (admin only).
+// Pass ?status=open or ?status=resolved to filter.
+func (s *APIServer) handleListDisputes(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	status := r.URL.Query().Get("status")
+	if status != "" && status != services.DisputeStatusOpen && status != services.DisputeStatusResolved {
+		http.Error(w, "status must be 'open' or 'resolved'", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	disputes, err := s.firestoreClient.ListDisputes(ctx, leagueID, status)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list disputes: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(disputes)
+}
+
+// handleResolveDispute closes a dispute (admin only). Once no open disputes remain against
+// the match, its disputed flag is cleared so it counts toward standings again.
+func (s *APIServer) handleResolveDispute(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	disputeID := r.PathValue("dispute_id")
+
+	ctx := r.Context()
+	admin, ok := s.requireLeagueAdmin(w, r, leagueID)
+	if !ok {
+		return
+	}
+
+	var req ResolveDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := s.firestoreClient.GetDispute(ctx, disputeID)
+	if err != nil || dispute.LeagueID != leagueID {
+		http.Error(w, "Dispute not found", http.StatusNotFound)
+		return
+	}
+
+	if err := services.ResolveDispute(dispute, req.Resolution, admin.ID, time.Now()); err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, services.ErrDisputeAlreadyResolved) {
+			code = http.StatusConflict
+		}
+		http.Error(w, fmt.Sprintf("Cannot resolve dispute: %v", err), code)
+		return
+	}
+
+	if err := s.firestoreClient.UpdateDispute(ctx, *dispute); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update dispute: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.clearMatchDisputeFlag(ctx, dispute.MatchID); err != nil {
+		// The dispute itself is resolved; the flag can be cleared by resolving again or editing the match
+		logger.WarnContext(ctx, "Failed to clear match dispute flag",
+			"match_id", dispute.MatchID,
+			"dispute_id", dispute.ID,
+			"error", err,
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dispute)
+}
+
+// clearMatchDisputeFlag un-flags a disputed match once none of its disputes remain open
+func (s *APIServer) clearMatchDisputeFlag(ctx context.Context, matchID string) error {
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if !match.Disputed {
+		return nil
+	}
+
+	disputes, err := s.firestoreClient.ListMatchDisputes(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if services.HasOpenDispute(disputes) {
+		return nil
+	}
+
+	match.Disputed = false
+	return s.firestoreClient.UpdateMatch(ctx, *match)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
+	"golf-league-manager/internal/validation"
+
+	"github.com/google/uuid"
+)
+
+// TeamScoreSubmission is one foursomes team's players and the hole scores of their shared ball
+type TeamScoreSubmission struct {
+	PlayerIDs  []string `json:"playerIds"`
+	HoleScores []int    `json:"holeScores"`
+}
+
+// EnterFoursomesRequest is the request body for entering a foursomes result
+type EnterFoursomesRequest struct {
+	CourseID string              `json:"courseId"`
+	Date     string              `json:"date"` // RFC3339
+	TeamA    TeamScoreSubmission `json:"teamA"`
+	TeamB    TeamScoreSubmission `json:"teamB"`
+}
+
+// handleEnterFoursomesResult scores and saves an alternate shot match between two teams of two.
+// Team handicaps are combined from the players' current season indexes on the course.
+func (s *APIServer) handleEnterFoursomesResult(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	var req EnterFoursomesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	matchDate, err := validation.ValidateDate(req.Date)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid date: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusNotFound)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, req.CourseID)
+	if err != nil || course.LeagueID != leagueID {
+		http.Error(w, "Course not found", http.StatusNotFound)
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasonPlayersMap := make(map[string]models.SeasonPlayer, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		seasonPlayersMap[sp.PlayerID] = sp
+	}
+
+	match := models.FoursomesMatch{
+		MatchDate: matchDate,
+		TeamA:     models.TeamScore{PlayerIDs: req.TeamA.PlayerIDs, HoleScores: req.TeamA.HoleScores},
+		TeamB:     models.TeamScore{PlayerIDs: req.TeamB.PlayerIDs, HoleScores: req.TeamB.HoleScores},
+	}
+	scored, err := services.ScoreFoursomesMatch(match, *course, *league, *season, seasonPlayersMap)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid foursomes result: %v", err), http.StatusBadRequest)
+		return
+	}
+	scored.ID = uuid.New().String()
+	scored.CreatedAt = time.Now()
+
+	if err := s.firestoreClient.CreateFoursomesMatch(ctx, scored); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save foursomes result: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(scored)
+}
+
+// handleListFoursomesResults returns a season's foursomes results, most recent first
+func (s *APIServer) handleListFoursomesResults(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	matches, err := s.firestoreClient.ListSeasonFoursomesMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list foursomes results: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(matches)
+}
@@ -10,8 +10,8 @@ import (
 
 	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 
-	"github.com/clerk/clerk-sdk-go/v2/user"
 	"github.com/google/uuid"
 )
 
@@ -210,6 +210,72 @@ func (s *APIServer) handleRevokeLeagueInvite(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRegenerateLeagueInvite replaces an invite with a new link carrying the same settings
+// and revokes the old one (admin only)
+func (s *APIServer) handleRegenerateLeagueInvite(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	inviteID := r.PathValue("invite_id")
+	if leagueID == "" || inviteID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "League ID and Invite ID are required")
+		return
+	}
+
+	ctx := r.Context()
+
+	// Get the authenticated user ID
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		s.respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get the player for this user
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		s.respondWithError(w, http.StatusNotFound, "Player not found")
+		return
+	}
+
+	// Check if user is an admin of the league
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to check admin status: %v", err))
+		return
+	}
+	if !isAdmin {
+		s.respondWithError(w, http.StatusForbidden, "Only league admins can regenerate invite links")
+		return
+	}
+
+	// Get the invite and verify it belongs to this league
+	invite, err := s.firestoreClient.GetLeagueInvite(ctx, inviteID)
+	if err != nil || invite.LeagueID != leagueID {
+		s.respondWithError(w, http.StatusNotFound, "Invite not found")
+		return
+	}
+
+	token, err := generateInviteToken()
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, "Failed to generate invite token")
+		return
+	}
+
+	revoked, fresh := services.RegenerateInvite(*invite, uuid.New().String(), token, player.ID, time.Now())
+
+	if err := s.firestoreClient.CreateLeagueInvite(ctx, fresh); err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create invite: %v", err))
+		return
+	}
+	if err := s.firestoreClient.UpdateLeagueInvite(ctx, revoked); err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to revoke invite: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(fresh)
+}
+
 // handleGetInviteByToken retrieves invite details by token (for accept flow)
 func (s *APIServer) handleGetInviteByToken(w http.ResponseWriter, r *http.Request) {
 	token := r.PathValue("token")
@@ -299,29 +365,9 @@ func (s *APIServer) handleAcceptLeagueInvite(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Get or create the player for this user
-	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
-	if err != nil {
-		// Player doesn't exist yet, create one automatically using Clerk user info
-		clerkUser, err := user.Get(ctx, userID)
-		if err != nil {
-			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get user information: %v", err))
-			return
-		}
-
-		// Create a new player profile
-		player = &models.Player{
-			ID:          uuid.New().String(),
-			Name:        getDisplayName(clerkUser),
-			Email:       getPrimaryEmail(clerkUser),
-			ClerkUserID: userID,
-			Active:      true,
-			CreatedAt:   time.Now(),
-		}
-
-		if err := s.firestoreClient.CreatePlayer(ctx, *player); err != nil {
-			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create player: %v", err))
-			return
-		}
+	player, ok := s.ensurePlayer(w, r, userID)
+	if !ok {
+		return
 	}
 
 	// Check if already a member
@@ -348,13 +394,18 @@ func (s *APIServer) handleAcceptLeagueInvite(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	// Add user to league as player
+	// Add user to league as player. The provisional handicap can be set by an admin later,
+	// unless the league seeds it from the player's index in another league.
+	provisional := 0.0
+	if league, err := s.firestoreClient.GetLeague(ctx, invite.LeagueID); err == nil {
+		provisional, _ = s.seededProvisional(ctx, *league, player.ID)
+	}
 	member := models.LeagueMember{
 		ID:                  uuid.New().String(),
 		LeagueID:            invite.LeagueID,
 		PlayerID:            player.ID,
 		Role:                "player",
-		ProvisionalHandicap: 0, // Can be set by admin later
+		ProvisionalHandicap: provisional,
 		JoinedAt:            time.Now(),
 	}
 
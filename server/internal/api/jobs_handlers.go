@@ -3,8 +3,11 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"golf-league-manager/internal/models"
 	"golf-league-manager/internal/services"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func (s *APIServer) handleRecalculateHandicaps(w http.ResponseWriter, r *http.Request) {
@@ -43,4 +46,227 @@ func (s *APIServer) handleProcessMatch(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
-}
\ No newline at end of file
+}
+
+// handleRecomputeAll recomputes every score differential in the league, then recalculates
+// handicaps for the active season once all scores are done. Large leagues can be processed
+// in pieces with maxChunks, passing the returned nextCursor back as cursor. Admin only.
+func (s *APIServer) handleRecomputeAll(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	opts := services.RecomputeOptions{Cursor: query.Get("cursor")}
+	for param, target := range map[string]*int{"chunkSize": &opts.ChunkSize, "maxChunks": &opts.MaxChunks} {
+		raw := query.Get(param)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			http.Error(w, fmt.Sprintf("%s must be a non-negative integer", param), http.StatusBadRequest)
+			return
+		}
+		*target = value
+	}
+
+	ctx := r.Context()
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusNotFound)
+		return
+	}
+	// Gross-only leagues record no differentials, so there is nothing to recompute
+	if !services.UsesHandicaps(*league) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(services.RecomputeResult{Done: true})
+		return
+	}
+	opts.StandardSlope = services.StandardSlope(*league)
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	// Differentials include the playing conditions adjustment of the day they were played
+	matchDays, err := s.firestoreClient.ListMatchDays(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list match days: %v", err), http.StatusInternalServerError)
+		return
+	}
+	pccByMatchDay := make(map[string]int, len(matchDays))
+	for _, md := range matchDays {
+		pccByMatchDay[md.ID] = md.PCC
+	}
+	pccByMatch := make(map[string]int)
+	err = s.firestoreClient.IterateLeagueMatches(ctx, leagueID, func(m models.Match) error {
+		pccByMatch[m.ID] = pccByMatchDay[m.MatchDayID]
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	result, err := services.RecomputeDifferentials(ctx, s.firestoreClient, leagueID, coursesMap, pccByMatch, opts)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recompute differentials (resume with cursor=%s): %v", result.NextCursor, err), http.StatusInternalServerError)
+		return
+	}
+
+	if result.Done {
+		job := services.NewHandicapRecalculationJob(s.firestoreClient)
+		if err := job.Run(ctx, leagueID); err != nil {
+			http.Error(w, fmt.Sprintf("Differentials recomputed but failed to recalculate handicaps: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleAutoArchiveSeasons archives the league's seasons that ended more than the league's
+// grace period ago and have no scheduled match days left. Archived seasons become read-only.
+func (s *APIServer) handleAutoArchiveSeasons(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusNotFound)
+		return
+	}
+
+	archived, err := services.AutoArchivePastSeasons(ctx, s.firestoreClient, leagueID, services.SeasonArchiveGraceDays(*league), time.Now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to archive seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "success",
+		"archived": archived,
+	})
+}
+
+// handleGetLastRecalcRun returns the timing and counts of the league's most recent handicap
+// recalculation
+func (s *APIServer) handleGetLastRecalcRun(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	run, err := s.firestoreClient.GetLastRecalcRun(r.Context(), leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get last recalculation run: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if run == nil {
+		http.Error(w, "Handicaps have not been recalculated for this league", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(run)
+}
+
+// handleRecalculateAllHandicaps recalculates handicaps for every league's active season. It is
+// called nightly by Cloud Scheduler and authenticated with the shared scheduler secret rather
+// than a user session.
+func (s *APIServer) handleRecalculateAllHandicaps(w http.ResponseWriter, r *http.Request) {
+	job := services.NewHandicapRecalculationJob(s.firestoreClient)
+	result, err := services.RecalculateAllLeagues(r.Context(), s.firestoreClient, job.Run)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recalculate handicaps: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleRecalculatePlayerHandicap recalculates one season player's index straight away, so a
+// player who just posted a score sees it without waiting for the nightly job. Players may
+// recalculate their own index and admins anyone's.
+func (s *APIServer) handleRecalculatePlayerHandicap(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || seasonID == "" || playerID == "" {
+		http.Error(w, "League ID, Season ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if !s.authorizePlayerRecord(w, r, leagueID, playerID, "Access denied: can only recalculate own handicap") {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	seasonPlayer, err := s.firestoreClient.GetSeasonPlayer(ctx, seasonID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season player: %v", err), http.StatusNotFound)
+		return
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	job := services.NewHandicapRecalculationJob(s.firestoreClient)
+	recalc, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, *seasonPlayer, coursesMap)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recalculate handicap: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recalc)
+}
@@ -3,11 +3,17 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/clerk/clerk-sdk-go/v2/user"
@@ -16,8 +22,12 @@ import (
 
 // Helper functions for Clerk user operations
 
-// getUserFromClerk fetches user information from Clerk API
+// getUserFromClerk fetches user information from Clerk API, giving up after
+// services.UserLookupTimeout
 func getUserFromClerk(ctx context.Context, userID string) (*clerk.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, services.UserLookupTimeout)
+	defer cancel()
+
 	clerkUser, err := user.Get(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user from Clerk: %w", err)
@@ -25,6 +35,30 @@ func getUserFromClerk(ctx context.Context, userID string) (*clerk.User, error) {
 	return clerkUser, nil
 }
 
+// clerkUserLookup fetches a user's profile from Clerk for creating their player
+func clerkUserLookup(ctx context.Context, userID string) (services.UserProfile, error) {
+	clerkUser, err := getUserFromClerk(ctx, userID)
+	if err != nil {
+		return services.UserProfile{}, err
+	}
+	return services.UserProfile{Name: getDisplayName(clerkUser), Email: getPrimaryEmail(clerkUser)}, nil
+}
+
+// ensurePlayer returns the signed-in user's player, creating it from their Clerk profile if
+// needed, and writes the error response when that fails. Clerk being unreachable is a 503.
+func (s *APIServer) ensurePlayer(w http.ResponseWriter, r *http.Request, userID string) (*models.Player, bool) {
+	player, err := services.EnsurePlayer(r.Context(), s.firestoreClient, clerkUserLookup, userID, services.UserLookupTimeout, time.Now())
+	if errors.Is(err, services.ErrUserLookupUnavailable) {
+		s.respondWithError(w, http.StatusServiceUnavailable, "Could not reach the sign-in service to set up your player profile, please try again shortly")
+		return nil, false
+	}
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to set up player: %v", err))
+		return nil, false
+	}
+	return player, true
+}
+
 // getDisplayName extracts a display name from Clerk user
 func getDisplayName(u *clerk.User) string {
 	if u.FirstName != nil && u.LastName != nil && *u.FirstName != "" && *u.LastName != "" {
@@ -65,6 +99,13 @@ func getPrimaryEmail(u *clerk.User) string {
 
 // League handlers
 
+// CreateLeagueResponse is the created league, with the season created alongside it when the
+// admin asked for one
+type CreateLeagueResponse struct {
+	models.League
+	Season *models.Season `json:"season,omitempty"`
+}
+
 // handleCreateLeague creates a new league with the creator as admin
 func (s *APIServer) handleCreateLeague(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -77,35 +118,15 @@ func (s *APIServer) handleCreateLeague(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get or create the player for this user
-	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
-	if err != nil {
-		// Player doesn't exist yet, create one automatically using Clerk user info
-		// Get user info from Clerk
-		clerkUser, err := getUserFromClerk(ctx, userID)
-		if err != nil {
-			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get user information: %v", err))
-			return
-		}
-
-		// Create a new player profile
-		player = &models.Player{
-			ID:          uuid.New().String(),
-			Name:        getDisplayName(clerkUser),
-			Email:       getPrimaryEmail(clerkUser),
-			ClerkUserID: userID,
-			Active:      true,
-			CreatedAt:   time.Now(),
-		}
-
-		if err := s.firestoreClient.CreatePlayer(ctx, *player); err != nil {
-			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create player: %v", err))
-			return
-		}
+	player, ok := s.ensurePlayer(w, r, userID)
+	if !ok {
+		return
 	}
 
 	var req struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name             string `json:"name"`
+		Description      string `json:"description"`
+		AutoCreateSeason bool   `json:"autoCreateSeason"` // Also create an active season for the current year
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
@@ -140,9 +161,20 @@ func (s *APIServer) handleCreateLeague(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	resp := CreateLeagueResponse{League: league}
+	if req.AutoCreateSeason {
+		season := services.DefaultSeason(league.ID, time.Now())
+		season.ID = uuid.New().String()
+		if err := s.firestoreClient.CreateSeason(ctx, season); err != nil {
+			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("League created but failed to create its season: %v", err))
+			return
+		}
+		resp.Season = &season
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(league)
+	json.NewEncoder(w).Encode(resp)
 }
 
 // handleListLeagues lists all leagues the user is a member of
@@ -206,6 +238,31 @@ func (s *APIServer) handleUpdateLeague(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
+
+		OneRoundPerDay            *bool   `json:"oneRoundPerDay"`
+		PlayingHandicapRounding   *string `json:"playingHandicapRounding"`
+		BulletinEditWindowMinutes *int    `json:"bulletinEditWindowMinutes"`
+		StandardSlope             *int    `json:"standardSlope"`
+		MaxStrokesPerHole         *int    `json:"maxStrokesPerHole"`
+		SeasonArchiveGraceDays    *int    `json:"seasonArchiveGraceDays"`
+		ProvisionalWeight         *int    `json:"provisionalWeight"`
+		HandicapWindowSize        *int    `json:"handicapWindowSize"`
+		HandicapUsedCount         *int    `json:"handicapUsedCount"`
+		MatchPointsPerHole        *int    `json:"matchPointsPerHole"`
+		UnestablishedAdjustment   *bool   `json:"unestablishedAdjustment"`
+
+		AbsentStrokesOverParPlusHandicap *int     `json:"absentStrokesOverParPlusHandicap"`
+		MinProvisionalHandicap           *float64 `json:"minProvisionalHandicap"`
+		MaxProvisionalHandicap           *float64 `json:"maxProvisionalHandicap"`
+		HandicapDecay                    *float64 `json:"handicapDecay"`
+		UseHandicaps                     *bool    `json:"useHandicaps"`
+		MatchOverallPoints               *int     `json:"matchOverallPoints"`
+		HandicapDropSchedule             *[]int   `json:"handicapDropSchedule"`
+		NetScoreFloorToPar               *int     `json:"netScoreFloorToPar"`
+		SeedProvisionalFromOtherLeagues  *bool    `json:"seedProvisionalFromOtherLeagues"`
+		HighRoundFlagMargin              *float64 `json:"highRoundFlagMargin"`
+		ExcludeFlaggedScores             *bool    `json:"excludeFlaggedScores"`
+		OfficialRoundsOnly               *bool    `json:"officialRoundsOnly"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
@@ -222,6 +279,157 @@ func (s *APIServer) handleUpdateLeague(w http.ResponseWriter, r *http.Request) {
 	// Update fields
 	league.Name = req.Name
 	league.Description = req.Description
+	if req.OneRoundPerDay != nil {
+		league.OneRoundPerDay = *req.OneRoundPerDay
+	}
+	if req.UnestablishedAdjustment != nil {
+		league.UnestablishedAdjustment = *req.UnestablishedAdjustment
+	}
+	if req.PlayingHandicapRounding != nil {
+		if !services.IsValidRounding(*req.PlayingHandicapRounding) {
+			s.respondWithError(w, http.StatusBadRequest, "playingHandicapRounding must be one of: nearest, up, down")
+			return
+		}
+		league.PlayingHandicapRounding = *req.PlayingHandicapRounding
+	}
+	if req.BulletinEditWindowMinutes != nil {
+		if *req.BulletinEditWindowMinutes < 0 {
+			s.respondWithError(w, http.StatusBadRequest, "bulletinEditWindowMinutes cannot be negative")
+			return
+		}
+		league.BulletinEditWindowMinutes = *req.BulletinEditWindowMinutes
+	}
+	if req.StandardSlope != nil {
+		// Existing differentials keep the old baseline until the recompute-all job is run
+		if *req.StandardSlope < services.MinSlopeRating || *req.StandardSlope > services.MaxSlopeRating {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("standardSlope must be between %d and %d", services.MinSlopeRating, services.MaxSlopeRating))
+			return
+		}
+		league.StandardSlope = *req.StandardSlope
+	}
+	if req.MaxStrokesPerHole != nil {
+		if *req.MaxStrokesPerHole < 1 || *req.MaxStrokesPerHole > services.MaxStrokesPerHoleLimit {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("maxStrokesPerHole must be between 1 and %d", services.MaxStrokesPerHoleLimit))
+			return
+		}
+		league.MaxStrokesPerHole = *req.MaxStrokesPerHole
+	}
+	if req.SeasonArchiveGraceDays != nil {
+		if *req.SeasonArchiveGraceDays < 1 || *req.SeasonArchiveGraceDays > services.MaxSeasonArchiveGraceDays {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("seasonArchiveGraceDays must be between 1 and %d", services.MaxSeasonArchiveGraceDays))
+			return
+		}
+		league.SeasonArchiveGraceDays = *req.SeasonArchiveGraceDays
+	}
+	if req.ProvisionalWeight != nil {
+		if *req.ProvisionalWeight < 1 || *req.ProvisionalWeight > services.MaxProvisionalWeight {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("provisionalWeight must be between 1 and %d", services.MaxProvisionalWeight))
+			return
+		}
+		league.ProvisionalWeight = *req.ProvisionalWeight
+	}
+	if req.HandicapWindowSize != nil || req.HandicapUsedCount != nil {
+		if req.HandicapWindowSize != nil {
+			if *req.HandicapWindowSize < 1 || *req.HandicapWindowSize > services.MaxHandicapWindowSize {
+				s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("handicapWindowSize must be between 1 and %d", services.MaxHandicapWindowSize))
+				return
+			}
+			league.HandicapWindowSize = *req.HandicapWindowSize
+		}
+		if req.HandicapUsedCount != nil {
+			if *req.HandicapUsedCount < 1 {
+				s.respondWithError(w, http.StatusBadRequest, "handicapUsedCount must be at least 1")
+				return
+			}
+			league.HandicapUsedCount = *req.HandicapUsedCount
+		}
+		if windowSize, _ := services.HandicapWindow(*league); league.HandicapUsedCount > windowSize {
+			s.respondWithError(w, http.StatusBadRequest, "handicapUsedCount cannot be larger than handicapWindowSize")
+			return
+		}
+	}
+	if req.HandicapDropSchedule != nil || req.HandicapWindowSize != nil {
+		// An empty schedule goes back to averaging the best handicapUsedCount
+		if req.HandicapDropSchedule != nil {
+			league.HandicapDropSchedule = *req.HandicapDropSchedule
+		}
+		if len(league.HandicapDropSchedule) > 0 {
+			windowSize, _ := services.HandicapWindow(*league)
+			if err := services.ValidateHandicapDropSchedule(league.HandicapDropSchedule, windowSize); err != nil {
+				s.respondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+	if req.MatchPointsPerHole != nil || req.MatchOverallPoints != nil {
+		// Completed matches keep the points they were awarded
+		if req.MatchPointsPerHole != nil {
+			league.MatchPointsPerHole = *req.MatchPointsPerHole
+		}
+		if req.MatchOverallPoints != nil {
+			overall := *req.MatchOverallPoints
+			league.MatchOverallPoints = &overall
+		}
+		if err := services.ValidateMatchPointRules(services.LeagueMatchPointRules(*league)); err != nil {
+			s.respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.AbsentStrokesOverParPlusHandicap != nil {
+		penalty := *req.AbsentStrokesOverParPlusHandicap
+		if penalty < 0 || penalty > services.MaxAbsentPenaltyStrokes {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("absentStrokesOverParPlusHandicap must be between 0 and %d", services.MaxAbsentPenaltyStrokes))
+			return
+		}
+		league.AbsentStrokesOverParPlusHandicap = &penalty
+	}
+	if req.MinProvisionalHandicap != nil || req.MaxProvisionalHandicap != nil {
+		if req.MinProvisionalHandicap != nil {
+			league.MinProvisionalHandicap = req.MinProvisionalHandicap
+		}
+		if req.MaxProvisionalHandicap != nil {
+			league.MaxProvisionalHandicap = req.MaxProvisionalHandicap
+		}
+		if minHandicap, maxHandicap := services.ProvisionalHandicapRange(*league); !(minHandicap < maxHandicap) {
+			s.respondWithError(w, http.StatusBadRequest, "minProvisionalHandicap must be less than maxProvisionalHandicap")
+			return
+		}
+	}
+	if req.HandicapDecay != nil {
+		if *req.HandicapDecay < 0 || *req.HandicapDecay > services.MaxHandicapDecay {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("handicapDecay must be between 0 and %g", services.MaxHandicapDecay))
+			return
+		}
+		league.HandicapDecay = *req.HandicapDecay
+	}
+	if req.UseHandicaps != nil {
+		useHandicaps := *req.UseHandicaps
+		league.UseHandicaps = &useHandicaps
+	}
+	if req.NetScoreFloorToPar != nil {
+		floor := *req.NetScoreFloorToPar
+		if floor < -services.MaxNetScoreFloorUnderPar || floor > 0 {
+			s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("netScoreFloorToPar must be between -%d and 0", services.MaxNetScoreFloorUnderPar))
+			return
+		}
+		league.NetScoreFloorToPar = &floor
+	}
+	if req.SeedProvisionalFromOtherLeagues != nil {
+		league.SeedProvisionalFromOtherLeagues = *req.SeedProvisionalFromOtherLeagues
+	}
+	if req.HighRoundFlagMargin != nil {
+		if err := services.ValidateHighRoundFlagMargin(*req.HighRoundFlagMargin); err != nil {
+			s.respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		league.HighRoundFlagMargin = *req.HighRoundFlagMargin
+	}
+	if req.ExcludeFlaggedScores != nil {
+		league.ExcludeFlaggedScores = *req.ExcludeFlaggedScores
+	}
+	if req.OfficialRoundsOnly != nil {
+		league.OfficialRoundsOnly = *req.OfficialRoundsOnly
+	}
 
 	if err := s.firestoreClient.UpdateLeague(ctx, *league); err != nil {
 		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update league: %v", err))
@@ -362,18 +570,31 @@ func (s *APIServer) handleListLeagueMembers(w http.ResponseWriter, r *http.Reque
 		Player *models.Player `json:"player"`
 	}
 
+	playerIDs := make([]string, len(members))
+	for i, member := range members {
+		playerIDs[i] = member.PlayerID
+	}
+	players, err := s.firestoreClient.GetPlayersByIDs(ctx, playerIDs)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get players: %v", err))
+		return
+	}
+
 	enrichedMembers := make([]LeagueMemberWithPlayer, 0, len(members))
 
 	for _, member := range members {
-		player, err := s.firestoreClient.GetPlayer(ctx, member.PlayerID)
-		if err != nil {
-			// Log error but continue, maybe player was deleted?
-			fmt.Printf("Failed to get player %s for member %s: %v\n", member.PlayerID, member.ID, err)
+		player, ok := players[member.PlayerID]
+		if !ok {
+			// Log but continue, maybe player was deleted?
+			logger.WarnContext(ctx, "Player for league member not found",
+				"player_id", member.PlayerID,
+				"member_id", member.ID,
+			)
 			continue
 		}
 		enrichedMembers = append(enrichedMembers, LeagueMemberWithPlayer{
 			LeagueMember: member,
-			Player:       player,
+			Player:       &player,
 		})
 	}
 
@@ -538,8 +759,8 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 
 	var req struct {
-		PlayerID            string  `json:"playerId"`
-		ProvisionalHandicap float64 `json:"provisionalHandicap"`
+		PlayerID            string   `json:"playerId"`
+		ProvisionalHandicap *float64 `json:"provisionalHandicap"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
@@ -551,6 +772,16 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		s.respondWithError(w, http.StatusNotFound, "Season not found")
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		s.respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+
 	// Check if player is a member of the league
 	members, err := s.firestoreClient.ListLeagueMembers(ctx, leagueID)
 	if err != nil {
@@ -559,14 +790,12 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 	}
 
 	var memberFound bool
-	var provisionalHandicap float64 = req.ProvisionalHandicap
+	var provisionalHandicap float64
 	for _, m := range members {
 		if m.PlayerID == req.PlayerID {
 			memberFound = true
 			// If no provisional handicap provided, use the one from league membership
-			if req.ProvisionalHandicap == 0 {
-				provisionalHandicap = m.ProvisionalHandicap
-			}
+			provisionalHandicap = m.ProvisionalHandicap
 			break
 		}
 	}
@@ -576,6 +805,30 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		s.respondWithError(w, http.StatusNotFound, fmt.Sprintf("Failed to get league: %v", err))
+		return
+	}
+	if req.ProvisionalHandicap != nil {
+		// An explicit 0.0 is a scratch player, not a missing value
+		provisionalHandicap = *req.ProvisionalHandicap
+	} else if provisionalHandicap == 0 {
+		if seeded, ok := s.seededProvisional(ctx, *league, req.PlayerID); ok {
+			provisionalHandicap = seeded
+		}
+	}
+	if err := services.ValidateProvisionalHandicap(*league, provisionalHandicap); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayer(ctx, req.PlayerID)
+	if err != nil {
+		s.respondWithError(w, http.StatusNotFound, fmt.Sprintf("Failed to get player: %v", err))
+		return
+	}
+
 	// Check if player is already in this season
 	existingSeasonPlayer, _ := s.firestoreClient.GetSeasonPlayer(ctx, seasonID, req.PlayerID)
 	if existingSeasonPlayer != nil {
@@ -586,6 +839,7 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 		// Reactivate the player
 		existingSeasonPlayer.IsActive = true
 		existingSeasonPlayer.ProvisionalHandicap = provisionalHandicap
+		existingSeasonPlayer.PlayerName = player.Name
 		if err := s.firestoreClient.UpdateSeasonPlayer(ctx, *existingSeasonPlayer); err != nil {
 			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to reactivate season player: %v", err))
 			return
@@ -601,6 +855,7 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 		ID:                  uuid.New().String(),
 		SeasonID:            seasonID,
 		PlayerID:            req.PlayerID,
+		PlayerName:          player.Name,
 		LeagueID:            leagueID,
 		ProvisionalHandicap: provisionalHandicap,
 		AddedAt:             time.Now(),
@@ -617,6 +872,160 @@ func (s *APIServer) handleAddSeasonPlayer(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(seasonPlayer)
 }
 
+// seededProvisional returns the provisional handicap a player new to the league starts from when
+// the league seeds provisionals from the player's other leagues. ok is false when there is none,
+// including for players already established in this league, whose own rounds count instead.
+func (s *APIServer) seededProvisional(ctx context.Context, league models.League, playerID string) (provisional float64, ok bool) {
+	if !league.SeedProvisionalFromOtherLeagues {
+		return 0, false
+	}
+	rounds, err := s.firestoreClient.CountPlayerHandicapScores(ctx, league.ID, playerID, league.OfficialRoundsOnly)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to count rounds before seeding provisional handicap",
+			"league_id", league.ID,
+			"player_id", playerID,
+			"error", err,
+		)
+		return 0, false
+	}
+	if services.IsEstablished(league, rounds) {
+		return 0, false
+	}
+
+	seed, ok, err := services.SeedProvisionalFromOtherLeagues(ctx, s.firestoreClient, league, playerID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to seed provisional handicap from other leagues",
+			"league_id", league.ID,
+			"player_id", playerID,
+			"error", err,
+		)
+		return 0, false
+	}
+	if !ok {
+		return 0, false
+	}
+	logger.InfoContext(ctx, "Seeded provisional handicap from another league",
+		"league_id", league.ID,
+		"player_id", playerID,
+		"source_league_id", seed.SourceLeagueID,
+		"provisional", seed.Provisional,
+	)
+	return seed.Provisional, true
+}
+
+// handleGetHandicapDistribution returns a histogram of the season's current handicap indexes
+func (s *APIServer) handleGetHandicapDistribution(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "League ID and Season ID are required")
+		return
+	}
+
+	bucketSize := services.DefaultDistributionBucketSize
+	if raw := r.URL.Query().Get("bucketSize"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 {
+			s.respondWithError(w, http.StatusBadRequest, "bucketSize must be a positive number")
+			return
+		}
+		bucketSize = parsed
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		s.respondWithError(w, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season players: %v", err))
+		return
+	}
+
+	distribution, err := services.ComputeHandicapDistribution(seasonPlayers, bucketSize)
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(distribution)
+}
+
+// handleGetSandbagReport lists the season's players whose net scores are consistently better
+// than their handicap predicts (admin only)
+func (s *APIServer) handleGetSandbagReport(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "League ID and Season ID are required")
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		s.respondWithError(w, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season players: %v", err))
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season matches: %v", err))
+		return
+	}
+	seasonMatches := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		seasonMatches[match.ID] = true
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to list courses: %v", err))
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, course := range courses {
+		coursesMap[course.ID] = course
+	}
+
+	// Only this season's rounds count toward the report
+	var scores []models.Score
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		playerScores, err := s.firestoreClient.ListPlayerLeagueScores(ctx, leagueID, sp.PlayerID)
+		if err != nil {
+			s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get scores: %v", err))
+			return
+		}
+		for _, score := range playerScores {
+			if seasonMatches[score.MatchID] {
+				scores = append(scores, score)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.DetectSandbaggers(seasonPlayers, scores, coursesMap))
+}
+
 // handleListSeasonPlayers lists all players in a season with their details
 func (s *APIServer) handleListSeasonPlayers(w http.ResponseWriter, r *http.Request) {
 	seasonID := r.PathValue("season_id")
@@ -633,34 +1042,104 @@ func (s *APIServer) handleListSeasonPlayers(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	playerIDs := make([]string, 0, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		if sp.IsActive {
+			playerIDs = append(playerIDs, sp.PlayerID)
+		}
+	}
+	players, err := s.firestoreClient.GetPlayersByIDs(ctx, playerIDs)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get players: %v", err))
+		return
+	}
+
 	// Enrich with player details
 	enrichedPlayers := make([]SeasonPlayerWithPlayer, 0, len(seasonPlayers))
 	for _, sp := range seasonPlayers {
 		if !sp.IsActive {
 			continue // Skip inactive players
 		}
-		player, err := s.firestoreClient.GetPlayer(ctx, sp.PlayerID)
-		if err != nil {
-			fmt.Printf("Failed to get player %s for season player %s: %v\n", sp.PlayerID, sp.ID, err)
+		player, ok := players[sp.PlayerID]
+		if !ok {
+			logger.WarnContext(ctx, "Player for season player not found",
+				"player_id", sp.PlayerID,
+				"season_player_id", sp.ID,
+			)
 			continue
 		}
+		// Rows enrolled before names were denormalized have no PlayerName yet
+		if sp.PlayerName == "" {
+			sp.PlayerName = player.Name
+		}
 		enrichedPlayers = append(enrichedPlayers, SeasonPlayerWithPlayer{
 			SeasonPlayer: sp,
-			Player:       player,
+			Player:       &player,
 		})
 	}
 
+	// Return a stable, alphabetized roster
+	sort.SliceStable(enrichedPlayers, func(i, j int) bool {
+		return services.SeasonPlayerLess(enrichedPlayers[i].SeasonPlayer, enrichedPlayers[j].SeasonPlayer)
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(enrichedPlayers)
 }
 
+// handleGetSeasonRoster lists a season's active players with their current index, matches played
+// and most recent result
+func (s *APIServer) handleGetSeasonRoster(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+
+	if leagueID == "" || seasonID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "League ID and Season ID are required")
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		s.respondWithError(w, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season players: %v", err))
+		return
+	}
+
+	// Only fall back to a player read for rows without a denormalized name
+	for i, sp := range seasonPlayers {
+		if sp.PlayerName != "" || !sp.IsActive {
+			continue
+		}
+		if player, err := s.firestoreClient.GetPlayer(ctx, sp.PlayerID); err == nil {
+			seasonPlayers[i].PlayerName = player.Name
+		}
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season matches: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.BuildSeasonRoster(seasonPlayers, matches))
+}
+
 // handleUpdateSeasonPlayer updates a season player's provisional handicap
 func (s *APIServer) handleUpdateSeasonPlayer(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
 	seasonID := r.PathValue("season_id")
 	playerID := r.PathValue("player_id")
 
-	if seasonID == "" || playerID == "" {
-		s.respondWithError(w, http.StatusBadRequest, "Season ID and Player ID are required")
+	if leagueID == "" || seasonID == "" || playerID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "League ID, Season ID and Player ID are required")
 		return
 	}
 
@@ -668,6 +1147,7 @@ func (s *APIServer) handleUpdateSeasonPlayer(w http.ResponseWriter, r *http.Requ
 
 	var req struct {
 		ProvisionalHandicap *float64 `json:"provisionalHandicap"`
+		Division            *string  `json:"division"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		s.respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
@@ -681,10 +1161,32 @@ func (s *APIServer) handleUpdateSeasonPlayer(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season: %v", err))
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		s.respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+
 	// Update provisional handicap if provided
 	if req.ProvisionalHandicap != nil {
+		league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+		if err != nil {
+			s.respondWithError(w, http.StatusNotFound, fmt.Sprintf("Failed to get league: %v", err))
+			return
+		}
+		if err := services.ValidateProvisionalHandicap(*league, *req.ProvisionalHandicap); err != nil {
+			s.respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		seasonPlayer.ProvisionalHandicap = *req.ProvisionalHandicap
 	}
+	if req.Division != nil {
+		seasonPlayer.Division = strings.TrimSpace(*req.Division)
+	}
 
 	if err := s.firestoreClient.UpdateSeasonPlayer(ctx, *seasonPlayer); err != nil {
 		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update season player: %v", err))
@@ -714,6 +1216,16 @@ func (s *APIServer) handleRemoveSeasonPlayer(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil {
+		s.respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get season: %v", err))
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		s.respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+
 	// Validation: Check if player is part of any scheduled matches in this season
 	scheduledMatches, err := s.firestoreClient.GetPlayerScheduledMatchesForSeason(ctx, seasonID, playerID)
 	if err != nil {
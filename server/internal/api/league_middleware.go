@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"golf-league-manager/internal/models"
 )
 
 // LeagueAdminMiddleware checks if the user is an admin of the specified league
@@ -150,3 +152,31 @@ func LeagueMemberMiddleware(fc interface {
 		})
 	}
 }
+
+// requireLeagueAdmin returns the authenticated player when they are a league admin.
+// It writes the error response and returns false otherwise.
+func (s *APIServer) requireLeagueAdmin(w http.ResponseWriter, r *http.Request, leagueID string) (*models.Player, bool) {
+	ctx := r.Context()
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return nil, false
+	}
+
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	if !isAdmin {
+		http.Error(w, "Access denied: league admin required", http.StatusForbidden)
+		return nil, false
+	}
+	return player, true
+}
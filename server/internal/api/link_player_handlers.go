@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 	"net/http"
 	"time"
 
@@ -135,4 +136,31 @@ func (s *APIServer) handleGetCurrentUser(w http.ResponseWriter, r *http.Request)
 		"linked": true,
 		"player": player,
 	})
-}
\ No newline at end of file
+}
+
+// handleGetActiveSeasons returns the current user's active season and next match in each of
+// their leagues, for the dashboard
+func (s *APIServer) handleGetActiveSeasons(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return
+	}
+
+	activity, err := services.PlayerActiveSeasons(ctx, s.firestoreClient, player.ID, time.Now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get active seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activity)
+}
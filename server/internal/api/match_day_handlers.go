@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 
 	"github.com/google/uuid"
 )
@@ -25,6 +27,8 @@ func (s *APIServer) handleCreateMatchDay(w http.ResponseWriter, r *http.Request)
 		CourseID string         `json:"courseId"`
 		SeasonID string         `json:"seasonId"`
 		Matches  []models.Match `json:"matches"`
+		services.MatchDayConditions
+		MatchPlayBasis string `json:"matchPlayBasis"` // "net" (default) or "gross"
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -39,8 +43,27 @@ func (s *APIServer) handleCreateMatchDay(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := services.ValidateMatchDayMatchups(req.Matches); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateMatchPlayBasis(req.MatchPlayBasis); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
 
+	season, err := s.firestoreClient.GetSeason(ctx, req.SeasonID)
+	if err != nil || season.LeagueID != leagueID {
+		respondWithError(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
 	// Create MatchDay
 	matchDay := models.MatchDay{
 		ID:        uuid.New().String(),
@@ -50,6 +73,13 @@ func (s *APIServer) handleCreateMatchDay(w http.ResponseWriter, r *http.Request)
 		CourseID:  req.CourseID,
 		Status:    "scheduled",
 		CreatedAt: time.Now(),
+
+		MatchPlayBasis: req.MatchPlayBasis,
+	}
+
+	if err := services.ApplyMatchDayConditions(&matchDay, req.MatchDayConditions); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	if err := s.firestoreClient.CreateMatchDay(ctx, matchDay); err != nil {
@@ -58,8 +88,15 @@ func (s *APIServer) handleCreateMatchDay(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Create Matches
+	playerIDs := make([]string, 0, len(req.Matches)*2)
+	for _, match := range req.Matches {
+		playerIDs = append(playerIDs, match.PlayerAID, match.PlayerBID)
+	}
+	playerNames := s.lookupPlayerNames(ctx, playerIDs...)
+
 	for i := range req.Matches {
 		match := req.Matches[i]
+		services.ApplyMatchPlayerNames(&match, playerNames)
 		match.ID = uuid.New().String()
 		match.LeagueID = leagueID
 		match.SeasonID = req.SeasonID
@@ -84,6 +121,77 @@ func (s *APIServer) handleCreateMatchDay(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// handleDuplicateMatchDay schedules a copy of a match day on a new date with the same course
+// and pairings
+func (s *APIServer) handleDuplicateMatchDay(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchDayID := r.PathValue("id")
+	if leagueID == "" || matchDayID == "" {
+		respondWithError(w, "League ID and Match Day ID are required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Date string `json:"date"` // YYYY-MM-DD
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	parsedDate, err := time.ParseInLocation("2006-01-02", req.Date, time.UTC)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Invalid date format. Expected YYYY-MM-DD, got: %s", req.Date), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	source, err := s.firestoreClient.GetMatchDay(ctx, matchDayID)
+	if err != nil || source.LeagueID != leagueID {
+		respondWithError(w, "Match day not found", http.StatusNotFound)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, source.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	sourceMatches, err := s.firestoreClient.GetMatchesByMatchDayID(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matchDay, matches := services.DuplicateMatchDay(*source, sourceMatches, parsedDate, time.Now(), func() string {
+		return uuid.New().String()
+	})
+
+	if err := s.firestoreClient.CreateMatchDay(ctx, matchDay); err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to create match day: %v", err), http.StatusInternalServerError)
+		return
+	}
+	for _, match := range matches {
+		if err := s.firestoreClient.CreateMatch(ctx, match); err != nil {
+			respondWithError(w, fmt.Sprintf("Failed to create match: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"matchDay": matchDay,
+		"matches":  matches,
+	})
+}
+
 // handleListMatchDaysWithStatus returns match days with their status information
 func (s *APIServer) handleListMatchDaysWithStatus(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
@@ -100,10 +208,11 @@ func (s *APIServer) handleListMatchDaysWithStatus(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Sort by date ascending for proper week number assignment
+	// Sort by date ascending; weeks are numbered within each season, as they are on scores
 	sort.Slice(matchDays, func(i, j int) bool {
 		return matchDays[i].Date.Before(matchDays[j].Date)
 	})
+	weeks := services.SeasonWeekNumbers(matchDays)
 
 	// Enrich match days with additional info
 	type MatchDayWithInfo struct {
@@ -113,12 +222,12 @@ func (s *APIServer) handleListMatchDaysWithStatus(w http.ResponseWriter, r *http
 	}
 
 	result := make([]MatchDayWithInfo, 0, len(matchDays))
-	for i, md := range matchDays {
+	for _, md := range matchDays {
 		scores, _ := s.firestoreClient.GetMatchDayScores(ctx, md.ID)
 		result = append(result, MatchDayWithInfo{
 			MatchDay:   md,
 			HasScores:  len(scores) > 0,
-			WeekNumber: i + 1,
+			WeekNumber: weeks[md.ID],
 		})
 	}
 
@@ -166,15 +275,27 @@ func (s *APIServer) handleUpdateMatchDay(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Prevent editing of completed or locked match days
-	if existingMatchDay.Status == "completed" || existingMatchDay.Status == "locked" {
-		respondWithError(w, "Cannot update a completed or locked match day", http.StatusForbidden)
+	// Prevent editing of locked match days
+	if existingMatchDay.Status == "locked" {
+		respondWithError(w, "Cannot update a locked match day", http.StatusForbidden)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, existingMatchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
 		return
 	}
 
 	var req struct {
 		Date     string `json:"date"`     // Accept as string in YYYY-MM-DD format
 		CourseID string `json:"courseId"` // Optional, only update if provided
+		services.MatchDayConditions
+		MatchPlayBasis *string `json:"matchPlayBasis"` // Optional, "net" or "gross"
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -182,6 +303,29 @@ func (s *APIServer) handleUpdateMatchDay(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Completed match days only accept condition updates; the date, course and match play basis
+	// are fixed once scores exist
+	if existingMatchDay.Status == "completed" && (req.Date != "" || req.CourseID != "") {
+		respondWithError(w, "Cannot change the date or course of a completed match day", http.StatusForbidden)
+		return
+	}
+	if req.MatchPlayBasis != nil && *req.MatchPlayBasis != existingMatchDay.MatchPlayBasis {
+		if existingMatchDay.Status == "completed" {
+			respondWithError(w, "Cannot change the match play basis of a completed match day", http.StatusForbidden)
+			return
+		}
+		if err := services.ValidateMatchPlayBasis(*req.MatchPlayBasis); err != nil {
+			respondWithError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		existingMatchDay.MatchPlayBasis = *req.MatchPlayBasis
+	}
+
+	if err := services.ApplyMatchDayConditions(existingMatchDay, req.MatchDayConditions); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Parse date if provided
 	var parsedDate time.Time
 	if req.Date != "" {
@@ -229,6 +373,176 @@ func (s *APIServer) handleUpdateMatchDay(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(existingMatchDay)
 }
 
+// handleChangeMatchDayCourse moves a match day and all of its matches to another course.
+// Scores already entered are rebuilt on the new course, completed matches are scored again,
+// and the affected players' indices are recalculated. Locked match days cannot be moved. Admin only.
+func (s *APIServer) handleChangeMatchDayCourse(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchDayID := r.PathValue("id")
+	if leagueID == "" || matchDayID == "" {
+		respondWithError(w, "League ID and Match Day ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	var req struct {
+		CourseID string `json:"courseId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.CourseID == "" {
+		respondWithError(w, "courseId is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, matchDayID)
+	if err != nil || matchDay.LeagueID != leagueID {
+		respondWithError(w, "Match day not found", http.StatusNotFound)
+		return
+	}
+	if matchDay.Status == "locked" {
+		respondWithError(w, "Cannot change the course of a locked match day", http.StatusForbidden)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, req.CourseID)
+	if err != nil || course.LeagueID != leagueID {
+		respondWithError(w, "Course not found", http.StatusNotFound)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, matchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetMatchesByMatchDayID(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scores, err := s.firestoreClient.GetMatchDayScores(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, matchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasonPlayersMap := make(map[string]models.SeasonPlayer, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		seasonPlayersMap[sp.PlayerID] = sp
+	}
+	scoredMatches := make(map[string][]string, len(scores))
+	for _, score := range scores {
+		if !score.PlayerAbsent {
+			scoredMatches[score.PlayerID] = append(scoredMatches[score.PlayerID], score.MatchID)
+		}
+	}
+	unestablished, err := s.unestablishedPlayers(ctx, *league, scoredMatches)
+	if err != nil {
+		respondWithError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	updatedDay, movedMatches, rescored, err := services.ChangeMatchDayCourse(*season, *league, *matchDay, matches, scores, *course, seasonPlayersMap, unestablished)
+	if err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(rescored) > 0 {
+		if err := s.firestoreClient.BatchUpsertScores(ctx, rescored); err != nil {
+			respondWithError(w, fmt.Sprintf("Failed to save scores: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	if len(movedMatches) > 0 {
+		if err := s.firestoreClient.BatchUpdateMatches(ctx, movedMatches); err != nil {
+			respondWithError(w, fmt.Sprintf("Failed to update matches: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := s.firestoreClient.UpdateMatchDay(ctx, updatedDay); err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to update match day: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if len(rescored) > 0 && services.UsesHandicaps(*league) {
+		s.recalculateMatchDayHandicaps(ctx, leagueID, matchDay.SeasonID, rescored)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"matchDay": updatedDay,
+		"matches":  movedMatches,
+		"scores":   rescored,
+	})
+}
+
+// recalculateMatchDayHandicaps brings the indices of everyone who played a rescored day up to
+// date, once per player however many of the day's rounds they played. Failures are logged;
+// the nightly job catches the players up.
+func (s *APIServer) recalculateMatchDayHandicaps(ctx context.Context, leagueID, seasonID string, scores []models.Score) {
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list season players for handicap recalc", "season_id", seasonID, "error", err)
+		return
+	}
+	seasonPlayersMap := make(map[string]models.SeasonPlayer, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		seasonPlayersMap[sp.PlayerID] = sp
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list courses for handicap recalc", "league_id", leagueID, "error", err)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	job := services.NewHandicapRecalculationJob(s.firestoreClient)
+	recalculated := make(map[string]bool, len(scores))
+	for _, score := range scores {
+		if score.PlayerAbsent || recalculated[score.PlayerID] {
+			continue
+		}
+		recalculated[score.PlayerID] = true
+		sp, ok := seasonPlayersMap[score.PlayerID]
+		if !ok {
+			logger.WarnContext(ctx, "Season player not found for handicap recalc", "player_id", score.PlayerID)
+			continue
+		}
+		if _, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, sp, coursesMap); err != nil {
+			logger.ErrorContext(ctx, "Failed to recalculate handicap", "player_id", score.PlayerID, "error", err)
+		}
+	}
+}
+
 func (s *APIServer) handleDeleteMatchDay(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
 	matchDayID := r.PathValue("id")
@@ -299,6 +613,16 @@ func (s *APIServer) handleUpdateMatchDayMatches(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	season, err := s.firestoreClient.GetSeason(ctx, existingMatchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
 	var req struct {
 		Matches []struct {
 			ID        string `json:"id"`        // Existing match ID (optional for new matches)
@@ -312,6 +636,15 @@ func (s *APIServer) handleUpdateMatchDayMatches(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	matchups := make([]models.Match, 0, len(req.Matches))
+	for _, reqMatch := range req.Matches {
+		matchups = append(matchups, models.Match{PlayerAID: reqMatch.PlayerAID, PlayerBID: reqMatch.PlayerBID})
+	}
+	if err := services.ValidateMatchDayMatchups(matchups); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get existing matches for this match day
 	existingMatches, err := s.firestoreClient.GetMatchesByMatchDayID(ctx, matchDayID)
 	if err != nil {
@@ -328,6 +661,12 @@ func (s *APIServer) handleUpdateMatchDayMatches(w http.ResponseWriter, r *http.R
 	// Track which existing matches are still in the request
 	requestedMatchIDs := make(map[string]bool)
 
+	playerIDs := make([]string, 0, len(req.Matches)*2)
+	for _, reqMatch := range req.Matches {
+		playerIDs = append(playerIDs, reqMatch.PlayerAID, reqMatch.PlayerBID)
+	}
+	playerNames := s.lookupPlayerNames(ctx, playerIDs...)
+
 	// Process each match in the request
 	updatedMatches := make([]models.Match, 0, len(req.Matches))
 	for _, reqMatch := range req.Matches {
@@ -336,6 +675,7 @@ func (s *APIServer) handleUpdateMatchDayMatches(w http.ResponseWriter, r *http.R
 			if existingMatch, ok := existingMatchMap[reqMatch.ID]; ok {
 				existingMatch.PlayerAID = reqMatch.PlayerAID
 				existingMatch.PlayerBID = reqMatch.PlayerBID
+				services.ApplyMatchPlayerNames(&existingMatch, playerNames)
 				if err := s.firestoreClient.UpdateMatch(ctx, existingMatch); err != nil {
 					respondWithError(w, fmt.Sprintf("Failed to update match: %v", err), http.StatusInternalServerError)
 					return
@@ -356,6 +696,7 @@ func (s *APIServer) handleUpdateMatchDayMatches(w http.ResponseWriter, r *http.R
 				PlayerBID:  reqMatch.PlayerBID,
 				Status:     "scheduled",
 			}
+			services.ApplyMatchPlayerNames(&newMatch, playerNames)
 			if err := s.firestoreClient.CreateMatch(ctx, newMatch); err != nil {
 				respondWithError(w, fmt.Sprintf("Failed to create match: %v", err), http.StatusInternalServerError)
 				return
@@ -405,6 +746,126 @@ func (s *APIServer) handleGetMatchDayMatches(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(matches)
 }
 
+// handleGetBlankScorecards returns pre-filled scorecards for every match on a match day, with
+// course pars, hole handicaps and each player's stroke dots, for players who score on paper
+func (s *APIServer) handleGetBlankScorecards(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchDayID := r.PathValue("id")
+	if leagueID == "" || matchDayID == "" {
+		respondWithError(w, "League ID and Match Day ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, matchDayID)
+	if err != nil || matchDay.LeagueID != leagueID {
+		respondWithError(w, "Match day not found", http.StatusNotFound)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetMatchesByMatchDayID(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, matchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to list season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasonPlayersMap := make(map[string]models.SeasonPlayer, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		seasonPlayersMap[sp.PlayerID] = sp
+	}
+
+	scorecards, err := services.BuildBlankScorecards(*matchDay, matches, coursesMap, seasonPlayersMap, *league)
+	if err != nil {
+		respondWithError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scorecards)
+}
+
+// handleGetMatchDayHandicaps returns every player's index, course handicap and playing
+// handicap for a match day, keyed by player ID, for screens that don't need full scorecards
+func (s *APIServer) handleGetMatchDayHandicaps(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchDayID := r.PathValue("id")
+	if leagueID == "" || matchDayID == "" {
+		respondWithError(w, "League ID and Match Day ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, matchDayID)
+	if err != nil || matchDay.LeagueID != leagueID {
+		respondWithError(w, "Match day not found", http.StatusNotFound)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetMatchesByMatchDayID(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, matchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to list season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasonPlayersMap := make(map[string]models.SeasonPlayer, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		seasonPlayersMap[sp.PlayerID] = sp
+	}
+
+	handicaps, err := services.MatchDayHandicaps(*matchDay, matches, coursesMap, seasonPlayersMap, *league)
+	if err != nil {
+		respondWithError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(handicaps)
+}
+
 // respondWithError sends a JSON error response
 func respondWithError(w http.ResponseWriter, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
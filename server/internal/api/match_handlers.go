@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -27,6 +31,34 @@ func (s *APIServer) handleCreateMatch(w http.ResponseWriter, r *http.Request) {
 	match.Status = "scheduled"
 
 	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	services.ApplyMatchPlayerNames(&match, s.lookupPlayerNames(ctx, match.PlayerAID, match.PlayerBID))
+
+	// With dedupe=true a retried create returns the existing matchup instead of a duplicate
+	if r.URL.Query().Get("dedupe") == "true" {
+		result, created, err := services.FindOrCreateMatch(ctx, s.firestoreClient, match)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to create match: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
 	if err := s.firestoreClient.CreateMatch(ctx, match); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to create match: %v", err), http.StatusInternalServerError)
 		return
@@ -71,8 +103,59 @@ func (s *APIServer) handleGetMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Attachments are only included for the match's players and league admins
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(match)
+	json.NewEncoder(w).Encode(MatchWithAttachments{Match: *match, Attachments: s.visibleAttachments(ctx, *match)})
+}
+
+// handleGetMatchDetail returns a match with both players, its course, both scorecards, who won
+// each hole and the points recorded, so clients don't have to piece them together
+func (s *APIServer) handleGetMatchDetail(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+	if leagueID == "" || matchID == "" {
+		http.Error(w, "League ID and Match ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, match.CourseID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get course: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scores, err := s.firestoreClient.GetMatchScores(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	players, err := s.firestoreClient.GetPlayersByIDs(ctx, []string{match.PlayerAID, match.PlayerBID})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get players: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	detailPlayer := func(playerID string) services.MatchDetailPlayer {
+		player := services.MatchDetailPlayer{PlayerID: playerID, PlayerName: players[playerID].Name}
+		if sp, err := s.firestoreClient.GetSeasonPlayer(ctx, match.SeasonID, playerID); err == nil {
+			player.HandicapIndex = services.EffectiveHandicapIndex(*sp)
+		}
+		return player
+	}
+
+	detail := services.BuildMatchDetail(*match, *course, detailPlayer(match.PlayerAID), detailPlayer(match.PlayerBID), scores)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
 }
 
 func (s *APIServer) handleUpdateMatch(w http.ResponseWriter, r *http.Request) {
@@ -109,6 +192,16 @@ func (s *APIServer) handleUpdateMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	season, err := s.firestoreClient.GetSeason(ctx, existingMatch.SeasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
 	ctx = r.Context()
 	if err := s.firestoreClient.UpdateMatch(ctx, match); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to update match: %v", err), http.StatusInternalServerError)
@@ -135,4 +228,649 @@ func (s *APIServer) handleGetSeasonMatches(w http.ResponseWriter, r *http.Reques
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(matches)
-}
\ No newline at end of file
+}
+
+// handleGetScheduleGrid returns a season's schedule as a grid of match days by players, with
+// each cell showing the player's opponent or a bye
+func (s *APIServer) handleGetScheduleGrid(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.BuildScheduleGrid(seasonID, seasonPlayers, matches))
+}
+
+// handleGetPairingsPreview proposes pairings of the season's active players balanced by
+// handicap (?mode=similar or mixed, default similar) without creating any matches, so an admin
+// can review them before scheduling. In an odd field the bye goes to a player who has had the
+// fewest so far this season.
+func (s *APIServer) handleGetPairingsPreview(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = services.PairingModeSimilar
+	}
+	if err := services.ValidatePairingMode(mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	byes := services.SeasonByes(seasonPlayers, matches)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.GenerateBalancedPairings(seasonPlayers, mode, byes))
+}
+
+// handleGetMatchesByDate lists every match in the league on a calendar date, across seasons,
+// with its course name
+func (s *APIServer) handleGetMatchesByDate(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", r.URL.Query().Get("date"), time.UTC)
+	if err != nil {
+		http.Error(w, "date is required in YYYY-MM-DD format", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	start, end := services.MatchDateRange(date)
+	matches, err := s.firestoreClient.ListLeagueMatchesInRange(ctx, leagueID, start, end)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.MatchesOnDate(matches, coursesMap, date))
+}
+
+// handleGetUnplayedMatches lists a season's matches that are still scheduled, oldest first,
+// for admins to follow up on
+func (s *APIServer) handleGetUnplayedMatches(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season players: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.UnplayedMatches(matches, seasonPlayers))
+}
+
+// handleGetMatchScenario projects a match in progress from the hole scores entered so far,
+// returning what the trailing player needs on the remaining holes
+func (s *APIServer) handleGetMatchScenario(w http.ResponseWriter, r *http.Request) {
+	matchID := r.PathValue("id")
+	if matchID == "" {
+		http.Error(w, "Match ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		HoleScoresA []int `json:"holeScoresA"`
+		HoleScoresB []int `json:"holeScoresB"`
+		ThroughHole *int  `json:"throughHole"` // Defaults to the holes both players have completed
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	throughHole := min(len(req.HoleScoresA), len(req.HoleScoresB))
+	if req.ThroughHole != nil {
+		if *req.ThroughHole < 0 || *req.ThroughHole > throughHole {
+			http.Error(w, fmt.Sprintf("throughHole must be between 0 and %d", throughHole), http.StatusBadRequest)
+			return
+		}
+		throughHole = *req.ThroughHole
+	}
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match: %v", err), http.StatusNotFound)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, match.CourseID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get course: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, match.LeagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Use the same handicap the score entry would: current index, falling back to provisional
+	handicapFor := func(playerID string) float64 {
+		sp, err := s.firestoreClient.GetSeasonPlayer(ctx, match.SeasonID, playerID)
+		if err != nil {
+			return 0
+		}
+		if sp.CurrentHandicapIndex > 0 {
+			return sp.CurrentHandicapIndex
+		}
+		return sp.ProvisionalHandicap
+	}
+
+	_, playingHCA := services.CalculateCourseAndPlayingHandicapForLeague(handicapFor(match.PlayerAID), *course, *league)
+	_, playingHCB := services.CalculateCourseAndPlayingHandicapForLeague(handicapFor(match.PlayerBID), *course, *league)
+	basis, err := s.matchPlayBasis(ctx, match)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	strokesMap := services.AssignStrokesForBasis(basis, match.PlayerAID, playingHCA, match.PlayerBID, playingHCB, *course)
+
+	scoreA := models.Score{PlayerID: match.PlayerAID, HoleScores: req.HoleScoresA}
+	scoreB := models.Score{PlayerID: match.PlayerBID, HoleScores: req.HoleScoresB}
+	rules := services.SeasonMatchPointRules(services.LeagueMatchPointRules(*league), *season)
+	scenario := services.ComputeScenario(scoreA, scoreB, strokesMap[match.PlayerAID], strokesMap[match.PlayerBID], throughHole, len(course.HolePars), rules)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scenario)
+}
+
+// matchPlayBasis returns the match play basis of a match's match day. Matches without a match
+// day are played net. A match day that can't be read is an error rather than a guess, since
+// scoring a gross match day net would give strokes that were never played off.
+func (s *APIServer) matchPlayBasis(ctx context.Context, match *models.Match) (string, error) {
+	if match.MatchDayID == "" {
+		return services.MatchPlayNet, nil
+	}
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, match.MatchDayID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get match day: %w", err)
+	}
+	return matchDay.MatchPlayBasis, nil
+}
+
+// handleGetAbsentPreview shows the inflated scores and resulting points for marking a
+// player absent, without saving anything
+func (s *APIServer) handleGetAbsentPreview(w http.ResponseWriter, r *http.Request) {
+	matchID := r.PathValue("id")
+	playerID := r.URL.Query().Get("playerId")
+	if matchID == "" || playerID == "" {
+		http.Error(w, "Match ID and playerId are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match: %v", err), http.StatusNotFound)
+		return
+	}
+	if playerID != match.PlayerAID && playerID != match.PlayerBID {
+		http.Error(w, "Player is not in this match", http.StatusBadRequest)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, match.CourseID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get course: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, match.LeagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scores, err := s.firestoreClient.GetMatchScores(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scoresByPlayer := make(map[string]models.Score, len(scores))
+	for _, score := range scores {
+		scoresByPlayer[score.PlayerID] = score
+	}
+
+	// Use the same handicap score entry would: the one already on the player's score for this
+	// match, otherwise current index, falling back to provisional
+	handicapFor := func(playerID string) float64 {
+		if score, ok := scoresByPlayer[playerID]; ok {
+			return score.HandicapIndex
+		}
+		sp, err := s.firestoreClient.GetSeasonPlayer(ctx, match.SeasonID, playerID)
+		if err != nil {
+			return 0
+		}
+		if sp.CurrentHandicapIndex > 0 {
+			return sp.CurrentHandicapIndex
+		}
+		return sp.ProvisionalHandicap
+	}
+
+	presentPlayerID := match.PlayerAID
+	if playerID == match.PlayerAID {
+		presentPlayerID = match.PlayerBID
+	}
+	var presentScore *models.Score
+	if score, ok := scoresByPlayer[presentPlayerID]; ok {
+		presentScore = &score
+	}
+
+	basis, err := s.matchPlayBasis(ctx, match)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	preview, err := services.PreviewAbsentMatch(*match, playerID, *course, basis,
+		handicapFor(match.PlayerAID), handicapFor(match.PlayerBID), *league, *season, presentScore)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// handleSimulateMatch replays a played match with a hypothetical handicap index for one
+// player and returns the strokes and points it would have produced, without saving anything
+func (s *APIServer) handleSimulateMatch(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchID := r.PathValue("id")
+	if matchID == "" {
+		http.Error(w, "Match ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		PlayerID      string   `json:"playerId"`
+		HandicapIndex *float64 `json:"handicapIndex"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.PlayerID == "" || req.HandicapIndex == nil {
+		http.Error(w, "playerId and handicapIndex are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		http.Error(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	course, err := s.firestoreClient.GetCourse(ctx, match.CourseID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get course: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, match.LeagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scores, err := s.firestoreClient.GetMatchScores(ctx, matchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scoresByPlayer := make(map[string]models.Score, len(scores))
+	for _, score := range scores {
+		scoresByPlayer[score.PlayerID] = score
+	}
+	scoreA, okA := scoresByPlayer[match.PlayerAID]
+	scoreB, okB := scoresByPlayer[match.PlayerBID]
+	if !okA || !okB {
+		http.Error(w, "Both players need scores entered before the match can be simulated", http.StatusConflict)
+		return
+	}
+
+	basis, err := s.matchPlayBasis(ctx, match)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	simulation, err := services.SimulateMatch(*match, scoreA, scoreB, *course, *league, *season, basis, req.PlayerID, *req.HandicapIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(simulation)
+}
+
+// lookupPlayerNames fetches the names of the given players, reading each player once.
+// Players that cannot be loaded are left out of the result.
+func (s *APIServer) lookupPlayerNames(ctx context.Context, playerIDs ...string) map[string]string {
+	names := make(map[string]string, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if playerID == "" {
+			continue
+		}
+		if _, ok := names[playerID]; ok {
+			continue
+		}
+		player, err := s.firestoreClient.GetPlayer(ctx, playerID)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to look up player name",
+				"player_id", playerID,
+				"error", err,
+			)
+			continue
+		}
+		names[playerID] = player.Name
+	}
+	return names
+}
+
+// handleBulkUpdateMatchPoints overrides the points of several completed matches at once for
+// admin corrections, without re-entering scorecards. Every update is validated before any
+// match is saved, and each change is recorded in the audit log.
+func (s *APIServer) handleBulkUpdateMatchPoints(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	admin, ok := s.requireLeagueAdmin(w, r, leagueID)
+	if !ok {
+		return
+	}
+
+	var updates []services.MatchPointsUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matches, err := s.firestoreClient.ListMatches(ctx, leagueID, "")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+	matchesMap := make(map[string]models.Match, len(matches))
+	for _, m := range matches {
+		matchesMap[m.ID] = m
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasons, err := s.firestoreClient.ListSeasons(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+	seasonsMap := make(map[string]models.Season, len(seasons))
+	for _, season := range seasons {
+		seasonsMap[season.ID] = season
+	}
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+	for _, update := range updates {
+		if match, ok := matchesMap[update.MatchID]; ok {
+			if err := services.CheckSeasonWritable(seasonsMap[match.SeasonID]); err != nil {
+				http.Error(w, fmt.Sprintf("Match %s: %v", match.ID, err), http.StatusConflict)
+				return
+			}
+		}
+	}
+
+	matchTotal := func(m models.Match) services.MatchPointsTotal {
+		return services.MatchTotal(*league, seasonsMap[m.SeasonID], coursesMap[m.CourseID])
+	}
+
+	updated, entries, err := services.ApplyBulkPoints(matchesMap, matchTotal, updates, admin.ID, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.firestoreClient.BatchUpdateMatches(ctx, updated); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	for i := range entries {
+		entries[i].ID = uuid.New().String()
+	}
+	if err := s.firestoreClient.CreateAuditEntries(ctx, entries); err != nil {
+		// The corrections are saved; a missing audit trail should not undo them
+		logger.WarnContext(ctx, "Failed to record match points corrections",
+			"league_id", leagueID,
+			"error", err,
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// handlePointsAudit lists a season's completed matches whose stored points differ from what
+// their scores produce, as happens after manual edits. With ?repair=true the recomputed points
+// are saved and each repair is recorded in the audit log.
+func (s *APIServer) handlePointsAudit(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	admin, ok := s.requireLeagueAdmin(w, r, leagueID)
+	if !ok {
+		return
+	}
+
+	repair := r.URL.Query().Get("repair") == "true"
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	// An archived season can still be audited, but not repaired
+	if repair {
+		if err := services.CheckSeasonWritable(*season); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusNotFound)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scoresByMatch, err := s.matchScores(ctx, matches, func(m models.Match) bool { return m.Status == "completed" })
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	report, err := services.AuditSeasonMatchPoints(*season, *league, matches, scoresByMatch)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recompute match points: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if repair && len(report.Mismatched) > 0 {
+		repaired, entries := services.RepairMatchPoints(matches, report.Mismatched, admin.ID, time.Now())
+		if err := s.firestoreClient.BatchUpdateMatches(ctx, repaired); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to update matches: %v", err), http.StatusInternalServerError)
+			return
+		}
+		report.Repaired = len(repaired)
+
+		for i := range entries {
+			entries[i].ID = uuid.New().String()
+		}
+		if err := s.firestoreClient.CreateAuditEntries(ctx, entries); err != nil {
+			// The repairs are saved; a missing audit trail should not undo them
+			logger.WarnContext(ctx, "Failed to record match points repairs",
+				"league_id", leagueID,
+				"error", err,
+			)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// matchScores fetches the scores of the matches selected by include, keyed by match ID
+func (s *APIServer) matchScores(ctx context.Context, matches []models.Match, include func(models.Match) bool) (map[string][]models.Score, error) {
+	scoresByMatch := make(map[string][]models.Score)
+	for _, match := range matches {
+		if !include(match) {
+			continue
+		}
+		scores, err := s.firestoreClient.GetMatchScores(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scores for match %s: %w", match.ID, err)
+		}
+		scoresByMatch[match.ID] = scores
+	}
+	return scoresByMatch, nil
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golf-league-manager/internal/services"
+)
+
+// UpdateNotificationPrefsRequest changes a player's notification preferences; omitted fields keep their current value
+type UpdateNotificationPrefsRequest struct {
+	MatchupReminders *bool `json:"matchupReminders"`
+	ResultsPosted    *bool `json:"resultsPosted"`
+	HandicapChanged  *bool `json:"handicapChanged"`
+}
+
+// authorizeNotificationPrefs allows players to manage their own preferences and league admins to manage anyone's.
+// It writes the error response and returns false when access is denied.
+func (s *APIServer) authorizeNotificationPrefs(w http.ResponseWriter, r *http.Request, leagueID, playerID string) bool {
+	ctx := r.Context()
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return false
+	}
+	if player.ID == playerID {
+		return true
+	}
+
+	isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, player.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+		return false
+	}
+	if !isAdmin {
+		http.Error(w, "Access denied: you can only manage your own notification preferences", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// handleGetNotificationPrefs returns a player's notification preferences for a league.
+// Players who have never saved preferences get the defaults, with everything on.
+func (s *APIServer) handleGetNotificationPrefs(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if !s.authorizeNotificationPrefs(w, r, leagueID, playerID) {
+		return
+	}
+
+	prefs, err := services.LoadNotificationPrefs(r.Context(), s.firestoreClient, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get notification preferences: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prefs)
+}
+
+// handleUpdateNotificationPrefs saves a player's notification preferences for a league
+func (s *APIServer) handleUpdateNotificationPrefs(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if !s.authorizeNotificationPrefs(w, r, leagueID, playerID) {
+		return
+	}
+
+	var req UpdateNotificationPrefsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	isMember, err := s.firestoreClient.IsLeagueMember(ctx, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check league membership: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !isMember {
+		http.Error(w, "Player is not a member of this league", http.StatusNotFound)
+		return
+	}
+
+	prefs, err := services.LoadNotificationPrefs(ctx, s.firestoreClient, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get notification preferences: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if req.MatchupReminders != nil {
+		prefs.MatchupReminders = *req.MatchupReminders
+	}
+	if req.ResultsPosted != nil {
+		prefs.ResultsPosted = *req.ResultsPosted
+	}
+	if req.HandicapChanged != nil {
+		prefs.HandicapChanged = *req.HandicapChanged
+	}
+	prefs.UpdatedAt = time.Now()
+
+	if err := s.firestoreClient.SetNotificationPrefs(ctx, &prefs); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save notification preferences: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prefs)
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 	"net/http"
 	"time"
 
@@ -80,11 +83,302 @@ func (s *APIServer) handleUpdatePlayer(w http.ResponseWriter, r *http.Request) {
 	player.ID = playerID
 
 	ctx := r.Context()
+
+	existing, err := s.firestoreClient.GetPlayer(ctx, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get player: %v", err), http.StatusNotFound)
+		return
+	}
+
 	if err := s.firestoreClient.UpdatePlayer(ctx, player); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to update player: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	// Keep denormalized names in sync; a failure here must not fail the rename itself
+	if existing.Name != player.Name {
+		if err := services.PropagatePlayerName(ctx, s.firestoreClient, playerID, player.Name); err != nil {
+			logger.WarnContext(ctx, "Player name propagation incomplete",
+				"player_id", playerID,
+				"error", err,
+			)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(player)
-}
\ No newline at end of file
+}
+
+// handleGetPlayerRemainingMatches returns the player's scheduled matches for a season, ordered by date.
+// Defaults to the league's active season when seasonId is not provided.
+func (s *APIServer) handleGetPlayerRemainingMatches(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	seasonID := r.URL.Query().Get("seasonId")
+	if seasonID == "" {
+		season, err := s.firestoreClient.GetActiveSeason(ctx, leagueID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get active season: %v", err), http.StatusNotFound)
+			return
+		}
+		seasonID = season.ID
+	}
+
+	matches, opponents, coursesMap, err := s.loadPlayerSchedule(ctx, leagueID, seasonID, playerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	remaining := services.BuildRemainingMatches(playerID, matches, opponents, coursesMap)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(remaining)
+}
+
+// handleGetPlayerPendingConfirmations returns the player's completed matches whose result is
+// still waiting on their confirmation, oldest first
+func (s *APIServer) handleGetPlayerPendingConfirmations(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matches, err := s.firestoreClient.ListMatches(ctx, leagueID, services.MatchStatusCompletedUnconfirmed)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.PendingConfirmations(playerID, matches))
+}
+
+// handleGetPlayerHolePerformance returns how often a player wins, loses and halves each hole of
+// a course on net across their completed matches there
+func (s *APIServer) handleGetPlayerHolePerformance(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+	courseID := r.URL.Query().Get("courseId")
+	if courseID == "" {
+		http.Error(w, "courseId is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matches, err := s.firestoreClient.GetPlayerCompletedMatches(ctx, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scoresByMatch, err := s.matchScores(ctx, matches, func(match models.Match) bool {
+		return match.CourseID == courseID
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	var scores []models.Score
+	for _, matchScores := range scoresByMatch {
+		scores = append(scores, matchScores...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.ComputeHolePerformance(matches, scores, playerID, courseID))
+}
+
+// handleGetPlayerCareer returns a player's lifetime stats across every season in the league
+func (s *APIServer) handleGetPlayerCareer(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	scores, err := s.firestoreClient.ListPlayerLeagueScores(ctx, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetPlayerCompletedMatches(ctx, leagueID, playerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	stats := services.ComputeCareerStats(playerID, scores, matches)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// handleComparePlayers returns two players' season performance side by side, including
+// their record against each other, for a rivalry page
+func (s *APIServer) handleComparePlayers(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	playerAID := r.URL.Query().Get("a")
+	playerBID := r.URL.Query().Get("b")
+	if playerAID == "" || playerBID == "" {
+		http.Error(w, "Query parameters a and b are required", http.StatusBadRequest)
+		return
+	}
+	if playerAID == playerBID {
+		http.Error(w, "Cannot compare a player with themselves", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	var scores []models.Score
+	for _, playerID := range []string{playerAID, playerBID} {
+		playerScores, err := s.firestoreClient.ListPlayerLeagueScores(ctx, leagueID, playerID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+			return
+		}
+		scores = append(scores, playerScores...)
+	}
+
+	comparison := services.ComparePlayers(seasonID, playerAID, playerBID, scores, matches)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comparison)
+}
+
+// handleGetPlayerScheduleICS returns a player's upcoming matches as an iCalendar feed
+func (s *APIServer) handleGetPlayerScheduleICS(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	seasonID := r.URL.Query().Get("seasonId")
+	if seasonID == "" {
+		season, err := s.firestoreClient.GetActiveSeason(ctx, leagueID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get active season: %v", err), http.StatusNotFound)
+			return
+		}
+		seasonID = season.ID
+	}
+
+	matches, opponents, coursesMap, err := s.loadPlayerSchedule(ctx, leagueID, seasonID, playerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ics := services.BuildICS(matches, playerID, opponents, coursesMap, time.Now())
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "inline; filename=\"schedule.ics\"")
+	w.Write([]byte(ics))
+}
+
+// handleExportPlayer returns a player's full record in a league as a single JSON document.
+// Players can export their own record; league admins can export anyone's.
+func (s *APIServer) handleExportPlayer(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if !s.authorizePlayerRecord(w, r, leagueID, playerID, "Access denied: can only export own record") {
+		return
+	}
+
+	export, err := services.ExportPlayerRecord(r.Context(), s.firestoreClient, leagueID, playerID, time.Now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to export player: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"player-%s.json\"", playerID))
+	json.NewEncoder(w).Encode(export)
+}
+
+// loadPlayerSchedule fetches a player's scheduled matches for a season along with the
+// opponents and courses needed to describe them. Opponents are only looked up when their
+// name was not denormalized onto the match.
+func (s *APIServer) loadPlayerSchedule(ctx context.Context, leagueID, seasonID, playerID string) ([]models.Match, map[string]models.Player, map[string]models.Course, error) {
+	matches, err := s.firestoreClient.GetPlayerScheduledMatchesForSeason(ctx, seasonID, playerID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to get scheduled matches: %v", err)
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to list courses: %v", err)
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	opponents := make(map[string]models.Player)
+	for _, match := range matches {
+		opponentID, opponentName := match.PlayerAID, match.PlayerAName
+		if opponentID == playerID {
+			opponentID, opponentName = match.PlayerBID, match.PlayerBName
+		}
+		if opponentName != "" {
+			continue
+		}
+		if _, ok := opponents[opponentID]; ok {
+			continue
+		}
+		opponent, err := s.firestoreClient.GetPlayer(ctx, opponentID)
+		if err != nil {
+			continue
+		}
+		opponents[opponentID] = *opponent
+	}
+
+	return matches, opponents, coursesMap, nil
+}
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"math"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
 
+	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
 	"golf-league-manager/internal/services"
 
@@ -14,10 +19,34 @@ import (
 )
 
 type ScoreSubmission struct {
-	MatchID      string `json:"matchId"`
-	PlayerID     string `json:"playerId"`
-	HoleScores   []int  `json:"holeScores"`
-	PlayerAbsent bool   `json:"playerAbsent"`
+	MatchID       string `json:"matchId"`
+	PlayerID      string `json:"playerId"`
+	HoleScores    []int  `json:"holeScores"`
+	PlayerAbsent  bool   `json:"playerAbsent"`
+	ConcededHoles []int  `json:"concededHoles"` // Holes (1-based) conceded to this player; a 0 score on these is filled with net par
+
+	StrokeOverride map[string][]int `json:"strokeOverride"` // Optional strokes per hole agreed by the players, keyed by player ID, used instead of the computed allocation
+
+	Makeup     bool `json:"makeup"`     // Played as a makeup for a missed match
+	Substitute bool `json:"substitute"` // Played by a substitute standing in for the player
+}
+
+// submittedStrokeOverride returns the stroke override sent with a match's submissions. Either
+// player's card may carry it, but when both do they must agree.
+func submittedStrokeOverride(submissions []ScoreSubmission) (map[string][]int, bool, error) {
+	var override map[string][]int
+	found := false
+	for _, sub := range submissions {
+		if sub.StrokeOverride == nil {
+			continue
+		}
+		if found && !reflect.DeepEqual(override, sub.StrokeOverride) {
+			return nil, false, fmt.Errorf("submissions disagree on the stroke override")
+		}
+		override = sub.StrokeOverride
+		found = true
+	}
+	return override, found, nil
 }
 
 // ScoreResponse is used for returning score data to the client
@@ -72,6 +101,69 @@ func (s *APIServer) handleGetMatchDayScores(w http.ResponseWriter, r *http.Reque
 	})
 }
 
+// handleGetMatchDayLeaderboard ranks a match day's field on gross and net, marked provisional
+// when fewer players played than the season's minimum field for statistics
+func (s *APIServer) handleGetMatchDayLeaderboard(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	matchDayID := r.PathValue("id")
+	if leagueID == "" || matchDayID == "" {
+		respondWithError(w, "League ID and Match Day ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, matchDayID)
+	if err != nil || matchDay.LeagueID != leagueID {
+		respondWithError(w, "Match day not found", http.StatusNotFound)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, matchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	scores, err := s.firestoreClient.GetMatchDayScores(ctx, matchDayID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.BuildMatchDayLeaderboard(*season, matchDayID, scores))
+}
+
+// handleGetPlayerMatchDayScores returns all of a player's scores on a match day,
+// including every match they played that day
+func (s *APIServer) handleGetPlayerMatchDayScores(w http.ResponseWriter, r *http.Request) {
+	matchDayID := r.PathValue("id")
+	playerID := r.PathValue("player_id")
+	if matchDayID == "" || playerID == "" {
+		respondWithError(w, "Match Day ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	if _, err := s.firestoreClient.GetMatchDay(ctx, matchDayID); err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get match day: %v", err), http.StatusNotFound)
+		return
+	}
+
+	scores, err := s.firestoreClient.GetPlayerMatchDayScores(ctx, matchDayID, playerID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.setDisplayNetScores(ctx, scores)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
 func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
 	if leagueID == "" {
@@ -90,6 +182,7 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 	}
 
 	ctx := r.Context()
+	log := logger.FromContext(ctx)
 
 	// Validate match day ID is provided
 	if req.MatchDayID == "" {
@@ -123,6 +216,32 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 		matchesMap[m.ID] = m
 	}
 
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	season, err := s.firestoreClient.GetSeason(ctx, currentMatchDay.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	// Strict seasons reject the whole submission rather than saving around a misplaced score
+	entries := make([]services.ScoreEntryRef, 0, len(req.Scores))
+	for _, sub := range req.Scores {
+		entries = append(entries, services.ScoreEntryRef{MatchID: sub.MatchID, PlayerID: sub.PlayerID})
+	}
+	if err := services.CheckScoreEntryPlayers(*season, matchesMap, entries); err != nil {
+		respondWithError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
 	if err != nil {
 		respondWithError(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
@@ -133,6 +252,27 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 		coursesMap[c.ID] = c
 	}
 
+	// Reject submissions that don't line up with the course's hole data rather than
+	// accepting scores that skip net double bogey adjustment
+	for _, sub := range req.Scores {
+		match, ok := matchesMap[sub.MatchID]
+		if !ok {
+			continue
+		}
+		course, ok := coursesMap[match.CourseID]
+		if !ok {
+			continue
+		}
+		holeScores := sub.HoleScores
+		if sub.PlayerAbsent {
+			holeScores = nil
+		}
+		if err := services.ValidateCourseHoles(course, holeScores); err != nil {
+			respondWithError(w, fmt.Sprintf("Invalid scores for match %s: %v", sub.MatchID, err), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Fetch Season Players to get current/provisional handicaps
 	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, currentMatchDay.SeasonID)
 	if err != nil {
@@ -147,7 +287,7 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 	// Fetch existing scores for the match day to handle updates and partial submissions
 	existingScores, err := s.firestoreClient.GetMatchDayScores(ctx, req.MatchDayID)
 	if err != nil {
-		log.Printf("Warning: Failed to get existing scores: %v", err)
+		log.Warn("Failed to get existing scores", "match_day_id", req.MatchDayID, "error", err)
 	}
 	// Map: MatchID -> PlayerID -> Score
 	existingScoresMap := make(map[string]map[string]models.Score)
@@ -169,25 +309,29 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 	scoresToSave := make([]models.Score, 0)
 	matchesToUpdate := make([]models.Match, 0)
 
-	// Helper to get effective handicap
-	getEffectiveHandicap := func(playerID string, matchID string) float64 {
-		// If score exists, use the handicap from that score (preserve history)
-		if matchScores, ok := existingScoresMap[matchID]; ok {
-			if score, ok := matchScores[playerID]; ok {
-				return score.HandicapIndex
-			}
-		}
-		// Otherwise use current or provisional from season player
-		if sp, ok := seasonPlayersMap[playerID]; ok {
-			if sp.CurrentHandicapIndex > 0 {
-				return sp.CurrentHandicapIndex
-			}
-			return sp.ProvisionalHandicap
-		}
-		return 0
+	// 4. Build every submitted score before any points are calculated. The existing scores
+	// map is only updated once all submissions are built, so handicap lookups always see the
+	// stored scores and points never depend on the order matches are processed in.
+	type matchStrokes struct {
+		a, b []int
+	}
+	strokesByMatch := make(map[string]matchStrokes)
+	submittedScores := make([]models.Score, 0, len(req.Scores))
+	overriddenMatches := make(map[string]bool)
+	var strokeAuditEntries []models.AuditEntry
+	var actorID string // Looked up with the first stroke override
+
+	scoredMatches := make(map[string][]string, len(req.Scores))
+	for _, sub := range req.Scores {
+		scoredMatches[sub.PlayerID] = append(scoredMatches[sub.PlayerID], sub.MatchID)
 	}
+	unestablished, err := s.unestablishedPlayers(ctx, *league, scoredMatches)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to check established handicaps: %v", err), http.StatusInternalServerError)
+		return
+	}
+	week := s.matchDayWeek(ctx, *currentMatchDay)
 
-	// 4. Process Matches
 	for matchID, submissions := range scoresByMatch {
 		match, ok := matchesMap[matchID]
 		if !ok {
@@ -201,201 +345,224 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 			continue
 		}
 
-		// Identify players
-		playerA := match.PlayerAID
-		playerB := match.PlayerBID
-
-		// Get Handicaps
-		handicapA := getEffectiveHandicap(playerA, matchID)
-		handicapB := getEffectiveHandicap(playerB, matchID)
+		// Get Handicaps, preserving the index on any score already entered for the match
+		indexA := services.MatchScoreIndex(match.PlayerAID, existingScoresMap[matchID], seasonPlayersMap)
+		indexB := services.MatchScoreIndex(match.PlayerBID, existingScoresMap[matchID], seasonPlayersMap)
 
 		// Calculate Playing Handicaps & Strokes
-		courseHCA, playingHCA := services.CalculateCourseAndPlayingHandicap(handicapA, course)
-		courseHCB, playingHCB := services.CalculateCourseAndPlayingHandicap(handicapB, course)
+		handicapA, handicapB := services.MatchHandicaps(match, course, *league, currentMatchDay.MatchPlayBasis, indexA, indexB)
 
-		strokesMap := services.AssignStrokes(playerA, playingHCA, playerB, playingHCB, course)
-		strokesA := strokesMap[playerA]
-		strokesB := strokesMap[playerB]
+		// Players may agree to play off different strokes than computed. An override sent with
+		// this submission is recorded on the match; otherwise one recorded earlier still applies.
+		override, submittedOverride, err := submittedStrokeOverride(submissions)
+		if err != nil {
+			processingErrors = append(processingErrors, fmt.Sprintf("Match %s: %v", matchID, err))
+			continue
+		}
+		if submittedOverride {
+			if err := services.ValidateStrokeOverride(match, len(course.HolePars), override); err != nil {
+				processingErrors = append(processingErrors, fmt.Sprintf("Invalid stroke override for match %s: %v", matchID, err))
+				continue
+			}
+			computedA, computedB := handicapA.Strokes, handicapB.Strokes
+			services.ApplyStrokeOverride(match, len(course.HolePars), override, &handicapA, &handicapB)
+			match = services.RecordStrokeOverride(match, override)
+			matchesMap[matchID] = match
+			overriddenMatches[matchID] = true
+			if actorID == "" {
+				actorID = s.auditActorID(ctx)
+			}
+			strokeAuditEntries = append(strokeAuditEntries, services.StrokeOverrideAuditEntry(match, computedA, computedB, handicapA.Strokes, handicapB.Strokes, actorID, time.Now()))
+		} else if match.StrokesOverridden {
+			services.ApplyStrokeOverride(match, len(course.HolePars), match.StrokeOverride, &handicapA, &handicapB)
+		}
+		strokesByMatch[matchID] = matchStrokes{a: handicapA.Strokes, b: handicapB.Strokes}
 
 		// Process each submission for this match
+		submitted := make(map[string]bool, len(submissions))
 		for _, sub := range submissions {
-			var leagueHandicapIndex float64
-			var playingHandicap int
-			var courseHandicap float64
-			var matchStrokes []int
-
-			if sub.PlayerID == playerA {
-				leagueHandicapIndex = handicapA
-				playingHandicap = playingHCA
-				courseHandicap = courseHCA
-				matchStrokes = strokesA
-			} else if sub.PlayerID == playerB {
-				leagueHandicapIndex = handicapB
-				playingHandicap = playingHCB
-				courseHandicap = courseHCB
-				matchStrokes = strokesB
-			} else {
-				processingErrors = append(processingErrors, fmt.Sprintf("Player %s not in match %s", sub.PlayerID, matchID))
+			if submitted[sub.PlayerID] {
+				processingErrors = append(processingErrors, fmt.Sprintf("Duplicate score for player %s in match %s", sub.PlayerID, matchID))
 				continue
 			}
+			submitted[sub.PlayerID] = true
 
-			var holeScores []int
-			var totalGross int
-			var adjustedScores []int
-			var totalAdjusted int
-			var differential float64
+			var handicap services.PlayerMatchHandicap
+			var playerName string
 
-			if sub.PlayerAbsent {
-				holeScores = services.CalculateAbsentPlayerScores(playingHandicap, course)
-				for _, sc := range holeScores {
-					totalGross += sc
-				}
-				adjustedScores = make([]int, len(holeScores))
-				copy(adjustedScores, holeScores)
-				totalAdjusted = totalGross
-				differential = 0
+			if sub.PlayerID == match.PlayerAID {
+				playerName = match.PlayerAName
+				handicap = handicapA
+			} else if sub.PlayerID == match.PlayerBID {
+				playerName = match.PlayerBName
+				handicap = handicapB
 			} else {
-				holeScores = sub.HoleScores
-				for _, sc := range holeScores {
-					totalGross += sc
-				}
-				adjustedScores = services.CalculateAdjustedGrossScores(holeScores, course, int(math.Round(courseHandicap)))
-				for _, sc := range adjustedScores {
-					totalAdjusted += sc
-				}
-				tempScore := models.Score{
-					AdjustedGross: totalAdjusted,
-				}
-				differential = services.CalculateDifferential(tempScore, course)
+				processingErrors = append(processingErrors, fmt.Sprintf("Player %s not in match %s", sub.PlayerID, matchID))
+				continue
 			}
-
-			// Calculate Net Hole Scores & Match Net Score
-			netHoleScores := make([]int, len(holeScores))
-			matchNetScore := 0
-			for i, gross := range holeScores {
-				netHoleScores[i] = gross - matchStrokes[i]
-				matchNetScore += netHoleScores[i]
+			if playerName == "" {
+				playerName = seasonPlayersMap[sub.PlayerID].PlayerName
 			}
-
-			// Prepare Score Object
-			scoreID := uuid.New().String()
-			if matchScores, ok := existingScoresMap[matchID]; ok {
-				if existingScore, ok := matchScores[sub.PlayerID]; ok {
-					scoreID = existingScore.ID
-				}
+			handicap.Unestablished = unestablished[sub.PlayerID]
+
+			card := services.ScoreCard{
+				HoleScores:    sub.HoleScores,
+				ConcededHoles: sub.ConcededHoles,
+				PlayerAbsent:  sub.PlayerAbsent,
+				Makeup:        sub.Makeup,
+				Substitute:    sub.Substitute,
 			}
-
-			score := models.Score{
-				ID:                      scoreID,
-				MatchID:                 matchID,
-				PlayerID:                sub.PlayerID,
-				LeagueID:                leagueID,
-				Date:                    match.MatchDate,
-				CourseID:                match.CourseID,
-				HoleScores:              holeScores,
-				HoleAdjustedGrossScores: adjustedScores,
-				MatchNetHoleScores:      netHoleScores,
-				GrossScore:              totalGross,
-				NetScore:                totalGross - playingHandicap,
-				MatchNetScore:           matchNetScore,
-				AdjustedGross:           totalAdjusted,
-				HandicapDifferential:    differential,
-				HandicapIndex:           leagueHandicapIndex,
-				CourseHandicap:          int(math.Round(courseHandicap)),
-				PlayingHandicap:         playingHandicap,
-				StrokesReceived:         playingHandicap, // Strokes received generally equals playing handicap
-				MatchStrokes:            matchStrokes,
-				PlayerAbsent:            sub.PlayerAbsent,
+			score, err := services.BuildMatchScore(match, course, *league, sub.PlayerID, card, handicap)
+			if err != nil {
+				processingErrors = append(processingErrors, fmt.Sprintf("Player %s in match %s: %v", sub.PlayerID, matchID, err))
+				continue
 			}
 
-			scoresToSave = append(scoresToSave, score)
-			
-			// Update in-memory map for points calculation
-			if _, ok := existingScoresMap[matchID]; !ok {
-				existingScoresMap[matchID] = make(map[string]models.Score)
+			// Prepare Score Object
+			score.ID = uuid.New().String()
+			if existingScore, ok := existingScoresMap[matchID][sub.PlayerID]; ok {
+				score.ID = existingScore.ID
 			}
-			existingScoresMap[matchID][sub.PlayerID] = score
-			
+			score.PlayerName = playerName
+			score.LeagueID = leagueID
+			score.WeekNumber = week
+
+			submittedScores = append(submittedScores, score)
 			processedCount++
 		}
+	}
+
+	for _, score := range submittedScores {
+		if _, ok := existingScoresMap[score.MatchID]; !ok {
+			existingScoresMap[score.MatchID] = make(map[string]models.Score)
+		}
+		existingScoresMap[score.MatchID][score.PlayerID] = score
+	}
+	scoresToSave = append(scoresToSave, submittedScores...)
 
-		// Calculate Match Points if both players have scores
-		// We use existingScoresMap which now contains the updated/new scores
-		matchScores := existingScoresMap[matchID]
-		scoreA, hasA := matchScores[playerA]
-		scoreB, hasB := matchScores[playerB]
-
-		if hasA && hasB {
-			// Recalculate points using the scores (which have correct MatchNetHoleScores derived from strokes)
-			// Note: CalculateMatchPoints uses HoleScores and Strokes to calculate net, 
-			// but our Score object already has MatchNetHoleScores. 
-			// services.CalculateMatchPoints takes Score objects and Strokes arrays.
-			
-			pointsA, pointsB := services.CalculateMatchPoints(scoreA, scoreB, strokesA, strokesB)
-
-			match.Status = "completed"
-			match.PlayerAPoints = pointsA
-			match.PlayerBPoints = pointsB
-			
+	// 5. Calculate Match Points for every submitted match that now has both players' scores
+	for matchID, strokes := range strokesByMatch {
+		match, ok, err := services.CompleteMatchWithRules(*season, services.LeagueMatchPointRules(*league), matchesMap[matchID], existingScoresMap[matchID], strokes.a, strokes.b)
+		if err != nil {
+			respondWithError(w, fmt.Sprintf("Failed to score match %s: %v", matchID, err), http.StatusInternalServerError)
+			return
+		}
+		if ok {
 			matchesToUpdate = append(matchesToUpdate, match)
+			delete(overriddenMatches, matchID)
+		}
+	}
+	// Matches still waiting on a card are saved too, so the override is there when it arrives
+	for matchID := range overriddenMatches {
+		matchesToUpdate = append(matchesToUpdate, matchesMap[matchID])
+	}
+
+	// Apply the playing conditions adjustment across the whole field for the day.
+	// Every non-absent score's differential is recomputed, including scores entered earlier,
+	// so the day stays consistent when later submissions change the PCC. Fields smaller than
+	// the season's minimum for statistics get no adjustment.
+	// Gross-only leagues record no differentials, so there is nothing to adjust.
+	usesHandicaps := services.UsesHandicaps(*league)
+	pccChanged := false
+	if usesHandicaps {
+		var dayScores []models.Score
+		for _, matchScores := range existingScoresMap {
+			for _, score := range matchScores {
+				dayScores = append(dayScores, score)
+			}
+		}
+		pcc := services.ComputeSeasonPCC(*season, dayScores, coursesMap, services.StandardSlope(*league))
+		pccChanged = pcc != currentMatchDay.PCC
+		if pccChanged {
+			scoresToSave = dayScores
+			currentMatchDay.PCC = pcc
+		}
+		for i := range scoresToSave {
+			if scoresToSave[i].PlayerAbsent {
+				continue
+			}
+			if course, ok := coursesMap[scoresToSave[i].CourseID]; ok {
+				if err := services.CheckSlopeRating(course.SlopeRating); err != nil {
+					log.Warn("Skipping differential for course with invalid slope", "course_id", course.ID, "player_id", scoresToSave[i].PlayerID, "error", err)
+					scoresToSave[i].HandicapDifferential = 0
+					continue
+				}
+				scoresToSave[i].HandicapDifferential = services.CalculateLeagueDifferential(scoresToSave[i], course, pcc, services.StandardSlope(*league))
+				services.FlagHighRound(*league, &scoresToSave[i])
+			}
 		}
 	}
 
-	// 5. Batch Save Scores
+	// 6. Batch Save Scores
 	if len(scoresToSave) > 0 {
 		if err := s.firestoreClient.BatchUpsertScores(ctx, scoresToSave); err != nil {
-			log.Printf("Error batch saving scores: %v", err)
+			log.Error("Failed to batch save scores", "match_day_id", req.MatchDayID, "error", err)
 			respondWithError(w, "Failed to save scores", http.StatusInternalServerError)
 			return
 		}
 	}
 
-	// 6. Recalculate Handicaps (for players who submitted non-absent scores)
+	if err := services.RecordScoreAchievements(ctx, s.firestoreClient, scoresToSave, coursesMap, currentMatchDay.SeasonID); err != nil {
+		log.Error("Failed to record achievements", "match_day_id", req.MatchDayID, "error", err)
+	}
+
+	// 7. Recalculate Handicaps (for players who submitted non-absent scores)
+	// When the PCC changed, every player's differential from the day moved, not just the submitters'
 	job := services.NewHandicapRecalculationJob(s.firestoreClient)
-	for _, sub := range req.Scores {
-		if !sub.PlayerAbsent {
+	for _, score := range scoresToSave {
+		if usesHandicaps && !score.PlayerAbsent {
 			// Get the season player record for handicap recalculation
-			sp, ok := seasonPlayersMap[sub.PlayerID]
+			sp, ok := seasonPlayersMap[score.PlayerID]
 			if !ok {
-				log.Printf("Season player not found for handicap recalc: %s", sub.PlayerID)
+				log.Warn("Season player not found for handicap recalc", "player_id", score.PlayerID)
 				continue
 			}
 
-			if err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, sp, coursesMap); err != nil {
-				log.Printf("Error recalculating handicap for player %s: %v", sub.PlayerID, err)
+			if _, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, sp, coursesMap); err != nil {
+				log.Error("Failed to recalculate handicap", "player_id", score.PlayerID, "error", err)
 			}
 		}
 	}
 
-	// 7. Batch Update Matches
+	// 8. Batch Update Matches
 	if len(matchesToUpdate) > 0 {
 		if err := s.firestoreClient.BatchUpdateMatches(ctx, matchesToUpdate); err != nil {
-			log.Printf("Error batch updating matches: %v", err)
+			log.Error("Failed to batch update matches", "match_day_id", req.MatchDayID, "error", err)
+		}
+	}
+	if len(strokeAuditEntries) > 0 {
+		for i := range strokeAuditEntries {
+			strokeAuditEntries[i].ID = uuid.New().String()
+		}
+		if err := s.firestoreClient.CreateAuditEntries(ctx, strokeAuditEntries); err != nil {
+			log.Warn("Failed to record stroke overrides", "match_day_id", req.MatchDayID, "error", err)
 		}
 	}
 
-	// 8. Update Match Day Status
-	if currentMatchDay.Status != "locked" && currentMatchDay.Status != "completed" {
-		currentMatchDay.Status = "completed"
+	// 9. Update Match Day Status
+	if status := services.MatchDayStatusAfterScoreEntry(*season, *currentMatchDay, matches, existingScoresMap); status != currentMatchDay.Status {
+		currentMatchDay.Status = status
 		if err := s.firestoreClient.UpdateMatchDay(ctx, *currentMatchDay); err != nil {
-			log.Printf("Error updating match day status to completed: %v", err)
+			log.Error("Failed to update match day status", "match_day_id", req.MatchDayID, "error", err)
+		}
+	} else if pccChanged {
+		if err := s.firestoreClient.UpdateMatchDay(ctx, *currentMatchDay); err != nil {
+			log.Error("Failed to update match day PCC", "match_day_id", req.MatchDayID, "error", err)
 		}
 	}
 
-	// 9. Lock previous match days (only if not an update)
-	if !isUpdate {
-		allMatchDays, err := s.firestoreClient.ListMatchDays(ctx, leagueID)
+	// 10. Lock previous match days (only if not an update, and not while this day is still open)
+	if !isUpdate && currentMatchDay.Status != "scheduled" {
+		seasonMatchDays, err := s.firestoreClient.ListMatchDaysBySeason(ctx, currentMatchDay.SeasonID)
 		if err == nil {
-			for _, md := range allMatchDays {
-				if md.SeasonID == currentMatchDay.SeasonID &&
-					md.Date.Before(currentMatchDay.Date) &&
-					md.Status != "locked" {
-					md.Status = "locked"
-					if err := s.firestoreClient.UpdateMatchDay(ctx, md); err != nil {
-						log.Printf("Error locking match day %s: %v", md.ID, err)
-					}
+			for _, md := range services.MatchDaysToLock(*currentMatchDay, seasonMatchDays) {
+				md.Status = "locked"
+				if err := s.firestoreClient.UpdateMatchDay(ctx, md); err != nil {
+					log.Error("Failed to lock match day", "match_day_id", md.ID, "error", err)
 				}
 			}
+		} else {
+			log.Error("Failed to list match days to lock", "season_id", currentMatchDay.SeasonID, "error", err)
 		}
 	}
 
@@ -421,6 +588,48 @@ func (s *APIServer) handleEnterMatchDayScores(w http.ResponseWriter, r *http.Req
 	json.NewEncoder(w).Encode(response)
 }
 
+// setDisplayNetScores fills in the display net of scores from one league before they are
+// returned, with the league's current net floor. Scores show their raw net if the league or
+// its courses can't be read.
+func (s *APIServer) setDisplayNetScores(ctx context.Context, scores []models.Score) {
+	if len(scores) == 0 {
+		return
+	}
+	leagueID := scores[0].LeagueID
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to get league for display net scores", "league_id", leagueID, "error", err)
+		services.SetDisplayNetScores(scores, nil, models.League{})
+		return
+	}
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to list courses for display net scores", "league_id", leagueID, "error", err)
+		services.SetDisplayNetScores(scores, nil, models.League{})
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+	services.SetDisplayNetScores(scores, coursesMap, *league)
+}
+
+// auditActorID returns the player ID of the signed-in user for the audit log, or their user
+// ID when they have no player record
+func (s *APIServer) auditActorID(ctx context.Context) string {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		return userID
+	}
+	return player.ID
+}
+
 func (s *APIServer) handleEnterScore(w http.ResponseWriter, r *http.Request) {
 	var score models.Score
 	if err := json.NewDecoder(r.Body).Decode(&score); err != nil {
@@ -506,50 +715,288 @@ func (s *APIServer) handleGetPlayerHandicap(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(response)
 }
 
-func (s *APIServer) handleGetPlayerScores(w http.ResponseWriter, r *http.Request) {
+// handleSubmitMyScore lets a player report their own scores for one of their matches without
+// waiting for the match day batch entry. Points are awarded once both players have reported.
+func (s *APIServer) handleSubmitMyScore(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
-	playerID := r.PathValue("id")
-	if leagueID == "" || playerID == "" {
-		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+	matchID := r.PathValue("id")
+	if leagueID == "" || matchID == "" {
+		respondWithError(w, "League ID and Match ID are required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		HoleScores    []int `json:"holeScores"`
+		PlayerAbsent  bool  `json:"playerAbsent"`
+		ConcededHoles []int `json:"concededHoles"` // Holes (1-based) conceded to the player; a 0 score on these is filled with net par
+		Makeup        bool  `json:"makeup"`        // Played as a makeup for a missed match
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
 		return
 	}
 
 	ctx := r.Context()
+	log := logger.FromContext(ctx)
 
 	userID, err := GetUserIDFromContext(ctx)
 	if err != nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		respondWithError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	player, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		respondWithError(w, "Player not found for authenticated user", http.StatusNotFound)
 		return
 	}
 
-	requestingPlayer, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	match, err := s.firestoreClient.GetMatch(ctx, matchID)
+	if err != nil || match.LeagueID != leagueID {
+		respondWithError(w, "Match not found", http.StatusNotFound)
+		return
+	}
+	if player.ID != match.PlayerAID && player.ID != match.PlayerBID {
+		respondWithError(w, "Access denied: can only submit scores for your own matches", http.StatusForbidden)
+		return
+	}
+
+	matchDay, err := s.firestoreClient.GetMatchDay(ctx, match.MatchDayID)
 	if err != nil {
-		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		respondWithError(w, fmt.Sprintf("Failed to get match day: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if matchDay.Status == "locked" {
+		respondWithError(w, "Cannot submit scores for a locked match day", http.StatusForbidden)
 		return
 	}
 
-	if requestingPlayer.ID != playerID {
-		isAdmin, err := s.firestoreClient.IsLeagueAdmin(ctx, leagueID, requestingPlayer.ID)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+	season, err := s.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get season: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		respondWithError(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to list courses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	coursesMap := make(map[string]models.Course)
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+	course, ok := coursesMap[match.CourseID]
+	if !ok {
+		respondWithError(w, fmt.Sprintf("Course %s not found", match.CourseID), http.StatusInternalServerError)
+		return
+	}
+	if err := services.CheckSlopeRating(course.SlopeRating); err != nil {
+		log.Warn("Score will have no differential, course has an invalid slope", "course_id", course.ID, "match_id", matchID, "error", err)
+	}
+
+	seasonPlayersMap := make(map[string]models.SeasonPlayer)
+	for _, playerID := range []string{match.PlayerAID, match.PlayerBID} {
+		if sp, err := s.firestoreClient.GetSeasonPlayer(ctx, match.SeasonID, playerID); err == nil {
+			seasonPlayersMap[playerID] = *sp
+		}
+	}
+
+	existingScores, err := s.firestoreClient.GetMatchScores(ctx, matchID)
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to get match scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	existingScoresMap := make(map[string]models.Score)
+	for _, score := range existingScores {
+		existingScoresMap[score.PlayerID] = score
+	}
+
+	card := services.ScoreCard{
+		HoleScores:    req.HoleScores,
+		ConcededHoles: req.ConcededHoles,
+		PlayerAbsent:  req.PlayerAbsent,
+		Makeup:        req.Makeup,
+	}
+	unestablished, err := s.unestablishedPlayers(ctx, *league, map[string][]string{player.ID: {matchID}})
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to check established handicap: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	report, err := services.SelfReportScore(*season, *league, *match, course, seasonPlayersMap, existingScoresMap, player.ID, card, *matchDay, unestablished[player.ID])
+	if errors.Is(err, services.ErrMatchPointsTotal) {
+		respondWithError(w, fmt.Sprintf("Failed to score match %s: %v", matchID, err), http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		respondWithError(w, fmt.Sprintf("Invalid scores for match %s: %v", matchID, err), http.StatusBadRequest)
+		return
+	}
+	if report.Score.ID == "" {
+		report.Score.ID = uuid.New().String()
+	}
+	report.Score.WeekNumber = s.matchDayWeek(ctx, *matchDay)
+
+	if err := s.firestoreClient.BatchUpsertScores(ctx, []models.Score{report.Score}); err != nil {
+		respondWithError(w, fmt.Sprintf("Failed to save score: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if report.Completed {
+		if err := s.firestoreClient.UpdateMatch(ctx, report.Match); err != nil {
+			respondWithError(w, fmt.Sprintf("Failed to update match: %v", err), http.StatusInternalServerError)
 			return
 		}
-		if !isAdmin {
-			http.Error(w, "Access denied: can only view own scores", http.StatusForbidden)
+	}
+	if err := services.RecordScoreAchievements(ctx, s.firestoreClient, []models.Score{report.Score}, coursesMap, match.SeasonID); err != nil {
+		log.Error("Failed to record achievements", "match_id", matchID, "error", err)
+	}
+
+	if !report.Score.PlayerAbsent {
+		if sp, ok := seasonPlayersMap[player.ID]; ok {
+			job := services.NewHandicapRecalculationJob(s.firestoreClient)
+			if _, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, sp, coursesMap); err != nil {
+				log.Error("Failed to recalculate handicap", "player_id", player.ID, "error", err)
+			}
+		} else {
+			log.Warn("Season player not found for handicap recalc", "player_id", player.ID)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (s *APIServer) handleGetPlayerScores(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	playerID := r.PathValue("id")
+	if leagueID == "" || playerID == "" {
+		http.Error(w, "League ID and Player ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	if !s.authorizePlayerRecord(w, r, leagueID, playerID, "Access denied: can only view own scores") {
+		return
+	}
+
+	// An optional week filters to rounds played in that week of their season
+	week := 0
+	if raw := r.URL.Query().Get("week"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			http.Error(w, "week must be a positive integer", http.StatusBadRequest)
 			return
 		}
+		week = value
 	}
 
-	scores, err := s.firestoreClient.GetPlayerScores(ctx, leagueID, playerID, 20) // Limit to last 20 scores
+	var scores []models.Score
+	var err error
+	if week > 0 {
+		scores, err = s.firestoreClient.ListPlayerLeagueScores(ctx, leagueID, playerID)
+		if err == nil {
+			scores = services.ScoresForWeek(scores, week)
+		}
+	} else {
+		scores, err = s.firestoreClient.GetPlayerScores(ctx, leagueID, playerID, 20) // Limit to last 20 scores
+	}
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	s.setDisplayNetScores(ctx, scores)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(scores)
 }
 
+// unestablishedPlayers returns which players have no established handicap yet, keyed by player
+// ID, in leagues using the unestablished adjustment. players maps each player to the matches
+// being scored, whose rounds don't count, so correcting a score doesn't change the player's
+// status. Other leagues need no lookups and get an empty map.
+func (s *APIServer) unestablishedPlayers(ctx context.Context, league models.League, players map[string][]string) (map[string]bool, error) {
+	unestablished := make(map[string]bool)
+	if !league.UnestablishedAdjustment || len(players) == 0 {
+		return unestablished, nil
+	}
+
+	playerIDs := make([]string, 0, len(players))
+	for playerID := range players {
+		playerIDs = append(playerIDs, playerID)
+	}
+	scoresByPlayer, err := s.firestoreClient.GetPlayersHandicapScores(ctx, league.ID, playerIDs, league.OfficialRoundsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player scores: %w", err)
+	}
+
+	for playerID, matchIDs := range players {
+		rounds := 0
+		for _, score := range scoresByPlayer[playerID] {
+			if !slices.Contains(matchIDs, score.MatchID) {
+				rounds++
+			}
+		}
+		unestablished[playerID] = !services.IsEstablished(league, rounds)
+	}
+	return unestablished, nil
+}
+
+// matchDayWeek returns which week of its season a match day falls in, for recording on its
+// scores. The week is informational, so when it can't be worked out it is logged and left at 0.
+func (s *APIServer) matchDayWeek(ctx context.Context, matchDay models.MatchDay) int {
+	matchDays, err := s.firestoreClient.ListMatchDaysBySeason(ctx, matchDay.SeasonID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to list match days for week number", "season_id", matchDay.SeasonID, "error", err)
+		return 0
+	}
+	return services.SeasonWeekNumbers(matchDays)[matchDay.ID]
+}
+
+// authorizePlayerRecord lets players read their own records and league admins read anyone's.
+// It writes the error response, using denied as the message when access is refused, and
+// returns false when the request should stop.
+func (s *APIServer) authorizePlayerRecord(w http.ResponseWriter, r *http.Request, leagueID, playerID, denied string) bool {
+	ctx := r.Context()
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	requestingPlayer, err := s.firestoreClient.GetPlayerByClerkID(ctx, userID)
+	if err != nil {
+		http.Error(w, "Player not found for authenticated user", http.StatusNotFound)
+		return false
+	}
+
+	isAdmin := false
+	if requestingPlayer.ID != playerID {
+		isAdmin, err = s.firestoreClient.IsLeagueAdmin(ctx, leagueID, requestingPlayer.ID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to check admin status: %v", err), http.StatusInternalServerError)
+			return false
+		}
+	}
+	if err := services.CheckPlayerRecordAccess(requestingPlayer.ID, playerID, isAdmin); err != nil {
+		http.Error(w, denied, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
 func (s *APIServer) handleGetMatchScores(w http.ResponseWriter, r *http.Request) {
 	matchID := r.PathValue("id")
 	if matchID == "" {
@@ -564,6 +1011,8 @@ func (s *APIServer) handleGetMatchScores(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	s.setDisplayNetScores(ctx, scores)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(scores)
 }
\ No newline at end of file
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
+)
+
+// handleListFlaggedScores returns the season's rounds flagged for review because their
+// differential sits far above the player's index, most recent first. Admin only.
+func (s *APIServer) handleListFlaggedScores(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	flagged, err := s.firestoreClient.ListFlaggedScores(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list flagged scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.SeasonFlaggedScores(flagged, matches))
+}
+
+// handleReviewFlaggedScore lets a flagged round stand. The flag is cleared for good and, when
+// the league holds flagged rounds back, the player's index is recalculated to include it.
+// Admin only.
+func (s *APIServer) handleReviewFlaggedScore(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	scoreID := r.PathValue("id")
+	if leagueID == "" || seasonID == "" || scoreID == "" {
+		http.Error(w, "League ID, Season ID and Score ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*season); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	score, err := s.firestoreClient.GetScore(ctx, scoreID)
+	if err != nil || score.LeagueID != leagueID {
+		http.Error(w, "Score not found", http.StatusNotFound)
+		return
+	}
+	match, err := s.firestoreClient.GetMatch(ctx, score.MatchID)
+	if err != nil || match.SeasonID != seasonID {
+		http.Error(w, "Score not found", http.StatusNotFound)
+		return
+	}
+	if !score.FlaggedForReview {
+		http.Error(w, "Score is not flagged for review", http.StatusConflict)
+		return
+	}
+
+	services.ReviewFlaggedScore(score, time.Now())
+	if err := s.firestoreClient.UpdateScore(ctx, *score); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update score: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if league.ExcludeFlaggedScores {
+		s.recalculateAfterReview(ctx, leagueID, seasonID, score.PlayerID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(score)
+}
+
+// recalculateAfterReview brings a player's index up to date once a held-back round counts.
+// Failures are logged; the nightly job catches the player up.
+func (s *APIServer) recalculateAfterReview(ctx context.Context, leagueID, seasonID, playerID string) {
+	log := logger.FromContext(ctx)
+
+	seasonPlayer, err := s.firestoreClient.GetSeasonPlayer(ctx, seasonID, playerID)
+	if err != nil {
+		log.Warn("Season player not found for handicap recalc", "player_id", playerID, "error", err)
+		return
+	}
+	courses, err := s.firestoreClient.ListCourses(ctx, leagueID)
+	if err != nil {
+		log.Error("Failed to list courses for handicap recalc", "league_id", leagueID, "error", err)
+		return
+	}
+	coursesMap := make(map[string]models.Course, len(courses))
+	for _, c := range courses {
+		coursesMap[c.ID] = c
+	}
+
+	job := services.NewHandicapRecalculationJob(s.firestoreClient)
+	if _, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, *seasonPlayer, coursesMap); err != nil {
+		log.Error("Failed to recalculate handicap", "player_id", playerID, "error", err)
+	}
+}
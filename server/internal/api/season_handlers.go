@@ -2,8 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
 	"net/http"
 	"time"
 
@@ -23,6 +25,27 @@ func (s *APIServer) handleCreateSeason(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := services.ValidateAbsentMatchRule(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateMinMatchesForStandings(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateHalvedHoleRule(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateWeeklyPointsTable(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateMinFieldForStats(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	season.ID = uuid.New().String()
 	season.LeagueID = leagueID
 	season.CreatedAt = time.Now()
@@ -38,6 +61,77 @@ func (s *APIServer) handleCreateSeason(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(season)
 }
 
+// handleCreateSeasonsBatch creates several seasons at once (e.g. spring, summer and fall)
+// after checking that none of their date ranges overlap each other or existing seasons
+func (s *APIServer) handleCreateSeasonsBatch(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	if leagueID == "" {
+		http.Error(w, "League ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Seasons []models.Season `json:"seasons"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	existing, err := s.firestoreClient.ListSeasons(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := services.ValidateSeasonBatch(existing, req.Seasons); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, season := range req.Seasons {
+		if err := services.ValidateAbsentMatchRule(season); err != nil {
+			http.Error(w, fmt.Sprintf("Season %q: %v", season.Name, err), http.StatusBadRequest)
+			return
+		}
+		if err := services.ValidateMinMatchesForStandings(season); err != nil {
+			http.Error(w, fmt.Sprintf("Season %q: %v", season.Name, err), http.StatusBadRequest)
+			return
+		}
+		if err := services.ValidateHalvedHoleRule(season); err != nil {
+			http.Error(w, fmt.Sprintf("Season %q: %v", season.Name, err), http.StatusBadRequest)
+			return
+		}
+		if err := services.ValidateWeeklyPointsTable(season); err != nil {
+			http.Error(w, fmt.Sprintf("Season %q: %v", season.Name, err), http.StatusBadRequest)
+			return
+		}
+		if err := services.ValidateMinFieldForStats(season); err != nil {
+			http.Error(w, fmt.Sprintf("Season %q: %v", season.Name, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	now := time.Now()
+	created := make([]models.Season, 0, len(req.Seasons))
+	for _, season := range req.Seasons {
+		season.ID = uuid.New().String()
+		season.LeagueID = leagueID
+		season.CreatedAt = now
+		created = append(created, season)
+	}
+
+	if err := s.firestoreClient.CreateSeasons(ctx, created); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create seasons: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
 func (s *APIServer) handleListSeasons(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
 	if leagueID == "" {
@@ -87,9 +181,41 @@ func (s *APIServer) handleUpdateSeason(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := services.ValidateAbsentMatchRule(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateMinMatchesForStandings(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateHalvedHoleRule(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateWeeklyPointsTable(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateMinFieldForStats(season); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	season.ID = seasonID
 
 	ctx := r.Context()
+
+	existing, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+	if err := services.CheckSeasonWritable(*existing); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
 	if err := s.firestoreClient.UpdateSeason(ctx, season); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to update season: %v", err), http.StatusInternalServerError)
 		return
@@ -99,6 +225,53 @@ func (s *APIServer) handleUpdateSeason(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(season)
 }
 
+// handleResetSeason clears a season's scores, achievements, foursomes and match results while
+// keeping its schedule and roster (admin only). The request must confirm the season's ID.
+func (s *APIServer) handleResetSeason(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	var req struct {
+		Confirm string `json:"confirm"` // Must be the season ID
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	result, err := services.ResetSeason(ctx, s.firestoreClient, *season, req.Confirm)
+	switch {
+	case errors.Is(err, services.ErrResetNotConfirmed):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, services.ErrSeasonArchived):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Failed to reset season: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 func (s *APIServer) handleGetActiveSeason(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
 	if leagueID == "" {
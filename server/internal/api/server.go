@@ -20,24 +20,44 @@ type APIServer struct {
 	firestoreClient *persistence.FirestoreClient
 	mux             *http.ServeMux
 	handler         http.Handler
+
+	// Limits applied to the score and import endpoints
+	timeout   func(http.Handler) http.Handler
+	bodyLimit func(http.Handler) http.Handler
+
+	// Guards the internal job endpoints called by Cloud Scheduler
+	schedulerAuth func(http.Handler) http.Handler
 }
 
 
-func NewAPIServer(fc *persistence.FirestoreClient, clerkSecretKey string, corsOrigins []string) (*APIServer, error) {
-	clerk.SetKey(clerkSecretKey)
+func NewAPIServer(fc *persistence.FirestoreClient, cfg *config.Config) (*APIServer, error) {
+	clerk.SetKey(cfg.ClerkSecretKey)
+
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = config.DefaultRequestTimeout
+	}
+	maxBodyBytes := cfg.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = config.DefaultMaxBodyBytes
+	}
 
 	server := &APIServer{
 		firestoreClient: fc,
 		mux:             http.NewServeMux(),
+		timeout:         middleware.Timeout(requestTimeout),
+		bodyLimit:       middleware.MaxBodySize(maxBodyBytes),
+		schedulerAuth:   middleware.SharedSecret(middleware.SchedulerSecretHeader, cfg.SchedulerSecret),
 	}
 	server.registerRoutes()
 
 	var handler http.Handler = server.mux
 	handler = middleware.Recovery()(handler)
 	handler = middleware.Logging()(handler)
-	handler = middleware.RequestID()(handler)
-	handler = middleware.CORS(corsOrigins)(handler)
+	handler = middleware.CORS(cfg.CORSOrigins)(handler)
 	handler = middleware.RateLimit()(handler)
+	// Outermost, so every response carries X-Request-ID, including rate limit rejections
+	handler = middleware.RequestID()(handler)
 
 	server.handler = handler
 	return server, nil
@@ -70,6 +90,7 @@ func (s *APIServer) registerRoutes() {
 	s.mux.Handle("POST /api/leagues/{league_id}/courses", chainMiddleware(http.HandlerFunc(s.handleCreateCourse), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/courses", chainMiddleware(http.HandlerFunc(s.handleListCourses), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/courses/{id}", chainMiddleware(http.HandlerFunc(s.handleGetCourse), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/courses/{id}/card", chainMiddleware(http.HandlerFunc(s.handleGetCourseCard), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/courses/{id}", chainMiddleware(http.HandlerFunc(s.handleUpdateCourse), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/players", chainMiddleware(http.HandlerFunc(s.handleCreatePlayer), authMiddleware))
@@ -78,57 +99,119 @@ func (s *APIServer) registerRoutes() {
 	s.mux.Handle("PUT /api/leagues/{league_id}/players/{id}", chainMiddleware(http.HandlerFunc(s.handleUpdatePlayer), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/seasons", chainMiddleware(http.HandlerFunc(s.handleCreateSeason), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/seasons/batch", chainMiddleware(http.HandlerFunc(s.handleCreateSeasonsBatch), s.timeout, s.bodyLimit, authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons", chainMiddleware(http.HandlerFunc(s.handleListSeasons), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{id}", chainMiddleware(http.HandlerFunc(s.handleGetSeason), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/seasons/{id}", chainMiddleware(http.HandlerFunc(s.handleUpdateSeason), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{id}/matches", chainMiddleware(http.HandlerFunc(s.handleGetSeasonMatches), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{id}/reset", chainMiddleware(http.HandlerFunc(s.handleResetSeason), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/schedule-grid", chainMiddleware(http.HandlerFunc(s.handleGetScheduleGrid), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/pairings-preview", chainMiddleware(http.HandlerFunc(s.handleGetPairingsPreview), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/unplayed", chainMiddleware(http.HandlerFunc(s.handleGetUnplayedMatches), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/active", chainMiddleware(http.HandlerFunc(s.handleGetActiveSeason), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{season_id}/players", chainMiddleware(http.HandlerFunc(s.handleAddSeasonPlayer), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/players", chainMiddleware(http.HandlerFunc(s.handleListSeasonPlayers), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/roster", chainMiddleware(http.HandlerFunc(s.handleGetSeasonRoster), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/handicap-distribution", chainMiddleware(http.HandlerFunc(s.handleGetHandicapDistribution), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/sandbag-report", chainMiddleware(http.HandlerFunc(s.handleGetSandbagReport), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/compare", chainMiddleware(http.HandlerFunc(s.handleComparePlayers), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/points-audit", chainMiddleware(http.HandlerFunc(s.handlePointsAudit), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/playoff-seeds", chainMiddleware(http.HandlerFunc(s.handleGetPlayoffSeeds), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{season_id}/foursomes", chainMiddleware(http.HandlerFunc(s.handleEnterFoursomesResult), s.timeout, s.bodyLimit, authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/foursomes", chainMiddleware(http.HandlerFunc(s.handleListFoursomesResults), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/achievements", chainMiddleware(http.HandlerFunc(s.handleListSeasonAchievements), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/progress", chainMiddleware(http.HandlerFunc(s.handleGetSeasonProgress), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/flagged-scores", chainMiddleware(http.HandlerFunc(s.handleListFlaggedScores), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{season_id}/flagged-scores/{id}/review", chainMiddleware(http.HandlerFunc(s.handleReviewFlaggedScore), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/seasons/{season_id}/players/{player_id}", chainMiddleware(http.HandlerFunc(s.handleUpdateSeasonPlayer), authMiddleware))
 	s.mux.Handle("DELETE /api/leagues/{league_id}/seasons/{season_id}/players/{player_id}", chainMiddleware(http.HandlerFunc(s.handleRemoveSeasonPlayer), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/matches", chainMiddleware(http.HandlerFunc(s.handleCreateMatch), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/matches", chainMiddleware(http.HandlerFunc(s.handleListMatches), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/matches/by-date", chainMiddleware(http.HandlerFunc(s.handleGetMatchesByDate), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/matches/{id}", chainMiddleware(http.HandlerFunc(s.handleGetMatch), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/matches/{id}/detail", chainMiddleware(http.HandlerFunc(s.handleGetMatchDetail), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/matches/{id}", chainMiddleware(http.HandlerFunc(s.handleUpdateMatch), authMiddleware))
+	s.mux.Handle("PUT /api/leagues/{league_id}/matches/bulk-points", chainMiddleware(http.HandlerFunc(s.handleBulkUpdateMatchPoints), s.timeout, s.bodyLimit, authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/matches/{id}/my-score", chainMiddleware(http.HandlerFunc(s.handleSubmitMyScore), s.timeout, s.bodyLimit, authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/matches/{id}/scenario", chainMiddleware(http.HandlerFunc(s.handleGetMatchScenario), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/matches/{id}/absent-preview", chainMiddleware(http.HandlerFunc(s.handleGetAbsentPreview), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/matches/{id}/simulate", chainMiddleware(http.HandlerFunc(s.handleSimulateMatch), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/match-days", chainMiddleware(http.HandlerFunc(s.handleCreateMatchDay), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/match-days", chainMiddleware(http.HandlerFunc(s.handleListMatchDaysWithStatus), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}", chainMiddleware(http.HandlerFunc(s.handleGetMatchDay), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/match-days/{id}", chainMiddleware(http.HandlerFunc(s.handleUpdateMatchDay), authMiddleware))
 	s.mux.Handle("DELETE /api/leagues/{league_id}/match-days/{id}", chainMiddleware(http.HandlerFunc(s.handleDeleteMatchDay), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/match-days/{id}/duplicate", chainMiddleware(http.HandlerFunc(s.handleDuplicateMatchDay), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/match-days/{id}/change-course", chainMiddleware(http.HandlerFunc(s.handleChangeMatchDayCourse), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/matches", chainMiddleware(http.HandlerFunc(s.handleGetMatchDayMatches), authMiddleware))
 	s.mux.Handle("PUT /api/leagues/{league_id}/match-days/{id}/matches", chainMiddleware(http.HandlerFunc(s.handleUpdateMatchDayMatches), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/scores", chainMiddleware(http.HandlerFunc(s.handleGetMatchDayScores), authMiddleware))
-	s.mux.Handle("POST /api/leagues/{league_id}/match-days/scores", chainMiddleware(http.HandlerFunc(s.handleEnterMatchDayScores), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/leaderboard", chainMiddleware(http.HandlerFunc(s.handleGetMatchDayLeaderboard), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/blank-scorecard", chainMiddleware(http.HandlerFunc(s.handleGetBlankScorecards), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/handicaps", chainMiddleware(http.HandlerFunc(s.handleGetMatchDayHandicaps), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/match-days/{id}/players/{player_id}/scores", chainMiddleware(http.HandlerFunc(s.handleGetPlayerMatchDayScores), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/match-days/scores", chainMiddleware(http.HandlerFunc(s.handleEnterMatchDayScores), s.timeout, s.bodyLimit, authMiddleware))
 
-	s.mux.Handle("POST /api/leagues/{league_id}/scores", chainMiddleware(http.HandlerFunc(s.handleEnterScore), authMiddleware))
-	s.mux.Handle("POST /api/leagues/{league_id}/scores/batch", chainMiddleware(http.HandlerFunc(s.handleEnterScoreBatch), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/scores", chainMiddleware(http.HandlerFunc(s.handleEnterScore), s.timeout, s.bodyLimit, authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/scores/batch", chainMiddleware(http.HandlerFunc(s.handleEnterScoreBatch), s.timeout, s.bodyLimit, authMiddleware))
 
 	s.mux.Handle("GET /api/leagues/{league_id}/standings", chainMiddleware(http.HandlerFunc(s.handleGetStandings), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/standings", chainMiddleware(http.HandlerFunc(s.handleGetSeasonStandings), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/standings/export", chainMiddleware(http.HandlerFunc(s.handleExportSeasonStandings), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/promotions", chainMiddleware(http.HandlerFunc(s.handleGetSeasonPromotions), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{season_id}/bulletin", chainMiddleware(http.HandlerFunc(s.handleCreateBulletinMessage), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/bulletin", chainMiddleware(http.HandlerFunc(s.handleListBulletinMessages), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/bulletin", chainMiddleware(http.HandlerFunc(s.handleListLeagueBulletinMessages), authMiddleware))
+	s.mux.Handle("PUT /api/leagues/{league_id}/bulletin/{message_id}", chainMiddleware(http.HandlerFunc(s.handleUpdateBulletinMessage), authMiddleware))
 	s.mux.Handle("DELETE /api/leagues/{league_id}/bulletin/{message_id}", chainMiddleware(http.HandlerFunc(s.handleDeleteBulletinMessage), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/bulletin/{message_id}/pin", chainMiddleware(http.HandlerFunc(s.handlePinBulletinMessage), authMiddleware))
+	s.mux.Handle("DELETE /api/leagues/{league_id}/bulletin/{message_id}/pin", chainMiddleware(http.HandlerFunc(s.handlePinBulletinMessage), authMiddleware))
+
+	s.mux.Handle("GET /api/admin/integrity-check", chainMiddleware(http.HandlerFunc(s.handleIntegrityCheck), authMiddleware))
 
 	s.mux.Handle("POST /api/user/link-player", chainMiddleware(http.HandlerFunc(s.handleLinkPlayerAccount), authMiddleware))
 	s.mux.Handle("GET /api/user/me", chainMiddleware(http.HandlerFunc(s.handleGetCurrentUser), authMiddleware))
+	s.mux.Handle("GET /api/user/me/active", chainMiddleware(http.HandlerFunc(s.handleGetActiveSeasons), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/invites", chainMiddleware(http.HandlerFunc(s.handleCreateLeagueInvite), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/invites", chainMiddleware(http.HandlerFunc(s.handleListLeagueInvites), authMiddleware))
 	s.mux.Handle("DELETE /api/leagues/{league_id}/invites/{invite_id}", chainMiddleware(http.HandlerFunc(s.handleRevokeLeagueInvite), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/invites/{invite_id}/regenerate", chainMiddleware(http.HandlerFunc(s.handleRegenerateLeagueInvite), authMiddleware))
 	s.mux.Handle("GET /api/invites/{token}", chainMiddleware(http.HandlerFunc(s.handleGetInviteByToken), authMiddleware))
 	s.mux.Handle("POST /api/invites/{token}/accept", chainMiddleware(http.HandlerFunc(s.handleAcceptLeagueInvite), authMiddleware))
 
 	s.mux.Handle("GET /api/leagues/{league_id}/seasons/{season_id}/players/{id}/handicap", chainMiddleware(http.HandlerFunc(s.handleGetPlayerHandicap), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/seasons/{season_id}/players/{id}/recalculate", chainMiddleware(http.HandlerFunc(s.handleRecalculatePlayerHandicap), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/remaining-matches", chainMiddleware(http.HandlerFunc(s.handleGetPlayerRemainingMatches), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/career", chainMiddleware(http.HandlerFunc(s.handleGetPlayerCareer), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/hole-performance", chainMiddleware(http.HandlerFunc(s.handleGetPlayerHolePerformance), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/pending-confirmations", chainMiddleware(http.HandlerFunc(s.handleGetPlayerPendingConfirmations), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/schedule.ics", chainMiddleware(http.HandlerFunc(s.handleGetPlayerScheduleICS), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/matches/{id}/disputes", chainMiddleware(http.HandlerFunc(s.handleRaiseDispute), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/matches/{id}/attachments", chainMiddleware(http.HandlerFunc(s.handleAddAttachment), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/matches/{id}/attachments", chainMiddleware(http.HandlerFunc(s.handleListAttachments), authMiddleware))
+	s.mux.Handle("DELETE /api/leagues/{league_id}/matches/{id}/attachments/{attachment_id}", chainMiddleware(http.HandlerFunc(s.handleDeleteAttachment), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/disputes", chainMiddleware(http.HandlerFunc(s.handleListDisputes), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/disputes/{dispute_id}/resolve", chainMiddleware(http.HandlerFunc(s.handleResolveDispute), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/notification-prefs", chainMiddleware(http.HandlerFunc(s.handleGetNotificationPrefs), authMiddleware))
+	s.mux.Handle("PUT /api/leagues/{league_id}/players/{id}/notification-prefs", chainMiddleware(http.HandlerFunc(s.handleUpdateNotificationPrefs), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/scores", chainMiddleware(http.HandlerFunc(s.handleGetPlayerScores), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/players/{id}/export", chainMiddleware(http.HandlerFunc(s.handleExportPlayer), authMiddleware))
 	s.mux.Handle("GET /api/leagues/{league_id}/matches/{id}/scores", chainMiddleware(http.HandlerFunc(s.handleGetMatchScores), authMiddleware))
 
 	s.mux.Handle("POST /api/leagues/{league_id}/jobs/recalculate-handicaps", chainMiddleware(http.HandlerFunc(s.handleRecalculateHandicaps), authMiddleware))
+	s.mux.Handle("GET /api/leagues/{league_id}/jobs/recalculate-handicaps/last-run", chainMiddleware(http.HandlerFunc(s.handleGetLastRecalcRun), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/jobs/recompute-all", chainMiddleware(http.HandlerFunc(s.handleRecomputeAll), authMiddleware))
+	s.mux.Handle("POST /api/leagues/{league_id}/jobs/auto-archive", chainMiddleware(http.HandlerFunc(s.handleAutoArchiveSeasons), authMiddleware))
 	s.mux.Handle("POST /api/leagues/{league_id}/jobs/process-match/{id}", chainMiddleware(http.HandlerFunc(s.handleProcessMatch), authMiddleware))
 
+	// Internal jobs are called by Cloud Scheduler with a shared secret, not a Clerk session
+	s.mux.Handle("POST /api/internal/jobs/recalculate-all-handicaps", chainMiddleware(http.HandlerFunc(s.handleRecalculateAllHandicaps), s.schedulerAuth))
+
 	healthHandler := handlers.NewHealthHandler(s.firestoreClient)
 	s.mux.HandleFunc("GET /health", healthHandler.HandleHealth)
 	s.mux.HandleFunc("GET /health/ready", healthHandler.HandleReadiness)
@@ -145,7 +228,7 @@ func StartServer(ctx context.Context, cfg *config.Config) (*ServerComponents, er
 		return nil, fmt.Errorf("failed to create firestore client: %w", err)
 	}
 
-	apiServer, err := NewAPIServer(fc, cfg.ClerkSecretKey, cfg.CORSOrigins)
+	apiServer, err := NewAPIServer(fc, cfg)
 	if err != nil {
 		fc.Close() 
 		return nil, fmt.Errorf("failed to create api server: %w", err)
@@ -1,21 +1,17 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sort"
-)
+	"strconv"
+	"strings"
 
-type StandingsEntry struct {
-	PlayerID      string `json:"playerId"`
-	PlayerName    string `json:"playerName"`
-	MatchesPlayed int    `json:"matchesPlayed"`
-	MatchesWon    int    `json:"matchesWon"`
-	MatchesLost   int    `json:"matchesLost"`
-	MatchesTied   int    `json:"matchesTied"`
-	TotalPoints   int    `json:"totalPoints"`
-}
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/services"
+)
 
 func (s *APIServer) handleGetStandings(w http.ResponseWriter, r *http.Request) {
 	leagueID := r.PathValue("league_id")
@@ -38,60 +34,405 @@ func (s *APIServer) handleGetStandings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	standingsMap := make(map[string]*StandingsEntry)
+	playerIDs := make([]string, len(members))
+	for i, member := range members {
+		playerIDs[i] = member.PlayerID
+	}
+	players, err := s.firestoreClient.GetPlayersByIDs(ctx, playerIDs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get players: %v", err), http.StatusInternalServerError)
+		return
+	}
 
+	roster := make([]services.StandingsEntry, 0, len(members))
 	for _, member := range members {
-		player, err := s.firestoreClient.GetPlayer(ctx, member.PlayerID)
-		if err != nil {
+		player, ok := players[member.PlayerID]
+		if !ok {
 			continue
 		}
-
-		entry := &StandingsEntry{
+		roster = append(roster, services.StandingsEntry{
 			PlayerID:   player.ID,
 			PlayerName: player.Name,
+		})
+	}
+
+	standings := services.ComputeStandings(roster, matches)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(standings)
+}
+
+// handleExportSeasonStandings exports the computed standings for a season as CSV or JSON
+func (s *APIServer) handleExportSeasonStandings(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		http.Error(w, "Format must be one of: csv, json", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	seasonStandings, err := s.computeSeasonStandings(ctx, *season)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// Players who did not qualify are listed after the ranked field
+	standings := append(seasonStandings.Standings, seasonStandings.Unranked...)
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"standings-%s.csv\"", seasonID))
+		if err := services.WriteStandingsCSV(w, standings); err != nil {
+			logger.ErrorContext(ctx, "Failed to write standings export",
+				"season_id", seasonID,
+				"error", err,
+			)
 		}
-		standingsMap[player.ID] = entry
+		return
 	}
 
-	for _, match := range matches {
-		if match.PlayerAPoints == 0 && match.PlayerBPoints == 0 {
-			continue
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(standings)
+}
+
+// handleGetSeasonStandings returns a season's standings, with players below the season's
+// qualifying number of matches listed separately as unranked
+func (s *APIServer) handleGetSeasonStandings(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	standings, err := s.computeSeasonStandings(ctx, *season)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(standings)
+}
+
+// handleGetSeasonProgress summarizes a season for the league homepage: match days played and
+// remaining, the next scheduled date, the points leader and the rounds posted so far
+func (s *APIServer) handleGetSeasonProgress(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	matchDays, err := s.firestoreClient.ListMatchDaysBySeason(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list match days: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	var scores []models.Score
+	for _, md := range matchDays {
+		dayScores, err := s.firestoreClient.GetMatchDayScores(ctx, md.ID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get scores: %v", err), http.StatusInternalServerError)
+			return
 		}
+		scores = append(scores, dayScores...)
+	}
 
-		if entryA, ok := standingsMap[match.PlayerAID]; ok {
-			entryA.MatchesPlayed++
-			entryA.TotalPoints += match.PlayerAPoints
-			if match.PlayerAPoints > match.PlayerBPoints {
-				entryA.MatchesWon++
-			} else if match.PlayerAPoints < match.PlayerBPoints {
-				entryA.MatchesLost++
-			} else {
-				entryA.MatchesTied++
-			}
+	// The leader is taken from the full ranking, so there is one before anyone has played
+	// enough matches to qualify
+	standingsByDivision, err := s.computeSeasonDivisionStandings(ctx, *season, false)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.BuildSeasonProgress(seasonID, matchDays, standingsByDivision[""], scores))
+}
+
+// computeSeasonStandings builds the standings for a season from its active roster and matches,
+// leaving players short of the season's qualifying number of matches unranked
+func (s *APIServer) computeSeasonStandings(ctx context.Context, season models.Season) (services.SeasonStandings, error) {
+	standingsByDivision, err := s.computeSeasonDivisionStandings(ctx, season, false)
+	if err != nil {
+		return services.SeasonStandings{}, err
+	}
+	return services.QualifyStandings(standingsByDivision[""], season.MinMatchesForStandings), nil
+}
+
+// computeSeasonDivisionStandings builds standings for a season. When byDivision is set, each
+// division is ranked separately and keyed by its name; otherwise the whole roster is ranked
+// under the empty key. Seasons with a weekly points table are ranked on position points from
+// each match day's scores instead of match points.
+func (s *APIServer) computeSeasonDivisionStandings(ctx context.Context, season models.Season, byDivision bool) (map[string][]services.StandingsEntry, error) {
+	seasonPlayers, err := s.firestoreClient.ListSeasonPlayers(ctx, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season players: %w", err)
+	}
+
+	var matches []models.Match
+	var weeks []map[string]int
+	if len(season.WeeklyPointsTable) > 0 {
+		weeks, err = s.weeklyPositionPoints(ctx, season)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		matches, err = s.firestoreClient.GetSeasonMatches(ctx, season.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get season matches: %w", err)
+		}
+	}
+
+	// Only fall back to player reads for rows without a denormalized name
+	var unnamed []string
+	for _, sp := range seasonPlayers {
+		if sp.IsActive && sp.PlayerName == "" {
+			unnamed = append(unnamed, sp.PlayerID)
 		}
+	}
+	players, err := s.firestoreClient.GetPlayersByIDs(ctx, unnamed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get players: %w", err)
+	}
 
-		if entryB, ok := standingsMap[match.PlayerBID]; ok {
-			entryB.MatchesPlayed++
-			entryB.TotalPoints += match.PlayerBPoints
-			if match.PlayerBPoints > match.PlayerAPoints {
-				entryB.MatchesWon++
-			} else if match.PlayerBPoints < match.PlayerAPoints {
-				entryB.MatchesLost++
-			} else {
-				entryB.MatchesTied++
+	rosters := make(map[string][]services.StandingsEntry)
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		name := sp.PlayerName
+		if name == "" {
+			player, ok := players[sp.PlayerID]
+			if !ok {
+				continue
 			}
+			name = player.Name
+		}
+		division := ""
+		if byDivision {
+			division = sp.Division
+		}
+		rosters[division] = append(rosters[division], services.StandingsEntry{
+			PlayerID:      sp.PlayerID,
+			PlayerName:    name,
+			HandicapIndex: sp.CurrentHandicapIndex,
+		})
+	}
+
+	standingsByDivision := make(map[string][]services.StandingsEntry, len(rosters))
+	for division, roster := range rosters {
+		if weeks != nil {
+			standingsByDivision[division] = services.ComputePositionStandings(roster, weeks)
+			continue
+		}
+		standingsByDivision[division] = services.ComputeStandings(roster, matches)
+	}
+	return standingsByDivision, nil
+}
+
+// weeklyPositionPoints awards the season's position points for each of its match days,
+// leaving out scores from disputed matches
+func (s *APIServer) weeklyPositionPoints(ctx context.Context, season models.Season) ([]map[string]int, error) {
+	matchDays, err := s.firestoreClient.ListMatchDaysBySeason(ctx, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match days: %w", err)
+	}
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season matches: %w", err)
+	}
+	disputed := make(map[string]bool)
+	for _, match := range matches {
+		if match.Disputed {
+			disputed[match.ID] = true
+		}
+	}
+
+	weeks := make([]map[string]int, 0, len(matchDays))
+	for _, matchDay := range matchDays {
+		scores, err := s.firestoreClient.GetMatchDayScores(ctx, matchDay.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scores for match day %s: %w", matchDay.ID, err)
 		}
+		weeks = append(weeks, services.ComputeWeeklyPositionPoints(scores, disputed, season.WeeklyPointsTable))
 	}
+	return weeks, nil
+}
 
-	standings := make([]StandingsEntry, 0, len(standingsMap))
-	for _, entry := range standingsMap {
-		standings = append(standings, *entry)
+// handleGetSeasonPromotions returns who would move between divisions based on the season's
+// standings within each division
+func (s *APIServer) handleGetSeasonPromotions(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
 	}
 
-	sort.Slice(standings, func(i, j int) bool {
-		return standings[i].TotalPoints > standings[j].TotalPoints
-	})
+	query := r.URL.Query()
+	config := services.PromotionConfig{
+		PromoteCount:  services.DefaultPromotionCount,
+		RelegateCount: services.DefaultPromotionCount,
+	}
+	for param, target := range map[string]*int{"promote": &config.PromoteCount, "relegate": &config.RelegateCount} {
+		raw := query.Get(param)
+		if raw == "" {
+			continue
+		}
+		count, err := strconv.Atoi(raw)
+		if err != nil || count < 0 {
+			http.Error(w, fmt.Sprintf("%s must be a non-negative integer", param), http.StatusBadRequest)
+			return
+		}
+		*target = count
+	}
+	if order := query.Get("order"); order != "" {
+		for _, division := range strings.Split(order, ",") {
+			config.DivisionOrder = append(config.DivisionOrder, strings.TrimSpace(division))
+		}
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	standingsByDivision, err := s.computeSeasonDivisionStandings(ctx, *season, true)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// Players without a division are not part of the promotion structure
+	delete(standingsByDivision, "")
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(standings)
-}
\ No newline at end of file
+	json.NewEncoder(w).Encode(services.ComputePromotionRelegation(standingsByDivision, config))
+}
+
+// PlayoffSeedsResponse is the seeding check for a season with the playoff seeds, which are
+// only given once the check passes
+type PlayoffSeedsResponse struct {
+	Check services.SeedingCheck     `json:"check"`
+	Seeds []services.StandingsEntry `json:"seeds"`
+}
+
+// handleGetPlayoffSeeds seeds the playoffs from a season's final standings. The standings are
+// first recomputed from every match's scores; if that finds results that were never processed
+// or points that don't match, seeding is refused with 409 and the check is returned so the
+// admin can process them first.
+func (s *APIServer) handleGetPlayoffSeeds(w http.ResponseWriter, r *http.Request) {
+	leagueID := r.PathValue("league_id")
+	seasonID := r.PathValue("season_id")
+	if leagueID == "" || seasonID == "" {
+		http.Error(w, "League ID and Season ID are required", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.requireLeagueAdmin(w, r, leagueID); !ok {
+		return
+	}
+
+	size := 0
+	if raw := r.URL.Query().Get("size"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			http.Error(w, "size must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		size = value
+	}
+
+	ctx := r.Context()
+
+	season, err := s.firestoreClient.GetSeason(ctx, seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		http.Error(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	league, err := s.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get league: %v", err), http.StatusNotFound)
+		return
+	}
+
+	seasonStandings, err := s.computeSeasonStandings(ctx, *season)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+	roster := append(append([]services.StandingsEntry{}, seasonStandings.Standings...), seasonStandings.Unranked...)
+
+	matches, err := s.firestoreClient.GetSeasonMatches(ctx, seasonID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get season matches: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scoresByMatch, err := s.matchScores(ctx, matches, func(m models.Match) bool { return !m.Disputed })
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get match scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	corrections, err := s.firestoreClient.ListAuditEntries(ctx, leagueID, services.AuditActionMatchPointsCorrected)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get points corrections: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	check, err := services.CheckSeedingStandings(*season, *league, roster, matches, scoresByMatch, services.CorrectedMatchIDs(corrections))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recompute standings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := PlayoffSeedsResponse{Check: check, Seeds: make([]services.StandingsEntry, 0)}
+	seeds, err := services.SeedPlayoffs(check, seasonStandings, size)
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+	} else {
+		response.Seeds = seeds
+	}
+	json.NewEncoder(w).Encode(response)
+}
@@ -5,7 +5,16 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout bounds how long a score or import request may run
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultMaxBodyBytes bounds the size of a score or import request body (1 MiB)
+	DefaultMaxBodyBytes int64 = 1 << 20
 )
 
 type Config struct {
@@ -15,6 +24,9 @@ type Config struct {
 	Environment string
 	LogLevel string
 	CORSOrigins []string
+	RequestTimeout time.Duration
+	MaxBodyBytes int64
+	SchedulerSecret string
 }
 
 func Load() (*Config, error) {
@@ -25,6 +37,10 @@ func Load() (*Config, error) {
 		Environment:    getEnvOrDefault("ENVIRONMENT", "production"),
 		LogLevel:       getEnvOrDefault("LOG_LEVEL", "INFO"),
 		CORSOrigins:    getEnvList("CORS_ORIGINS", []string{"*"}),
+		RequestTimeout: DefaultRequestTimeout,
+		MaxBodyBytes:   DefaultMaxBodyBytes,
+		// Optional: the scheduled job endpoints reject every request until it is set
+		SchedulerSecret: os.Getenv("SCHEDULER_SECRET"),
 	}
 
 	if cfg.ClerkSecretKey == "" {
@@ -41,17 +57,36 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("LOG_LEVEL must be one of: DEBUG, INFO, WARN, ERROR (got: %s)", cfg.LogLevel)
 	}
 
+	if value := os.Getenv("REQUEST_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("REQUEST_TIMEOUT must be a positive duration such as 30s (got: %s)", value)
+		}
+		cfg.RequestTimeout = timeout
+	}
+
+	if value := os.Getenv("MAX_BODY_BYTES"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("MAX_BODY_BYTES must be a positive number of bytes (got: %s)", value)
+		}
+		cfg.MaxBodyBytes = limit
+	}
+
 	return cfg, nil
 }
 
 func (c *Config) MaskSensitive() map[string]interface{} {
 	return map[string]interface{}{
-		"port":         c.Port,
-		"project_id":   c.ProjectID,
-		"clerk_secret": maskString(c.ClerkSecretKey),
-		"environment":  c.Environment,
-		"log_level":    c.LogLevel,
-		"cors_origins": c.CORSOrigins,
+		"port":             c.Port,
+		"project_id":       c.ProjectID,
+		"clerk_secret":     maskString(c.ClerkSecretKey),
+		"environment":      c.Environment,
+		"log_level":        c.LogLevel,
+		"cors_origins":     c.CORSOrigins,
+		"request_timeout":  c.RequestTimeout.String(),
+		"max_body_bytes":   c.MaxBodyBytes,
+		"scheduler_secret": maskString(c.SchedulerSecret),
 	}
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadValid(t *testing.T) {
@@ -139,3 +140,56 @@ func TestCORSOrigins(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestLimits(t *testing.T) {
+	os.Setenv("GCP_PROJECT_ID", "test-project")
+	os.Setenv("CLERK_SECRET_KEY", "test-secret-key")
+	defer os.Unsetenv("GCP_PROJECT_ID")
+	defer os.Unsetenv("CLERK_SECRET_KEY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.RequestTimeout != DefaultRequestTimeout || cfg.MaxBodyBytes != DefaultMaxBodyBytes {
+		t.Errorf("Expected default limits, got timeout %v and body limit %d", cfg.RequestTimeout, cfg.MaxBodyBytes)
+	}
+
+	os.Setenv("REQUEST_TIMEOUT", "5s")
+	os.Setenv("MAX_BODY_BYTES", "4096")
+	defer os.Unsetenv("REQUEST_TIMEOUT")
+	defer os.Unsetenv("MAX_BODY_BYTES")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.RequestTimeout != 5*time.Second || cfg.MaxBodyBytes != 4096 {
+		t.Errorf("Expected 5s and 4096 bytes, got %v and %d", cfg.RequestTimeout, cfg.MaxBodyBytes)
+	}
+
+	os.Setenv("MAX_BODY_BYTES", "lots")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid MAX_BODY_BYTES, got none")
+	}
+}
+
+func TestSchedulerSecret(t *testing.T) {
+	os.Setenv("GCP_PROJECT_ID", "test-project")
+	os.Setenv("CLERK_SECRET_KEY", "test-secret-key")
+	os.Setenv("SCHEDULER_SECRET", "scheduler-secret-abcd")
+	defer os.Unsetenv("GCP_PROJECT_ID")
+	defer os.Unsetenv("CLERK_SECRET_KEY")
+	defer os.Unsetenv("SCHEDULER_SECRET")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SchedulerSecret != "scheduler-secret-abcd" {
+		t.Errorf("Expected scheduler secret to be loaded, got '%s'", cfg.SchedulerSecret)
+	}
+	if masked := cfg.MaskSensitive()["scheduler_secret"]; masked != "sche****abcd" {
+		t.Errorf("Expected masked scheduler secret 'sche****abcd', got '%v'", masked)
+	}
+}
@@ -52,8 +52,9 @@ func Get() *slog.Logger {
 	return defaultLogger
 }
 
-// WithRequestID returns a logger with the request ID from context
-func WithRequestID(ctx context.Context) *slog.Logger {
+// FromContext returns the request-scoped logger: the default logger enriched with the
+// request ID set by the RequestID middleware, or the default logger when there is none
+func FromContext(ctx context.Context) *slog.Logger {
 	logger := Get()
 	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
 		return logger.With("request_id", requestID)
@@ -61,6 +62,11 @@ func WithRequestID(ctx context.Context) *slog.Logger {
 	return logger
 }
 
+// WithRequestID returns a logger with the request ID from context
+func WithRequestID(ctx context.Context) *slog.Logger {
+	return FromContext(ctx)
+}
+
 // Debug logs a debug-level message
 func Debug(msg string, args ...any) {
 	Get().Debug(msg, args...)
@@ -83,20 +89,20 @@ func Error(msg string, args ...any) {
 
 // DebugContext logs a debug-level message with context
 func DebugContext(ctx context.Context, msg string, args ...any) {
-	WithRequestID(ctx).Debug(msg, args...)
+	FromContext(ctx).Debug(msg, args...)
 }
 
 // InfoContext logs an info-level message with context
 func InfoContext(ctx context.Context, msg string, args ...any) {
-	WithRequestID(ctx).Info(msg, args...)
+	FromContext(ctx).Info(msg, args...)
 }
 
 // WarnContext logs a warning-level message with context
 func WarnContext(ctx context.Context, msg string, args ...any) {
-	WithRequestID(ctx).Warn(msg, args...)
+	FromContext(ctx).Warn(msg, args...)
 }
 
 // ErrorContext logs an error-level message with context
 func ErrorContext(ctx context.Context, msg string, args ...any) {
-	WithRequestID(ctx).Error(msg, args...)
+	FromContext(ctx).Error(msg, args...)
 }
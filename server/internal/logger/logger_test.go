@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+// captureLogs points the default logger at a buffer for the duration of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := defaultLogger
+	defaultLogger = slog.New(slog.NewJSONHandler(&buf, nil))
+	t.Cleanup(func() { defaultLogger = previous })
+	return &buf
+}
+
+func TestFromContextIncludesRequestID(t *testing.T) {
+	buf := captureLogs(t)
+
+	ctx := context.WithValue(context.Background(), RequestIDKey, "req-123")
+	FromContext(ctx).Info("score saved", "player_id", "p1")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output %q: %v", buf.String(), err)
+	}
+	if entry["request_id"] != "req-123" {
+		t.Errorf("request_id = %v, want req-123", entry["request_id"])
+	}
+	if entry["player_id"] != "p1" {
+		t.Errorf("player_id = %v, want p1", entry["player_id"])
+	}
+}
+
+func TestFromContextWithoutRequestID(t *testing.T) {
+	buf := captureLogs(t)
+
+	FromContext(context.Background()).Info("no request")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output %q: %v", buf.String(), err)
+	}
+	if _, ok := entry["request_id"]; ok {
+		t.Errorf("unexpected request_id in %q", buf.String())
+	}
+}
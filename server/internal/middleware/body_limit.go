@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"golf-league-manager/internal/logger"
+)
+
+// MaxBodySize is a middleware that rejects request bodies larger than limit bytes with a
+// 413 Request Entity Too Large. The body is read up front, so handlers never see a truncated
+// body and don't need to handle the limit themselves.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				rejectBody(w, r, limit)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					rejectBody(w, r, limit)
+					return
+				}
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func rejectBody(w http.ResponseWriter, r *http.Request, limit int64) {
+	logger.WarnContext(r.Context(), "Request body too large",
+		"path", r.URL.Path,
+		"limit_bytes", limit,
+	)
+	http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+}
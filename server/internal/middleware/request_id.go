@@ -10,14 +10,23 @@ import (
 	"golf-league-manager/internal/logger"
 )
 
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds incoming request IDs, which are echoed back and written to logs
+const maxRequestIDLength = 128
+
 // RequestID is a middleware that adds a unique request ID to each request context.
 // The request ID can be used for distributed tracing and log correlation.
+// The ID is echoed in the X-Request-ID response header so clients can match a response to the
+// server's logs. Install it outermost so responses written by other middleware, such as rate
+// limit rejections and CORS preflights, carry the header too.
 func RequestID() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Check if request ID already exists in header (for distributed tracing)
-			requestID := r.Header.Get("X-Request-ID")
-			if requestID == "" {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
 				requestID = uuid.New().String()
 			}
 
@@ -25,10 +34,24 @@ func RequestID() func(http.Handler) http.Handler {
 			ctx := context.WithValue(r.Context(), logger.RequestIDKey, requestID)
 
 			// Add request ID to response header
-			w.Header().Set("X-Request-ID", requestID)
+			w.Header().Set(RequestIDHeader, requestID)
 
 			// Continue with the request
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// validRequestID reports whether an incoming request ID can be reused: non-empty, not overlong
+// and made only of visible ASCII characters
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"golf-league-manager/internal/logger"
@@ -60,3 +61,57 @@ func TestRequestIDPreserved(t *testing.T) {
 		t.Errorf("Expected X-Request-ID %s, got %s", existingRequestID, rr.Header().Get("X-Request-ID"))
 	}
 }
+
+func TestRequestIDHeaderMatchesGeneratedID(t *testing.T) {
+	var contextID string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextID, _ = r.Context().Value(logger.RequestIDKey).(string)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if contextID == "" || rr.Header().Get(RequestIDHeader) != contextID {
+		t.Errorf("X-Request-ID = %q, want the generated ID %q", rr.Header().Get(RequestIDHeader), contextID)
+	}
+}
+
+func TestRequestIDOnRejectedResponses(t *testing.T) {
+	// Responses written by inner middleware before the handler runs still carry the ID
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(RequestIDHeader, "client-abc")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", rr.Code)
+	}
+	if rr.Header().Get(RequestIDHeader) != "client-abc" {
+		t.Errorf("Expected X-Request-ID client-abc, got %q", rr.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestRequestIDRejectsInvalidIncomingID(t *testing.T) {
+	for _, incoming := range []string{"has space", "tab\there", strings.Repeat("a", maxRequestIDLength+1)} {
+		var contextID string
+		handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contextID, _ = r.Context().Value(logger.RequestIDKey).(string)
+		}))
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(RequestIDHeader, incoming)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		got := rr.Header().Get(RequestIDHeader)
+		if got == incoming || got == "" || got != contextID {
+			t.Errorf("incoming %q: X-Request-ID = %q, context ID = %q, want a fresh generated ID in both", incoming, got, contextID)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"golf-league-manager/internal/logger"
+)
+
+// SchedulerSecretHeader carries the shared secret on requests from Cloud Scheduler
+const SchedulerSecretHeader = "X-Scheduler-Secret"
+
+// SharedSecret is a middleware for machine-to-machine endpoints that rejects requests whose
+// header doesn't carry the configured secret with a 401 Unauthorized. With no secret
+// configured every request is rejected, so the endpoints stay closed until one is set.
+func SharedSecret(header, secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(header)
+			if secret == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+				logger.WarnContext(r.Context(), "Rejected request without a valid shared secret",
+					"path", r.URL.Path,
+					"header_present", provided != "",
+				)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSharedSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		provided   string
+		wantStatus int
+		wantCalled bool
+	}{
+		{name: "valid secret", secret: "s3cret", provided: "s3cret", wantStatus: http.StatusOK, wantCalled: true},
+		{name: "missing header", secret: "s3cret", provided: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", secret: "s3cret", provided: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "no secret configured", secret: "", provided: "", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := SharedSecret(SchedulerSecretHeader, tt.secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("POST", "/api/internal/jobs/test", nil)
+			if tt.provided != "" {
+				req.Header.Set(SchedulerSecretHeader, tt.provided)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("handler called = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
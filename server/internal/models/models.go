@@ -9,6 +9,31 @@ type League struct {
 	Description string    `firestore:"description" json:"description"`
 	CreatedBy   string    `firestore:"created_by" json:"createdBy"` // Player ID who created the league
 	CreatedAt   time.Time `firestore:"created_at" json:"createdAt"`
+
+	OneRoundPerDay            bool   `firestore:"one_round_per_day" json:"oneRoundPerDay"`                       // Count at most one round per player per day toward handicaps
+	PlayingHandicapRounding   string `firestore:"playing_handicap_rounding" json:"playingHandicapRounding"`      // nearest|up|down, applied to .5 playing handicaps (default nearest)
+	BulletinEditWindowMinutes int    `firestore:"bulletin_edit_window_minutes" json:"bulletinEditWindowMinutes"` // How long authors can edit bulletin posts (default 10)
+	StandardSlope             int    `firestore:"standard_slope" json:"standardSlope"`                           // Baseline slope for course handicaps and differentials (default 113)
+	MaxStrokesPerHole         int    `firestore:"max_strokes_per_hole" json:"maxStrokesPerHole"`                 // Most handicap strokes a player receives on one hole (default 3)
+	SeasonArchiveGraceDays    int    `firestore:"season_archive_grace_days" json:"seasonArchiveGraceDays"`       // Days after a season ends before it is archived automatically (default 14)
+	ProvisionalWeight         int    `firestore:"provisional_weight" json:"provisionalWeight"`                   // Phantom rounds the provisional handicap counts as for new players (default 2)
+	HandicapWindowSize        int    `firestore:"handicap_window_size" json:"handicapWindowSize"`                // Most recent rounds considered for a handicap (default 5)
+	HandicapUsedCount         int    `firestore:"handicap_used_count" json:"handicapUsedCount"`                  // Best rounds within the window averaged for a handicap (default 3)
+	MatchPointsPerHole        int    `firestore:"match_points_per_hole" json:"matchPointsPerHole"`               // Points for winning a hole in a match (default 2)
+	UnestablishedAdjustment   bool   `firestore:"unestablished_adjustment" json:"unestablishedAdjustment"`       // Cap players without an established handicap at par + 5 per hole instead of net double bogey
+
+	AbsentStrokesOverParPlusHandicap *int     `firestore:"absent_strokes_over_par_plus_handicap" json:"absentStrokesOverParPlusHandicap"` // Strokes over par plus handicap charged to absent players (default 3)
+	MinProvisionalHandicap           *float64 `firestore:"min_provisional_handicap" json:"minProvisionalHandicap"`                        // Lowest provisional handicap a season player may be given (default -10)
+	MaxProvisionalHandicap           *float64 `firestore:"max_provisional_handicap" json:"maxProvisionalHandicap"`                        // Highest provisional handicap a season player may be given (default 54)
+	HandicapDecay                    float64  `firestore:"handicap_decay" json:"handicapDecay"`                                           // Experimental per-day recency decay for handicap differentials (0 = standard best 3 of 5)
+	UseHandicaps                     *bool    `firestore:"use_handicaps" json:"useHandicaps"`                                             // Whether matches are played with handicaps; false plays straight gross (default true)
+	MatchOverallPoints               *int     `firestore:"match_overall_points" json:"matchOverallPoints"`                                // Points for the lower overall net in a match, however many holes (default 4)
+	HandicapDropSchedule             []int    `firestore:"handicap_drop_schedule" json:"handicapDropSchedule"`                            // Best differentials averaged for 1, 2, 3... rounds; the last entry applies beyond (default best HandicapUsedCount)
+	NetScoreFloorToPar               *int     `firestore:"net_score_floor_to_par" json:"netScoreFloorToPar"`                              // Lowest displayed net score relative to par, e.g. 0 for never under par (default no floor)
+	SeedProvisionalFromOtherLeagues  bool     `firestore:"seed_provisional_from_other_leagues" json:"seedProvisionalFromOtherLeagues"`    // Start new players from their established index in another league they belong to
+	HighRoundFlagMargin              float64  `firestore:"high_round_flag_margin" json:"highRoundFlagMargin"`                             // Strokes a round's differential may exceed the player's index before it is flagged for review (default 15)
+	ExcludeFlaggedScores             bool     `firestore:"exclude_flagged_scores" json:"excludeFlaggedScores"`                            // Leave flagged rounds out of handicaps until an admin reviews them
+	OfficialRoundsOnly               bool     `firestore:"official_rounds_only" json:"officialRoundsOnly"`                                // Count only official rounds toward handicaps, leaving out makeups and substitute rounds
 }
 
 // LeagueMember represents a player's membership in a league with their role
@@ -28,11 +53,22 @@ type SeasonPlayer struct {
 	ID                  string    `firestore:"id" json:"id"`
 	SeasonID            string    `firestore:"season_id" json:"seasonId"`
 	PlayerID            string    `firestore:"player_id" json:"playerId"`
+	PlayerName          string    `firestore:"player_name" json:"playerName"` // Denormalized at enrollment for roster ordering
 	LeagueID            string    `firestore:"league_id" json:"leagueId"`
 	ProvisionalHandicap float64   `firestore:"provisional_handicap" json:"provisionalHandicap"` // Starting handicap for this season
 	CurrentHandicapIndex float64   `firestore:"current_handicap_index" json:"currentHandicapIndex"` // Current handicap index for this season
 	AddedAt             time.Time `firestore:"added_at" json:"addedAt"`
 	IsActive            bool      `firestore:"is_active" json:"isActive"` // Whether player is active in the season
+	Division            string    `firestore:"division" json:"division"`  // Division within the season, empty when the league is not split
+
+	IndexHistory []IndexPoint `firestore:"index_history" json:"indexHistory"` // Index after each match day's recalculation, oldest first
+}
+
+// IndexPoint records a season player's handicap index after a match day was scored
+type IndexPoint struct {
+	Date          time.Time `firestore:"date" json:"date"`
+	MatchDayID    string    `firestore:"match_day_id" json:"matchDayId"`
+	HandicapIndex float64   `firestore:"handicap_index" json:"handicapIndex"`
 }
 
 // Player represents a golf league player (global, can be in multiple leagues)
@@ -60,13 +96,44 @@ type LeagueInvite struct {
 
 // BulletinMessage represents a message posted to a season's bulletin board
 type BulletinMessage struct {
-	ID         string    `firestore:"id" json:"id"`
-	SeasonID   string    `firestore:"season_id" json:"seasonId"`
-	LeagueID   string    `firestore:"league_id" json:"leagueId"`
-	PlayerID   string    `firestore:"player_id" json:"playerId"`
-	PlayerName string    `firestore:"player_name" json:"playerName"` // Denormalized for display
-	Content    string    `firestore:"content" json:"content"`
-	CreatedAt  time.Time `firestore:"created_at" json:"createdAt"`
+	ID         string     `firestore:"id" json:"id"`
+	SeasonID   string     `firestore:"season_id" json:"seasonId"`
+	LeagueID   string     `firestore:"league_id" json:"leagueId"`
+	PlayerID   string     `firestore:"player_id" json:"playerId"`
+	PlayerName string     `firestore:"player_name" json:"playerName"` // Denormalized for display
+	Content    string     `firestore:"content" json:"content"`
+	CreatedAt  time.Time  `firestore:"created_at" json:"createdAt"`
+	EditedAt   *time.Time `firestore:"edited_at" json:"editedAt"` // Set when the content is changed after posting
+	Pinned     bool       `firestore:"pinned" json:"pinned"`      // Pinned messages are listed before all others
+	PinnedAt   *time.Time `firestore:"pinned_at" json:"pinnedAt"`
+}
+
+// NotificationPrefs stores which notifications a player wants for a league.
+// A player with no stored preferences receives everything.
+type NotificationPrefs struct {
+	ID               string    `firestore:"id" json:"id"` // league ID and player ID joined by an underscore
+	LeagueID         string    `firestore:"league_id" json:"leagueId"`
+	PlayerID         string    `firestore:"player_id" json:"playerId"`
+	MatchupReminders bool      `firestore:"matchup_reminders" json:"matchupReminders"`
+	ResultsPosted    bool      `firestore:"results_posted" json:"resultsPosted"`
+	HandicapChanged  bool      `firestore:"handicap_changed" json:"handicapChanged"`
+	UpdatedAt        time.Time `firestore:"updated_at" json:"updatedAt"`
+}
+
+// RecalcRun holds the timing and counts of a league's most recent handicap recalculation.
+// There is one per league, overwritten by each run.
+type RecalcRun struct {
+	ID               string    `firestore:"id" json:"id"` // the league ID
+	LeagueID         string    `firestore:"league_id" json:"leagueId"`
+	SeasonID         string    `firestore:"season_id" json:"seasonId"`
+	StartedAt        time.Time `firestore:"started_at" json:"startedAt"`
+	FinishedAt       time.Time `firestore:"finished_at" json:"finishedAt"`
+	DurationMs       int64     `firestore:"duration_ms" json:"durationMs"`
+	AvgPlayerMs      float64   `firestore:"avg_player_ms" json:"avgPlayerMs"` // per processed player
+	PlayersProcessed int       `firestore:"players_processed" json:"playersProcessed"`
+	PlayersSucceeded int       `firestore:"players_succeeded" json:"playersSucceeded"`
+	PlayersFailed    int       `firestore:"players_failed" json:"playersFailed"`
+	PlayersSkipped   int       `firestore:"players_skipped" json:"playersSkipped"` // inactive season players
 }
 
 // Round struct removed - merged into Score
@@ -103,6 +170,20 @@ type Season struct {
 	Active      bool      `firestore:"active" json:"active"`
 	Description string    `firestore:"description" json:"description"`
 	CreatedAt   time.Time `firestore:"created_at" json:"createdAt"`
+
+	AbsentMatchRule      string  `firestore:"absent_match_rule" json:"absentMatchRule"`           // play-inflated|fixed-fraction (default play-inflated)
+	AbsentPointsFraction float64 `firestore:"absent_points_fraction" json:"absentPointsFraction"` // Share of the match points an absent player gets under fixed-fraction
+	HalvedHoleRule       string  `firestore:"halved_hole_rule" json:"halvedHoleRule"`             // split|none: halved holes split the points or score nothing (default split)
+	NassauMode           bool    `firestore:"nassau_mode" json:"nassauMode"`                      // On 18 holes, award the overall points separately for the front nine, back nine and total
+	WeeklyPointsTable    []int   `firestore:"weekly_points_table" json:"weeklyPointsTable"`       // Season points by weekly finishing position on net (1st, 2nd, ...); replaces match points in the standings when set
+
+	Archived   bool       `firestore:"archived" json:"archived"`      // Archived seasons are read-only
+	ArchivedAt *time.Time `firestore:"archived_at" json:"archivedAt"` // When the season was archived
+
+	RequireCompleteMatchDays bool `firestore:"require_complete_match_days" json:"requireCompleteMatchDays"` // Keep a match day open until every match has both scores
+	MinMatchesForStandings   int  `firestore:"min_matches_for_standings" json:"minMatchesForStandings"`     // Matches needed to be ranked in the final standings (0 ranks everyone)
+	StrictScoreEntry         bool `firestore:"strict_score_entry" json:"strictScoreEntry"`                 // Reject a whole score submission if any score is for a player not in its match
+	MinFieldForStats         int  `firestore:"min_field_for_stats" json:"minFieldForStats"`                 // Players needed on a match day for a PCC and a final leaderboard (0 applies no minimum)
 }
 
 // MatchDay represents a collection of matches at a specific course on a specific day
@@ -114,6 +195,13 @@ type MatchDay struct {
 	CourseID  string    `firestore:"course_id" json:"courseId"`
 	Status    string    `firestore:"status" json:"status"` // scheduled|completed|locked
 	CreatedAt time.Time `firestore:"created_at" json:"createdAt"`
+
+	Conditions   string `firestore:"conditions" json:"conditions"`      // Free-form playing conditions (e.g. "windy, firm greens")
+	TemperatureF *int   `firestore:"temperature_f" json:"temperatureF"` // Optional temperature in Fahrenheit
+	WindMph      *int   `firestore:"wind_mph" json:"windMph"`           // Optional wind speed in miles per hour
+	PCC          int    `firestore:"pcc" json:"pcc"`                    // Playing conditions adjustment applied to differentials (-1 to +3)
+
+	MatchPlayBasis string `firestore:"match_play_basis" json:"matchPlayBasis"` // "net" (default) or "gross": gross match play gives no strokes
 }
 
 // Match represents a head-to-head match between two players
@@ -124,6 +212,8 @@ type Match struct {
 	MatchDayID    string    `firestore:"match_day_id" json:"matchDayId"` // Reference to the match day
 	PlayerAID     string    `firestore:"player_a_id" json:"playerAId"`
 	PlayerBID     string    `firestore:"player_b_id" json:"playerBId"`
+	PlayerAName   string    `firestore:"player_a_name" json:"playerAName"`     // Denormalized at write time
+	PlayerBName   string    `firestore:"player_b_name" json:"playerBName"`     // Denormalized at write time
 	CourseID      string    `firestore:"course_id" json:"courseId"`            // Denormalized from MatchDay for easier querying if needed, or can be removed. Keeping for now.
 	MatchDate     time.Time `firestore:"match_date" json:"matchDate"`          // Denormalized
 	Status        string    `firestore:"status" json:"status"`                 // scheduled|completed
@@ -131,6 +221,94 @@ type Match struct {
 	PlayerBPoints int       `firestore:"player_b_points" json:"playerBPoints"` // Match points earned by Player B
 	PlayerAAbsent bool      `firestore:"player_a_absent" json:"playerAAbsent"` // True if Player A was absent
 	PlayerBAbsent bool      `firestore:"player_b_absent" json:"playerBAbsent"` // True if Player B was absent
+	Disputed      bool      `firestore:"disputed" json:"disputed"`             // Result is under dispute and left out of standings
+
+	PlayerAConfirmed bool `firestore:"player_a_confirmed" json:"playerAConfirmed"` // Player A has confirmed a completed-unconfirmed result
+	PlayerBConfirmed bool `firestore:"player_b_confirmed" json:"playerBConfirmed"` // Player B has confirmed a completed-unconfirmed result
+
+	StrokesOverridden bool             `firestore:"strokes_overridden" json:"strokesOverridden"` // Played off strokes the players agreed on instead of the computed allocation
+	StrokeOverride    map[string][]int `firestore:"stroke_override" json:"strokeOverride"`       // Agreed strokes received on each hole, keyed by player ID
+}
+
+// TeamScore is one team's card in a foursomes (alternate shot) match. The two players share a
+// ball, so the team has a single score on each hole.
+type TeamScore struct {
+	PlayerIDs     []string `firestore:"player_ids" json:"playerIds"`
+	PlayerNames   []string `firestore:"player_names" json:"playerNames"` // Denormalized at write time
+	HoleScores    []int    `firestore:"hole_scores" json:"holeScores"`
+	GrossScore    int      `firestore:"gross_score" json:"grossScore"`
+	TeamHandicap  int      `firestore:"team_handicap" json:"teamHandicap"`    // Combined playing handicap of the two players
+	Strokes       []int    `firestore:"strokes" json:"strokes"`               // Strokes received on each hole
+	MatchNetScore int      `firestore:"match_net_score" json:"matchNetScore"` // Gross less the strokes received
+}
+
+// FoursomesMatch is an alternate shot match between two teams of two. Foursomes rounds are
+// played for their own points and don't count toward handicaps or the season standings.
+type FoursomesMatch struct {
+	ID          string    `firestore:"id" json:"id"`
+	LeagueID    string    `firestore:"league_id" json:"leagueId"`
+	SeasonID    string    `firestore:"season_id" json:"seasonId"`
+	CourseID    string    `firestore:"course_id" json:"courseId"`
+	MatchDate   time.Time `firestore:"match_date" json:"matchDate"`
+	TeamA       TeamScore `firestore:"team_a" json:"teamA"`
+	TeamB       TeamScore `firestore:"team_b" json:"teamB"`
+	TeamAPoints int       `firestore:"team_a_points" json:"teamAPoints"`
+	TeamBPoints int       `firestore:"team_b_points" json:"teamBPoints"`
+	CreatedAt   time.Time `firestore:"created_at" json:"createdAt"`
+}
+
+// Dispute is a player's challenge to a posted match result
+type Dispute struct {
+	ID         string     `firestore:"id" json:"id"`
+	LeagueID   string     `firestore:"league_id" json:"leagueId"`
+	MatchID    string     `firestore:"match_id" json:"matchId"`
+	RaisedBy   string     `firestore:"raised_by" json:"raisedBy"` // Player ID
+	Reason     string     `firestore:"reason" json:"reason"`
+	Status     string     `firestore:"status" json:"status"` // open|resolved
+	CreatedAt  time.Time  `firestore:"created_at" json:"createdAt"`
+	Resolution string     `firestore:"resolution" json:"resolution"`
+	ResolvedBy string     `firestore:"resolved_by" json:"resolvedBy,omitempty"` // Player ID of the admin who resolved it
+	ResolvedAt *time.Time `firestore:"resolved_at" json:"resolvedAt,omitempty"`
+}
+
+// Attachment is a photo or scan attached to a match, such as the paper scorecard. The file is
+// uploaded elsewhere; only its URL is stored.
+type Attachment struct {
+	ID         string    `firestore:"id" json:"id"`
+	LeagueID   string    `firestore:"league_id" json:"leagueId"`
+	MatchID    string    `firestore:"match_id" json:"matchId"`
+	URL        string    `firestore:"url" json:"url"`
+	UploadedBy string    `firestore:"uploaded_by" json:"uploadedBy"` // Player ID
+	CreatedAt  time.Time `firestore:"created_at" json:"createdAt"`
+}
+
+// AuditEntry records an administrative change to league data
+type AuditEntry struct {
+	ID         string    `firestore:"id" json:"id"`
+	LeagueID   string    `firestore:"league_id" json:"leagueId"`
+	Action     string    `firestore:"action" json:"action"`          // e.g. match_points_corrected
+	EntityType string    `firestore:"entity_type" json:"entityType"` // Collection of the changed record, e.g. match
+	EntityID   string    `firestore:"entity_id" json:"entityId"`
+	ActorID    string    `firestore:"actor_id" json:"actorId"` // Player ID of the admin who made the change
+	Before     string    `firestore:"before" json:"before"`
+	After      string    `firestore:"after" json:"after"`
+	CreatedAt  time.Time `firestore:"created_at" json:"createdAt"`
+}
+
+// Achievement is a notable hole a player made in a league round, such as a hole-in-one
+type Achievement struct {
+	ID         string    `firestore:"id" json:"id"`
+	LeagueID   string    `firestore:"league_id" json:"leagueId"`
+	SeasonID   string    `firestore:"season_id" json:"seasonId"`
+	PlayerID   string    `firestore:"player_id" json:"playerId"`
+	PlayerName string    `firestore:"player_name" json:"playerName"` // Denormalized at write time
+	Type       string    `firestore:"type" json:"type"`              // hole-in-one|eagle
+	MatchID    string    `firestore:"match_id" json:"matchId"`
+	ScoreID    string    `firestore:"score_id" json:"scoreId"` // Score the achievement was detected on
+	HoleNumber int       `firestore:"hole_number" json:"holeNumber"`
+	Strokes    int       `firestore:"strokes" json:"strokes"`
+	Par        int       `firestore:"par" json:"par"`
+	Date       time.Time `firestore:"date" json:"date"`
 }
 
 // Score represents a player's scorecard for a match and serves as the handicap record
@@ -138,6 +316,7 @@ type Score struct {
 	ID                      string    `firestore:"id" json:"id"`
 	MatchID                 string    `firestore:"match_id" json:"matchId"`
 	PlayerID                string    `firestore:"player_id" json:"playerId"`
+	PlayerName              string    `firestore:"player_name" json:"playerName"`                             // Denormalized at score entry
 	LeagueID                string    `firestore:"league_id" json:"leagueId"`                                 // Added for easier querying
 	Date                    time.Time `firestore:"date" json:"date"`                                          // Added for easier querying
 	CourseID                string    `firestore:"course_id" json:"courseId"`                                 // Added for easier querying
@@ -145,8 +324,9 @@ type Score struct {
 	HoleAdjustedGrossScores []int     `firestore:"hole_adjusted_gross_scores" json:"holeAdjustedGrossScores"` // Net Double Bogey adjusted
 	MatchNetHoleScores      []int     `firestore:"match_net_hole_scores" json:"matchNetHoleScores"`           // Gross - Match Strokes (per hole)
 	GrossScore              int       `firestore:"gross_score" json:"grossScore"`                             // Total Gross
-	NetScore                int       `firestore:"net_score" json:"netScore"`                                 // Total Net (Gross - Playing Handicap) - kept for display/simple net
-	MatchNetScore           int       `firestore:"match_net_score" json:"matchNetScore"`                      // Total Match Net (Sum of NetHoleScores)
+	NetScore                int       `firestore:"net_score" json:"netScore"`                                 // Total Net (Gross - full Playing Handicap)
+	DisplayNetScore         int       `firestore:"-" json:"displayNetScore"`                                  // NetScore raised to the league's net floor when set; filled in when scores are served, never stored
+	MatchNetScore           int       `firestore:"match_net_score" json:"matchNetScore"`                      // Total Match Net (Sum of NetHoleScores): gross less only the strokes received in the match, used for match points
 	AdjustedGross           int       `firestore:"adjusted_gross" json:"adjustedGross"`                       // Total Adjusted Gross
 	HandicapDifferential    float64   `firestore:"handicap_differential" json:"handicapDifferential"`
 	HandicapIndex           float64   `firestore:"handicap_index" json:"handicapIndex"`     // Index used for this round
@@ -155,4 +335,13 @@ type Score struct {
 	StrokesReceived         int       `firestore:"strokes_received" json:"strokesReceived"` // Total strokes received (Playing Handicap)
 	MatchStrokes            []int     `firestore:"match_strokes" json:"matchStrokes"`       // Strokes received per hole for the match
 	PlayerAbsent            bool      `firestore:"player_absent" json:"playerAbsent"`
+	ConcededHoles           []int     `firestore:"conceded_holes" json:"concededHoles"` // Holes (1-based) conceded to this player by the opponent in match play
+	WeekNumber              int       `firestore:"week_number" json:"weekNumber"`       // Week of the season the round was played in, from its match day (0 when not recorded)
+
+	FlaggedForReview bool       `firestore:"flagged_for_review" json:"flaggedForReview"` // Differential far above the player's index; may be a data-entry error
+	ReviewedAt       *time.Time `firestore:"reviewed_at" json:"reviewedAt"`              // When an admin reviewed a flagged round and let it stand
+
+	Makeup     bool  `firestore:"makeup" json:"makeup"`         // Played as a makeup for a missed match
+	Substitute bool  `firestore:"substitute" json:"substitute"` // Played by a substitute standing in for the rostered player
+	Official   *bool `firestore:"official" json:"official"`     // False for makeup and substitute rounds; nil (rounds entered before the flag existed) is official
 }
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+)
+
+// GetAllBatchSize is how many documents a batched read requests in one GetAll call
+const GetAllBatchSize = 100
+
+// batchFetcher reads the documents with the given IDs in one call. Documents that don't exist
+// are left out of the result.
+type batchFetcher[T any] func(ctx context.Context, ids []string) (map[string]T, error)
+
+// getInBatches reads documents by ID in batches of at most batchSize, skipping empty and
+// repeated IDs, and merges the results keyed by ID
+func getInBatches[T any](ctx context.Context, ids []string, batchSize int, fetch batchFetcher[T]) (map[string]T, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	results := make(map[string]T, len(unique))
+	for start := 0; start < len(unique); start += batchSize {
+		end := min(start+batchSize, len(unique))
+		batch, err := fetch(ctx, unique[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for id, item := range batch {
+			results[id] = item
+		}
+	}
+	return results, nil
+}
+
+// GetPlayersByIDs retrieves players by ID with batched reads, keyed by player ID. Players
+// that don't exist are absent from the map rather than an error, so callers enriching lists
+// can skip them.
+func (fc *FirestoreClient) GetPlayersByIDs(ctx context.Context, ids []string) (map[string]models.Player, error) {
+	fetch := func(ctx context.Context, ids []string) (map[string]models.Player, error) {
+		ctx, cancel := withTimeout(ctx)
+		defer cancel()
+
+		refs := make([]*firestore.DocumentRef, len(ids))
+		for i, id := range ids {
+			refs[i] = fc.client.Collection("players").Doc(id)
+		}
+
+		players := make(map[string]models.Player, len(ids))
+		err := retryOnTransientError(ctx, func() error {
+			docs, err := fc.client.GetAll(ctx, refs)
+			if err != nil {
+				return fmt.Errorf("failed to get players: %w", err)
+			}
+			for _, doc := range docs {
+				if !doc.Exists() {
+					continue
+				}
+				var p models.Player
+				if err := doc.DataTo(&p); err != nil {
+					return fmt.Errorf("failed to parse player data: %w", err)
+				}
+				players[doc.Ref.ID] = p
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return players, nil
+	}
+
+	players, err := getInBatches(ctx, ids, GetAllBatchSize, fetch)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve players",
+			"player_count", len(ids),
+			"error", err,
+		)
+		return nil, err
+	}
+	return players, nil
+}
+
+// InQueryBatchSize is the most values a Firestore "in" filter accepts
+const InQueryBatchSize = 30
+
+// GetPlayersHandicapScores retrieves the scores in a league that can go into each player's
+// handicap, keyed by player ID, querying players in batches rather than one at a time. Absent
+// rounds, and makeup and substitute rounds with officialOnly set, are left out.
+func (fc *FirestoreClient) GetPlayersHandicapScores(ctx context.Context, leagueID string, playerIDs []string, officialOnly bool) (map[string][]models.Score, error) {
+	fetch := func(ctx context.Context, ids []string) (map[string][]models.Score, error) {
+		ctx, cancel := withTimeout(ctx)
+		defer cancel()
+
+		iter := fc.client.Collection("scores").
+			Where("league_id", "==", leagueID).
+			Where("player_id", "in", ids).
+			Documents(ctx)
+		defer iter.Stop()
+
+		scores := make(map[string][]models.Score, len(ids))
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to iterate scores: %w", err)
+			}
+
+			var score models.Score
+			if err := doc.DataTo(&score); err != nil {
+				return nil, fmt.Errorf("failed to parse score data: %w", err)
+			}
+			if countsForHandicap(score, officialOnly) {
+				scores[score.PlayerID] = append(scores[score.PlayerID], score)
+			}
+		}
+		return scores, nil
+	}
+
+	scores, err := getInBatches(ctx, playerIDs, InQueryBatchSize, fetch)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve handicap scores",
+			"league_id", leagueID,
+			"player_count", len(playerIDs),
+			"error", err,
+		)
+		return nil, err
+	}
+	return scores, nil
+}
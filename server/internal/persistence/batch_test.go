@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mockBatches serves documents from memory the way GetAll would, leaving out missing IDs
+type mockBatches struct {
+	docs    map[string]string
+	batches [][]string
+	fail    bool
+}
+
+func (m *mockBatches) fetch(ctx context.Context, ids []string) (map[string]string, error) {
+	m.batches = append(m.batches, ids)
+	if m.fail {
+		return nil, errors.New("backend unavailable")
+	}
+	found := make(map[string]string)
+	for _, id := range ids {
+		if doc, ok := m.docs[id]; ok {
+			found[id] = doc
+		}
+	}
+	return found, nil
+}
+
+func TestGetInBatchesMixedIDs(t *testing.T) {
+	m := &mockBatches{docs: map[string]string{"p1": "Alice", "p3": "Cara"}}
+
+	got, err := getInBatches(context.Background(), []string{"p1", "missing", "p3", "p1", ""}, GetAllBatchSize, m.fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.batches) != 1 {
+		t.Fatalf("made %d calls, want 1", len(m.batches))
+	}
+	if len(m.batches[0]) != 3 {
+		t.Errorf("requested %v, want p1, missing and p3 once each", m.batches[0])
+	}
+	if len(got) != 2 || got["p1"] != "Alice" || got["p3"] != "Cara" {
+		t.Errorf("got %v, want p1 and p3 only", got)
+	}
+	if _, ok := got["missing"]; ok {
+		t.Error("missing ID should be absent from the result")
+	}
+}
+
+func TestGetInBatchesSplitsLargeRequests(t *testing.T) {
+	m := &mockBatches{docs: map[string]string{"a": "1", "e": "5", "g": "7"}}
+
+	got, err := getInBatches(context.Background(), []string{"a", "b", "c", "d", "e", "f", "g"}, 3, m.fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.batches) != 3 || len(m.batches[2]) != 1 {
+		t.Errorf("batches = %v, want sizes 3, 3 and 1", m.batches)
+	}
+	if len(got) != 3 {
+		t.Errorf("got %v, want a, e and g", got)
+	}
+}
+
+func TestGetInBatchesNoIDs(t *testing.T) {
+	m := &mockBatches{}
+	got, err := getInBatches(context.Background(), nil, GetAllBatchSize, m.fetch)
+	if err != nil || len(got) != 0 || len(m.batches) != 0 {
+		t.Errorf("got %v, %v after %d calls, want an empty map and no calls", got, err, len(m.batches))
+	}
+}
+
+func TestGetInBatchesFetchErrorPropagates(t *testing.T) {
+	m := &mockBatches{fail: true}
+	if _, err := getInBatches(context.Background(), []string{"p1"}, GetAllBatchSize, m.fetch); err == nil {
+		t.Error("expected the fetch error to be returned")
+	}
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,6 +22,9 @@ const (
 	MaxRetries = 3
 )
 
+// ErrNoActiveSeason is returned by GetActiveSeason when the league has no active season
+var ErrNoActiveSeason = errors.New("no active season found")
+
 // FirestoreClient wraps the Firestore client for database operations
 type FirestoreClient struct {
 	client *firestore.Client
@@ -349,6 +353,40 @@ func (fc *FirestoreClient) ListLeagueMembers(ctx context.Context, leagueID strin
 	return members, nil
 }
 
+// ListPlayerMemberships retrieves a player's membership records in a league, including
+// soft-deleted ones
+func (fc *FirestoreClient) ListPlayerMemberships(ctx context.Context, leagueID, playerID string) ([]models.LeagueMember, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("league_members").
+		Where("league_id", "==", leagueID).
+		Where("player_id", "==", playerID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	members := make([]models.LeagueMember, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate player memberships", "error", err)
+			return nil, fmt.Errorf("failed to iterate league members: %w", err)
+		}
+
+		var member models.LeagueMember
+		if err := doc.DataTo(&member); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse league member data", "error", err)
+			return nil, fmt.Errorf("failed to parse league member data: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
 // SoftDeleteLeagueMember performs a soft delete on a league member
 func (fc *FirestoreClient) SoftDeleteLeagueMember(ctx context.Context, memberID string) error {
 	ctx, cancel := withTimeout(ctx)
@@ -373,10 +411,11 @@ func (fc *FirestoreClient) SoftDeleteLeagueMember(ctx context.Context, memberID
 
 // matchFilter defines the filter criteria for player match queries
 type matchFilter struct {
-	leagueID string
-	seasonID string
-	playerID string
-	status   string
+	leagueID   string
+	seasonID   string
+	matchDayID string
+	playerID   string
+	status     string
 }
 
 // getPlayerMatches is a helper function to retrieve matches where a player is involved
@@ -392,6 +431,9 @@ func (fc *FirestoreClient) getPlayerMatches(ctx context.Context, filter matchFil
 	if filter.seasonID != "" {
 		queryA = queryA.Where("season_id", "==", filter.seasonID)
 	}
+	if filter.matchDayID != "" {
+		queryA = queryA.Where("match_day_id", "==", filter.matchDayID)
+	}
 	queryA = queryA.Where("player_a_id", "==", filter.playerID)
 	if filter.status != "" {
 		queryA = queryA.Where("status", "==", filter.status)
@@ -424,6 +466,9 @@ func (fc *FirestoreClient) getPlayerMatches(ctx context.Context, filter matchFil
 	if filter.seasonID != "" {
 		queryB = queryB.Where("season_id", "==", filter.seasonID)
 	}
+	if filter.matchDayID != "" {
+		queryB = queryB.Where("match_day_id", "==", filter.matchDayID)
+	}
 	queryB = queryB.Where("player_b_id", "==", filter.playerID)
 	if filter.status != "" {
 		queryB = queryB.Where("status", "==", filter.status)
@@ -475,6 +520,17 @@ func (fc *FirestoreClient) GetPlayerCompletedMatches(ctx context.Context, league
 	})
 }
 
+// GetPlayerLeagueMatches retrieves every match the player is in within a league, whatever its status
+func (fc *FirestoreClient) GetPlayerLeagueMatches(ctx context.Context, leagueID, playerID string) ([]models.Match, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return fc.getPlayerMatches(ctx, matchFilter{
+		leagueID: leagueID,
+		playerID: playerID,
+	})
+}
+
 // SeasonPlayer operations
 
 // CreateSeasonPlayer adds a player to a season
@@ -575,6 +631,38 @@ func (fc *FirestoreClient) ListSeasonPlayers(ctx context.Context, seasonID strin
 	return seasonPlayers, nil
 }
 
+// ListLeagueSeasonPlayers retrieves season players from every season in a league
+func (fc *FirestoreClient) ListLeagueSeasonPlayers(ctx context.Context, leagueID string) ([]models.SeasonPlayer, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("season_players").
+		Where("league_id", "==", leagueID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	seasonPlayers := make([]models.SeasonPlayer, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate league season players", "error", err)
+			return nil, fmt.Errorf("failed to iterate season players: %w", err)
+		}
+
+		var seasonPlayer models.SeasonPlayer
+		if err := doc.DataTo(&seasonPlayer); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse season player data", "error", err)
+			return nil, fmt.Errorf("failed to parse season player data: %w", err)
+		}
+		seasonPlayers = append(seasonPlayers, seasonPlayer)
+	}
+
+	return seasonPlayers, nil
+}
+
 // RemoveSeasonPlayer marks a season player as inactive
 func (fc *FirestoreClient) RemoveSeasonPlayer(ctx context.Context, seasonPlayerID string) error {
 	ctx, cancel := withTimeout(ctx)
@@ -633,6 +721,41 @@ func (fc *FirestoreClient) CountPlayerScores(ctx context.Context, leagueID, play
 	return count, nil
 }
 
+// CountPlayerHandicapScores counts a player's rounds in a league that can go into their
+// handicap, the count that decides whether they are established. Absent rounds, and makeup
+// and substitute rounds with officialOnly set, are left out.
+func (fc *FirestoreClient) CountPlayerHandicapScores(ctx context.Context, leagueID, playerID string, officialOnly bool) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("scores").
+		Where("league_id", "==", leagueID).
+		Where("player_id", "==", playerID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to count player scores: %w", err)
+		}
+
+		var score models.Score
+		if err := doc.DataTo(&score); err != nil {
+			return 0, fmt.Errorf("failed to parse score data: %w", err)
+		}
+		if countsForHandicap(score, officialOnly) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 // GetPlayerLeagues retrieves all leagues a player is a member of
 func (fc *FirestoreClient) GetPlayerLeagues(ctx context.Context, playerID string) ([]models.League, error) {
 	ctx, cancel := withTimeout(ctx)
@@ -940,9 +1063,76 @@ func (fc *FirestoreClient) GetPlayerScores(ctx context.Context, leagueID, player
 	return scores, nil
 }
 
+// ListPlayerLeagueScores retrieves every score a player has recorded in a league, across all seasons
+func (fc *FirestoreClient) ListPlayerLeagueScores(ctx context.Context, leagueID, playerID string) ([]models.Score, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("scores").
+		Where("league_id", "==", leagueID).
+		Where("player_id", "==", playerID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	scores := make([]models.Score, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate player scores", "error", err)
+			return nil, fmt.Errorf("failed to iterate scores: %w", err)
+		}
+
+		var score models.Score
+		if err := doc.DataTo(&score); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse score data", "error", err)
+			return nil, fmt.Errorf("failed to parse score data: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
+// ListFlaggedScores retrieves a league's scores that are flagged for review
+func (fc *FirestoreClient) ListFlaggedScores(ctx context.Context, leagueID string) ([]models.Score, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("scores").
+		Where("league_id", "==", leagueID).
+		Where("flagged_for_review", "==", true).
+		Documents(ctx)
+	defer iter.Stop()
+
+	scores := make([]models.Score, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate flagged scores", "error", err)
+			return nil, fmt.Errorf("failed to iterate scores: %w", err)
+		}
+
+		var score models.Score
+		if err := doc.DataTo(&score); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse score data", "error", err)
+			return nil, fmt.Errorf("failed to parse score data: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
 // GetPlayerScoresForHandicap retrieves the last N non-absent scores for a player in a specific league
 // This is used for handicap calculations where absent rounds should not be considered
-func (fc *FirestoreClient) GetPlayerScoresForHandicap(ctx context.Context, leagueID, playerID string, limit int) ([]models.Score, error) {
+// With officialOnly set, makeup and substitute rounds are left out as well
+func (fc *FirestoreClient) GetPlayerScoresForHandicap(ctx context.Context, leagueID, playerID string, limit int, officialOnly bool) ([]models.Score, error) {
 	// We need to fetch more scores than the limit to account for absent rounds that will be filtered out
 	// Using 3x the limit should be sufficient in most cases
 	fetchLimit := limit * 3
@@ -970,8 +1160,8 @@ func (fc *FirestoreClient) GetPlayerScoresForHandicap(ctx context.Context, leagu
 			return nil, fmt.Errorf("failed to parse score data: %w", err)
 		}
 
-		// Skip absent rounds for handicap calculations
-		if score.PlayerAbsent {
+		// Skip absent rounds, and unofficial ones when asked, for handicap calculations
+		if !countsForHandicap(score, officialOnly) {
 			continue
 		}
 
@@ -986,6 +1176,15 @@ func (fc *FirestoreClient) GetPlayerScoresForHandicap(ctx context.Context, leagu
 	return scores, nil
 }
 
+// countsForHandicap reports whether a stored score can go into a handicap calculation.
+// Scores saved before the official flag existed have none and count as official.
+func countsForHandicap(score models.Score, officialOnly bool) bool {
+	if score.PlayerAbsent {
+		return false
+	}
+	return !officialOnly || score.Official == nil || *score.Official
+}
+
 // models.Course operations
 
 // CreateCourse creates a new course in Firestore
@@ -1087,6 +1286,40 @@ func (fc *FirestoreClient) CreateMatch(ctx context.Context, match models.Match)
 	return nil
 }
 
+// FindMatch looks up an existing matchup between two players on a match day, in either
+// player order. It returns nil without an error when there is no such match.
+func (fc *FirestoreClient) FindMatch(ctx context.Context, matchDayID, playerAID, playerBID string) (*models.Match, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	pairs := [][2]string{{playerAID, playerBID}, {playerBID, playerAID}}
+	for _, pair := range pairs {
+		iter := fc.client.Collection("matches").
+			Where("match_day_id", "==", matchDayID).
+			Where("player_a_id", "==", pair[0]).
+			Where("player_b_id", "==", pair[1]).
+			Limit(1).
+			Documents(ctx)
+
+		doc, err := iter.Next()
+		iter.Stop()
+		if err == iterator.Done {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find match: %w", err)
+		}
+
+		var match models.Match
+		if err := doc.DataTo(&match); err != nil {
+			return nil, fmt.Errorf("failed to parse match data: %w", err)
+		}
+		return &match, nil
+	}
+
+	return nil, nil
+}
+
 // GetMatch retrieves a match by ID
 func (fc *FirestoreClient) GetMatch(ctx context.Context, matchID string) (*models.Match, error) {
 	doc, err := fc.client.Collection("matches").Doc(matchID).Get(ctx)
@@ -1226,6 +1459,34 @@ func (fc *FirestoreClient) ListMatchDays(ctx context.Context, leagueID string) (
 	return matchDays, nil
 }
 
+// ListMatchDaysBySeason retrieves all match days for a season, newest first
+func (fc *FirestoreClient) ListMatchDaysBySeason(ctx context.Context, seasonID string) ([]models.MatchDay, error) {
+	iter := fc.client.Collection("match_days").
+		Where("season_id", "==", seasonID).
+		OrderBy("date", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	matchDays := make([]models.MatchDay, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate match days: %w", err)
+		}
+
+		var matchDay models.MatchDay
+		if err := doc.DataTo(&matchDay); err != nil {
+			return nil, fmt.Errorf("failed to parse match day data: %w", err)
+		}
+		matchDays = append(matchDays, matchDay)
+	}
+
+	return matchDays, nil
+}
+
 // GetPlayerMatchScores retrieves all scores for a specific player in a match
 func (fc *FirestoreClient) GetPlayerMatchScores(ctx context.Context, matchID, playerID string) ([]models.Score, error) {
 	iter := fc.client.Collection("scores").
@@ -1254,60 +1515,171 @@ func (fc *FirestoreClient) GetPlayerMatchScores(ctx context.Context, matchID, pl
 	return scores, nil
 }
 
-// Season operations
-
-// CreateSeason creates a new season in Firestore
-func (fc *FirestoreClient) CreateSeason(ctx context.Context, season models.Season) error {
-	_, err := fc.client.Collection("seasons").Doc(season.ID).Set(ctx, season)
-	if err != nil {
-		return fmt.Errorf("failed to create season: %w", err)
-	}
-	return nil
-}
-
-// GetSeason retrieves a season by ID
-func (fc *FirestoreClient) GetSeason(ctx context.Context, seasonID string) (*models.Season, error) {
-	doc, err := fc.client.Collection("seasons").Doc(seasonID).Get(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get season: %w", err)
-	}
-
-	var season models.Season
-	if err := doc.DataTo(&season); err != nil {
-		return nil, fmt.Errorf("failed to parse season data: %w", err)
-	}
-
-	return &season, nil
-}
-
-// UpdateSeason updates an existing season
-func (fc *FirestoreClient) UpdateSeason(ctx context.Context, season models.Season) error {
-	_, err := fc.client.Collection("seasons").Doc(season.ID).Set(ctx, season)
-	if err != nil {
-		return fmt.Errorf("failed to update season: %w", err)
-	}
-	return nil
-}
+// UpdateDenormalizedPlayerName rewrites a denormalized player name field on every document in
+// a collection whose idField matches the player, using batched writes.
+// Returns the number of documents updated; when some writes fail, the count covers only the
+// ones that succeeded and an error reports the failures.
+func (fc *FirestoreClient) UpdateDenormalizedPlayerName(ctx context.Context, collection, idField, nameField, playerID, name string) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 
-// ListSeasons retrieves all seasons for a league
-func (fc *FirestoreClient) ListSeasons(ctx context.Context, leagueID string) ([]models.Season, error) {
-	iter := fc.client.Collection("seasons").
-		Where("league_id", "==", leagueID).
-		OrderBy("start_date", firestore.Desc).
+	iter := fc.client.Collection(collection).
+		Where(idField, "==", playerID).
 		Documents(ctx)
 	defer iter.Stop()
 
-	seasons := make([]models.Season, 0)
+	bw := fc.client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var queueErr error
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate seasons: %w", err)
+			queueErr = fmt.Errorf("failed to iterate %s for rename: %w", collection, err)
+			break
+		}
+		job, err := bw.Update(doc.Ref, []firestore.Update{{Path: nameField, Value: name}})
+		if err != nil {
+			queueErr = fmt.Errorf("failed to queue %s rename: %w", collection, err)
+			break
 		}
+		jobs = append(jobs, job)
+	}
 
-		var season models.Season
+	// End flushes every queued write, so each job has its result once it returns
+	bw.End()
+
+	count := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		count++
+	}
+	if queueErr != nil {
+		return count, queueErr
+	}
+	if firstErr != nil {
+		return count, fmt.Errorf("failed to rename %d of %d %s: %w", len(jobs)-count, len(jobs), collection, firstErr)
+	}
+	return count, nil
+}
+
+// GetPlayerMatchDayScores retrieves all of a player's scores on a match day, across every match
+// they played that day (e.g. a regular match and a makeup match)
+func (fc *FirestoreClient) GetPlayerMatchDayScores(ctx context.Context, matchDayID, playerID string) ([]models.Score, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	matches, err := fc.getPlayerMatches(ctx, matchFilter{
+		matchDayID: matchDayID,
+		playerID:   playerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return playerMatchDayScores(ctx, matches, playerID, fc.GetPlayerMatchScores)
+}
+
+// playerMatchDayScores gathers a player's scores from each of the matches they played on a
+// match day, in match order, fetching each match once
+func playerMatchDayScores(ctx context.Context, matches []models.Match, playerID string, fetch func(ctx context.Context, matchID, playerID string) ([]models.Score, error)) ([]models.Score, error) {
+	scores := make([]models.Score, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if seen[match.ID] {
+			continue
+		}
+		seen[match.ID] = true
+
+		matchScores, err := fetch(ctx, match.ID, playerID)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, matchScores...)
+	}
+
+	return scores, nil
+}
+
+// Season operations
+
+// CreateSeason creates a new season in Firestore
+func (fc *FirestoreClient) CreateSeason(ctx context.Context, season models.Season) error {
+	_, err := fc.client.Collection("seasons").Doc(season.ID).Set(ctx, season)
+	if err != nil {
+		return fmt.Errorf("failed to create season: %w", err)
+	}
+	return nil
+}
+
+// CreateSeasons creates a set of seasons in one transaction, so either all are saved or none
+func (fc *FirestoreClient) CreateSeasons(ctx context.Context, seasons []models.Season) error {
+	err := fc.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, season := range seasons {
+			if err := tx.Set(fc.client.Collection("seasons").Doc(season.ID), season); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create seasons: %w", err)
+	}
+	return nil
+}
+
+// GetSeason retrieves a season by ID
+func (fc *FirestoreClient) GetSeason(ctx context.Context, seasonID string) (*models.Season, error) {
+	doc, err := fc.client.Collection("seasons").Doc(seasonID).Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+
+	var season models.Season
+	if err := doc.DataTo(&season); err != nil {
+		return nil, fmt.Errorf("failed to parse season data: %w", err)
+	}
+
+	return &season, nil
+}
+
+// UpdateSeason updates an existing season
+func (fc *FirestoreClient) UpdateSeason(ctx context.Context, season models.Season) error {
+	_, err := fc.client.Collection("seasons").Doc(season.ID).Set(ctx, season)
+	if err != nil {
+		return fmt.Errorf("failed to update season: %w", err)
+	}
+	return nil
+}
+
+// ListSeasons retrieves all seasons for a league
+func (fc *FirestoreClient) ListSeasons(ctx context.Context, leagueID string) ([]models.Season, error) {
+	iter := fc.client.Collection("seasons").
+		Where("league_id", "==", leagueID).
+		OrderBy("start_date", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	seasons := make([]models.Season, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate seasons: %w", err)
+		}
+
+		var season models.Season
 		if err := doc.DataTo(&season); err != nil {
 			return nil, fmt.Errorf("failed to parse season data: %w", err)
 		}
@@ -1328,7 +1700,7 @@ func (fc *FirestoreClient) GetActiveSeason(ctx context.Context, leagueID string)
 
 	doc, err := iter.Next()
 	if err == iterator.Done {
-		return nil, fmt.Errorf("no active season found")
+		return nil, ErrNoActiveSeason
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active season: %w", err)
@@ -1346,7 +1718,38 @@ func (fc *FirestoreClient) GetActiveSeason(ctx context.Context, leagueID string)
 func (fc *FirestoreClient) GetSeasonMatches(ctx context.Context, seasonID string) ([]models.Match, error) {
 	iter := fc.client.Collection("matches").
 		Where("season_id", "==", seasonID).
-		OrderBy("week_number", firestore.Asc).
+		OrderBy("match_date", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	matches := make([]models.Match, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate matches: %w", err)
+		}
+
+		var match models.Match
+		if err := doc.DataTo(&match); err != nil {
+			return nil, fmt.Errorf("failed to parse match data: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+// ListLeagueMatchesInRange returns a league's matches, across all seasons, whose match date is
+// at or after start and before end, ordered by date
+func (fc *FirestoreClient) ListLeagueMatchesInRange(ctx context.Context, leagueID string, start, end time.Time) ([]models.Match, error) {
+	iter := fc.client.Collection("matches").
+		Where("league_id", "==", leagueID).
+		Where("match_date", ">=", start).
+		Where("match_date", "<", end).
+		OrderBy("match_date", firestore.Asc).
 		Documents(ctx)
 	defer iter.Stop()
 
@@ -1500,6 +1903,60 @@ func (fc *FirestoreClient) BatchUpdateMatches(ctx context.Context, matches []mod
 	return nil
 }
 
+// AuditEntry operations
+
+// CreateAuditEntries saves a set of audit entries using BulkWriter
+func (fc *FirestoreClient) CreateAuditEntries(ctx context.Context, entries []models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	bw := fc.client.BulkWriter(ctx)
+
+	for _, entry := range entries {
+		ref := fc.client.Collection("audit_log").Doc(entry.ID)
+		if _, err := bw.Set(ref, entry); err != nil {
+			return fmt.Errorf("failed to add audit entry to bulk writer: %w", err)
+		}
+	}
+
+	bw.Flush()
+	return nil
+}
+
+// ListAuditEntries retrieves a league's audit entries for one action
+func (fc *FirestoreClient) ListAuditEntries(ctx context.Context, leagueID, action string) ([]models.AuditEntry, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("audit_log").
+		Where("league_id", "==", leagueID).
+		Where("action", "==", action).
+		Documents(ctx)
+	defer iter.Stop()
+
+	entries := make([]models.AuditEntry, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate audit entries", "error", err)
+			return nil, fmt.Errorf("failed to iterate audit entries: %w", err)
+		}
+
+		var entry models.AuditEntry
+		if err := doc.DataTo(&entry); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse audit entry data", "error", err)
+			return nil, fmt.Errorf("failed to parse audit entry data: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
 // UpdateScore updates an existing score
 func (fc *FirestoreClient) UpdateScore(ctx context.Context, score models.Score) error {
 	_, err := fc.client.Collection("scores").Doc(score.ID).Set(ctx, score)
@@ -1565,6 +2022,57 @@ func (fc *FirestoreClient) GetBulletinMessage(ctx context.Context, messageID str
 	return message, nil
 }
 
+// ListPinnedBulletinMessages retrieves every pinned message for a season
+func (fc *FirestoreClient) ListPinnedBulletinMessages(ctx context.Context, seasonID string) ([]models.BulletinMessage, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("bulletin_messages").
+		Where("season_id", "==", seasonID).
+		Where("pinned", "==", true).
+		Documents(ctx)
+	defer iter.Stop()
+
+	messages := make([]models.BulletinMessage, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate pinned bulletin messages", "error", err)
+			return nil, fmt.Errorf("failed to iterate pinned bulletin messages: %w", err)
+		}
+
+		var message models.BulletinMessage
+		if err := doc.DataTo(&message); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse bulletin message data", "error", err)
+			return nil, fmt.Errorf("failed to parse bulletin message data: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// UpdateBulletinMessage saves changes to an existing bulletin message
+func (fc *FirestoreClient) UpdateBulletinMessage(ctx context.Context, message models.BulletinMessage) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("bulletin_messages").Doc(message.ID).Set(ctx, message)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to update bulletin message",
+				"message_id", message.ID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to update bulletin message: %w", err)
+		}
+		return nil
+	})
+}
+
 // ListBulletinMessages retrieves all messages for a season, ordered by creation time descending
 func (fc *FirestoreClient) ListBulletinMessages(ctx context.Context, seasonID string, limit int) ([]models.BulletinMessage, error) {
 	ctx, cancel := withTimeout(ctx)
@@ -1608,6 +2116,56 @@ func (fc *FirestoreClient) ListBulletinMessages(ctx context.Context, seasonID st
 	return messages, nil
 }
 
+// ListLeagueBulletinMessages retrieves a page of messages across all of a league's seasons,
+// ordered by creation time descending. pageToken is the token returned by the previous page,
+// or empty for the first page. The returned token is empty when there are no more pages.
+func (fc *FirestoreClient) ListLeagueBulletinMessages(ctx context.Context, leagueID string, limit int, pageToken string) ([]models.BulletinMessage, string, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	query := fc.client.Collection("bulletin_messages").
+		Where("league_id", "==", leagueID).
+		OrderBy("created_at", firestore.Desc)
+
+	if pageToken != "" {
+		// The token is the ID of the last message on the previous page
+		cursor, err := fc.client.Collection("bulletin_messages").Doc(pageToken).Get(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid page token: %w", err)
+		}
+		query = query.StartAfter(cursor)
+	}
+
+	iter := query.Limit(limit).Documents(ctx)
+	defer iter.Stop()
+
+	messages := make([]models.BulletinMessage, 0, limit)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate league bulletin messages", "error", err)
+			return nil, "", fmt.Errorf("failed to iterate bulletin messages: %w", err)
+		}
+
+		var message models.BulletinMessage
+		if err := doc.DataTo(&message); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse bulletin message data", "error", err)
+			return nil, "", fmt.Errorf("failed to parse bulletin message data: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	nextPageToken := ""
+	if len(messages) == limit {
+		nextPageToken = messages[len(messages)-1].ID
+	}
+
+	return messages, nextPageToken, nil
+}
+
 // DeleteBulletinMessage deletes a bulletin message by ID
 func (fc *FirestoreClient) DeleteBulletinMessage(ctx context.Context, messageID string) error {
 	ctx, cancel := withTimeout(ctx)
@@ -1779,3 +2337,581 @@ func (fc *FirestoreClient) ListLeagueInvites(ctx context.Context, leagueID strin
 
 	return invites, nil
 }
+
+// Dispute operations
+
+// CreateDispute creates a new match result dispute
+func (fc *FirestoreClient) CreateDispute(ctx context.Context, dispute models.Dispute) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("disputes").Doc(dispute.ID).Set(ctx, dispute)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to create dispute",
+				"dispute_id", dispute.ID,
+				"match_id", dispute.MatchID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to create dispute: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetDispute retrieves a dispute by ID
+func (fc *FirestoreClient) GetDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var dispute *models.Dispute
+	err := retryOnTransientError(ctx, func() error {
+		doc, err := fc.client.Collection("disputes").Doc(disputeID).Get(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get dispute: %w", err)
+		}
+
+		var d models.Dispute
+		if err := doc.DataTo(&d); err != nil {
+			return fmt.Errorf("failed to parse dispute data: %w", err)
+		}
+		dispute = &d
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return dispute, nil
+}
+
+// UpdateDispute saves changes to an existing dispute
+func (fc *FirestoreClient) UpdateDispute(ctx context.Context, dispute models.Dispute) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("disputes").Doc(dispute.ID).Set(ctx, dispute)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to update dispute",
+				"dispute_id", dispute.ID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to update dispute: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListDisputes retrieves a league's disputes, newest first, optionally filtered by status
+func (fc *FirestoreClient) ListDisputes(ctx context.Context, leagueID, status string) ([]models.Dispute, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	query := fc.client.Collection("disputes").Where("league_id", "==", leagueID)
+	if status != "" {
+		query = query.Where("status", "==", status)
+	}
+	iter := query.OrderBy("created_at", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	return collectDisputes(ctx, iter)
+}
+
+// ListMatchDisputes retrieves every dispute raised against a match
+func (fc *FirestoreClient) ListMatchDisputes(ctx context.Context, matchID string) ([]models.Dispute, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("disputes").Where("match_id", "==", matchID).Documents(ctx)
+	defer iter.Stop()
+
+	return collectDisputes(ctx, iter)
+}
+
+func collectDisputes(ctx context.Context, iter *firestore.DocumentIterator) ([]models.Dispute, error) {
+	disputes := make([]models.Dispute, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate disputes", "error", err)
+			return nil, fmt.Errorf("failed to iterate disputes: %w", err)
+		}
+
+		var dispute models.Dispute
+		if err := doc.DataTo(&dispute); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse dispute data", "error", err)
+			return nil, fmt.Errorf("failed to parse dispute data: %w", err)
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	return disputes, nil
+}
+
+// Attachment operations
+
+// CreateAttachment saves a new match attachment
+func (fc *FirestoreClient) CreateAttachment(ctx context.Context, attachment models.Attachment) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("attachments").Doc(attachment.ID).Set(ctx, attachment)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to create attachment",
+				"attachment_id", attachment.ID,
+				"match_id", attachment.MatchID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to create attachment: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetAttachment retrieves an attachment by ID
+func (fc *FirestoreClient) GetAttachment(ctx context.Context, attachmentID string) (*models.Attachment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var attachment *models.Attachment
+	err := retryOnTransientError(ctx, func() error {
+		doc, err := fc.client.Collection("attachments").Doc(attachmentID).Get(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get attachment: %w", err)
+		}
+
+		var a models.Attachment
+		if err := doc.DataTo(&a); err != nil {
+			return fmt.Errorf("failed to parse attachment data: %w", err)
+		}
+		attachment = &a
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment deletes an attachment by ID
+func (fc *FirestoreClient) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	_, err := fc.client.Collection("attachments").Doc(attachmentID).Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+// ListMatchAttachments retrieves every attachment on a match
+func (fc *FirestoreClient) ListMatchAttachments(ctx context.Context, matchID string) ([]models.Attachment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("attachments").Where("match_id", "==", matchID).Documents(ctx)
+	defer iter.Stop()
+
+	attachments := make([]models.Attachment, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate attachments: %w", err)
+		}
+
+		var attachment models.Attachment
+		if err := doc.DataTo(&attachment); err != nil {
+			return nil, fmt.Errorf("failed to parse attachment data: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// NotificationPrefs operations
+
+// notificationPrefsID returns the document ID for a player's preferences in a league
+func notificationPrefsID(leagueID, playerID string) string {
+	return leagueID + "_" + playerID
+}
+
+// GetNotificationPrefs retrieves a player's notification preferences for a league.
+// It returns nil without an error when the player has never saved any.
+func (fc *FirestoreClient) GetNotificationPrefs(ctx context.Context, leagueID, playerID string) (*models.NotificationPrefs, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var prefs *models.NotificationPrefs
+	err := retryOnTransientError(ctx, func() error {
+		doc, err := fc.client.Collection("notification_prefs").Doc(notificationPrefsID(leagueID, playerID)).Get(ctx)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get notification preferences: %w", err)
+		}
+
+		var p models.NotificationPrefs
+		if err := doc.DataTo(&p); err != nil {
+			return fmt.Errorf("failed to parse notification preferences: %w", err)
+		}
+		prefs = &p
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SetNotificationPrefs saves a player's notification preferences for a league, filling in the document ID
+func (fc *FirestoreClient) SetNotificationPrefs(ctx context.Context, prefs *models.NotificationPrefs) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	prefs.ID = notificationPrefsID(prefs.LeagueID, prefs.PlayerID)
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("notification_prefs").Doc(prefs.ID).Set(ctx, prefs)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to save notification preferences",
+				"league_id", prefs.LeagueID,
+				"player_id", prefs.PlayerID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to save notification preferences: %w", err)
+		}
+		return nil
+	})
+}
+
+// RecalcRun operations
+
+// GetLastRecalcRun retrieves the stats of a league's most recent handicap recalculation.
+// It returns nil without an error when the league has never run one.
+func (fc *FirestoreClient) GetLastRecalcRun(ctx context.Context, leagueID string) (*models.RecalcRun, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var run *models.RecalcRun
+	err := retryOnTransientError(ctx, func() error {
+		doc, err := fc.client.Collection("recalc_runs").Doc(leagueID).Get(ctx)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get recalculation run: %w", err)
+		}
+
+		var r models.RecalcRun
+		if err := doc.DataTo(&r); err != nil {
+			return fmt.Errorf("failed to parse recalculation run: %w", err)
+		}
+		run = &r
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// SaveRecalcRun replaces the league's last handicap recalculation stats, keyed by league ID
+func (fc *FirestoreClient) SaveRecalcRun(ctx context.Context, run *models.RecalcRun) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	run.ID = run.LeagueID
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("recalc_runs").Doc(run.ID).Set(ctx, run)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to save recalculation run",
+				"league_id", run.LeagueID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to save recalculation run: %w", err)
+		}
+		return nil
+	})
+}
+
+// FoursomesMatch operations
+
+// CreateFoursomesMatch saves a scored foursomes match
+func (fc *FirestoreClient) CreateFoursomesMatch(ctx context.Context, match models.FoursomesMatch) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return retryOnTransientError(ctx, func() error {
+		_, err := fc.client.Collection("foursomes_matches").Doc(match.ID).Set(ctx, match)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to create foursomes match",
+				"match_id", match.ID,
+				"season_id", match.SeasonID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to create foursomes match: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListSeasonFoursomesMatches retrieves a season's foursomes matches, most recent first
+func (fc *FirestoreClient) ListSeasonFoursomesMatches(ctx context.Context, seasonID string) ([]models.FoursomesMatch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("foursomes_matches").
+		Where("season_id", "==", seasonID).
+		OrderBy("match_date", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	matches := make([]models.FoursomesMatch, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate foursomes matches", "error", err)
+			return nil, fmt.Errorf("failed to iterate foursomes matches: %w", err)
+		}
+
+		var match models.FoursomesMatch
+		if err := doc.DataTo(&match); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse foursomes match data", "error", err)
+			return nil, fmt.Errorf("failed to parse foursomes match data: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+// DeleteSeasonFoursomesMatches deletes every foursomes match scored in a season and returns how
+// many were deleted
+func (fc *FirestoreClient) DeleteSeasonFoursomesMatches(ctx context.Context, seasonID string) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("foursomes_matches").
+		Where("season_id", "==", seasonID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	bw := fc.client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var queueErr error
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			queueErr = fmt.Errorf("failed to iterate season foursomes matches: %w", err)
+			break
+		}
+		job, err := bw.Delete(doc.Ref)
+		if err != nil {
+			queueErr = fmt.Errorf("failed to queue foursomes match deletion: %w", err)
+			break
+		}
+		jobs = append(jobs, job)
+	}
+
+	// End flushes every queued delete, so each job has its result once it returns
+	bw.End()
+
+	count := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		count++
+	}
+	if queueErr != nil {
+		return count, queueErr
+	}
+	if firstErr != nil {
+		return count, fmt.Errorf("failed to delete %d of %d foursomes matches: %w", len(jobs)-count, len(jobs), firstErr)
+	}
+	return count, nil
+}
+
+// Achievement operations
+
+// ReplaceScoreAchievements saves the achievements detected on a score, removing any recorded for
+// it before that are no longer on the card
+func (fc *FirestoreClient) ReplaceScoreAchievements(ctx context.Context, scoreID string, achievements []models.Achievement) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	keep := make(map[string]bool, len(achievements))
+	for _, a := range achievements {
+		keep[a.ID] = true
+	}
+
+	iter := fc.client.Collection("achievements").
+		Where("score_id", "==", scoreID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	bw := fc.client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var queueErr error
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			queueErr = fmt.Errorf("failed to iterate score achievements: %w", err)
+			break
+		}
+		if keep[doc.Ref.ID] {
+			continue
+		}
+		job, err := bw.Delete(doc.Ref)
+		if err != nil {
+			queueErr = fmt.Errorf("failed to queue achievement deletion: %w", err)
+			break
+		}
+		jobs = append(jobs, job)
+	}
+
+	if queueErr == nil {
+		for _, a := range achievements {
+			job, err := bw.Set(fc.client.Collection("achievements").Doc(a.ID), a)
+			if err != nil {
+				queueErr = fmt.Errorf("failed to queue achievement: %w", err)
+				break
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	// End flushes every queued write, so each job has its result once it returns
+	bw.End()
+
+	if queueErr != nil {
+		return queueErr
+	}
+	failed := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("failed to save %d of %d achievement writes: %w", failed, len(jobs), firstErr)
+	}
+	return nil
+}
+
+// ListSeasonAchievements retrieves a season's achievements, most recent first
+func (fc *FirestoreClient) ListSeasonAchievements(ctx context.Context, seasonID string) ([]models.Achievement, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("achievements").
+		Where("season_id", "==", seasonID).
+		OrderBy("date", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	achievements := make([]models.Achievement, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to iterate achievements", "error", err)
+			return nil, fmt.Errorf("failed to iterate achievements: %w", err)
+		}
+
+		var a models.Achievement
+		if err := doc.DataTo(&a); err != nil {
+			logger.ErrorContext(ctx, "Failed to parse achievement data", "error", err)
+			return nil, fmt.Errorf("failed to parse achievement data: %w", err)
+		}
+		achievements = append(achievements, a)
+	}
+
+	return achievements, nil
+}
+
+// DeleteSeasonAchievements deletes every achievement recorded in a season and returns how many
+// were deleted
+func (fc *FirestoreClient) DeleteSeasonAchievements(ctx context.Context, seasonID string) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	iter := fc.client.Collection("achievements").
+		Where("season_id", "==", seasonID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	bw := fc.client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var queueErr error
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			queueErr = fmt.Errorf("failed to iterate season achievements: %w", err)
+			break
+		}
+		job, err := bw.Delete(doc.Ref)
+		if err != nil {
+			queueErr = fmt.Errorf("failed to queue achievement deletion: %w", err)
+			break
+		}
+		jobs = append(jobs, job)
+	}
+
+	// End flushes every queued delete, so each job has its result once it returns
+	bw.End()
+
+	count := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		count++
+	}
+	if queueErr != nil {
+		return count, queueErr
+	}
+	if firstErr != nil {
+		return count, fmt.Errorf("failed to delete %d of %d achievements: %w", len(jobs)-count, len(jobs), firstErr)
+	}
+	return count, nil
+}
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestCountsForHandicapOfficialRounds(t *testing.T) {
+	official, unofficial := true, false
+	scores := []models.Score{
+		{ID: "regular", Official: &official},
+		{ID: "makeup", Makeup: true, Official: &unofficial},
+		{ID: "substitute", Substitute: true, Official: &unofficial},
+		{ID: "legacy"}, // Entered before the official flag existed
+		{ID: "absent", PlayerAbsent: true, Official: &official},
+	}
+
+	counted := func(officialOnly bool) []string {
+		var ids []string
+		for _, score := range scores {
+			if countsForHandicap(score, officialOnly) {
+				ids = append(ids, score.ID)
+			}
+		}
+		return ids
+	}
+
+	if got := counted(false); len(got) != 4 || got[0] != "regular" || got[1] != "makeup" || got[2] != "substitute" || got[3] != "legacy" {
+		t.Errorf("all rounds: counted %v, want every non-absent round", got)
+	}
+	if got := counted(true); len(got) != 2 || got[0] != "regular" || got[1] != "legacy" {
+		t.Errorf("official only: counted %v, want regular and legacy", got)
+	}
+}
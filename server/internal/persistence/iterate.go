@@ -0,0 +1,140 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+)
+
+// IteratePageSize is how many documents IterateCollection reads per query
+const IteratePageSize = 300
+
+// pageFetcher reads up to limit documents following the cursor, where a nil cursor starts at
+// the beginning. It returns the decoded documents and the cursor to pass for the next page.
+type pageFetcher[T any] func(ctx context.Context, after any, limit int) ([]T, any, error)
+
+// IterateCollection streams every document matched by query to fn, one page at a time, so
+// a whole collection can be processed without holding it in memory. Pages continue from the
+// last document read, so the query must have a stable order (ordering by document ID is
+// enough). Each page gets its own timeout. Iteration stops at the first error from fn, which
+// is returned unchanged.
+func IterateCollection[T any](ctx context.Context, query firestore.Query, fn func(T) error) error {
+	return iteratePages(ctx, IteratePageSize, queryPageFetcher[T](query), fn)
+}
+
+// ListCollection reads every document matched by query one page at a time, like
+// IterateCollection, and returns them all. The query must have a stable order.
+func ListCollection[T any](ctx context.Context, query firestore.Query) ([]T, error) {
+	return collectPages(ctx, IteratePageSize, queryPageFetcher[T](query))
+}
+
+// queryPageFetcher reads pages of query, each with its own timeout, continuing from the last
+// document of the previous page
+func queryPageFetcher[T any](query firestore.Query) pageFetcher[T] {
+	return func(ctx context.Context, after any, limit int) ([]T, any, error) {
+		ctx, cancel := withTimeout(ctx)
+		defer cancel()
+
+		q := query
+		if after != nil {
+			q = q.StartAfter(after.(*firestore.DocumentSnapshot))
+		}
+		iter := q.Limit(limit).Documents(ctx)
+		defer iter.Stop()
+
+		items := make([]T, 0, limit)
+		var last *firestore.DocumentSnapshot
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var item T
+			if err := doc.DataTo(&item); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse document %s: %w", doc.Ref.ID, err)
+			}
+			items = append(items, item)
+			last = doc
+		}
+		return items, last, nil
+	}
+}
+
+// iteratePages drives a pageFetcher until a short page signals the end of the results
+func iteratePages[T any](ctx context.Context, pageSize int, fetch pageFetcher[T], fn func(T) error) error {
+	var cursor any
+	for {
+		items, next, err := fetch(ctx, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to iterate documents: %w", err)
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if len(items) < pageSize {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// collectPages drives a pageFetcher to the end of the results and returns every document
+func collectPages[T any](ctx context.Context, pageSize int, fetch pageFetcher[T]) ([]T, error) {
+	items := make([]T, 0)
+	err := iteratePages(ctx, pageSize, fetch, func(item T) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// IterateLeagueScores streams every score in a league to fn in document ID order
+func (fc *FirestoreClient) IterateLeagueScores(ctx context.Context, leagueID string, fn func(models.Score) error) error {
+	return fc.IterateLeagueScoresAfter(ctx, leagueID, "", fn)
+}
+
+// IterateLeagueScoresAfter streams a league's scores to fn in document ID order, starting
+// after the score with ID afterID (or from the beginning when afterID is empty)
+func (fc *FirestoreClient) IterateLeagueScoresAfter(ctx context.Context, leagueID, afterID string, fn func(models.Score) error) error {
+	query := fc.client.Collection("scores").
+		Where("league_id", "==", leagueID).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	if afterID != "" {
+		query = query.StartAfter(afterID)
+	}
+	return IterateCollection(ctx, query, fn)
+}
+
+// IterateLeagueMatches streams every match in a league to fn in document ID order
+func (fc *FirestoreClient) IterateLeagueMatches(ctx context.Context, leagueID string, fn func(models.Match) error) error {
+	query := fc.client.Collection("matches").
+		Where("league_id", "==", leagueID).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	return IterateCollection(ctx, query, fn)
+}
+
+// ListAllLeagues retrieves every league in the deployment, regardless of membership, a page
+// at a time. It is meant for maintenance jobs that run across all leagues.
+func (fc *FirestoreClient) ListAllLeagues(ctx context.Context) ([]models.League, error) {
+	query := fc.client.Collection("leagues").OrderBy(firestore.DocumentID, firestore.Asc)
+	leagues, err := ListCollection[models.League](ctx, query)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list all leagues", "error", err)
+		return nil, err
+	}
+	return leagues, nil
+}
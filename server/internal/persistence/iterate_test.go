@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+// mockPages serves documents from memory the way a paged query would, using the index of
+// the next document as the cursor
+type mockPages struct {
+	docs    []string
+	fetches int
+	failAt  int // Fetch number (1-based) that fails; zero never fails
+}
+
+func (m *mockPages) fetch(ctx context.Context, after any, limit int) ([]string, any, error) {
+	m.fetches++
+	if m.fetches == m.failAt {
+		return nil, nil, errors.New("backend unavailable")
+	}
+
+	start := 0
+	if after != nil {
+		start = after.(int)
+	}
+	end := min(start+limit, len(m.docs))
+	return m.docs[start:end], end, nil
+}
+
+func TestIteratePagesVisitsEveryDocument(t *testing.T) {
+	for _, count := range []int{0, 2, 3, 7, 9} {
+		docs := make([]string, count)
+		for i := range docs {
+			docs[i] = string(rune('a' + i))
+		}
+		pages := &mockPages{docs: docs}
+
+		var visited []string
+		err := iteratePages(context.Background(), 3, pages.fetch, func(doc string) error {
+			visited = append(visited, doc)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%d docs: unexpected error: %v", count, err)
+		}
+		if len(visited) != count {
+			t.Fatalf("%d docs: visited %d", count, len(visited))
+		}
+		for i := range docs {
+			if visited[i] != docs[i] {
+				t.Errorf("%d docs: visited[%d] = %q, want %q", count, i, visited[i], docs[i])
+			}
+		}
+		// A full final page needs one more fetch to find out there is nothing after it
+		if want := count/3 + 1; pages.fetches != want {
+			t.Errorf("%d docs: fetched %d pages, want %d", count, pages.fetches, want)
+		}
+	}
+}
+
+func TestIteratePagesCallbackErrorStopsIteration(t *testing.T) {
+	pages := &mockPages{docs: []string{"a", "b", "c", "d", "e"}}
+	stop := errors.New("stop")
+
+	var visited []string
+	err := iteratePages(context.Background(), 2, pages.fetch, func(doc string) error {
+		visited = append(visited, doc)
+		if doc == "c" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want the callback's error", err)
+	}
+	if len(visited) != 3 || pages.fetches != 2 {
+		t.Errorf("visited %v over %d fetches, want [a b c] over 2", visited, pages.fetches)
+	}
+}
+
+func TestIteratePagesFetchErrorPropagates(t *testing.T) {
+	pages := &mockPages{docs: []string{"a", "b", "c", "d", "e"}, failAt: 2}
+
+	var visited []string
+	err := iteratePages(context.Background(), 2, pages.fetch, func(doc string) error {
+		visited = append(visited, doc)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the fetch error to be returned")
+	}
+	if len(visited) != 2 {
+		t.Errorf("visited %v, want only the first page", visited)
+	}
+}
+
+func TestCollectPagesReturnsAllLeagues(t *testing.T) {
+	leagues := make([]models.League, 7)
+	for i := range leagues {
+		leagues[i] = models.League{ID: string(rune('a' + i)), Name: "League " + string(rune('A'+i))}
+	}
+
+	fetches := 0
+	fetch := func(ctx context.Context, after any, limit int) ([]models.League, any, error) {
+		fetches++
+		start := 0
+		if after != nil {
+			start = after.(int)
+		}
+		end := min(start+limit, len(leagues))
+		return leagues[start:end], end, nil
+	}
+
+	got, err := collectPages(context.Background(), 3, fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(leagues) {
+		t.Fatalf("got %d leagues, want %d", len(got), len(leagues))
+	}
+	for i := range leagues {
+		if got[i].ID != leagues[i].ID {
+			t.Errorf("got[%d] = %s, want %s", i, got[i].ID, leagues[i].ID)
+		}
+	}
+	if fetches != 3 {
+		t.Errorf("fetched %d pages, want 3", fetches)
+	}
+}
+
+func TestCollectPagesFetchError(t *testing.T) {
+	pages := &mockPages{docs: []string{"a", "b", "c"}, failAt: 2}
+	if got, err := collectPages(context.Background(), 2, pages.fetch); err == nil || got != nil {
+		t.Errorf("got %v, %v; want no documents and the fetch error", got, err)
+	}
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+// mockMatchScores serves scores by match ID from memory the way GetPlayerMatchScores would
+type mockMatchScores struct {
+	scores  map[string][]models.Score
+	fetched []string
+	fail    bool
+}
+
+func (m *mockMatchScores) fetch(ctx context.Context, matchID, playerID string) ([]models.Score, error) {
+	m.fetched = append(m.fetched, matchID)
+	if m.fail {
+		return nil, errors.New("backend unavailable")
+	}
+	var scores []models.Score
+	for _, score := range m.scores[matchID] {
+		if score.PlayerID == playerID {
+			scores = append(scores, score)
+		}
+	}
+	return scores, nil
+}
+
+func TestPlayerMatchDayScoresOneMatch(t *testing.T) {
+	m := &mockMatchScores{scores: map[string][]models.Score{
+		"m1": {{ID: "s1", MatchID: "m1", PlayerID: "p1"}, {ID: "s2", MatchID: "m1", PlayerID: "p2"}},
+	}}
+	matches := []models.Match{{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"}}
+
+	got, err := playerMatchDayScores(context.Background(), matches, "p1", m.fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "s1" {
+		t.Errorf("got %+v, want only p1's score s1", got)
+	}
+}
+
+func TestPlayerMatchDayScoresTwoMatches(t *testing.T) {
+	// A regular match and a makeup match on the same day
+	m := &mockMatchScores{scores: map[string][]models.Score{
+		"regular": {{ID: "s1", MatchID: "regular", PlayerID: "p1"}, {ID: "s2", MatchID: "regular", PlayerID: "p2"}},
+		"makeup":  {{ID: "s3", MatchID: "makeup", PlayerID: "p3"}, {ID: "s4", MatchID: "makeup", PlayerID: "p1"}},
+	}}
+	matches := []models.Match{
+		{ID: "regular", PlayerAID: "p1", PlayerBID: "p2"},
+		{ID: "makeup", PlayerAID: "p3", PlayerBID: "p1"},
+	}
+
+	got, err := playerMatchDayScores(context.Background(), matches, "p1", m.fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "s1" || got[1].ID != "s4" {
+		t.Errorf("got %+v, want s1 then s4", got)
+	}
+	if len(m.fetched) != 2 {
+		t.Errorf("fetched %v, want each match once", m.fetched)
+	}
+}
+
+func TestPlayerMatchDayScoresFetchError(t *testing.T) {
+	m := &mockMatchScores{fail: true}
+	matches := []models.Match{{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"}}
+
+	if _, err := playerMatchDayScores(context.Background(), matches, "p1", m.fetch); err == nil {
+		t.Error("expected the fetch error to be returned")
+	}
+}
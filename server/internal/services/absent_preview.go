@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// Bases for the present player's side of an absent preview
+const (
+	AbsentPreviewBasisScore = "score" // The present player's entered score for the match
+	AbsentPreviewBasisPar   = "par"   // A hypothetical gross par round
+)
+
+// AbsentPreview shows what marking a player absent would produce without saving anything
+type AbsentPreview struct {
+	MatchID           string `json:"matchId"`
+	AbsentPlayerID    string `json:"absentPlayerId"`
+	PresentPlayerID   string `json:"presentPlayerId"`
+	AbsentHoleScores  []int  `json:"absentHoleScores"`
+	AbsentGrossScore  int    `json:"absentGrossScore"`
+	PresentHoleScores []int  `json:"presentHoleScores"`
+	PresentBasis      string `json:"presentBasis"`
+	AbsentStrokes     []int  `json:"absentStrokes"`
+	PresentStrokes    []int  `json:"presentStrokes"`
+	AbsentPoints      int    `json:"absentPoints"`
+	PresentPoints     int    `json:"presentPoints"`
+}
+
+// PreviewAbsentMatch computes the inflated hole scores for absentPlayerID and the match points
+// that would result. The present player's entered score is used when there is one, otherwise
+// they are assumed to shoot gross par. handicapA and handicapB are the league handicap indexes
+// score entry would use for the match's player A and player B; the league supplies the
+// rounding, absent penalty and match point settings, and the season's rules decide the points.
+// basis is the match day's match play basis.
+func PreviewAbsentMatch(match models.Match, absentPlayerID string, course models.Course, basis string, handicapA, handicapB float64, league models.League, season models.Season, presentScore *models.Score) (AbsentPreview, error) {
+	var presentPlayerID string
+	switch absentPlayerID {
+	case match.PlayerAID:
+		presentPlayerID = match.PlayerBID
+	case match.PlayerBID:
+		presentPlayerID = match.PlayerAID
+	default:
+		return AbsentPreview{}, fmt.Errorf("player %s is not in match %s", absentPlayerID, match.ID)
+	}
+
+	_, playingHCA := CalculateCourseAndPlayingHandicapForLeague(handicapA, course, league)
+	_, playingHCB := CalculateCourseAndPlayingHandicapForLeague(handicapB, course, league)
+	strokesMap := AssignStrokesForBasis(basis, match.PlayerAID, playingHCA, match.PlayerBID, playingHCB, course)
+
+	absentPlayingHC := playingHCA
+	if absentPlayerID == match.PlayerBID {
+		absentPlayingHC = playingHCB
+	}
+
+	absentScore := models.Score{
+		PlayerID:     absentPlayerID,
+		HoleScores:   CalculateAbsentPlayerScoresWithPenalty(absentPlayingHC, AbsentPenaltyStrokes(league), course),
+		PlayerAbsent: true,
+	}
+
+	preview := AbsentPreview{
+		MatchID:          match.ID,
+		AbsentPlayerID:   absentPlayerID,
+		PresentPlayerID:  presentPlayerID,
+		AbsentHoleScores: absentScore.HoleScores,
+		AbsentStrokes:    strokesMap[absentPlayerID],
+		PresentStrokes:   strokesMap[presentPlayerID],
+	}
+	for _, sc := range absentScore.HoleScores {
+		preview.AbsentGrossScore += sc
+	}
+
+	var present models.Score
+	if presentScore != nil && !presentScore.PlayerAbsent && len(presentScore.HoleScores) > 0 {
+		present = *presentScore
+		preview.PresentBasis = AbsentPreviewBasisScore
+	} else {
+		present = models.Score{PlayerID: presentPlayerID, HoleScores: append([]int(nil), course.HolePars...)}
+		preview.PresentBasis = AbsentPreviewBasisPar
+	}
+	preview.PresentHoleScores = present.HoleScores
+
+	rules := LeagueMatchPointRules(league)
+	if absentPlayerID == match.PlayerAID {
+		pointsA, pointsB, err := ScoreMatchPointsWithRules(season, rules, absentScore, present, preview.AbsentStrokes, preview.PresentStrokes)
+		if err != nil {
+			return AbsentPreview{}, err
+		}
+		preview.AbsentPoints, preview.PresentPoints = pointsA, pointsB
+	} else {
+		pointsA, pointsB, err := ScoreMatchPointsWithRules(season, rules, present, absentScore, preview.PresentStrokes, preview.AbsentStrokes)
+		if err != nil {
+			return AbsentPreview{}, err
+		}
+		preview.PresentPoints, preview.AbsentPoints = pointsA, pointsB
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func absentPreviewCourse() models.Course {
+	return models.Course{
+		Par:           36,
+		CourseRating:  35.5,
+		SlopeRating:   125,
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4, 4},
+		HoleHandicaps: []int{3, 9, 1, 5, 7, 8, 2, 4, 6},
+	}
+}
+
+func TestPreviewAbsentMatchMatchesCommit(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	handicapA, handicapB := 14.2, 6.8
+	present := models.Score{PlayerID: "pB", HoleScores: []int{5, 3, 6, 4, 5, 4, 5, 4, 5}}
+
+	preview, err := PreviewAbsentMatch(match, "pA", course, MatchPlayNet, handicapA, handicapB, models.League{}, models.Season{}, &present)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reproduce what score entry does when the absence is committed
+	_, playingHCA := CalculateCourseAndPlayingHandicap(handicapA, course)
+	_, playingHCB := CalculateCourseAndPlayingHandicap(handicapB, course)
+	strokes := AssignStrokes("pA", playingHCA, "pB", playingHCB, course)
+	committedA := models.Score{PlayerID: "pA", HoleScores: CalculateAbsentPlayerScores(playingHCA, course), PlayerAbsent: true}
+	wantA, wantB := CalculateMatchPointsWithRules(committedA, present, strokes["pA"], strokes["pB"], DefaultMatchPointRules)
+
+	if preview.AbsentPoints != wantA || preview.PresentPoints != wantB {
+		t.Errorf("preview points = %d-%d, want %d-%d", preview.AbsentPoints, preview.PresentPoints, wantA, wantB)
+	}
+	if preview.PresentBasis != AbsentPreviewBasisScore {
+		t.Errorf("present basis = %q, want %q", preview.PresentBasis, AbsentPreviewBasisScore)
+	}
+	if preview.PresentPlayerID != "pB" {
+		t.Errorf("present player = %q, want pB", preview.PresentPlayerID)
+	}
+
+	gross := 0
+	for i, sc := range committedA.HoleScores {
+		if preview.AbsentHoleScores[i] != sc {
+			t.Errorf("absent hole %d = %d, want %d", i+1, preview.AbsentHoleScores[i], sc)
+		}
+		gross += sc
+	}
+	if preview.AbsentGrossScore != gross {
+		t.Errorf("absent gross = %d, want %d", preview.AbsentGrossScore, gross)
+	}
+	// Playing handicap + par + 3
+	if gross != playingHCA+course.Par+3 {
+		t.Errorf("absent gross = %d, want playing handicap + par + 3 = %d", gross, playingHCA+course.Par+3)
+	}
+}
+
+func TestPreviewAbsentMatchParBasis(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+
+	// Player B is absent and player A has not entered a score yet
+	preview, err := PreviewAbsentMatch(match, "pB", course, MatchPlayNet, 10.0, 10.0, models.League{}, models.Season{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.PresentBasis != AbsentPreviewBasisPar {
+		t.Errorf("present basis = %q, want %q", preview.PresentBasis, AbsentPreviewBasisPar)
+	}
+	for i, par := range course.HolePars {
+		if preview.PresentHoleScores[i] != par {
+			t.Errorf("present hole %d = %d, want par %d", i+1, preview.PresentHoleScores[i], par)
+		}
+	}
+
+	// Equal handicaps, so a par round beats the absent player's inflated round everywhere
+	if preview.PresentPoints != DefaultMatchPointRules.Total(holesPerRound) || preview.AbsentPoints != 0 {
+		t.Errorf("points = %d-%d, want %d-0", preview.PresentPoints, preview.AbsentPoints, DefaultMatchPointRules.Total(holesPerRound))
+	}
+}
+
+func TestPreviewAbsentMatchPlayerNotInMatch(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	if _, err := PreviewAbsentMatch(match, "pC", absentPreviewCourse(), MatchPlayNet, 0, 0, models.League{}, models.Season{}, nil); err == nil {
+		t.Error("expected error for player not in match, got nil")
+	}
+}
+
+func TestPreviewAbsentMatchFixedFraction(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	season := models.Season{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0.5}
+
+	preview, err := PreviewAbsentMatch(match, "pB", absentPreviewCourse(), MatchPlayNet, 10.0, 10.0, models.League{}, season, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.AbsentPoints != 11 || preview.PresentPoints != 11 {
+		t.Errorf("preview points = %d absent, %d present, want 11 each", preview.AbsentPoints, preview.PresentPoints)
+	}
+}
+
+func TestPreviewAbsentMatchLeagueRules(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	perHole, overall := 4, 6
+	league := models.League{MatchPointsPerHole: perHole, MatchOverallPoints: &overall}
+
+	// Equal handicaps, so a par round takes every point: 9 holes at 4 plus 6 overall
+	preview, err := PreviewAbsentMatch(match, "pB", absentPreviewCourse(), MatchPlayNet, 10.0, 10.0, league, models.Season{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.PresentPoints != 42 || preview.AbsentPoints != 0 {
+		t.Errorf("points = %d-%d, want 42-0", preview.PresentPoints, preview.AbsentPoints)
+	}
+
+	// A fixed fraction applies to the league's match total
+	season := models.Season{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0.25}
+	preview, err = PreviewAbsentMatch(match, "pB", absentPreviewCourse(), MatchPlayNet, 10.0, 10.0, league, season, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.AbsentPoints != 10 || preview.PresentPoints != 32 {
+		t.Errorf("fixed fraction points = %d absent, %d present, want 10 and 32", preview.AbsentPoints, preview.PresentPoints)
+	}
+}
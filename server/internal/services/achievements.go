@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+)
+
+// Achievement types
+const (
+	AchievementHoleInOne = "hole-in-one"
+	AchievementEagle     = "eagle" // Two or more under par on a hole, other than an ace
+)
+
+// AchievementStore replaces the achievements recorded for a score
+type AchievementStore interface {
+	ReplaceScoreAchievements(ctx context.Context, scoreID string, achievements []models.Achievement) error
+}
+
+// DetectAchievements finds the notable holes on a score: a 1 on any hole is a hole-in-one, and
+// any other hole two or more under par is an eagle. Absent scores and conceded holes, whose
+// scores were filled in rather than played, never count. Each achievement's ID is derived from
+// the score and hole so a corrected score replaces its achievements instead of adding more.
+func DetectAchievements(score models.Score, course models.Course, seasonID string) []models.Achievement {
+	achievements := make([]models.Achievement, 0)
+	if score.PlayerAbsent {
+		return achievements
+	}
+	conceded := concededHoleSet(score.ConcededHoles)
+
+	for i, strokes := range score.HoleScores {
+		if i >= len(course.HolePars) || conceded[i] || strokes <= 0 {
+			continue
+		}
+		par := course.HolePars[i]
+
+		var achievementType string
+		switch {
+		case strokes == 1:
+			achievementType = AchievementHoleInOne
+		case strokes <= par-2:
+			achievementType = AchievementEagle
+		default:
+			continue
+		}
+
+		achievements = append(achievements, models.Achievement{
+			ID:         fmt.Sprintf("%s-%d", score.ID, i+1),
+			LeagueID:   score.LeagueID,
+			SeasonID:   seasonID,
+			PlayerID:   score.PlayerID,
+			PlayerName: score.PlayerName,
+			Type:       achievementType,
+			MatchID:    score.MatchID,
+			ScoreID:    score.ID,
+			HoleNumber: i + 1,
+			Strokes:    strokes,
+			Par:        par,
+			Date:       score.Date,
+		})
+	}
+	return achievements
+}
+
+// RecordScoreAchievements detects and saves the achievements on each saved score, replacing
+// any recorded for it before. Scores on unknown courses are skipped. Failures are logged and
+// the first is returned after every score has been tried; the scores themselves are already
+// saved.
+func RecordScoreAchievements(ctx context.Context, store AchievementStore, scores []models.Score, courses map[string]models.Course, seasonID string) error {
+	var firstErr error
+	for _, score := range scores {
+		course, ok := courses[score.CourseID]
+		if !ok {
+			continue
+		}
+		achievements := DetectAchievements(score, course, seasonID)
+		if err := store.ReplaceScoreAchievements(ctx, score.ID, achievements); err != nil {
+			logger.WarnContext(ctx, "Failed to record score achievements",
+				"score_id", score.ID,
+				"player_id", score.PlayerID,
+				"error", err,
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, a := range achievements {
+			logger.InfoContext(ctx, "Achievement recorded",
+				"type", a.Type,
+				"player_id", a.PlayerID,
+				"hole", a.HoleNumber,
+			)
+		}
+	}
+	return firstErr
+}
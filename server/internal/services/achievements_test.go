@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestDetectAchievements(t *testing.T) {
+	course := absentPreviewCourse()
+	score := models.Score{
+		ID:         "sc1",
+		PlayerID:   "p1",
+		PlayerName: "Alice",
+		HoleScores: []int{4, 1, 3, 4, 4, 2, 5, 4, 4},
+	}
+
+	got := DetectAchievements(score, course, "s1")
+	if len(got) != 2 {
+		t.Fatalf("got %d achievements, want 2: %+v", len(got), got)
+	}
+	// An ace on a par 3 is only a hole-in-one, not also an eagle
+	if got[0].Type != AchievementHoleInOne || got[0].HoleNumber != 2 || got[0].ID != "sc1-2" {
+		t.Errorf("first achievement = %+v, want a hole-in-one on hole 2", got[0])
+	}
+	if got[1].Type != AchievementEagle || got[1].HoleNumber != 3 || got[1].Par != 5 || got[1].Strokes != 3 {
+		t.Errorf("second achievement = %+v, want an eagle on hole 3", got[1])
+	}
+	if got[1].SeasonID != "s1" || got[1].PlayerName != "Alice" || got[1].ScoreID != "sc1" {
+		t.Errorf("achievement not filled in: %+v", got[1])
+	}
+}
+
+func TestDetectAchievementsIgnoresUnplayedHoles(t *testing.T) {
+	course := absentPreviewCourse()
+
+	absent := models.Score{ID: "sc1", PlayerAbsent: true, HoleScores: []int{4, 1, 3, 4, 4, 3, 5, 4, 4}}
+	if got := DetectAchievements(absent, course, "s1"); len(got) != 0 {
+		t.Errorf("absent score: got %+v, want none", got)
+	}
+
+	conceded := models.Score{ID: "sc2", ConcededHoles: []int{3}, HoleScores: []int{4, 3, 3, 4, 4, 3, 5, 4, 4}}
+	if got := DetectAchievements(conceded, course, "s1"); len(got) != 0 {
+		t.Errorf("conceded hole: got %+v, want none", got)
+	}
+}
+
+type fakeAchievementStore struct {
+	saved map[string][]models.Achievement
+	fail  string
+}
+
+func (f *fakeAchievementStore) ReplaceScoreAchievements(ctx context.Context, scoreID string, achievements []models.Achievement) error {
+	if scoreID == f.fail {
+		return errors.New("write failed")
+	}
+	f.saved[scoreID] = achievements
+	return nil
+}
+
+func TestRecordScoreAchievements(t *testing.T) {
+	course := absentPreviewCourse()
+	courses := map[string]models.Course{"c1": course}
+	scores := []models.Score{
+		{ID: "sc1", CourseID: "c1", HoleScores: []int{4, 1, 5, 4, 4, 3, 5, 4, 4}},
+		{ID: "sc2", CourseID: "c1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}},
+		{ID: "sc3", CourseID: "unknown", HoleScores: []int{1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{ID: "sc4", CourseID: "c1", HoleScores: []int{4, 3, 3, 4, 4, 3, 5, 4, 4}},
+	}
+	store := &fakeAchievementStore{saved: make(map[string][]models.Achievement), fail: "sc4"}
+
+	err := RecordScoreAchievements(context.Background(), store, scores, courses, "s1")
+	if err == nil {
+		t.Error("expected the failed write to be returned")
+	}
+	if len(store.saved["sc1"]) != 1 || store.saved["sc1"][0].Type != AchievementHoleInOne {
+		t.Errorf("sc1 achievements = %+v, want one hole-in-one", store.saved["sc1"])
+	}
+	// A score without achievements is still replaced, clearing any from before a correction
+	if got, ok := store.saved["sc2"]; !ok || len(got) != 0 {
+		t.Errorf("sc2 achievements = %+v (saved %v), want an empty replacement", got, ok)
+	}
+	if _, ok := store.saved["sc3"]; ok {
+		t.Error("score on an unknown course should be skipped")
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+// ActiveSeasonStore looks up a player's leagues, each league's active season and the player's
+// scheduled matches in it
+type ActiveSeasonStore interface {
+	GetPlayerLeagues(ctx context.Context, playerID string) ([]models.League, error)
+	GetActiveSeason(ctx context.Context, leagueID string) (*models.Season, error)
+	GetPlayerScheduledMatchesForSeason(ctx context.Context, seasonID, playerID string) ([]models.Match, error)
+}
+
+// LeagueActivity is a player's current season and next match in one of their leagues
+type LeagueActivity struct {
+	League       models.League  `json:"league"`
+	ActiveSeason *models.Season `json:"activeSeason"` // Nil when the league has no active season
+	NextMatch    *models.Match  `json:"nextMatch"`    // Nil when nothing is scheduled from today on
+}
+
+// PlayerActiveSeasons returns, for each league the player belongs to, the league's active
+// season and the player's next scheduled match in it. Leagues without an active season are
+// still listed, with no season or match.
+func PlayerActiveSeasons(ctx context.Context, store ActiveSeasonStore, playerID string, now time.Time) ([]LeagueActivity, error) {
+	leagues, err := store.GetPlayerLeagues(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leagues: %w", err)
+	}
+
+	activity := make([]LeagueActivity, 0, len(leagues))
+	for _, league := range leagues {
+		entry := LeagueActivity{League: league}
+
+		season, err := store.GetActiveSeason(ctx, league.ID)
+		if errors.Is(err, persistence.ErrNoActiveSeason) {
+			activity = append(activity, entry)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active season for league %s: %w", league.ID, err)
+		}
+		entry.ActiveSeason = season
+
+		matches, err := store.GetPlayerScheduledMatchesForSeason(ctx, season.ID, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scheduled matches for league %s: %w", league.ID, err)
+		}
+		entry.NextMatch = nextMatch(matches, now)
+
+		activity = append(activity, entry)
+	}
+
+	return activity, nil
+}
+
+// nextMatch returns the earliest match dated today or later. Matches from earlier days that
+// were never played are skipped.
+func nextMatch(matches []models.Match, now time.Time) *models.Match {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var next *models.Match
+	for i := range matches {
+		if matches[i].MatchDate.Before(today) {
+			continue
+		}
+		if next == nil || matches[i].MatchDate.Before(next.MatchDate) {
+			next = &matches[i]
+		}
+	}
+	return next
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+// fakeActiveSeasonStore serves leagues, active seasons and scheduled matches from memory
+type fakeActiveSeasonStore struct {
+	leagues []models.League
+	seasons map[string]models.Season  // keyed by league ID
+	matches map[string][]models.Match // keyed by season ID
+}
+
+func (f *fakeActiveSeasonStore) GetPlayerLeagues(ctx context.Context, playerID string) ([]models.League, error) {
+	return f.leagues, nil
+}
+
+func (f *fakeActiveSeasonStore) GetActiveSeason(ctx context.Context, leagueID string) (*models.Season, error) {
+	season, ok := f.seasons[leagueID]
+	if !ok {
+		return nil, persistence.ErrNoActiveSeason
+	}
+	return &season, nil
+}
+
+func (f *fakeActiveSeasonStore) GetPlayerScheduledMatchesForSeason(ctx context.Context, seasonID, playerID string) ([]models.Match, error) {
+	return f.matches[seasonID], nil
+}
+
+func TestPlayerActiveSeasons(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	store := &fakeActiveSeasonStore{
+		leagues: []models.League{{ID: "l1"}, {ID: "l2"}, {ID: "l3"}},
+		seasons: map[string]models.Season{
+			"l1": {ID: "s1", LeagueID: "l1"},
+			"l3": {ID: "s3", LeagueID: "l3"},
+		},
+		matches: map[string][]models.Match{
+			"s1": {
+				{ID: "m-next-week", MatchDate: today.AddDate(0, 0, 7)},
+				// Scheduled earlier today still counts as upcoming
+				{ID: "m-today", MatchDate: today},
+				// Never played last week, so it is not the next match
+				{ID: "m-missed", MatchDate: today.AddDate(0, 0, -7)},
+			},
+		},
+	}
+
+	activity, err := PlayerActiveSeasons(context.Background(), store, "p1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(activity) != 3 {
+		t.Fatalf("got %d leagues, want 3", len(activity))
+	}
+
+	if a := activity[0]; a.ActiveSeason == nil || a.ActiveSeason.ID != "s1" || a.NextMatch == nil || a.NextMatch.ID != "m-today" {
+		t.Errorf("l1 = %+v, want season s1 with next match m-today", a)
+	}
+	if a := activity[1]; a.League.ID != "l2" || a.ActiveSeason != nil || a.NextMatch != nil {
+		t.Errorf("l2 = %+v, want no active season or match", a)
+	}
+	if a := activity[2]; a.ActiveSeason == nil || a.ActiveSeason.ID != "s3" || a.NextMatch != nil {
+		t.Errorf("l3 = %+v, want season s3 with nothing scheduled", a)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// Grace period before a finished season is archived automatically
+const (
+	DefaultSeasonArchiveGraceDays = 14
+	MaxSeasonArchiveGraceDays     = 365
+)
+
+// ErrSeasonArchived is returned when writing to a season that has been archived
+var ErrSeasonArchived = errors.New("season is archived and read-only")
+
+// SeasonArchiveStore lists a league's seasons and match days and saves archived seasons
+type SeasonArchiveStore interface {
+	ListSeasons(ctx context.Context, leagueID string) ([]models.Season, error)
+	ListMatchDaysBySeason(ctx context.Context, seasonID string) ([]models.MatchDay, error)
+	UpdateSeason(ctx context.Context, season models.Season) error
+}
+
+// SeasonArchiveGraceDays returns how many days after its end date a season stays open
+func SeasonArchiveGraceDays(league models.League) int {
+	if league.SeasonArchiveGraceDays > 0 {
+		return league.SeasonArchiveGraceDays
+	}
+	return DefaultSeasonArchiveGraceDays
+}
+
+// CheckSeasonWritable rejects changes to an archived season
+func CheckSeasonWritable(season models.Season) error {
+	if season.Archived {
+		return ErrSeasonArchived
+	}
+	return nil
+}
+
+// ShouldArchiveSeason reports whether a season ended more than graceDays before now and has
+// no scheduled match days left to play. Seasons that are already archived are skipped.
+func ShouldArchiveSeason(season models.Season, matchDays []models.MatchDay, graceDays int, now time.Time) bool {
+	if season.Archived || season.EndDate.IsZero() {
+		return false
+	}
+	if !now.After(season.EndDate.AddDate(0, 0, graceDays)) {
+		return false
+	}
+	for _, md := range matchDays {
+		if md.Status == "scheduled" {
+			return false
+		}
+	}
+	return true
+}
+
+// AutoArchivePastSeasons archives every season in the league that ShouldArchiveSeason selects,
+// marking it inactive, and returns the seasons it archived. Seasons archived before an error
+// stay archived, so running the job again picks up where it failed.
+func AutoArchivePastSeasons(ctx context.Context, store SeasonArchiveStore, leagueID string, graceDays int, now time.Time) ([]models.Season, error) {
+	seasons, err := store.ListSeasons(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seasons: %w", err)
+	}
+
+	archived := []models.Season{}
+	for _, season := range seasons {
+		if season.Archived || !now.After(season.EndDate.AddDate(0, 0, graceDays)) {
+			continue
+		}
+
+		matchDays, err := store.ListMatchDaysBySeason(ctx, season.ID)
+		if err != nil {
+			return archived, fmt.Errorf("failed to list match days for season %s: %w", season.ID, err)
+		}
+		if !ShouldArchiveSeason(season, matchDays, graceDays, now) {
+			continue
+		}
+
+		archivedAt := now
+		season.Archived = true
+		season.ArchivedAt = &archivedAt
+		season.Active = false
+		if err := store.UpdateSeason(ctx, season); err != nil {
+			return archived, fmt.Errorf("failed to archive season %s: %w", season.ID, err)
+		}
+		archived = append(archived, season)
+	}
+
+	return archived, nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeSeasonArchiveStore keeps seasons and match days in memory and records updates
+type fakeSeasonArchiveStore struct {
+	seasons   []models.Season
+	matchDays map[string][]models.MatchDay // keyed by season ID
+	updated   []models.Season
+}
+
+func (f *fakeSeasonArchiveStore) ListSeasons(ctx context.Context, leagueID string) ([]models.Season, error) {
+	return f.seasons, nil
+}
+
+func (f *fakeSeasonArchiveStore) ListMatchDaysBySeason(ctx context.Context, seasonID string) ([]models.MatchDay, error) {
+	return f.matchDays[seasonID], nil
+}
+
+func (f *fakeSeasonArchiveStore) UpdateSeason(ctx context.Context, season models.Season) error {
+	f.updated = append(f.updated, season)
+	return nil
+}
+
+func TestAutoArchivePastSeasons(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	ended := now.AddDate(0, 0, -30)
+
+	tests := []struct {
+		name         string
+		matchDays    []models.MatchDay
+		wantArchived bool
+	}{
+		{
+			name: "all match days played",
+			matchDays: []models.MatchDay{
+				{ID: "md1", Status: "completed"},
+				{ID: "md2", Status: "locked"},
+			},
+			wantArchived: true,
+		},
+		{
+			name: "scheduled match day remaining",
+			matchDays: []models.MatchDay{
+				{ID: "md1", Status: "locked"},
+				{ID: "md2", Status: "scheduled"},
+			},
+			wantArchived: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSeasonArchiveStore{
+				seasons:   []models.Season{{ID: "s1", LeagueID: "l1", EndDate: ended, Active: true}},
+				matchDays: map[string][]models.MatchDay{"s1": tt.matchDays},
+			}
+
+			archived, err := AutoArchivePastSeasons(context.Background(), store, "l1", DefaultSeasonArchiveGraceDays, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantArchived {
+				if len(archived) != 0 || len(store.updated) != 0 {
+					t.Errorf("expected no seasons archived, got %+v", archived)
+				}
+				return
+			}
+			if len(archived) != 1 || len(store.updated) != 1 {
+				t.Fatalf("expected one season archived, got %+v", archived)
+			}
+			saved := store.updated[0]
+			if !saved.Archived || saved.Active {
+				t.Errorf("saved season archived=%v active=%v, want archived and inactive", saved.Archived, saved.Active)
+			}
+			if saved.ArchivedAt == nil || !saved.ArchivedAt.Equal(now) {
+				t.Errorf("archivedAt = %v, want %v", saved.ArchivedAt, now)
+			}
+		})
+	}
+}
+
+func TestShouldArchiveSeasonGracePeriod(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	played := []models.MatchDay{{Status: "completed"}}
+
+	if ShouldArchiveSeason(models.Season{EndDate: now.AddDate(0, 0, -10)}, played, 14, now) {
+		t.Error("season inside the grace period should not be archived")
+	}
+	if !ShouldArchiveSeason(models.Season{EndDate: now.AddDate(0, 0, -15)}, played, 14, now) {
+		t.Error("season past the grace period should be archived")
+	}
+	if ShouldArchiveSeason(models.Season{EndDate: now.AddDate(0, 0, -15), Archived: true}, played, 14, now) {
+		t.Error("archived season should not be archived again")
+	}
+}
+
+func TestCheckSeasonWritable(t *testing.T) {
+	if err := CheckSeasonWritable(models.Season{}); err != nil {
+		t.Errorf("open season: unexpected error %v", err)
+	}
+	if err := CheckSeasonWritable(models.Season{Archived: true}); err != ErrSeasonArchived {
+		t.Errorf("archived season: err = %v, want ErrSeasonArchived", err)
+	}
+}
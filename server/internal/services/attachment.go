@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// MaxAttachmentURLLength is the longest attachment URL allowed, in bytes
+const MaxAttachmentURLLength = 2048
+
+// ErrAttachmentAccess means the requester is neither in the match nor a league admin
+var ErrAttachmentAccess = errors.New("only players in the match and league admins can access its attachments")
+
+// CheckAttachmentAccess allows the match's players and league admins to add, see and remove
+// its attachments
+func CheckAttachmentAccess(match models.Match, playerID string, isAdmin bool) error {
+	if isAdmin || playerID == match.PlayerAID || playerID == match.PlayerBID {
+		return nil
+	}
+	return ErrAttachmentAccess
+}
+
+// NewAttachment attaches the file at rawURL to a match for uploadedBy. The URL must be an
+// absolute http or https link, since uploads are handled outside the app. The caller assigns
+// the attachment ID and saves it.
+func NewAttachment(match models.Match, uploadedBy string, isAdmin bool, rawURL string, now time.Time) (models.Attachment, error) {
+	if err := CheckAttachmentAccess(match, uploadedBy, isAdmin); err != nil {
+		return models.Attachment{}, err
+	}
+	if rawURL == "" {
+		return models.Attachment{}, fmt.Errorf("url is required")
+	}
+	if len(rawURL) > MaxAttachmentURLLength {
+		return models.Attachment{}, fmt.Errorf("url must be %d characters or less", MaxAttachmentURLLength)
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return models.Attachment{}, fmt.Errorf("url must be an absolute http or https link")
+	}
+
+	return models.Attachment{
+		LeagueID:   match.LeagueID,
+		MatchID:    match.ID,
+		URL:        rawURL,
+		UploadedBy: uploadedBy,
+		CreatedAt:  now,
+	}, nil
+}
+
+// SortAttachments orders a match's attachments oldest first
+func SortAttachments(attachments []models.Attachment) {
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestNewAttachment(t *testing.T) {
+	match := models.Match{ID: "m1", LeagueID: "l1", PlayerAID: "pA", PlayerBID: "pB"}
+	now := time.Date(2025, 6, 12, 20, 0, 0, 0, time.UTC)
+
+	attachment, err := NewAttachment(match, "pB", false, "https://storage.example.com/cards/m1.jpg", now)
+	if err != nil {
+		t.Fatalf("participant: unexpected error %v", err)
+	}
+	if attachment.MatchID != "m1" || attachment.LeagueID != "l1" || attachment.UploadedBy != "pB" || !attachment.CreatedAt.Equal(now) {
+		t.Errorf("attachment = %+v, want pB's upload on m1 in l1", attachment)
+	}
+
+	if _, err := NewAttachment(match, "admin", true, "https://storage.example.com/cards/m1.jpg", now); err != nil {
+		t.Errorf("admin: unexpected error %v", err)
+	}
+	if _, err := NewAttachment(match, "pC", false, "https://storage.example.com/cards/m1.jpg", now); !errors.Is(err, ErrAttachmentAccess) {
+		t.Errorf("outsider: err = %v, want ErrAttachmentAccess", err)
+	}
+
+	for _, bad := range []string{"", "cards/m1.jpg", "javascript:alert(1)", "ftp://example.com/m1.jpg"} {
+		if _, err := NewAttachment(match, "pA", false, bad, now); err == nil {
+			t.Errorf("url %q: expected an error", bad)
+		}
+	}
+}
+
+func TestCheckAttachmentAccessAndListing(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	if err := CheckAttachmentAccess(match, "pA", false); err != nil {
+		t.Errorf("player A: unexpected error %v", err)
+	}
+	if err := CheckAttachmentAccess(match, "pC", true); err != nil {
+		t.Errorf("admin: unexpected error %v", err)
+	}
+	if err := CheckAttachmentAccess(match, "pC", false); !errors.Is(err, ErrAttachmentAccess) {
+		t.Errorf("outsider: err = %v, want ErrAttachmentAccess", err)
+	}
+
+	first := time.Date(2025, 6, 12, 20, 0, 0, 0, time.UTC)
+	attachments := []models.Attachment{
+		{ID: "a2", CreatedAt: first.Add(time.Hour)},
+		{ID: "a1", CreatedAt: first},
+		{ID: "a3", CreatedAt: first.Add(2 * time.Hour)},
+	}
+	SortAttachments(attachments)
+	if attachments[0].ID != "a1" || attachments[1].ID != "a2" || attachments[2].ID != "a3" {
+		t.Errorf("order = %s, %s, %s, want a1, a2, a3", attachments[0].ID, attachments[1].ID, attachments[2].ID)
+	}
+}
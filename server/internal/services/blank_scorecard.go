@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// ScorecardHole is one column of a printed scorecard
+type ScorecardHole struct {
+	Number   int `json:"number"`
+	Par      int `json:"par"`
+	Handicap int `json:"handicap"` // Stroke index of the hole
+}
+
+// ScorecardPlayer is one row of a printed scorecard. Strokes holds the stroke dots to mark on
+// each hole.
+type ScorecardPlayer struct {
+	PlayerID        string  `json:"playerId"`
+	PlayerName      string  `json:"playerName"`
+	HandicapIndex   float64 `json:"handicapIndex"`
+	PlayingHandicap int     `json:"playingHandicap"`
+	Strokes         []int   `json:"strokes"`
+}
+
+// BlankScorecard is a pre-filled card for one match, ready to be scored on paper
+type BlankScorecard struct {
+	MatchID    string          `json:"matchId"`
+	CourseID   string          `json:"courseId"`
+	CourseName string          `json:"courseName"`
+	Holes      []ScorecardHole `json:"holes"`
+	PlayerA    ScorecardPlayer `json:"playerA"`
+	PlayerB    ScorecardPlayer `json:"playerB"`
+}
+
+// MatchDayScorecards holds the blank scorecards for every match on a match day
+type MatchDayScorecards struct {
+	MatchDayID string           `json:"matchDayId"`
+	Date       time.Time        `json:"date"`
+	Scorecards []BlankScorecard `json:"scorecards"`
+}
+
+// EffectiveHandicapIndex returns the index a season player takes into their next match: their
+// current index, or their provisional handicap until an index has been established
+func EffectiveHandicapIndex(sp models.SeasonPlayer) float64 {
+	if sp.CurrentHandicapIndex > 0 {
+		return sp.CurrentHandicapIndex
+	}
+	return sp.ProvisionalHandicap
+}
+
+// BuildBlankScorecards lays out a scorecard for each match on a match day with the course's
+// pars and hole handicaps and each player's stroke dots. Strokes are assigned exactly as score
+// entry will assign them, from the players' current season indexes, so a gross match day's
+// cards have no dots. Matches without their own
+// course are played on the match day's course. Cards are ordered by player A's name.
+func BuildBlankScorecards(matchDay models.MatchDay, matches []models.Match, courses map[string]models.Course, seasonPlayers map[string]models.SeasonPlayer, league models.League) (MatchDayScorecards, error) {
+	result := MatchDayScorecards{
+		MatchDayID: matchDay.ID,
+		Date:       matchDay.Date,
+		Scorecards: make([]BlankScorecard, 0, len(matches)),
+	}
+
+	for _, match := range matches {
+		courseID := match.CourseID
+		if courseID == "" {
+			courseID = matchDay.CourseID
+		}
+		course, ok := courses[courseID]
+		if !ok {
+			return MatchDayScorecards{}, fmt.Errorf("course %s for match %s not found", courseID, match.ID)
+		}
+
+		holes := make([]ScorecardHole, len(course.HolePars))
+		for i, par := range course.HolePars {
+			holes[i] = ScorecardHole{Number: i + 1, Par: par}
+			if i < len(course.HoleHandicaps) {
+				holes[i].Handicap = course.HoleHandicaps[i]
+			}
+		}
+
+		playerA := scorecardPlayer(match.PlayerAID, match.PlayerAName, seasonPlayers, course, league)
+		playerB := scorecardPlayer(match.PlayerBID, match.PlayerBName, seasonPlayers, course, league)
+		strokes := AssignStrokesForBasis(matchDay.MatchPlayBasis, playerA.PlayerID, playerA.PlayingHandicap, playerB.PlayerID, playerB.PlayingHandicap, course)
+		playerA.Strokes = strokes[playerA.PlayerID]
+		playerB.Strokes = strokes[playerB.PlayerID]
+
+		result.Scorecards = append(result.Scorecards, BlankScorecard{
+			MatchID:    match.ID,
+			CourseID:   course.ID,
+			CourseName: course.Name,
+			Holes:      holes,
+			PlayerA:    playerA,
+			PlayerB:    playerB,
+		})
+	}
+
+	sort.SliceStable(result.Scorecards, func(i, j int) bool {
+		return result.Scorecards[i].PlayerA.PlayerName < result.Scorecards[j].PlayerA.PlayerName
+	})
+
+	return result, nil
+}
+
+// MatchDayHandicap is a player's handicaps on the course they play on a match day
+type MatchDayHandicap struct {
+	HandicapIndex   float64 `json:"handicapIndex"`
+	CourseHandicap  float64 `json:"courseHandicap"`
+	PlayingHandicap int     `json:"playingHandicap"`
+}
+
+// MatchDayHandicaps returns the handicaps of every player on a match day, keyed by player ID,
+// worked out the same way as the blank scorecards: from their current season index on their
+// match's course, or the match day's course when the match has none. It is the lighter
+// alternative to the scorecards when only the handicaps are needed.
+func MatchDayHandicaps(matchDay models.MatchDay, matches []models.Match, courses map[string]models.Course, seasonPlayers map[string]models.SeasonPlayer, league models.League) (map[string]MatchDayHandicap, error) {
+	handicaps := make(map[string]MatchDayHandicap, len(matches)*2)
+	for _, match := range matches {
+		courseID := match.CourseID
+		if courseID == "" {
+			courseID = matchDay.CourseID
+		}
+		course, ok := courses[courseID]
+		if !ok {
+			return nil, fmt.Errorf("course %s for match %s not found", courseID, match.ID)
+		}
+
+		for _, playerID := range []string{match.PlayerAID, match.PlayerBID} {
+			player := scorecardPlayer(playerID, "", seasonPlayers, course, league)
+			courseHandicap, _ := CalculateCourseAndPlayingHandicapForLeague(player.HandicapIndex, course, league)
+			handicaps[playerID] = MatchDayHandicap{
+				HandicapIndex:   player.HandicapIndex,
+				CourseHandicap:  courseHandicap,
+				PlayingHandicap: player.PlayingHandicap,
+			}
+		}
+	}
+	return handicaps, nil
+}
+
+// scorecardPlayer fills in a player's name and handicaps for a scorecard, preferring the name
+// stored on the match
+func scorecardPlayer(playerID, name string, seasonPlayers map[string]models.SeasonPlayer, course models.Course, league models.League) ScorecardPlayer {
+	sp := seasonPlayers[playerID]
+	if name == "" {
+		name = sp.PlayerName
+	}
+	index := EffectiveHandicapIndex(sp)
+	_, playingHandicap := CalculateCourseAndPlayingHandicapForLeague(index, course, league)
+
+	return ScorecardPlayer{
+		PlayerID:        playerID,
+		PlayerName:      name,
+		HandicapIndex:   index,
+		PlayingHandicap: playingHandicap,
+	}
+}
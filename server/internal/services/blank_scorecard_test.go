@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildBlankScorecardsStrokeDots(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c1"
+	course.Name = "North Nine"
+	courses := map[string]models.Course{"c1": course}
+
+	matchDay := models.MatchDay{ID: "md1", CourseID: "c1"}
+	matches := []models.Match{
+		{ID: "m2", PlayerAID: "p3", PlayerAName: "Zed", PlayerBID: "p4", PlayerBName: "Yan"},
+		{ID: "m1", PlayerAID: "p1", PlayerAName: "Alice", PlayerBID: "p2", CourseID: "c1"},
+	}
+	seasonPlayers := map[string]models.SeasonPlayer{
+		"p1": {PlayerID: "p1", CurrentHandicapIndex: 4.1},
+		"p2": {PlayerID: "p2", PlayerName: "Bob", CurrentHandicapIndex: 16.3},
+		// No index yet, so the provisional handicap is used
+		"p3": {PlayerID: "p3", ProvisionalHandicap: 22.0},
+		"p4": {PlayerID: "p4", CurrentHandicapIndex: 9.5},
+	}
+	league := models.League{}
+
+	cards, err := BuildBlankScorecards(matchDay, matches, courses, seasonPlayers, league)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cards.Scorecards) != 2 {
+		t.Fatalf("got %d scorecards, want 2", len(cards.Scorecards))
+	}
+	if cards.Scorecards[0].MatchID != "m1" || cards.Scorecards[0].PlayerB.PlayerName != "Bob" {
+		t.Errorf("first card = %s with player B %q, want m1 with Bob", cards.Scorecards[0].MatchID, cards.Scorecards[0].PlayerB.PlayerName)
+	}
+
+	for _, card := range cards.Scorecards {
+		if len(card.Holes) != 9 || card.Holes[2].Par != 5 || card.Holes[2].Handicap != 1 || card.CourseName != "North Nine" {
+			t.Errorf("%s: course layout not filled in: %+v", card.MatchID, card.Holes)
+		}
+
+		_, playingA := CalculateCourseAndPlayingHandicapForLeague(EffectiveHandicapIndex(seasonPlayers[card.PlayerA.PlayerID]), course, league)
+		_, playingB := CalculateCourseAndPlayingHandicapForLeague(EffectiveHandicapIndex(seasonPlayers[card.PlayerB.PlayerID]), course, league)
+		want := AssignStrokes(card.PlayerA.PlayerID, playingA, card.PlayerB.PlayerID, playingB, course)
+
+		for _, player := range []ScorecardPlayer{card.PlayerA, card.PlayerB} {
+			if len(player.Strokes) != 9 {
+				t.Fatalf("%s/%s: got %d stroke holes, want 9", card.MatchID, player.PlayerID, len(player.Strokes))
+			}
+			for hole, dots := range player.Strokes {
+				if dots != want[player.PlayerID][hole] {
+					t.Errorf("%s/%s hole %d: %d dots, AssignStrokes gives %d", card.MatchID, player.PlayerID, hole+1, dots, want[player.PlayerID][hole])
+				}
+			}
+		}
+		if sumStrokes(card.PlayerA.Strokes) > 0 && sumStrokes(card.PlayerB.Strokes) > 0 {
+			t.Errorf("%s: both players receive strokes", card.MatchID)
+		}
+	}
+}
+
+func TestBuildBlankScorecardsMissingCourse(t *testing.T) {
+	matches := []models.Match{{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"}}
+	if _, err := BuildBlankScorecards(models.MatchDay{CourseID: "gone"}, matches, nil, nil, models.League{}); err == nil {
+		t.Error("expected an error for a match on an unknown course")
+	}
+}
+
+func TestMatchDayHandicaps(t *testing.T) {
+	dayCourse := absentPreviewCourse()
+	dayCourse.ID = "c1"
+	hardCourse := absentPreviewCourse()
+	hardCourse.ID = "c2"
+	hardCourse.SlopeRating = 140
+	hardCourse.CourseRating = 37.2
+	courses := map[string]models.Course{"c1": dayCourse, "c2": hardCourse}
+
+	matchDay := models.MatchDay{ID: "md1", CourseID: "c1"}
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p4", CourseID: "c2"},
+	}
+	seasonPlayers := map[string]models.SeasonPlayer{
+		"p1": {PlayerID: "p1", CurrentHandicapIndex: 4.1},
+		"p2": {PlayerID: "p2", CurrentHandicapIndex: 16.3},
+		"p3": {PlayerID: "p3", ProvisionalHandicap: 22.0},
+		"p4": {PlayerID: "p4", CurrentHandicapIndex: 9.5},
+	}
+	league := models.League{}
+
+	handicaps, err := MatchDayHandicaps(matchDay, matches, courses, seasonPlayers, league)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(handicaps) != 4 {
+		t.Fatalf("got %d players, want 4", len(handicaps))
+	}
+
+	wantCourse := map[string]models.Course{"p1": dayCourse, "p2": dayCourse, "p3": hardCourse, "p4": hardCourse}
+	for playerID, course := range wantCourse {
+		index := EffectiveHandicapIndex(seasonPlayers[playerID])
+		courseHandicap, playingHandicap := CalculateCourseAndPlayingHandicap(index, course)
+		got := handicaps[playerID]
+		if got.HandicapIndex != index || got.CourseHandicap != courseHandicap || got.PlayingHandicap != playingHandicap {
+			t.Errorf("%s = %+v, want index %.1f, course %.1f, playing %d", playerID, got, index, courseHandicap, playingHandicap)
+		}
+	}
+}
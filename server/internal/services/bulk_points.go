@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// AuditActionMatchPointsCorrected is recorded when an admin overrides a match's points
+const AuditActionMatchPointsCorrected = "match_points_corrected"
+
+// MatchPointsUpdate sets the points of one match directly
+type MatchPointsUpdate struct {
+	MatchID       string `json:"matchId"`
+	PlayerAPoints int    `json:"playerAPoints"`
+	PlayerBPoints int    `json:"playerBPoints"`
+}
+
+// MatchPointsTotal is what a match's points may add up to. When halved holes are split the
+// points always add up to Max. When they score nothing each halved hole takes HalvedHole
+// points off Max, so the points add up to Max less a multiple of HalvedHole, for at most
+// Holes halved holes.
+type MatchPointsTotal struct {
+	Max        int
+	HalvedHole int
+	Holes      int
+}
+
+// ValidateMatchPoints checks that a points split is non-negative and adds up to a total the
+// match can award
+func ValidateMatchPoints(pointsA, pointsB int, total MatchPointsTotal) error {
+	if pointsA < 0 || pointsB < 0 {
+		return fmt.Errorf("points cannot be negative")
+	}
+	sum := pointsA + pointsB
+	if total.HalvedHole == 0 {
+		if sum != total.Max {
+			return fmt.Errorf("points must add up to %d, got %d", total.Max, sum)
+		}
+		return nil
+	}
+	lowest := total.Max - total.HalvedHole*total.Holes
+	if sum > total.Max || sum < lowest || (total.Max-sum)%total.HalvedHole != 0 {
+		return fmt.Errorf("points must add up to %d, or less by a multiple of %d down to %d, got %d",
+			total.Max, total.HalvedHole, lowest, sum)
+	}
+	return nil
+}
+
+// MatchTotal returns the points a match on course may award under the league's match point
+// values and the season's rules. A course without hole pars is taken to be 9 holes.
+func MatchTotal(league models.League, season models.Season, course models.Course) MatchPointsTotal {
+	holes := len(course.HolePars)
+	if holes == 0 {
+		holes = holesPerRound
+	}
+	rules := SeasonMatchPointRules(LeagueMatchPointRules(league), season)
+	total := MatchPointsTotal{Max: rules.Total(holes), Holes: holes}
+	if rules.NoHalvedHolePoints {
+		total.HalvedHole = rules.PerHole
+	}
+	return total
+}
+
+// ApplyBulkPoints validates a batch of points corrections against the league's matches and
+// returns the updated matches along with an audit entry for each; the caller assigns the
+// entries' IDs. matchTotal gives the points a match may award, which each update must add
+// up to. Only completed matches can be corrected, and each match may appear once.
+// The batch is all or nothing: the first invalid update is reported and nothing is returned
+// to save.
+func ApplyBulkPoints(matches map[string]models.Match, matchTotal func(models.Match) MatchPointsTotal, updates []MatchPointsUpdate, actorID string, now time.Time) ([]models.Match, []models.AuditEntry, error) {
+	if len(updates) == 0 {
+		return nil, nil, fmt.Errorf("at least one update is required")
+	}
+
+	updated := make([]models.Match, 0, len(updates))
+	entries := make([]models.AuditEntry, 0, len(updates))
+	seen := make(map[string]bool, len(updates))
+	for i, update := range updates {
+		match, ok := matches[update.MatchID]
+		if !ok {
+			return nil, nil, fmt.Errorf("update %d: match %q not found", i+1, update.MatchID)
+		}
+		if seen[update.MatchID] {
+			return nil, nil, fmt.Errorf("update %d: match %s appears more than once", i+1, update.MatchID)
+		}
+		seen[update.MatchID] = true
+		if match.Status != "completed" {
+			return nil, nil, fmt.Errorf("update %d: match %s is not completed", i+1, update.MatchID)
+		}
+		if err := ValidateMatchPoints(update.PlayerAPoints, update.PlayerBPoints, matchTotal(match)); err != nil {
+			return nil, nil, fmt.Errorf("update %d: match %s: %w", i+1, update.MatchID, err)
+		}
+
+		entries = append(entries, models.AuditEntry{
+			LeagueID:   match.LeagueID,
+			Action:     AuditActionMatchPointsCorrected,
+			EntityType: "match",
+			EntityID:   match.ID,
+			ActorID:    actorID,
+			Before:     fmt.Sprintf("%d-%d", match.PlayerAPoints, match.PlayerBPoints),
+			After:      fmt.Sprintf("%d-%d", update.PlayerAPoints, update.PlayerBPoints),
+			CreatedAt:  now,
+		})
+
+		match.PlayerAPoints = update.PlayerAPoints
+		match.PlayerBPoints = update.PlayerBPoints
+		updated = append(updated, match)
+	}
+
+	return updated, entries, nil
+}
+
+// CorrectedMatchIDs returns the IDs of the matches whose points an admin corrected, from the
+// league's match points correction audit entries
+func CorrectedMatchIDs(entries []models.AuditEntry) map[string]bool {
+	corrected := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Action == AuditActionMatchPointsCorrected && entry.EntityType == "match" {
+			corrected[entry.EntityID] = true
+		}
+	}
+	return corrected
+}
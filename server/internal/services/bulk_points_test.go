@@ -0,0 +1,132 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func bulkPointsMatches() map[string]models.Match {
+	return map[string]models.Match{
+		"m1": {ID: "m1", LeagueID: "l1", Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8},
+		"m2": {ID: "m2", LeagueID: "l1", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11},
+		"m3": {ID: "m3", LeagueID: "l1", Status: "scheduled"},
+	}
+}
+
+// defaultMatchTotal is the 22 points of a 9-hole match under the default rules
+func defaultMatchTotal(models.Match) MatchPointsTotal {
+	return MatchTotal(models.League{}, models.Season{}, absentPreviewCourse())
+}
+
+func TestApplyBulkPoints(t *testing.T) {
+	now := time.Date(2025, 9, 30, 18, 0, 0, 0, time.UTC)
+	updates := []MatchPointsUpdate{
+		{MatchID: "m1", PlayerAPoints: 12, PlayerBPoints: 10},
+		{MatchID: "m2", PlayerAPoints: 0, PlayerBPoints: 22},
+	}
+
+	matches, entries, err := ApplyBulkPoints(bulkPointsMatches(), defaultMatchTotal, updates, "admin1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(matches) != 2 || len(entries) != 2 {
+		t.Fatalf("got %d matches and %d audit entries, want 2 and 2", len(matches), len(entries))
+	}
+	if matches[0].PlayerAPoints != 12 || matches[0].PlayerBPoints != 10 {
+		t.Errorf("m1 points = %d-%d, want 12-10", matches[0].PlayerAPoints, matches[0].PlayerBPoints)
+	}
+	if matches[1].PlayerAPoints != 0 || matches[1].PlayerBPoints != 22 {
+		t.Errorf("m2 points = %d-%d, want 0-22", matches[1].PlayerAPoints, matches[1].PlayerBPoints)
+	}
+
+	entry := entries[0]
+	if entry.EntityID != "m1" || entry.LeagueID != "l1" || entry.ActorID != "admin1" || entry.Action != AuditActionMatchPointsCorrected {
+		t.Errorf("audit entry = %+v, want m1 corrected by admin1", entry)
+	}
+	if entry.Before != "14-8" || entry.After != "12-10" || !entry.CreatedAt.Equal(now) {
+		t.Errorf("audit change = %s -> %s at %v, want 14-8 -> 12-10 at %v", entry.Before, entry.After, entry.CreatedAt, now)
+	}
+}
+
+func TestApplyBulkPointsRejectsInvalidBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates []MatchPointsUpdate
+		wantErr string
+	}{
+		{"points do not sum to match total", []MatchPointsUpdate{
+			{MatchID: "m1", PlayerAPoints: 12, PlayerBPoints: 10},
+			{MatchID: "m2", PlayerAPoints: 12, PlayerBPoints: 12},
+		}, "add up to 22"},
+		{"negative points", []MatchPointsUpdate{{MatchID: "m1", PlayerAPoints: 24, PlayerBPoints: -2}}, "negative"},
+		{"unknown match", []MatchPointsUpdate{{MatchID: "m9", PlayerAPoints: 11, PlayerBPoints: 11}}, "not found"},
+		{"match not completed", []MatchPointsUpdate{{MatchID: "m3", PlayerAPoints: 11, PlayerBPoints: 11}}, "not completed"},
+		{"duplicate match", []MatchPointsUpdate{
+			{MatchID: "m1", PlayerAPoints: 11, PlayerBPoints: 11},
+			{MatchID: "m1", PlayerAPoints: 12, PlayerBPoints: 10},
+		}, "more than once"},
+		{"empty batch", nil, "at least one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, entries, err := ApplyBulkPoints(bulkPointsMatches(), defaultMatchTotal, tt.updates, "admin1", time.Now())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+			if matches != nil || entries != nil {
+				t.Errorf("expected nothing to save, got %d matches and %d entries", len(matches), len(entries))
+			}
+		})
+	}
+}
+
+func TestApplyBulkPointsLeagueMatchTotal(t *testing.T) {
+	perHole, overall := 4, 6
+	league := models.League{MatchPointsPerHole: perHole, MatchOverallPoints: &overall}
+	course := absentPreviewCourse()
+	course.HolePars = append(course.HolePars, course.HolePars...)
+	matchTotal := func(models.Match) MatchPointsTotal { return MatchTotal(league, models.Season{}, course) }
+
+	// 18 holes at 4 points plus 6 overall
+	if got := matchTotal(models.Match{}).Max; got != 78 {
+		t.Fatalf("match total = %d, want 78", got)
+	}
+
+	updates := []MatchPointsUpdate{{MatchID: "m1", PlayerAPoints: 40, PlayerBPoints: 38}}
+	if _, _, err := ApplyBulkPoints(bulkPointsMatches(), matchTotal, updates, "admin1", time.Now()); err != nil {
+		t.Errorf("unexpected error for a 78 point split: %v", err)
+	}
+
+	updates = []MatchPointsUpdate{{MatchID: "m1", PlayerAPoints: 12, PlayerBPoints: 10}}
+	_, _, err := ApplyBulkPoints(bulkPointsMatches(), matchTotal, updates, "admin1", time.Now())
+	if err == nil || !strings.Contains(err.Error(), "add up to 78") {
+		t.Errorf("err = %v, want the default 22 rejected", err)
+	}
+}
+
+func TestApplyBulkPointsNoHalvedHolePoints(t *testing.T) {
+	season := models.Season{HalvedHoleRule: HalvedHoleNone}
+	matchTotal := func(models.Match) MatchPointsTotal {
+		return MatchTotal(models.League{}, season, absentPreviewCourse())
+	}
+
+	// Up to 22 points, 2 fewer for each of at most 9 halved holes
+	for _, points := range [][2]int{{14, 8}, {12, 8}, {10, 2}, {2, 2}} {
+		updates := []MatchPointsUpdate{{MatchID: "m1", PlayerAPoints: points[0], PlayerBPoints: points[1]}}
+		if _, _, err := ApplyBulkPoints(bulkPointsMatches(), matchTotal, updates, "admin1", time.Now()); err != nil {
+			t.Errorf("%d-%d: unexpected error: %v", points[0], points[1], err)
+		}
+	}
+
+	for _, points := range [][2]int{{14, 10}, {12, 7}, {0, 2}} {
+		updates := []MatchPointsUpdate{{MatchID: "m1", PlayerAPoints: points[0], PlayerBPoints: points[1]}}
+		if _, _, err := ApplyBulkPoints(bulkPointsMatches(), matchTotal, updates, "admin1", time.Now()); err == nil {
+			t.Errorf("%d-%d: expected an error", points[0], points[1])
+		}
+	}
+}
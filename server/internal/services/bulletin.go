@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// MaxBulletinMessageLength is the longest bulletin message allowed, in bytes
+const MaxBulletinMessageLength = 1000
+
+// DefaultBulletinEditWindow is how long after posting an author can edit a message when the
+// league does not configure its own window
+const DefaultBulletinEditWindow = 10 * time.Minute
+
+// Errors returned when a bulletin message edit is not allowed
+var (
+	ErrBulletinEditNotAuthor   = errors.New("can only edit your own messages")
+	ErrBulletinEditWindowEnded = errors.New("the edit window for this message has ended")
+)
+
+// ValidateBulletinContent checks that message content is present and within the length limit
+func ValidateBulletinContent(content string) error {
+	if content == "" {
+		return fmt.Errorf("message content is required")
+	}
+	if len(content) > MaxBulletinMessageLength {
+		return fmt.Errorf("message content must be %d characters or less", MaxBulletinMessageLength)
+	}
+	return nil
+}
+
+// BulletinEditWindow returns how long the league lets authors edit their messages
+func BulletinEditWindow(league models.League) time.Duration {
+	if league.BulletinEditWindowMinutes > 0 {
+		return time.Duration(league.BulletinEditWindowMinutes) * time.Minute
+	}
+	return DefaultBulletinEditWindow
+}
+
+// CanEditBulletinMessage reports whether a player may edit a message at the given time.
+// Admins can edit any message at any time; authors can edit their own messages until the
+// edit window after CreatedAt has passed.
+func CanEditBulletinMessage(message models.BulletinMessage, playerID string, isAdmin bool, window time.Duration, now time.Time) error {
+	if isAdmin {
+		return nil
+	}
+	if message.PlayerID != playerID {
+		return ErrBulletinEditNotAuthor
+	}
+	if now.After(message.CreatedAt.Add(window)) {
+		return ErrBulletinEditWindowEnded
+	}
+	return nil
+}
+
+// LeagueBulletinEntry is a bulletin message with the name of the season it was posted in
+type LeagueBulletinEntry struct {
+	models.BulletinMessage
+	SeasonName string `json:"seasonName"`
+}
+
+// AttachSeasonNames pairs each message with its season's name, keeping the message order.
+// Messages from seasons not in the list get an empty season name.
+func AttachSeasonNames(messages []models.BulletinMessage, seasons []models.Season) []LeagueBulletinEntry {
+	names := make(map[string]string, len(seasons))
+	for _, season := range seasons {
+		names[season.ID] = season.Name
+	}
+
+	entries := make([]LeagueBulletinEntry, 0, len(messages))
+	for _, message := range messages {
+		entries = append(entries, LeagueBulletinEntry{
+			BulletinMessage: message,
+			SeasonName:      names[message.SeasonID],
+		})
+	}
+	return entries
+}
+
+// OrderBulletinMessages merges a season's pinned messages with its most recent messages for
+// display. Pinned messages come first, most recently pinned first, followed by the rest
+// newest first. A pinned message that also appears among the recent ones is listed once.
+func OrderBulletinMessages(pinned, recent []models.BulletinMessage) []models.BulletinMessage {
+	ordered := make([]models.BulletinMessage, 0, len(pinned)+len(recent))
+	seen := make(map[string]bool, len(pinned)+len(recent))
+	for _, group := range [][]models.BulletinMessage{pinned, recent} {
+		for _, message := range group {
+			if seen[message.ID] {
+				continue
+			}
+			seen[message.ID] = true
+			ordered = append(ordered, message)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if a.Pinned && a.PinnedAt != nil && b.PinnedAt != nil && !a.PinnedAt.Equal(*b.PinnedAt) {
+			return a.PinnedAt.After(*b.PinnedAt)
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	return ordered
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestAttachSeasonNames(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	seasons := []models.Season{
+		{ID: "spring", Name: "Spring 2025"},
+		{ID: "summer", Name: "Summer 2025"},
+	}
+	// League-wide listing, newest first across both seasons
+	messages := []models.BulletinMessage{
+		{ID: "m4", SeasonID: "summer", Content: "Rain date moved", CreatedAt: base.Add(4 * time.Hour)},
+		{ID: "m3", SeasonID: "spring", Content: "Final standings posted", CreatedAt: base.Add(3 * time.Hour)},
+		{ID: "m2", SeasonID: "summer", Content: "Welcome back", CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "m1", SeasonID: "deleted", Content: "Orphaned", CreatedAt: base.Add(1 * time.Hour)},
+	}
+
+	entries := AttachSeasonNames(messages, seasons)
+
+	want := []struct{ id, season string }{
+		{"m4", "Summer 2025"},
+		{"m3", "Spring 2025"},
+		{"m2", "Summer 2025"},
+		{"m1", ""},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i].ID != w.id || entries[i].SeasonName != w.season {
+			t.Errorf("entry %d = %s/%q, want %s/%q", i, entries[i].ID, entries[i].SeasonName, w.id, w.season)
+		}
+	}
+
+	// The message fields and season name serialize side by side
+	data, err := json.Marshal(entries[0])
+	if err != nil {
+		t.Fatalf("failed to marshal entry: %v", err)
+	}
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if decoded["content"] != "Rain date moved" || decoded["seasonName"] != "Summer 2025" {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestCanEditBulletinMessage(t *testing.T) {
+	posted := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	message := models.BulletinMessage{ID: "m1", PlayerID: "author", CreatedAt: posted}
+	window := BulletinEditWindow(models.League{})
+
+	tests := []struct {
+		name     string
+		playerID string
+		isAdmin  bool
+		now      time.Time
+		wantErr  error
+	}{
+		{"author within window", "author", false, posted.Add(9 * time.Minute), nil},
+		{"author at window end", "author", false, posted.Add(10 * time.Minute), nil},
+		{"author after window", "author", false, posted.Add(11 * time.Minute), ErrBulletinEditWindowEnded},
+		{"other player within window", "someone-else", false, posted.Add(time.Minute), ErrBulletinEditNotAuthor},
+		{"admin long after posting", "admin", true, posted.Add(72 * time.Hour), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CanEditBulletinMessage(message, tt.playerID, tt.isAdmin, window, tt.now)
+			if err != tt.wantErr {
+				t.Errorf("CanEditBulletinMessage() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulletinEditWindow(t *testing.T) {
+	if got := BulletinEditWindow(models.League{}); got != DefaultBulletinEditWindow {
+		t.Errorf("default window = %v, want %v", got, DefaultBulletinEditWindow)
+	}
+	if got := BulletinEditWindow(models.League{BulletinEditWindowMinutes: 30}); got != 30*time.Minute {
+		t.Errorf("configured window = %v, want 30m", got)
+	}
+}
+
+func TestValidateBulletinContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"normal", "Tee times moved to 5:30", false},
+		{"at limit", strings.Repeat("a", MaxBulletinMessageLength), false},
+		{"over limit", strings.Repeat("a", MaxBulletinMessageLength+1), true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBulletinContent(tt.content); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBulletinContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderBulletinMessages(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+	pinnedAt := func(h int) *time.Time { tm := at(h); return &tm }
+
+	ids := func(messages []models.BulletinMessage) []string {
+		out := make([]string, len(messages))
+		for i, m := range messages {
+			out[i] = m.ID
+		}
+		return out
+	}
+
+	t.Run("no pinned messages keeps newest first", func(t *testing.T) {
+		recent := []models.BulletinMessage{
+			{ID: "m3", CreatedAt: at(3)},
+			{ID: "m2", CreatedAt: at(2)},
+			{ID: "m1", CreatedAt: at(1)},
+		}
+		got := ids(OrderBulletinMessages(nil, recent))
+		if !equalIDs(got, []string{"m3", "m2", "m1"}) {
+			t.Errorf("order = %v, want [m3 m2 m1]", got)
+		}
+	})
+
+	t.Run("pinned messages lead regardless of age", func(t *testing.T) {
+		oldAnnouncement := models.BulletinMessage{ID: "rules", CreatedAt: at(-48), Pinned: true, PinnedAt: pinnedAt(-47)}
+		schedule := models.BulletinMessage{ID: "schedule", CreatedAt: at(2), Pinned: true, PinnedAt: pinnedAt(5)}
+		pinned := []models.BulletinMessage{oldAnnouncement, schedule}
+		// The schedule post is recent enough to also be in the latest page
+		recent := []models.BulletinMessage{
+			{ID: "m4", CreatedAt: at(4)},
+			{ID: "m3", CreatedAt: at(3)},
+			schedule,
+			{ID: "m1", CreatedAt: at(1)},
+		}
+
+		got := ids(OrderBulletinMessages(pinned, recent))
+		want := []string{"schedule", "rules", "m4", "m3", "m1"}
+		if !equalIDs(got, want) {
+			t.Errorf("order = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// CareerRound identifies a single notable round
+type CareerRound struct {
+	ScoreID    string    `json:"scoreId"`
+	MatchID    string    `json:"matchId"`
+	CourseID   string    `json:"courseId"`
+	Date       time.Time `json:"date"`
+	GrossScore int       `json:"grossScore"`
+}
+
+// CareerStats summarizes a player's results across every season in a league
+type CareerStats struct {
+	PlayerID         string       `json:"playerId"`
+	SeasonsPlayed    int          `json:"seasonsPlayed"`
+	TotalRounds      int          `json:"totalRounds"`
+	AverageGross     float64      `json:"averageGross"`
+	AverageNet       float64      `json:"averageNet"`
+	TotalMatchPoints int          `json:"totalMatchPoints"`
+	MatchesPlayed    int          `json:"matchesPlayed"`
+	Wins             int          `json:"wins"`
+	Losses           int          `json:"losses"`
+	Ties             int          `json:"ties"`
+	LowestRound      *CareerRound `json:"lowestRound"` // Nil until the player has posted a round
+	BestIndex        *float64     `json:"bestIndex"`   // Lowest handicap index the player has played to
+}
+
+// ComputeCareerStats aggregates a player's scores and matches from all seasons.
+// Absent rounds are left out of the scoring figures. Match results are counted the same way
+// as in standings: only completed matches with points recorded and not under dispute.
+// Averages are rounded to one decimal place.
+func ComputeCareerStats(playerID string, scores []models.Score, matches []models.Match) CareerStats {
+	stats := CareerStats{PlayerID: playerID}
+	seasons := make(map[string]bool)
+
+	var grossTotal, netTotal int
+	for _, score := range scores {
+		if score.PlayerID != playerID || score.PlayerAbsent {
+			continue
+		}
+		stats.TotalRounds++
+		grossTotal += score.GrossScore
+		netTotal += score.NetScore
+
+		if stats.LowestRound == nil || score.GrossScore < stats.LowestRound.GrossScore {
+			stats.LowestRound = &CareerRound{
+				ScoreID:    score.ID,
+				MatchID:    score.MatchID,
+				CourseID:   score.CourseID,
+				Date:       score.Date,
+				GrossScore: score.GrossScore,
+			}
+		}
+		if stats.BestIndex == nil || score.HandicapIndex < *stats.BestIndex {
+			index := score.HandicapIndex
+			stats.BestIndex = &index
+		}
+	}
+	if stats.TotalRounds > 0 {
+		stats.AverageGross = math.Round(float64(grossTotal)/float64(stats.TotalRounds)*10) / 10
+		stats.AverageNet = math.Round(float64(netTotal)/float64(stats.TotalRounds)*10) / 10
+	}
+
+	for _, match := range matches {
+		if match.Status != "completed" || match.Disputed {
+			continue
+		}
+		if match.PlayerAPoints == 0 && match.PlayerBPoints == 0 {
+			continue
+		}
+
+		var pointsFor, pointsAgainst int
+		switch playerID {
+		case match.PlayerAID:
+			pointsFor, pointsAgainst = match.PlayerAPoints, match.PlayerBPoints
+		case match.PlayerBID:
+			pointsFor, pointsAgainst = match.PlayerBPoints, match.PlayerAPoints
+		default:
+			continue
+		}
+
+		seasons[match.SeasonID] = true
+		stats.MatchesPlayed++
+		stats.TotalMatchPoints += pointsFor
+		switch {
+		case pointsFor > pointsAgainst:
+			stats.Wins++
+		case pointsFor < pointsAgainst:
+			stats.Losses++
+		default:
+			stats.Ties++
+		}
+	}
+	stats.SeasonsPlayed = len(seasons)
+
+	return stats
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComputeCareerStatsAcrossSeasons(t *testing.T) {
+	spring := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+	fall := time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	scores := []models.Score{
+		// 2023 season
+		{ID: "s1", MatchID: "m1", PlayerID: "p1", Date: spring, GrossScore: 44, NetScore: 36, HandicapIndex: 14.2},
+		{ID: "s2", MatchID: "m2", PlayerID: "p1", Date: spring.AddDate(0, 0, 7), GrossScore: 41, NetScore: 34, HandicapIndex: 13.8},
+		// Absent rounds do not count toward scoring
+		{ID: "s3", MatchID: "m3", PlayerID: "p1", Date: spring.AddDate(0, 0, 14), GrossScore: 50, NetScore: 43, PlayerAbsent: true},
+		// 2024 season
+		{ID: "s4", MatchID: "m4", PlayerID: "p1", Date: fall, CourseID: "c2", GrossScore: 39, NetScore: 33, HandicapIndex: 12.9},
+		{ID: "s5", MatchID: "m5", PlayerID: "p1", Date: fall.AddDate(0, 0, 7), GrossScore: 43, NetScore: 37, HandicapIndex: 12.1},
+	}
+	matches := []models.Match{
+		{ID: "m1", SeasonID: "2023", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8},
+		{ID: "m2", SeasonID: "2023", PlayerAID: "p3", PlayerBID: "p1", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11},
+		{ID: "m3", SeasonID: "2023", PlayerAID: "p1", PlayerBID: "p4", Status: "completed", PlayerAPoints: 4, PlayerBPoints: 18},
+		{ID: "m4", SeasonID: "2024", PlayerAID: "p2", PlayerBID: "p1", Status: "completed", PlayerAPoints: 6, PlayerBPoints: 16},
+		{ID: "m5", SeasonID: "2024", PlayerAID: "p1", PlayerBID: "p3", Status: "completed", PlayerAPoints: 12, PlayerBPoints: 10},
+		// Not yet played and disputed matches are left out
+		{ID: "m6", SeasonID: "2024", PlayerAID: "p1", PlayerBID: "p4", Status: "scheduled"},
+		{ID: "m7", SeasonID: "2024", PlayerAID: "p1", PlayerBID: "p4", Status: "completed", PlayerAPoints: 20, PlayerBPoints: 2, Disputed: true},
+	}
+
+	stats := ComputeCareerStats("p1", scores, matches)
+
+	if stats.SeasonsPlayed != 2 {
+		t.Errorf("seasons played = %d, want 2", stats.SeasonsPlayed)
+	}
+	if stats.TotalRounds != 4 {
+		t.Errorf("total rounds = %d, want 4", stats.TotalRounds)
+	}
+	// Gross (44+41+39+43)/4 = 41.75, net (36+34+33+37)/4 = 35
+	if stats.AverageGross != 41.8 || stats.AverageNet != 35.0 {
+		t.Errorf("averages = %.1f/%.1f, want 41.8/35.0", stats.AverageGross, stats.AverageNet)
+	}
+	if stats.TotalMatchPoints != 14+11+4+16+12 {
+		t.Errorf("total match points = %d, want %d", stats.TotalMatchPoints, 14+11+4+16+12)
+	}
+	if stats.MatchesPlayed != 5 || stats.Wins != 3 || stats.Losses != 1 || stats.Ties != 1 {
+		t.Errorf("record = %d played %d-%d-%d, want 5 played 3-1-1", stats.MatchesPlayed, stats.Wins, stats.Losses, stats.Ties)
+	}
+	if stats.LowestRound == nil || stats.LowestRound.ScoreID != "s4" || stats.LowestRound.GrossScore != 39 || stats.LowestRound.CourseID != "c2" {
+		t.Errorf("lowest round = %+v, want s4 with 39", stats.LowestRound)
+	}
+	if stats.BestIndex == nil || *stats.BestIndex != 12.1 {
+		t.Errorf("best index = %v, want 12.1", stats.BestIndex)
+	}
+}
+
+func TestComputeCareerStatsNoRounds(t *testing.T) {
+	stats := ComputeCareerStats("p1", nil, nil)
+
+	if stats.TotalRounds != 0 || stats.MatchesPlayed != 0 || stats.AverageGross != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+	if stats.LowestRound != nil || stats.BestIndex != nil {
+		t.Errorf("expected no lowest round or best index, got %+v / %v", stats.LowestRound, stats.BestIndex)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// IndexPoint is the handicap index a player carried into one round
+type IndexPoint struct {
+	Date          time.Time `json:"date"`
+	MatchID       string    `json:"matchId"`
+	HandicapIndex float64   `json:"handicapIndex"`
+}
+
+// HeadToHead is the record between two players in the matches they played against each other
+type HeadToHead struct {
+	MatchesPlayed int      `json:"matchesPlayed"`
+	PlayerAWins   int      `json:"playerAWins"`
+	PlayerBWins   int      `json:"playerBWins"`
+	Ties          int      `json:"ties"`
+	PlayerAPoints int      `json:"playerAPoints"`
+	PlayerBPoints int      `json:"playerBPoints"`
+	MatchIDs      []string `json:"matchIds"`
+}
+
+// PlayerSeasonSide is one player's half of a side-by-side comparison
+type PlayerSeasonSide struct {
+	PlayerID   string       `json:"playerId"`
+	Stats      CareerStats  `json:"stats"`
+	IndexTrend []IndexPoint `json:"indexTrend"`
+}
+
+// PlayerComparison compares two players' performance within a single season
+type PlayerComparison struct {
+	SeasonID   string           `json:"seasonId"`
+	PlayerA    PlayerSeasonSide `json:"playerA"`
+	PlayerB    PlayerSeasonSide `json:"playerB"`
+	HeadToHead HeadToHead       `json:"headToHead"`
+}
+
+// ComputeIndexTrend lists the handicap index a player used for each round they played, oldest
+// first. Absent rounds are left out since they say nothing about the player's game.
+func ComputeIndexTrend(playerID string, scores []models.Score) []IndexPoint {
+	played := make([]models.Score, 0, len(scores))
+	for _, score := range scores {
+		if score.PlayerID == playerID && !score.PlayerAbsent {
+			played = append(played, score)
+		}
+	}
+	sort.SliceStable(played, func(i, j int) bool {
+		return played[i].Date.Before(played[j].Date)
+	})
+
+	trend := make([]IndexPoint, len(played))
+	for i, score := range played {
+		trend[i] = IndexPoint{Date: score.Date, MatchID: score.MatchID, HandicapIndex: score.HandicapIndex}
+	}
+	return trend
+}
+
+// ComputeHeadToHead tallies the matches between two players. Matches are counted the same way
+// as in standings: only completed matches with points recorded and not under dispute.
+func ComputeHeadToHead(playerAID, playerBID string, matches []models.Match) HeadToHead {
+	h2h := HeadToHead{MatchIDs: []string{}}
+	for _, match := range matches {
+		if match.Status != "completed" || match.Disputed {
+			continue
+		}
+		if match.PlayerAPoints == 0 && match.PlayerBPoints == 0 {
+			continue
+		}
+
+		var pointsA, pointsB int
+		switch {
+		case match.PlayerAID == playerAID && match.PlayerBID == playerBID:
+			pointsA, pointsB = match.PlayerAPoints, match.PlayerBPoints
+		case match.PlayerAID == playerBID && match.PlayerBID == playerAID:
+			pointsA, pointsB = match.PlayerBPoints, match.PlayerAPoints
+		default:
+			continue
+		}
+
+		h2h.MatchesPlayed++
+		h2h.PlayerAPoints += pointsA
+		h2h.PlayerBPoints += pointsB
+		h2h.MatchIDs = append(h2h.MatchIDs, match.ID)
+		switch {
+		case pointsA > pointsB:
+			h2h.PlayerAWins++
+		case pointsA < pointsB:
+			h2h.PlayerBWins++
+		default:
+			h2h.Ties++
+		}
+	}
+	return h2h
+}
+
+// ComparePlayers builds a side-by-side comparison of two players for one season. Matches from
+// other seasons are ignored, as are scores from matches outside the season, so the career
+// figures, index trends and head-to-head all cover the same rounds.
+func ComparePlayers(seasonID, playerAID, playerBID string, scores []models.Score, matches []models.Match) PlayerComparison {
+	seasonMatches := make([]models.Match, 0, len(matches))
+	inSeason := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if match.SeasonID == seasonID {
+			seasonMatches = append(seasonMatches, match)
+			inSeason[match.ID] = true
+		}
+	}
+
+	seasonScores := make([]models.Score, 0, len(scores))
+	for _, score := range scores {
+		if inSeason[score.MatchID] {
+			seasonScores = append(seasonScores, score)
+		}
+	}
+
+	side := func(playerID string) PlayerSeasonSide {
+		return PlayerSeasonSide{
+			PlayerID:   playerID,
+			Stats:      ComputeCareerStats(playerID, seasonScores, seasonMatches),
+			IndexTrend: ComputeIndexTrend(playerID, seasonScores),
+		}
+	}
+
+	return PlayerComparison{
+		SeasonID:   seasonID,
+		PlayerA:    side(playerAID),
+		PlayerB:    side(playerBID),
+		HeadToHead: ComputeHeadToHead(playerAID, playerBID, seasonMatches),
+	}
+}
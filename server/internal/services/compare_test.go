@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComparePlayersOverlappingMatchDays(t *testing.T) {
+	week1 := time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week1.AddDate(0, 0, 14)
+
+	matches := []models.Match{
+		// Week 1: p1 and p2 play each other
+		{ID: "m1", SeasonID: "s1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 15, PlayerBPoints: 7},
+		// Week 2: both play on the same match day against other opponents
+		{ID: "m2", SeasonID: "s1", PlayerAID: "p1", PlayerBID: "p3", Status: "completed", PlayerAPoints: 9, PlayerBPoints: 13},
+		{ID: "m3", SeasonID: "s1", PlayerAID: "p4", PlayerBID: "p2", Status: "completed", PlayerAPoints: 10, PlayerBPoints: 12},
+		// Week 3: a rematch with the sides swapped
+		{ID: "m4", SeasonID: "s1", PlayerAID: "p2", PlayerBID: "p1", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11},
+		// Another season does not count
+		{ID: "m9", SeasonID: "s0", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 2, PlayerBPoints: 20},
+	}
+	scores := []models.Score{
+		{ID: "a3", MatchID: "m4", PlayerID: "p1", Date: week3, GrossScore: 40, NetScore: 33, HandicapIndex: 12.4},
+		{ID: "a1", MatchID: "m1", PlayerID: "p1", Date: week1, GrossScore: 42, NetScore: 35, HandicapIndex: 13.0},
+		{ID: "a2", MatchID: "m2", PlayerID: "p1", Date: week2, GrossScore: 44, NetScore: 37, HandicapIndex: 12.8},
+		{ID: "b1", MatchID: "m1", PlayerID: "p2", Date: week1, GrossScore: 47, NetScore: 36, HandicapIndex: 18.5},
+		{ID: "b2", MatchID: "m3", PlayerID: "p2", Date: week2, GrossScore: 45, NetScore: 34, HandicapIndex: 18.1},
+		{ID: "b3", MatchID: "m4", PlayerID: "p2", Date: week3, GrossScore: 46, NetScore: 35, HandicapIndex: 17.9},
+		{ID: "x1", MatchID: "m9", PlayerID: "p1", Date: week1.AddDate(-1, 0, 0), GrossScore: 38, NetScore: 30, HandicapIndex: 9.0},
+	}
+
+	c := ComparePlayers("s1", "p1", "p2", scores, matches)
+
+	a, b := c.PlayerA.Stats, c.PlayerB.Stats
+	if a.TotalRounds != 3 || b.TotalRounds != 3 {
+		t.Errorf("rounds = %d/%d, want 3/3", a.TotalRounds, b.TotalRounds)
+	}
+	// p1 gross (42+44+40)/3 = 42, p2 gross (47+45+46)/3 = 46
+	if a.AverageGross != 42.0 || b.AverageGross != 46.0 {
+		t.Errorf("gross averages = %.1f/%.1f, want 42.0/46.0", a.AverageGross, b.AverageGross)
+	}
+	if a.TotalMatchPoints != 15+9+11 || b.TotalMatchPoints != 7+12+11 {
+		t.Errorf("points = %d/%d, want %d/%d", a.TotalMatchPoints, b.TotalMatchPoints, 15+9+11, 7+12+11)
+	}
+
+	if len(c.PlayerA.IndexTrend) != 3 {
+		t.Fatalf("p1 trend has %d points, want 3", len(c.PlayerA.IndexTrend))
+	}
+	for i, want := range []float64{13.0, 12.8, 12.4} {
+		if got := c.PlayerA.IndexTrend[i].HandicapIndex; got != want {
+			t.Errorf("p1 trend[%d] = %.1f, want %.1f", i, got, want)
+		}
+	}
+
+	h2h := c.HeadToHead
+	if h2h.MatchesPlayed != 2 || h2h.PlayerAWins != 1 || h2h.PlayerBWins != 0 || h2h.Ties != 1 {
+		t.Errorf("head-to-head = %+v, want 2 played, 1-0-1", h2h)
+	}
+	if h2h.PlayerAPoints != 26 || h2h.PlayerBPoints != 18 {
+		t.Errorf("head-to-head points = %d/%d, want 26/18", h2h.PlayerAPoints, h2h.PlayerBPoints)
+	}
+	if !equalIDs(h2h.MatchIDs, []string{"m1", "m4"}) {
+		t.Errorf("head-to-head matches = %v, want [m1 m4]", h2h.MatchIDs)
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// MatchStatusCompletedUnconfirmed is a match whose result is in but hasn't been confirmed by
+// both players yet
+const MatchStatusCompletedUnconfirmed = "completed-unconfirmed"
+
+// PendingConfirmations returns the matches waiting on the player to confirm their result:
+// completed-unconfirmed matches the player is in and hasn't confirmed, oldest first
+func PendingConfirmations(playerID string, matches []models.Match) []models.Match {
+	pending := make([]models.Match, 0)
+	for _, match := range matches {
+		if match.Status != MatchStatusCompletedUnconfirmed {
+			continue
+		}
+		if (match.PlayerAID == playerID && !match.PlayerAConfirmed) ||
+			(match.PlayerBID == playerID && !match.PlayerBConfirmed) {
+			pending = append(pending, match)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].MatchDate.Before(pending[j].MatchDate)
+	})
+	return pending
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestPendingConfirmations(t *testing.T) {
+	week1 := time.Date(2026, 5, 6, 18, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	matches := []models.Match{
+		// Waiting on p1; p2 has already confirmed
+		{ID: "m-pending", PlayerAID: "p1", PlayerBID: "p2", Status: MatchStatusCompletedUnconfirmed, PlayerBConfirmed: true, MatchDate: week2},
+		// Confirmed by both players
+		{ID: "m-confirmed", PlayerAID: "p3", PlayerBID: "p1", Status: MatchStatusCompletedUnconfirmed, PlayerAConfirmed: true, PlayerBConfirmed: true, MatchDate: week1},
+		{ID: "m-completed", PlayerAID: "p1", PlayerBID: "p4", Status: "completed", MatchDate: week1},
+		{ID: "m-scheduled", PlayerAID: "p1", PlayerBID: "p5", Status: "scheduled", MatchDate: week2},
+		{ID: "m-other", PlayerAID: "p6", PlayerBID: "p7", Status: MatchStatusCompletedUnconfirmed, MatchDate: week1},
+	}
+
+	pending := PendingConfirmations("p1", matches)
+	if len(pending) != 1 || pending[0].ID != "m-pending" {
+		t.Errorf("p1 pending = %v, want only m-pending", pending)
+	}
+
+	// p2 confirmed their side already
+	if pending := PendingConfirmations("p2", matches); len(pending) != 0 {
+		t.Errorf("p2 pending = %v, want none", pending)
+	}
+}
+
+func TestPendingConfirmationsOldestFirst(t *testing.T) {
+	week1 := time.Date(2026, 5, 6, 18, 0, 0, 0, time.UTC)
+	matches := []models.Match{
+		{ID: "m2", PlayerAID: "p1", PlayerBID: "p2", Status: MatchStatusCompletedUnconfirmed, MatchDate: week1.AddDate(0, 0, 7)},
+		{ID: "m1", PlayerAID: "p3", PlayerBID: "p1", Status: MatchStatusCompletedUnconfirmed, MatchDate: week1},
+	}
+
+	pending := PendingConfirmations("p1", matches)
+	if len(pending) != 2 || pending[0].ID != "m1" || pending[1].ID != "m2" {
+		t.Errorf("pending = %v, want m1 then m2", pending)
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// CourseCardHole is one hole of a course card. MissingPar and MissingHandicap mark data that wasn't
+// stored on the course and was filled in for display.
+type CourseCardHole struct {
+	Number          int  `json:"number"`
+	Par             int  `json:"par"`
+	Handicap        int  `json:"handicap"` // Stroke index of the hole
+	MissingPar      bool `json:"missingPar,omitempty"`
+	MissingHandicap bool `json:"missingHandicap,omitempty"`
+}
+
+// CourseCard is a course's hole-by-hole layout, ready to render as a printable card. Complete
+// is false when any hole data had to be filled in or doesn't add up, with each problem listed.
+type CourseCard struct {
+	CourseID     string           `json:"courseId"`
+	CourseName   string           `json:"courseName"`
+	Par          int              `json:"par"`
+	CourseRating float64          `json:"courseRating"`
+	SlopeRating  int              `json:"slopeRating"`
+	Holes        []CourseCardHole `json:"holes"`
+	Complete     bool             `json:"complete"`
+	Problems     []string         `json:"problems"`
+}
+
+// BuildCourseCard lays out a course hole by hole. The card has as many holes as the longer of
+// the course's pars and stroke indexes, or 9 when neither is stored. A missing par is left at 0;
+// missing stroke indexes are filled, in hole order, with the indexes no hole uses, so the card
+// still shows where strokes would fall. Both are flagged on the hole and listed as problems,
+// along with duplicate stroke indexes and a full set of pars that doesn't add up to the
+// course's par.
+func BuildCourseCard(course models.Course) CourseCard {
+	holes := len(course.HolePars)
+	if len(course.HoleHandicaps) > holes {
+		holes = len(course.HoleHandicaps)
+	}
+	if holes == 0 {
+		holes = holesPerRound
+	}
+
+	card := CourseCard{
+		CourseID:     course.ID,
+		CourseName:   course.Name,
+		Par:          course.Par,
+		CourseRating: course.CourseRating,
+		SlopeRating:  course.SlopeRating,
+		Holes:        make([]CourseCardHole, holes),
+		Problems:     make([]string, 0),
+	}
+
+	used := make(map[int]int, holes) // Stroke index to the first hole using it
+	totalPar, missingPars := 0, 0
+	for i := range card.Holes {
+		hole := CourseCardHole{Number: i + 1}
+		if i < len(course.HolePars) && course.HolePars[i] > 0 {
+			hole.Par = course.HolePars[i]
+			totalPar += hole.Par
+		} else {
+			hole.MissingPar = true
+			missingPars++
+			card.Problems = append(card.Problems, fmt.Sprintf("hole %d has no par", hole.Number))
+		}
+
+		if i < len(course.HoleHandicaps) && course.HoleHandicaps[i] >= 1 && course.HoleHandicaps[i] <= holes {
+			hole.Handicap = course.HoleHandicaps[i]
+			if first, dup := used[hole.Handicap]; dup {
+				card.Problems = append(card.Problems, fmt.Sprintf("holes %d and %d share stroke index %d", first, hole.Number, hole.Handicap))
+			} else {
+				used[hole.Handicap] = hole.Number
+			}
+		} else {
+			hole.MissingHandicap = true
+			card.Problems = append(card.Problems, fmt.Sprintf("hole %d has no valid stroke index", hole.Number))
+		}
+		card.Holes[i] = hole
+	}
+
+	// Fill the missing stroke indexes with the unused ones, lowest first
+	unused := make([]int, 0)
+	for index := 1; index <= holes; index++ {
+		if _, ok := used[index]; !ok {
+			unused = append(unused, index)
+		}
+	}
+	for i := range card.Holes {
+		if card.Holes[i].MissingHandicap && len(unused) > 0 {
+			card.Holes[i].Handicap = unused[0]
+			unused = unused[1:]
+		}
+	}
+
+	if missingPars == 0 && course.Par > 0 && totalPar != course.Par {
+		card.Problems = append(card.Problems, fmt.Sprintf("hole pars add up to %d but the course par is %d", totalPar, course.Par))
+	}
+
+	card.Complete = len(card.Problems) == 0
+	return card
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildCourseCardComplete(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c1"
+	course.Name = "North Nine"
+
+	card := BuildCourseCard(course)
+	if !card.Complete || len(card.Problems) != 0 {
+		t.Fatalf("complete = %v, problems = %v, want a complete card", card.Complete, card.Problems)
+	}
+	if card.CourseName != "North Nine" || card.Par != 36 || card.SlopeRating != 125 || len(card.Holes) != 9 {
+		t.Errorf("card header = %+v", card)
+	}
+	for i, hole := range card.Holes {
+		if hole.Number != i+1 || hole.Par != course.HolePars[i] || hole.Handicap != course.HoleHandicaps[i] || hole.MissingPar || hole.MissingHandicap {
+			t.Errorf("hole %d = %+v, want par %d and stroke index %d", i+1, hole, course.HolePars[i], course.HoleHandicaps[i])
+		}
+	}
+}
+
+func TestBuildCourseCardIncomplete(t *testing.T) {
+	course := models.Course{
+		ID:  "c2",
+		Par: 36,
+		// Hole 9's par is missing, hole 4 has no stroke index and holes 8 and 9 share one
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4},
+		HoleHandicaps: []int{3, 9, 1, 0, 7, 8, 2, 4, 4},
+	}
+
+	card := BuildCourseCard(course)
+	if card.Complete {
+		t.Fatal("expected an incomplete card")
+	}
+	if len(card.Holes) != 9 {
+		t.Fatalf("got %d holes, want 9", len(card.Holes))
+	}
+
+	if hole := card.Holes[8]; !hole.MissingPar || hole.Par != 0 {
+		t.Errorf("hole 9 = %+v, want a flagged missing par", hole)
+	}
+	// Stroke indexes 5 and 6 are unused; the first goes to hole 4
+	if hole := card.Holes[3]; !hole.MissingHandicap || hole.Handicap != 5 {
+		t.Errorf("hole 4 = %+v, want stroke index 5 filled in and flagged", hole)
+	}
+
+	want := []string{
+		"hole 4 has no valid stroke index",
+		"hole 9 has no par",
+		"holes 8 and 9 share stroke index 4",
+	}
+	if len(card.Problems) != len(want) {
+		t.Fatalf("problems = %q, want %q", card.Problems, want)
+	}
+	for i := range want {
+		if card.Problems[i] != want[i] {
+			t.Errorf("problem %d = %q, want %q", i, card.Problems[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// Dispute statuses
+const (
+	DisputeStatusOpen     = "open"
+	DisputeStatusResolved = "resolved"
+)
+
+// MaxDisputeReasonLength is the longest dispute reason or resolution allowed, in bytes
+const MaxDisputeReasonLength = 1000
+
+// Errors returned when a dispute cannot be raised or resolved
+var (
+	ErrDisputeNotParticipant    = errors.New("only players in the match can dispute its result")
+	ErrDisputeMatchNotCompleted = errors.New("only completed matches can be disputed")
+	ErrDisputeAlreadyResolved   = errors.New("dispute is already resolved")
+)
+
+// RaiseDispute opens a dispute by a match participant against a completed match.
+// When flagMatch is set the match is marked disputed, which keeps it out of standings until
+// every dispute against it is resolved. The caller assigns the dispute ID and saves both.
+func RaiseDispute(match *models.Match, raisedBy, reason string, flagMatch bool, now time.Time) (models.Dispute, error) {
+	if raisedBy != match.PlayerAID && raisedBy != match.PlayerBID {
+		return models.Dispute{}, ErrDisputeNotParticipant
+	}
+	if match.Status != "completed" {
+		return models.Dispute{}, ErrDisputeMatchNotCompleted
+	}
+	if err := validateDisputeText("reason", reason); err != nil {
+		return models.Dispute{}, err
+	}
+
+	if flagMatch {
+		match.Disputed = true
+	}
+
+	return models.Dispute{
+		LeagueID:  match.LeagueID,
+		MatchID:   match.ID,
+		RaisedBy:  raisedBy,
+		Reason:    reason,
+		Status:    DisputeStatusOpen,
+		CreatedAt: now,
+	}, nil
+}
+
+// ResolveDispute closes an open dispute with the admin's resolution
+func ResolveDispute(dispute *models.Dispute, resolution, resolvedBy string, now time.Time) error {
+	if dispute.Status == DisputeStatusResolved {
+		return ErrDisputeAlreadyResolved
+	}
+	if err := validateDisputeText("resolution", resolution); err != nil {
+		return err
+	}
+
+	dispute.Status = DisputeStatusResolved
+	dispute.Resolution = resolution
+	dispute.ResolvedBy = resolvedBy
+	dispute.ResolvedAt = &now
+	return nil
+}
+
+// HasOpenDispute reports whether any of the disputes is still open
+func HasOpenDispute(disputes []models.Dispute) bool {
+	for _, dispute := range disputes {
+		if dispute.Status == DisputeStatusOpen {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDisputeText(field, text string) error {
+	if text == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if len(text) > MaxDisputeReasonLength {
+		return fmt.Errorf("%s must be %d characters or less", field, MaxDisputeReasonLength)
+	}
+	return nil
+}
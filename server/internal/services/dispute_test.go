@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestRaiseDispute(t *testing.T) {
+	now := time.Date(2024, 6, 12, 18, 0, 0, 0, time.UTC)
+	match := models.Match{ID: "m1", LeagueID: "league-1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"}
+
+	dispute, err := RaiseDispute(&match, "p2", "Hole 7 score was entered wrong", true, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dispute.Status != DisputeStatusOpen || dispute.MatchID != "m1" || dispute.LeagueID != "league-1" || dispute.RaisedBy != "p2" {
+		t.Errorf("dispute = %+v, want open dispute on m1 raised by p2", dispute)
+	}
+	if !dispute.CreatedAt.Equal(now) {
+		t.Errorf("created at = %v, want %v", dispute.CreatedAt, now)
+	}
+	if !match.Disputed {
+		t.Error("expected match to be flagged as disputed")
+	}
+
+	unflagged := models.Match{ID: "m2", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"}
+	if _, err := RaiseDispute(&unflagged, "p1", "Wrong tees", false, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unflagged.Disputed {
+		t.Error("match flagged as disputed without being asked to")
+	}
+}
+
+func TestRaiseDisputeRejected(t *testing.T) {
+	now := time.Now()
+	completed := models.Match{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"}
+	scheduled := models.Match{ID: "m2", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+
+	if _, err := RaiseDispute(&completed, "p3", "Not my match", true, now); !errors.Is(err, ErrDisputeNotParticipant) {
+		t.Errorf("non-participant: got %v, want ErrDisputeNotParticipant", err)
+	}
+	if _, err := RaiseDispute(&scheduled, "p1", "Not played yet", true, now); !errors.Is(err, ErrDisputeMatchNotCompleted) {
+		t.Errorf("scheduled match: got %v, want ErrDisputeMatchNotCompleted", err)
+	}
+	if _, err := RaiseDispute(&completed, "p1", "", true, now); err == nil {
+		t.Error("expected an error for an empty reason")
+	}
+	if completed.Disputed || scheduled.Disputed {
+		t.Error("rejected dispute must not flag the match")
+	}
+}
+
+func TestResolveDispute(t *testing.T) {
+	now := time.Date(2024, 6, 13, 9, 0, 0, 0, time.UTC)
+	dispute := models.Dispute{ID: "d1", MatchID: "m1", Status: DisputeStatusOpen}
+
+	if err := ResolveDispute(&dispute, "Scorecard corrected", "admin-1", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dispute.Status != DisputeStatusResolved || dispute.Resolution != "Scorecard corrected" || dispute.ResolvedBy != "admin-1" {
+		t.Errorf("dispute = %+v, want resolved by admin-1", dispute)
+	}
+	if dispute.ResolvedAt == nil || !dispute.ResolvedAt.Equal(now) {
+		t.Errorf("resolved at = %v, want %v", dispute.ResolvedAt, now)
+	}
+
+	if err := ResolveDispute(&dispute, "Again", "admin-1", now); !errors.Is(err, ErrDisputeAlreadyResolved) {
+		t.Errorf("second resolve: got %v, want ErrDisputeAlreadyResolved", err)
+	}
+}
+
+func TestHasOpenDispute(t *testing.T) {
+	disputes := []models.Dispute{{Status: DisputeStatusResolved}, {Status: DisputeStatusOpen}}
+	if !HasOpenDispute(disputes) {
+		t.Error("expected an open dispute")
+	}
+	if HasOpenDispute(disputes[:1]) {
+		t.Error("expected no open disputes")
+	}
+}
+
+func TestComputeStandingsExcludesDisputedMatches(t *testing.T) {
+	roster := []StandingsEntry{
+		{PlayerID: "p1", PlayerName: "Alice"},
+		{PlayerID: "p2", PlayerName: "Bob"},
+	}
+	match := models.Match{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 15, PlayerBPoints: 7}
+	other := models.Match{ID: "m2", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 10, PlayerBPoints: 12}
+
+	if _, err := RaiseDispute(&match, "p2", "Points miscounted", true, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	standings := ComputeStandings(roster, []models.Match{match, other})
+	for _, entry := range standings {
+		if entry.MatchesPlayed != 1 {
+			t.Errorf("player %s: got %d matches played, want 1 while m1 is disputed", entry.PlayerID, entry.MatchesPlayed)
+		}
+	}
+	if standings[0].PlayerID != "p2" || standings[0].TotalPoints != 12 {
+		t.Errorf("leader = %+v, want Bob on 12 points", standings[0])
+	}
+
+	// Clearing the flag once the dispute is resolved brings the match back
+	match.Disputed = false
+	standings = ComputeStandings(roster, []models.Match{match, other})
+	if standings[0].PlayerID != "p1" || standings[0].TotalPoints != 25 {
+		t.Errorf("leader = %+v, want Alice on 25 points", standings[0])
+	}
+}
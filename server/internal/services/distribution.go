@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// DefaultDistributionBucketSize is the width of each handicap bucket when none is requested
+const DefaultDistributionBucketSize = 5.0
+
+// MaxDistributionBuckets is the most buckets a distribution may span
+const MaxDistributionBuckets = 100
+
+// ErrTooManyBuckets means the bucket size is too small for the spread of indexes
+var ErrTooManyBuckets = errors.New("too many distribution buckets")
+
+// DistributionBucket counts the players whose index falls in [Min, Max)
+type DistributionBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// Distribution is a histogram of handicap indexes with summary statistics
+type Distribution struct {
+	BucketSize  float64              `json:"bucketSize"`
+	PlayerCount int                  `json:"playerCount"`
+	MinIndex    float64              `json:"minIndex"`
+	MedianIndex float64              `json:"medianIndex"`
+	MaxIndex    float64              `json:"maxIndex"`
+	Buckets     []DistributionBucket `json:"buckets"`
+}
+
+// ComputeHandicapDistribution buckets the active season players by handicap index.
+// Each player's current index is used, falling back to their provisional handicap before
+// one has been established. Buckets are aligned to multiples of bucketSize and run without
+// gaps from the lowest to the highest occupied bucket, so plus handicaps land in negative
+// buckets. A bucketSize of zero or less uses DefaultDistributionBucketSize. A bucket size that
+// would span more than MaxDistributionBuckets returns ErrTooManyBuckets.
+func ComputeHandicapDistribution(seasonPlayers []models.SeasonPlayer, bucketSize float64) (Distribution, error) {
+	if bucketSize <= 0 {
+		bucketSize = DefaultDistributionBucketSize
+	}
+
+	indexes := make([]float64, 0, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		index := sp.CurrentHandicapIndex
+		if index == 0 {
+			index = sp.ProvisionalHandicap
+		}
+		indexes = append(indexes, index)
+	}
+
+	distribution := Distribution{
+		BucketSize:  bucketSize,
+		PlayerCount: len(indexes),
+		Buckets:     []DistributionBucket{},
+	}
+	if len(indexes) == 0 {
+		return distribution, nil
+	}
+
+	sort.Float64s(indexes)
+	distribution.MinIndex = indexes[0]
+	distribution.MaxIndex = indexes[len(indexes)-1]
+
+	mid := len(indexes) / 2
+	if len(indexes)%2 == 0 {
+		distribution.MedianIndex = math.Round((indexes[mid-1]+indexes[mid])/2*10) / 10
+	} else {
+		distribution.MedianIndex = indexes[mid]
+	}
+
+	// Compare as floats first so a tiny bucket size can't overflow the bucket numbers
+	firstBucket := math.Floor(distribution.MinIndex / bucketSize)
+	lastBucket := math.Floor(distribution.MaxIndex / bucketSize)
+	if lastBucket-firstBucket+1 > MaxDistributionBuckets {
+		return Distribution{}, fmt.Errorf("%w: a bucket size of %g spans %.0f buckets, the most is %d",
+			ErrTooManyBuckets, bucketSize, lastBucket-firstBucket+1, MaxDistributionBuckets)
+	}
+	first, last := int(firstBucket), int(lastBucket)
+	for b := first; b <= last; b++ {
+		distribution.Buckets = append(distribution.Buckets, DistributionBucket{
+			Min: float64(b) * bucketSize,
+			Max: float64(b+1) * bucketSize,
+		})
+	}
+	for _, index := range indexes {
+		distribution.Buckets[int(math.Floor(index/bucketSize))-first].Count++
+	}
+
+	return distribution, nil
+}
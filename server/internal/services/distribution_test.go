@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComputeHandicapDistribution(t *testing.T) {
+	seasonPlayers := []models.SeasonPlayer{
+		{PlayerID: "p1", IsActive: true, CurrentHandicapIndex: 3.2},
+		{PlayerID: "p2", IsActive: true, CurrentHandicapIndex: 4.9},
+		{PlayerID: "p3", IsActive: true, CurrentHandicapIndex: 5.0},
+		{PlayerID: "p4", IsActive: true, CurrentHandicapIndex: 12.4},
+		{PlayerID: "p5", IsActive: true, CurrentHandicapIndex: 17.8},
+		// No established index yet, so the provisional handicap is used
+		{PlayerID: "p6", IsActive: true, ProvisionalHandicap: 14.0},
+		// Inactive players are left out
+		{PlayerID: "p7", IsActive: false, CurrentHandicapIndex: 30.0},
+	}
+
+	d, err := ComputeHandicapDistribution(seasonPlayers, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.PlayerCount != 6 {
+		t.Errorf("player count = %d, want 6", d.PlayerCount)
+	}
+	if d.MinIndex != 3.2 || d.MaxIndex != 17.8 {
+		t.Errorf("min/max = %.1f/%.1f, want 3.2/17.8", d.MinIndex, d.MaxIndex)
+	}
+	// Sorted: 3.2 4.9 5.0 12.4 14.0 17.8, so the median is (5.0 + 12.4) / 2
+	if d.MedianIndex != 8.7 {
+		t.Errorf("median = %.2f, want 8.7", d.MedianIndex)
+	}
+
+	want := []DistributionBucket{
+		{Min: 0, Max: 5, Count: 2},
+		{Min: 5, Max: 10, Count: 1},
+		{Min: 10, Max: 15, Count: 2},
+		{Min: 15, Max: 20, Count: 1},
+	}
+	if len(d.Buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d: %+v", len(d.Buckets), len(want), d.Buckets)
+	}
+	for i, b := range d.Buckets {
+		if b != want[i] {
+			t.Errorf("bucket %d = %+v, want %+v", i, b, want[i])
+		}
+	}
+}
+
+func TestComputeHandicapDistributionOddCountAndPlusHandicap(t *testing.T) {
+	seasonPlayers := []models.SeasonPlayer{
+		{IsActive: true, CurrentHandicapIndex: -1.5},
+		{IsActive: true, CurrentHandicapIndex: 6.0},
+		{IsActive: true, CurrentHandicapIndex: 22.0},
+	}
+
+	d, err := ComputeHandicapDistribution(seasonPlayers, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.MedianIndex != 6.0 {
+		t.Errorf("median = %.1f, want 6.0", d.MedianIndex)
+	}
+	want := []DistributionBucket{
+		{Min: -10, Max: 0, Count: 1},
+		{Min: 0, Max: 10, Count: 1},
+		{Min: 10, Max: 20, Count: 0},
+		{Min: 20, Max: 30, Count: 1},
+	}
+	if len(d.Buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d: %+v", len(d.Buckets), len(want), d.Buckets)
+	}
+	for i, b := range d.Buckets {
+		if b != want[i] {
+			t.Errorf("bucket %d = %+v, want %+v", i, b, want[i])
+		}
+	}
+}
+
+func TestComputeHandicapDistributionEmpty(t *testing.T) {
+	d, err := ComputeHandicapDistribution(nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.PlayerCount != 0 || len(d.Buckets) != 0 {
+		t.Errorf("expected empty distribution, got %+v", d)
+	}
+	if d.BucketSize != DefaultDistributionBucketSize {
+		t.Errorf("bucket size = %.1f, want default %.1f", d.BucketSize, DefaultDistributionBucketSize)
+	}
+}
+
+func TestComputeHandicapDistributionTooManyBuckets(t *testing.T) {
+	seasonPlayers := []models.SeasonPlayer{
+		{IsActive: true, CurrentHandicapIndex: 0.0},
+		{IsActive: true, CurrentHandicapIndex: 30.0},
+	}
+
+	// 30 strokes at 0.01 per bucket would span 3001 buckets
+	if _, err := ComputeHandicapDistribution(seasonPlayers, 0.01); !errors.Is(err, ErrTooManyBuckets) {
+		t.Errorf("err = %v, want ErrTooManyBuckets", err)
+	}
+	if _, err := ComputeHandicapDistribution(seasonPlayers, 1); err != nil {
+		t.Errorf("31 buckets should be allowed, got %v", err)
+	}
+}
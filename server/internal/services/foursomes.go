@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"golf-league-manager/internal/models"
+)
+
+// FoursomesHandicapAllowance is the share of each member's course handicap that goes into a
+// foursomes team's combined handicap
+const FoursomesHandicapAllowance = 0.5
+
+// FoursomesTeamHandicap combines the course handicaps of a team's players into the team's
+// playing handicap: FoursomesHandicapAllowance of each, rounded once on the total
+func FoursomesTeamHandicap(courseHandicaps ...float64) int {
+	var total float64
+	for _, ch := range courseHandicaps {
+		total += ch * FoursomesHandicapAllowance
+	}
+	return int(math.Round(total))
+}
+
+// ValidateFoursomesTeams checks that each team is two different players and nobody plays for
+// both teams
+func ValidateFoursomesTeams(teamA, teamB models.TeamScore) error {
+	seen := make(map[string]string, 4)
+	names := []string{"teamA", "teamB"}
+	for t, team := range []models.TeamScore{teamA, teamB} {
+		name := names[t]
+		if len(team.PlayerIDs) != 2 {
+			return fmt.Errorf("%s must have exactly 2 players, got %d", name, len(team.PlayerIDs))
+		}
+		for _, playerID := range team.PlayerIDs {
+			if playerID == "" {
+				return fmt.Errorf("%s has a player without an ID", name)
+			}
+			if other, ok := seen[playerID]; ok {
+				if other == name {
+					return fmt.Errorf("%s lists player %s twice", name, playerID)
+				}
+				return fmt.Errorf("player %s is on both teams", playerID)
+			}
+			seen[playerID] = name
+		}
+	}
+	return nil
+}
+
+// FoursomesStrokes allocates strokes between two foursomes teams from their team handicaps,
+// exactly as between two players: only the higher handicap team receives the difference
+func FoursomesStrokes(teamA, teamB models.TeamScore, course models.Course) (strokesA, strokesB []int) {
+	strokes := AssignStrokes("A", teamA.TeamHandicap, "B", teamB.TeamHandicap, course)
+	return strokes["A"], strokes["B"]
+}
+
+// CalculateFoursomesPoints scores a foursomes match like a singles match, comparing the teams'
+// net hole scores after the strokes from their combined handicaps, with the league's match
+// point values and the season's halved hole rule
+func CalculateFoursomesPoints(teamA, teamB models.TeamScore, course models.Course, season models.Season, rules MatchPointRules) (pointsA, pointsB int, err error) {
+	strokesA, strokesB := FoursomesStrokes(teamA, teamB, course)
+	scoreA := models.Score{HoleScores: teamA.HoleScores}
+	scoreB := models.Score{HoleScores: teamB.HoleScores}
+	return ScoreMatchPointsWithRules(season, rules, scoreA, scoreB, strokesA, strokesB)
+}
+
+// ScoreFoursomesMatch fills in a foursomes match entered with each team's players and hole
+// scores: every player's course handicap on the match's course from their current season
+// index, the teams' combined handicaps and strokes, their gross and net totals, and the points.
+func ScoreFoursomesMatch(match models.FoursomesMatch, course models.Course, league models.League, season models.Season, seasonPlayers map[string]models.SeasonPlayer) (models.FoursomesMatch, error) {
+	if err := ValidateFoursomesTeams(match.TeamA, match.TeamB); err != nil {
+		return models.FoursomesMatch{}, err
+	}
+	for _, team := range []models.TeamScore{match.TeamA, match.TeamB} {
+		if len(team.HoleScores) == 0 {
+			return models.FoursomesMatch{}, fmt.Errorf("hole scores are required for both teams")
+		}
+		if err := ValidateCourseHoles(course, team.HoleScores); err != nil {
+			return models.FoursomesMatch{}, err
+		}
+		for i, sc := range team.HoleScores {
+			if sc <= 0 {
+				return models.FoursomesMatch{}, fmt.Errorf("hole %d score must be positive", i+1)
+			}
+		}
+	}
+
+	teams := []*models.TeamScore{&match.TeamA, &match.TeamB}
+	for _, team := range teams {
+		team.PlayerNames = make([]string, len(team.PlayerIDs))
+		courseHandicaps := make([]float64, len(team.PlayerIDs))
+		for i, playerID := range team.PlayerIDs {
+			sp, ok := seasonPlayers[playerID]
+			if !ok {
+				return models.FoursomesMatch{}, fmt.Errorf("player %s is not in this season", playerID)
+			}
+			team.PlayerNames[i] = sp.PlayerName
+			courseHandicaps[i], _ = CalculateCourseAndPlayingHandicapForLeague(EffectiveHandicapIndex(sp), course, league)
+		}
+		team.TeamHandicap = FoursomesTeamHandicap(courseHandicaps...)
+	}
+
+	match.TeamA.Strokes, match.TeamB.Strokes = FoursomesStrokes(match.TeamA, match.TeamB, course)
+	for _, team := range teams {
+		team.GrossScore, team.MatchNetScore = 0, 0
+		for i, sc := range team.HoleScores {
+			team.GrossScore += sc
+			team.MatchNetScore += sc - team.Strokes[i]
+		}
+	}
+
+	pointsA, pointsB, err := CalculateFoursomesPoints(match.TeamA, match.TeamB, course, season, LeagueMatchPointRules(league))
+	if err != nil {
+		return models.FoursomesMatch{}, err
+	}
+	match.TeamAPoints, match.TeamBPoints = pointsA, pointsB
+	match.CourseID = course.ID
+	match.LeagueID = league.ID
+	match.SeasonID = season.ID
+	return match, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestFoursomesTeamHandicap(t *testing.T) {
+	tests := []struct {
+		name            string
+		courseHandicaps []float64
+		want            int
+	}{
+		{"half of each member, rounded on the total", []float64{10.4, 7.3}, 9},
+		{"scratch team", []float64{0, 0}, 0},
+		{"rounds half up", []float64{12, 3}, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoursomesTeamHandicap(tt.courseHandicaps...); got != tt.want {
+				t.Errorf("FoursomesTeamHandicap(%v) = %d, want %d", tt.courseHandicaps, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoursomesStrokes(t *testing.T) {
+	course := absentPreviewCourse()
+	teamA := models.TeamScore{TeamHandicap: 9}
+	teamB := models.TeamScore{TeamHandicap: 5}
+
+	strokesA, strokesB := FoursomesStrokes(teamA, teamB, course)
+	// The difference of 4 goes to team A on the holes with stroke index 1-4
+	wantA := []int{1, 0, 1, 0, 0, 0, 1, 1, 0}
+	for i := range wantA {
+		if strokesA[i] != wantA[i] || strokesB[i] != 0 {
+			t.Errorf("hole %d: strokes %d-%d, want %d-0", i+1, strokesA[i], strokesB[i], wantA[i])
+		}
+	}
+}
+
+func TestCalculateFoursomesPointsHeadToHead(t *testing.T) {
+	course := absentPreviewCourse()
+	teamA := models.TeamScore{TeamHandicap: 9, HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}}
+	teamB := models.TeamScore{TeamHandicap: 5, HoleScores: []int{4, 3, 5, 5, 4, 3, 5, 4, 4}}
+
+	// Net, team A: 4 4 5 5 5 4 5 4 5 (41); team B: 4 3 5 5 4 3 5 4 4 (37). Five holes halved,
+	// four won by team B, and team B takes the overall points.
+	pointsA, pointsB, err := CalculateFoursomesPoints(teamA, teamB, course, models.Season{}, DefaultMatchPointRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pointsA != 5 || pointsB != 17 {
+		t.Errorf("points = %d-%d, want 5-17", pointsA, pointsB)
+	}
+
+	// Halved holes score nothing under the season's none rule
+	pointsA, pointsB, err = CalculateFoursomesPoints(teamA, teamB, course, models.Season{HalvedHoleRule: HalvedHoleNone}, DefaultMatchPointRules)
+	if err != nil || pointsA != 0 || pointsB != 12 {
+		t.Errorf("no halved points: %d-%d, err = %v, want 0-12", pointsA, pointsB, err)
+	}
+}
+
+func TestScoreFoursomesMatch(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c1"
+	league := models.League{ID: "l1"}
+	season := models.Season{ID: "s1"}
+	seasonPlayers := map[string]models.SeasonPlayer{
+		"p1": {PlayerID: "p1", PlayerName: "Alice", CurrentHandicapIndex: 8.2},
+		"p2": {PlayerID: "p2", PlayerName: "Bob", CurrentHandicapIndex: 14.6},
+		"p3": {PlayerID: "p3", PlayerName: "Cara", CurrentHandicapIndex: 3.1},
+		"p4": {PlayerID: "p4", PlayerName: "Dev", ProvisionalHandicap: 11.0},
+	}
+	match := models.FoursomesMatch{
+		TeamA: models.TeamScore{PlayerIDs: []string{"p1", "p2"}, HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}},
+		TeamB: models.TeamScore{PlayerIDs: []string{"p3", "p4"}, HoleScores: []int{4, 3, 5, 5, 4, 3, 5, 4, 4}},
+	}
+
+	scored, err := ScoreFoursomesMatch(match, course, league, season, seasonPlayers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	courseHandicap := func(playerID string) float64 {
+		ch, _ := CalculateCourseAndPlayingHandicapForLeague(EffectiveHandicapIndex(seasonPlayers[playerID]), course, league)
+		return ch
+	}
+	wantA := FoursomesTeamHandicap(courseHandicap("p1"), courseHandicap("p2"))
+	wantB := FoursomesTeamHandicap(courseHandicap("p3"), courseHandicap("p4"))
+	if scored.TeamA.TeamHandicap != wantA || scored.TeamB.TeamHandicap != wantB {
+		t.Errorf("team handicaps = %d and %d, want %d and %d", scored.TeamA.TeamHandicap, scored.TeamB.TeamHandicap, wantA, wantB)
+	}
+	if sumStrokes(scored.TeamA.Strokes)-sumStrokes(scored.TeamB.Strokes) != wantA-wantB {
+		t.Errorf("strokes %v and %v don't give the handicap difference %d", scored.TeamA.Strokes, scored.TeamB.Strokes, wantA-wantB)
+	}
+	if scored.TeamA.GrossScore != 45 || scored.TeamA.MatchNetScore != 45-sumStrokes(scored.TeamA.Strokes) {
+		t.Errorf("team A gross %d, net %d", scored.TeamA.GrossScore, scored.TeamA.MatchNetScore)
+	}
+	if scored.TeamAPoints+scored.TeamBPoints != 22 {
+		t.Errorf("points = %d-%d, want 22 in total", scored.TeamAPoints, scored.TeamBPoints)
+	}
+	if scored.TeamA.PlayerNames[1] != "Bob" || scored.CourseID != "c1" || scored.SeasonID != "s1" || scored.LeagueID != "l1" {
+		t.Errorf("match not filled in: %+v", scored)
+	}
+}
+
+func TestScoreFoursomesMatchRejectsBadTeams(t *testing.T) {
+	course := absentPreviewCourse()
+	seasonPlayers := map[string]models.SeasonPlayer{"p1": {PlayerID: "p1"}, "p2": {PlayerID: "p2"}, "p3": {PlayerID: "p3"}}
+	scores := []int{4, 3, 5, 4, 4, 3, 5, 4, 4}
+
+	tests := []struct {
+		name  string
+		teamA []string
+		teamB []string
+	}{
+		{"player on both teams", []string{"p1", "p2"}, []string{"p2", "p3"}},
+		{"three players", []string{"p1", "p2", "p3"}, []string{"p4", "p5"}},
+		{"player twice", []string{"p1", "p1"}, []string{"p2", "p3"}},
+		{"not in the season", []string{"p1", "p2"}, []string{"p3", "p9"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := models.FoursomesMatch{
+				TeamA: models.TeamScore{PlayerIDs: tt.teamA, HoleScores: scores},
+				TeamB: models.TeamScore{PlayerIDs: tt.teamB, HoleScores: scores},
+			}
+			if _, err := ScoreFoursomesMatch(match, course, models.League{}, models.Season{}, seasonPlayers); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"slices"
 	"time"
@@ -20,14 +22,128 @@ type Hole struct {
 	StrokeIndex int
 }
 
+// DefaultStandardSlope is the USGA slope rating of a course of standard difficulty
+const DefaultStandardSlope = 113
+
+// Slope ratings allowed by the USGA
+const (
+	MinSlopeRating = 55
+	MaxSlopeRating = 155
+)
+
+// ErrInvalidSlopeRating means a course's slope rating can't be used in handicap formulas
+var ErrInvalidSlopeRating = errors.New("slope rating must be positive")
+
+// CheckSlopeRating reports whether a slope rating is safe to calculate with. Courses saved
+// before slope validation may have been left at 0.
+func CheckSlopeRating(slopeRating int) error {
+	if slopeRating <= 0 {
+		return fmt.Errorf("%w, got %d", ErrInvalidSlopeRating, slopeRating)
+	}
+	return nil
+}
+
+// MaxCourseRatingOffset is how far a course rating may sit above or below the course's par
+const MaxCourseRatingOffset = 10.0
+
+// Provisional handicap bounds from the World Handicap System, used when a league sets none
+const (
+	DefaultMinProvisionalHandicap = -10.0
+	DefaultMaxProvisionalHandicap = 54.0
+)
+
+// ProvisionalHandicapRange returns the lowest and highest provisional handicap the league allows
+func ProvisionalHandicapRange(league models.League) (float64, float64) {
+	minHandicap, maxHandicap := DefaultMinProvisionalHandicap, DefaultMaxProvisionalHandicap
+	if league.MinProvisionalHandicap != nil {
+		minHandicap = *league.MinProvisionalHandicap
+	}
+	if league.MaxProvisionalHandicap != nil {
+		maxHandicap = *league.MaxProvisionalHandicap
+	}
+	return minHandicap, maxHandicap
+}
+
+// ValidateProvisionalHandicap rejects a provisional handicap outside the league's range, since
+// extreme values distort the provisional-weighted index of a player's first rounds
+func ValidateProvisionalHandicap(league models.League, provisional float64) error {
+	minHandicap, maxHandicap := ProvisionalHandicapRange(league)
+	if math.IsNaN(provisional) || provisional < minHandicap || provisional > maxHandicap {
+		return fmt.Errorf("provisional handicap must be between %.1f and %.1f", minHandicap, maxHandicap)
+	}
+	return nil
+}
+
+// StandardSlope returns the baseline slope the league's handicap system is built on
+func StandardSlope(league models.League) int {
+	if league.StandardSlope > 0 {
+		return league.StandardSlope
+	}
+	return DefaultStandardSlope
+}
+
 // CourseHandicap calculates the course handicap from league handicap
 func CourseHandicap(handicapIndex float64, slopeRating int, courseRating float64, par int) float64 {
-	return (handicapIndex * float64(slopeRating) / 113) + (courseRating - float64(par))
+	return CourseHandicapWithSlope(handicapIndex, slopeRating, courseRating, par, DefaultStandardSlope)
+}
+
+// CourseHandicapWithSlope calculates the course handicap against a non-standard baseline slope.
+// A slope that is not positive gives a course handicap of 0 instead of a nonsensical value.
+func CourseHandicapWithSlope(handicapIndex float64, slopeRating int, courseRating float64, par int, standardSlope int) float64 {
+	if CheckSlopeRating(slopeRating) != nil || standardSlope <= 0 {
+		return 0
+	}
+	return (handicapIndex * float64(slopeRating) / float64(standardSlope)) + (courseRating - float64(par))
+}
+
+// Playing handicap rounding modes. They only differ when the value falls exactly on .5.
+const (
+	RoundingNearest = "nearest" // Half away from zero (9.5 → 10, -0.5 → -1)
+	RoundingUp      = "up"      // Half toward positive infinity (9.5 → 10, -0.5 → 0)
+	RoundingDown    = "down"    // Half toward negative infinity (9.5 → 9, -0.5 → -1)
+)
+
+// IsValidRounding reports whether mode is a supported playing handicap rounding mode.
+// An empty mode is accepted and treated as RoundingNearest.
+func IsValidRounding(mode string) bool {
+	switch mode {
+	case "", RoundingNearest, RoundingUp, RoundingDown:
+		return true
+	}
+	return false
 }
 
 // PlayingHandicap calculates the playing handicap from course handicap
 func PlayingHandicap(courseHandicap float64, allowance float64) int {
-	return int(math.Round(courseHandicap * allowance))
+	return PlayingHandicapWithRounding(courseHandicap, allowance, RoundingNearest)
+}
+
+// PlayingHandicapWithRounding calculates the playing handicap from course handicap using
+// the league's rounding mode. Unknown modes fall back to RoundingNearest.
+func PlayingHandicapWithRounding(courseHandicap float64, allowance float64, rounding string) int {
+	value := courseHandicap * allowance
+	switch rounding {
+	case RoundingUp:
+		return int(math.Floor(value + 0.5))
+	case RoundingDown:
+		return int(math.Ceil(value - 0.5))
+	default:
+		return int(math.Round(value))
+	}
+}
+
+// Limits on the strokes a player receives on a single hole
+const (
+	DefaultMaxStrokesPerHole = 3 // Reached on 9 holes at a course handicap of 27
+	MaxStrokesPerHoleLimit   = 5 // Highest per-hole cap a league can configure
+)
+
+// MaxStrokesPerHole returns the most strokes the league gives a player on any one hole
+func MaxStrokesPerHole(league models.League) int {
+	if league.MaxStrokesPerHole > 0 {
+		return league.MaxStrokesPerHole
+	}
+	return DefaultMaxStrokesPerHole
 }
 
 // calculateStrokesForHole calculates the number of strokes a player receives on a specific hole
@@ -36,7 +152,8 @@ func PlayingHandicap(courseHandicap float64, allowance float64) int {
 // - Course handicap 1-9: 1 stroke on holes where stroke index <= handicap
 // - Course handicap 10-18: 1 stroke on all holes, plus 1 extra on holes where stroke index <= (handicap - 9)
 // - Course handicap 19-27: 2 strokes on all holes, plus 1 extra on holes where stroke index <= (handicap - 18)
-func calculateStrokesForHole(courseHandicap int, strokeIndex int, numHoles int) int {
+// No hole receives more than maxStrokes, however high the handicap.
+func calculateStrokesForHole(courseHandicap int, strokeIndex int, numHoles int, maxStrokes int) int {
 	if courseHandicap <= 0 {
 		return 0
 	}
@@ -46,10 +163,11 @@ func calculateStrokesForHole(courseHandicap int, strokeIndex int, numHoles int)
 	remainingStrokes := courseHandicap % numHoles
 
 	// Add an extra stroke if this hole's stroke index qualifies
+	strokes := baseStrokes
 	if strokeIndex <= remainingStrokes {
-		return baseStrokes + 1
+		strokes++
 	}
-	return baseStrokes
+	return min(strokes, maxStrokes)
 }
 
 // AdjustedGrossScoreNetDoubleBogey calculates adjusted gross score using Net Double Bogey rule
@@ -59,7 +177,7 @@ func AdjustedGrossScoreNetDoubleBogey(grossHoleScore []int, holeData []Hole, cou
 	numHoles := len(grossHoleScore)
 
 	for i := 0; i < numHoles; i++ {
-		strokes := calculateStrokesForHole(courseHandicap, holeData[i].StrokeIndex, numHoles)
+		strokes := calculateStrokesForHole(courseHandicap, holeData[i].StrokeIndex, numHoles, DefaultMaxStrokesPerHole)
 		netDoubleBogey := holeData[i].Par + 2 + strokes
 		if grossHoleScore[i] > netDoubleBogey {
 			adjustedGrossScore += netDoubleBogey
@@ -72,7 +190,17 @@ func AdjustedGrossScoreNetDoubleBogey(grossHoleScore []int, holeData []Hole, cou
 
 // ScoreDifferential calculates the score differential
 func ScoreDifferential(adjustedGrossScore int, courseRating float64, slopeRating int) float64 {
-	return (float64(adjustedGrossScore) - courseRating) * 113 / float64(slopeRating)
+	return ScoreDifferentialWithSlope(adjustedGrossScore, courseRating, slopeRating, DefaultStandardSlope)
+}
+
+// ScoreDifferentialWithSlope calculates the score differential against a non-standard baseline slope.
+// A course without a valid slope rating gives a differential of 0 rather than NaN or Inf; callers
+// should check the slope with CheckSlopeRating and skip such rounds.
+func ScoreDifferentialWithSlope(adjustedGrossScore int, courseRating float64, slopeRating int, standardSlope int) float64 {
+	if CheckSlopeRating(slopeRating) != nil {
+		return 0
+	}
+	return (float64(adjustedGrossScore) - courseRating) * float64(standardSlope) / float64(slopeRating)
 }
 
 // Handicap calculates handicap from differentials
@@ -128,6 +256,13 @@ func CalculateDifferential(score models.Score, course models.Course) float64 {
 	return ScoreDifferential(score.AdjustedGross, course.CourseRating, course.SlopeRating)
 }
 
+// CalculateLeagueDifferential calculates the score differential for a round with the
+// league's baseline slope and the playing conditions adjustment for the day.
+// Formula: ((adjusted_gross - course_rating - pcc) * standard_slope) / slope_rating
+func CalculateLeagueDifferential(score models.Score, course models.Course, pcc int, standardSlope int) float64 {
+	return ScoreDifferentialWithSlope(score.AdjustedGross, course.CourseRating+float64(pcc), course.SlopeRating, standardSlope)
+}
+
 // CalculateLeagueHandicap calculates the league handicap from the last 5 scores
 // Uses the best 3 of the last 5 differentials, rounded to 0.1
 // NOTE: This function does NOT incorporate provisional handicap. Use CalculateHandicapWithProvisional
@@ -161,56 +296,178 @@ func CalculateLeagueHandicap(scores []models.Score, courses map[string]models.Co
 	return math.Round(Handicap(differentials, 3, 5)*10) / 10
 }
 
-// CalculateHandicapWithProvisional calculates the league handicap following league rules:
-// This properly incorporates the provisional handicap based on the number of rounds played:
+// Phantom rounds the provisional handicap counts as in a new player's handicap
+const (
+	DefaultProvisionalWeight = 2
+	MaxProvisionalWeight     = 5
+)
+
+// ProvisionalWeight returns how many phantom rounds the league's provisional handicap counts as
+func ProvisionalWeight(league models.League) int {
+	if league.ProvisionalWeight > 0 {
+		return league.ProvisionalWeight
+	}
+	return DefaultProvisionalWeight
+}
+
+// HandicapOptions are a league's settings for averaging differentials into a handicap.
+// Zero values use the defaults.
+type HandicapOptions struct {
+	// ProvisionalWeight is how many phantom rounds the provisional handicap counts as
+	ProvisionalWeight int
+	// DropSchedule gives how many of the best rounds are averaged for each round count
+	DropSchedule []int
+}
+
+// LeagueHandicapOptions returns the league's provisional weight and drop schedule
+func LeagueHandicapOptions(league models.League) HandicapOptions {
+	return HandicapOptions{
+		ProvisionalWeight: ProvisionalWeight(league),
+		DropSchedule:      HandicapDropSchedule(league),
+	}
+}
+
+// CalculateHandicapWithProvisional calculates the league handicap from the differentials of
+// the rounds in a player's window. The provisional handicap counts as opts.ProvisionalWeight
+// phantom rounds and each real round replaces one phantom, so with weight N a single round
+// gives ((N × provisional) + diff₁) / (N + 1). Once the provisional has dropped out, as many
+// of the best differentials are averaged as opts.DropSchedule gives for the number of rounds
+// (see ScoresUsed). With the defaults:
 //   - 0 rounds: Use provisional handicap
 //   - 1 round: ((2 × provisional) + diff₁) / 3
 //   - 2 rounds: (provisional + diff₁ + diff₂) / 3
 //   - 3 rounds: Average of all 3 differentials
-//   - 4 rounds: Average of best 3 differentials (drop 1 worst)
-//   - 5+ rounds: Average of best 3 differentials from last 5 rounds
-func CalculateHandicapWithProvisional(differentials []float64, provisionalHandicap float64) float64 {
+//   - 4+ rounds: Average of best 3 differentials
+func CalculateHandicapWithProvisional(differentials []float64, provisionalHandicap float64, opts HandicapOptions) float64 {
+	weight, schedule := opts.ProvisionalWeight, opts.DropSchedule
+	if weight <= 0 {
+		weight = DefaultProvisionalWeight
+	}
+	if len(schedule) == 0 {
+		schedule = DefaultHandicapDropSchedule
+	}
 	scoreCount := len(differentials)
+	usedCount := ScoresUsed(schedule, scoreCount)
 
 	var leagueHandicap float64
 
-	switch {
+	switch phantoms := weight + 1 - scoreCount; {
 	case scoreCount == 0:
 		// Use provisional handicap
 		leagueHandicap = provisionalHandicap
 
-	case scoreCount == 1:
-		// ((2 × provisional) + diff₁) / 3
-		leagueHandicap = ((2 * provisionalHandicap) + differentials[0]) / 3
-
-	case scoreCount == 2:
-		// (provisional + diff₁ + diff₂) / 3
-		leagueHandicap = (provisionalHandicap + differentials[0] + differentials[1]) / 3
+	case phantoms > 0:
+		// ((phantoms × provisional) + diff₁ + ... + diffₙ) / (phantoms + n)
+		sum := float64(phantoms) * provisionalHandicap
+		for _, diff := range differentials {
+			sum += diff
+		}
+		leagueHandicap = sum / float64(phantoms+scoreCount)
 
-	case scoreCount == 3:
-		// Average all 3 differentials
-		sum := differentials[0] + differentials[1] + differentials[2]
-		leagueHandicap = sum / 3
+	case scoreCount <= usedCount:
+		// Nothing is dropped yet, average all differentials
+		sum := 0.0
+		for _, diff := range differentials {
+			sum += diff
+		}
+		leagueHandicap = sum / float64(scoreCount)
 
-	default: // 4+ rounds
-		// Sort differentials ascending to find best 3
+	default:
+		// Sort differentials ascending to find the best usedCount
 		sortedDiffs := make([]float64, len(differentials))
 		copy(sortedDiffs, differentials)
 		slices.Sort(sortedDiffs)
 
-		// Take best (lowest) 3
-		sum := sortedDiffs[0] + sortedDiffs[1] + sortedDiffs[2]
-		leagueHandicap = sum / 3
+		// Take the best (lowest) usedCount
+		sum := 0.0
+		for _, diff := range sortedDiffs[:usedCount] {
+			sum += diff
+		}
+		leagueHandicap = sum / float64(usedCount)
 	}
 
 	// Round to nearest 0.1
 	return math.Round(leagueHandicap*10) / 10
 }
 
-// CalculateAdjustedGrossScores applies the Net Double Bogey rule for all players
-// All players (including new players with provisional handicaps) use net double bogey
+// UsesHandicaps reports whether the league plays with handicaps. Gross-only leagues give no
+// strokes, record no differentials and keep no handicap index.
+func UsesHandicaps(league models.League) bool {
+	return league.UseHandicaps == nil || *league.UseHandicaps
+}
+
+// MaxHandicapDecay is the steepest per-day recency decay a league can set
+const MaxHandicapDecay = 1.0
+
+// CalculateWeightedHandicap averages differentials with exponential time-decay weights, so
+// newer rounds count more. Each differential is weighted e^(-decay × days), where days is its
+// age relative to the newest round. A decay of zero or less weights every round equally. The
+// result is rounded to the nearest 0.1; no differentials gives 0.
+//
+// This is an experimental alternative to the best-3-of-5 calculation and is only used by
+// leagues that opt in with League.HandicapDecay.
+func CalculateWeightedHandicap(differentials []Differential, decay float64) float64 {
+	if len(differentials) == 0 {
+		return 0
+	}
+	if decay < 0 {
+		decay = 0
+	}
+
+	newest := differentials[0].Timestamp
+	for _, d := range differentials[1:] {
+		if d.Timestamp.After(newest) {
+			newest = d.Timestamp
+		}
+	}
+
+	var weightedSum, totalWeight float64
+	for _, d := range differentials {
+		days := newest.Sub(d.Timestamp).Hours() / 24
+		weight := math.Exp(-decay * days)
+		weightedSum += weight * d.Value
+		totalWeight += weight
+	}
+
+	return math.Round(weightedSum/totalWeight*10) / 10
+}
+
+// UnestablishedMaxOverPar is the most strokes over par counted on a hole for a player without
+// an established handicap in a league using the unestablished adjustment
+const UnestablishedMaxOverPar = 5
+
+// IsEstablished reports whether a player with the given number of rounds posted has an
+// established handicap: enough rounds that their provisional handicap has dropped out of it
+func IsEstablished(league models.League, roundsPosted int) bool {
+	return roundsPosted > ProvisionalWeight(league)
+}
+
+// CalculateAdjustedGrossScoresParCap caps every hole at par plus UnestablishedMaxOverPar, the
+// WHS maximum for players without an established handicap. Unlike net double bogey it doesn't
+// depend on the strokes received, which a provisional handicap can't be trusted for.
+func CalculateAdjustedGrossScoresParCap(grossScores []int, course models.Course) []int {
+	if len(grossScores) != len(course.HolePars) {
+		return grossScores
+	}
+
+	adjustedScores := make([]int, len(grossScores))
+	for i, gross := range grossScores {
+		adjustedScores[i] = min(gross, course.HolePars[i]+UnestablishedMaxOverPar)
+	}
+	return adjustedScores
+}
+
+// CalculateAdjustedGrossScores applies the Net Double Bogey rule with the default limit on
+// strokes per hole. Players without an established handicap are capped with
+// CalculateAdjustedGrossScoresParCap instead in leagues using the unestablished adjustment.
 // Net Double Bogey = Par + 2 + strokes received on that hole (based on course handicap)
 func CalculateAdjustedGrossScores(grossScores []int, course models.Course, courseHandicap int) []int {
+	return CalculateAdjustedGrossScoresWithCap(grossScores, course, courseHandicap, DefaultMaxStrokesPerHole)
+}
+
+// CalculateAdjustedGrossScoresWithCap applies the Net Double Bogey rule with the league's
+// limit on strokes per hole, so very high handicaps still get a sensible maximum hole score
+func CalculateAdjustedGrossScoresWithCap(grossScores []int, course models.Course, courseHandicap int, maxStrokesPerHole int) []int {
 	if len(grossScores) != len(course.HolePars) {
 		return grossScores
 	}
@@ -220,7 +477,7 @@ func CalculateAdjustedGrossScores(grossScores []int, course models.Course, cours
 
 	// Calculate adjusted scores for each hole using net double bogey rule
 	for i := range grossScores {
-		strokes := calculateStrokesForHole(courseHandicap, course.HoleHandicaps[i], numHoles)
+		strokes := calculateStrokesForHole(courseHandicap, course.HoleHandicaps[i], numHoles, maxStrokesPerHole)
 		netDoubleBogey := course.HolePars[i] + 2 + strokes
 		if grossScores[i] > netDoubleBogey {
 			adjustedScores[i] = netDoubleBogey
@@ -232,13 +489,67 @@ func CalculateAdjustedGrossScores(grossScores []int, course models.Course, cours
 	return adjustedScores
 }
 
+// ValidateCourseHoles checks that a course's hole data is usable for scoring the given hole
+// scores. The course must have a par and a handicap ranking for every hole, and non-empty
+// hole scores must cover exactly the course's holes. Without this, net double bogey
+// adjustment would silently be skipped. Pass nil hole scores to check only the course.
+func ValidateCourseHoles(course models.Course, holeScores []int) error {
+	if len(course.HolePars) == 0 {
+		return fmt.Errorf("course %s (%s) has no hole pars", course.Name, course.ID)
+	}
+	if len(course.HoleHandicaps) != len(course.HolePars) {
+		return fmt.Errorf("course %s (%s) has %d hole handicaps but %d hole pars",
+			course.Name, course.ID, len(course.HoleHandicaps), len(course.HolePars))
+	}
+	if len(holeScores) > 0 && len(holeScores) != len(course.HolePars) {
+		return fmt.Errorf("course %s (%s) has %d hole pars but %d hole scores were submitted",
+			course.Name, course.ID, len(course.HolePars), len(holeScores))
+	}
+	return nil
+}
+
+// ValidateCourse checks a course's ratings before it is saved. The slope rating must be in the
+// USGA range and the course rating within MaxCourseRatingOffset strokes of par; a slope left at
+// 0 would otherwise turn every differential on the course into a division by zero. Every hole
+// needs a par and a handicap ranking, or no scorecard could be entered on the course.
+func ValidateCourse(course models.Course) error {
+	if course.Par <= 0 {
+		return fmt.Errorf("par must be positive")
+	}
+	if len(course.HolePars) == 0 {
+		return fmt.Errorf("hole pars are required")
+	}
+	if len(course.HoleHandicaps) != len(course.HolePars) {
+		return fmt.Errorf("got %d hole handicaps for %d hole pars", len(course.HoleHandicaps), len(course.HolePars))
+	}
+	if course.SlopeRating < MinSlopeRating || course.SlopeRating > MaxSlopeRating {
+		return fmt.Errorf("slope rating must be between %d and %d, got %d", MinSlopeRating, MaxSlopeRating, course.SlopeRating)
+	}
+	if math.Abs(course.CourseRating-float64(course.Par)) > MaxCourseRatingOffset {
+		return fmt.Errorf("course rating %.1f must be within %.0f strokes of par %d", course.CourseRating, MaxCourseRatingOffset, course.Par)
+	}
+	return nil
+}
+
 // CalculateCourseAndPlayingHandicap calculates course and playing handicap
 // course_handicap = (league_handicap * slope_rating / 113) + (course_rating - par)
 // playing_handicap = round(course_handicap * 0.95)
 func CalculateCourseAndPlayingHandicap(leagueHC float64, course models.Course) (float64, int) {
-	
-	courseHC := CourseHandicap(leagueHC, course.SlopeRating, course.CourseRating, course.Par)
-	playingHC := PlayingHandicap(courseHC, 0.95)
+	return courseAndPlayingHandicap(leagueHC, course, RoundingNearest, DefaultStandardSlope)
+}
+
+// CalculateCourseAndPlayingHandicapForLeague calculates course and playing handicap with the
+// league's rounding mode and baseline slope. Both are 0 in a gross-only league.
+func CalculateCourseAndPlayingHandicapForLeague(leagueHC float64, course models.Course, league models.League) (float64, int) {
+	if !UsesHandicaps(league) {
+		return 0, 0
+	}
+	return courseAndPlayingHandicap(leagueHC, course, league.PlayingHandicapRounding, StandardSlope(league))
+}
+
+func courseAndPlayingHandicap(leagueHC float64, course models.Course, rounding string, standardSlope int) (float64, int) {
+	courseHC := CourseHandicapWithSlope(leagueHC, course.SlopeRating, course.CourseRating, course.Par, standardSlope)
+	playingHC := PlayingHandicapWithRounding(courseHC, 0.95, rounding)
 	return courseHC, playingHC
 }
 
@@ -249,3 +560,126 @@ func ApplyProvisionalAdjustment(playingHandicap int, matchesPlayed int) int {
 	}
 	return playingHandicap
 }
+
+// Handicap windows: the most recent rounds considered, and how many of the best of those count
+const (
+	DefaultHandicapWindowSize = 5
+	DefaultHandicapUsedCount  = 3
+	MaxHandicapWindowSize     = 20
+)
+
+// HandicapWindow returns how many of a player's most recent rounds the league considers for a
+// handicap and how many of the best of those are averaged. The used count never exceeds the
+// window.
+func HandicapWindow(league models.League) (windowSize, usedCount int) {
+	windowSize = DefaultHandicapWindowSize
+	if league.HandicapWindowSize > 0 {
+		windowSize = league.HandicapWindowSize
+	}
+	usedCount = DefaultHandicapUsedCount
+	if league.HandicapUsedCount > 0 {
+		usedCount = league.HandicapUsedCount
+	}
+	if usedCount > windowSize {
+		usedCount = windowSize
+	}
+	return windowSize, usedCount
+}
+
+// DefaultHandicapDropSchedule lists how many of the best differentials are averaged for 1, 2, 3
+// and 4 or more rounds in a player's window. Every round counts until a player has 3, and from
+// the 4th round on the worst rounds are dropped and the best 3 averaged. The first rounds are
+// normally blended with the provisional handicap instead, see CalculateHandicapWithProvisional.
+var DefaultHandicapDropSchedule = []int{1, 2, 3, 3}
+
+// DropScheduleForUsedCount builds the drop schedule that averages every round up to usedCount
+// rounds and the best usedCount after that. A usedCount of 3 gives DefaultHandicapDropSchedule.
+func DropScheduleForUsedCount(usedCount int) []int {
+	schedule := make([]int, 0, usedCount+1)
+	for rounds := 1; rounds <= usedCount; rounds++ {
+		schedule = append(schedule, rounds)
+	}
+	return append(schedule, usedCount)
+}
+
+// HandicapDropSchedule returns the league's drop schedule. Leagues without one average the
+// best HandicapUsedCount of their window, dropping rounds as soon as there are more.
+func HandicapDropSchedule(league models.League) []int {
+	if len(league.HandicapDropSchedule) > 0 {
+		return league.HandicapDropSchedule
+	}
+	_, usedCount := HandicapWindow(league)
+	return DropScheduleForUsedCount(usedCount)
+}
+
+// ScoresUsed returns how many of the best differentials a drop schedule averages for the
+// number of rounds. Entry i is for i+1 rounds and the last entry applies to any number of
+// rounds beyond the table. The result never exceeds the number of rounds.
+func ScoresUsed(schedule []int, rounds int) int {
+	if rounds <= 0 || len(schedule) == 0 {
+		return 0
+	}
+	used := schedule[len(schedule)-1]
+	if rounds <= len(schedule) {
+		used = schedule[rounds-1]
+	}
+	return min(used, rounds)
+}
+
+// ValidateHandicapDropSchedule checks a league's drop schedule: it needs an entry for at least
+// one round, no more entries than the handicap window, and each entry must use at least one
+// and at most all of the rounds it is for.
+func ValidateHandicapDropSchedule(schedule []int, windowSize int) error {
+	if len(schedule) == 0 || len(schedule) > windowSize {
+		return fmt.Errorf("handicapDropSchedule must have between 1 and %d entries", windowSize)
+	}
+	for i, used := range schedule {
+		if used < 1 || used > i+1 {
+			return fmt.Errorf("handicapDropSchedule entry for %d rounds must be between 1 and %d", i+1, i+1)
+		}
+	}
+	return nil
+}
+
+// DedupeSameDayScores keeps at most one score per calendar day, choosing the round with the
+// lowest differential when a player has several (e.g. a makeup match played the same day).
+// The order of the remaining scores follows the input order.
+func DedupeSameDayScores(scores []models.Score, courses map[string]models.Course) []models.Score {
+	bestByDay := make(map[string]int, len(scores))
+	result := make([]models.Score, 0, len(scores))
+
+	for _, score := range scores {
+		day := score.Date.UTC().Format("2006-01-02")
+		idx, seen := bestByDay[day]
+		if !seen {
+			bestByDay[day] = len(result)
+			result = append(result, score)
+			continue
+		}
+		if scoreDifferential(score, courses) < scoreDifferential(result[idx], courses) {
+			result[idx] = score
+		}
+	}
+
+	return result
+}
+
+// selectHandicapScores picks the scores that count toward a handicap from a player's most
+// recent rounds, collapsing same-day rounds first when the league requires it.
+func selectHandicapScores(scores []models.Score, courses map[string]models.Course, oneRoundPerDay bool, limit int) []models.Score {
+	if oneRoundPerDay {
+		scores = DedupeSameDayScores(scores, courses)
+	}
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+	return scores
+}
+
+// scoreDifferential returns the stored differential for a score, calculating it when missing
+func scoreDifferential(score models.Score, courses map[string]models.Course) float64 {
+	if score.HandicapDifferential != 0 {
+		return score.HandicapDifferential
+	}
+	return CalculateDifferential(score, courses[score.CourseID])
+}
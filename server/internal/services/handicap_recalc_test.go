@@ -160,3 +160,138 @@ func TestHandicapRecalculationAfterRoundEntry(t *testing.T) {
 		t.Errorf("Updated handicap should be non-negative, got %.1f", updatedHandicap)
 	}
 }
+
+func TestSelectHandicapScoresSameDay(t *testing.T) {
+	course := models.Course{ID: "course-1", Par: 36, CourseRating: 35.0, SlopeRating: 113}
+	coursesMap := map[string]models.Course{course.ID: course}
+
+	day := func(n int) time.Time {
+		return time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC).AddDate(0, 0, -7*n)
+	}
+
+	// Most recent first, as returned by GetPlayerScoresForHandicap.
+	// The first match day has a regular match and a makeup match.
+	scores := []models.Score{
+		{ID: "regular", MatchID: "m1", CourseID: course.ID, Date: day(0), AdjustedGross: 44},
+		{ID: "makeup", MatchID: "m2", CourseID: course.ID, Date: day(0).Add(2 * time.Hour), AdjustedGross: 40},
+		{ID: "week-1", CourseID: course.ID, Date: day(1), AdjustedGross: 42},
+		{ID: "week-2", CourseID: course.ID, Date: day(2), AdjustedGross: 43},
+		{ID: "week-3", CourseID: course.ID, Date: day(3), AdjustedGross: 45},
+		{ID: "week-4", CourseID: course.ID, Date: day(4), AdjustedGross: 41},
+	}
+
+	tests := []struct {
+		name           string
+		oneRoundPerDay bool
+		wantIDs        []string
+	}{
+		{
+			name:           "disabled counts both same-day rounds",
+			oneRoundPerDay: false,
+			wantIDs:        []string{"regular", "makeup", "week-1", "week-2", "week-3"},
+		},
+		{
+			name:           "enabled keeps the best same-day round",
+			oneRoundPerDay: true,
+			wantIDs:        []string{"makeup", "week-1", "week-2", "week-3", "week-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectHandicapScores(scores, coursesMap, tt.oneRoundPerDay, DefaultHandicapWindowSize)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("got %d scores, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("position %d: got %s, want %s", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeSeasonPlayerHandicap(t *testing.T) {
+	course := models.Course{ID: "course-1", Par: 36, CourseRating: 35.5, SlopeRating: 113}
+	coursesMap := map[string]models.Course{course.ID: course}
+	seasonPlayer := models.SeasonPlayer{PlayerID: "p1", SeasonID: "s1", ProvisionalHandicap: 12.0, CurrentHandicapIndex: 11.4}
+
+	// Most recent first, as the job reads them; one score has no stored differential yet
+	base := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	scores := []models.Score{
+		{ID: "s5", CourseID: course.ID, Date: base.AddDate(0, 0, 28), HandicapDifferential: 9.8},
+		{ID: "s4", CourseID: course.ID, Date: base.AddDate(0, 0, 21), AdjustedGross: 44},
+		{ID: "s3", CourseID: course.ID, Date: base.AddDate(0, 0, 14), HandicapDifferential: 7.1},
+		{ID: "s2", CourseID: course.ID, Date: base.AddDate(0, 0, 7), HandicapDifferential: 11.6},
+		{ID: "s1", CourseID: course.ID, Date: base, HandicapDifferential: 6.4},
+	}
+
+	recalc := ComputeSeasonPlayerHandicap(seasonPlayer, models.League{}, scores, coursesMap)
+
+	// The same index a full recalculation works out for this player
+	differentials := []float64{9.8, CalculateLeagueDifferential(scores[1], course, 0, StandardSlope(models.League{})), 7.1, 11.6, 6.4}
+	want := CalculateHandicapWithProvisional(differentials, 12.0, HandicapOptions{})
+	if recalc.HandicapIndex != want {
+		t.Errorf("index = %.1f, want %.1f from a full recalculation", recalc.HandicapIndex, want)
+	}
+	if recalc.Method != HandicapMethodBest || recalc.ScoresCounted != 5 || recalc.ScoresUsed != 3 {
+		t.Errorf("breakdown = %s from %d scores using %d, want best 3 of 5", recalc.Method, recalc.ScoresCounted, recalc.ScoresUsed)
+	}
+	if len(recalc.Differentials) != 5 || recalc.Differentials[1] != differentials[1] {
+		t.Errorf("differentials = %v, want %v", recalc.Differentials, differentials)
+	}
+	if recalc.PreviousIndex != 11.4 || recalc.PlayerID != "p1" || recalc.SeasonID != "s1" {
+		t.Errorf("recalc = %+v, want p1 in s1 moving from 11.4", recalc)
+	}
+}
+
+func TestComputeSeasonPlayerHandicapSkippedScores(t *testing.T) {
+	course := models.Course{ID: "course-1", Par: 36, CourseRating: 35.5, SlopeRating: 113}
+	coursesMap := map[string]models.Course{course.ID: course}
+	seasonPlayer := models.SeasonPlayer{PlayerID: "p1", SeasonID: "s1", ProvisionalHandicap: 12.0}
+
+	// The second score has no stored differential and its course is unknown, so it is skipped
+	scores := []models.Score{
+		{ID: "s3", CourseID: course.ID, HandicapDifferential: 9.8},
+		{ID: "s2", CourseID: "missing", AdjustedGross: 44},
+		{ID: "s1", CourseID: course.ID, HandicapDifferential: 6.4},
+	}
+
+	recalc := ComputeSeasonPlayerHandicap(seasonPlayer, models.League{}, scores, coursesMap)
+	if recalc.ScoresCounted != 2 || len(recalc.Differentials) != 2 {
+		t.Errorf("counted %d scores with differentials %v, want the 2 usable rounds", recalc.ScoresCounted, recalc.Differentials)
+	}
+	if want := CalculateHandicapWithProvisional([]float64{9.8, 6.4}, 12.0, HandicapOptions{}); recalc.HandicapIndex != want {
+		t.Errorf("index = %.1f, want %.1f from the usable rounds", recalc.HandicapIndex, want)
+	}
+}
+
+func TestComputeSeasonPlayerHandicapMethods(t *testing.T) {
+	course := models.Course{ID: "course-1", Par: 36, CourseRating: 35.5, SlopeRating: 113}
+	coursesMap := map[string]models.Course{course.ID: course}
+	seasonPlayer := models.SeasonPlayer{PlayerID: "p1", ProvisionalHandicap: 12.0}
+	one := []models.Score{{ID: "s1", CourseID: course.ID, HandicapDifferential: 8.0}}
+	grossOnly := false
+
+	tests := []struct {
+		name   string
+		league models.League
+		scores []models.Score
+		method string
+		index  float64
+	}{
+		{name: "no scores", scores: nil, method: HandicapMethodProvisional, index: 12.0},
+		{name: "provisional blended in", scores: one, method: HandicapMethodBlended, index: CalculateHandicapWithProvisional([]float64{8.0}, 12.0, HandicapOptions{})},
+		{name: "gross-only league", league: models.League{UseHandicaps: &grossOnly}, scores: one, method: HandicapMethodNone, index: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recalc := ComputeSeasonPlayerHandicap(seasonPlayer, tt.league, tt.scores, coursesMap)
+			if recalc.Method != tt.method || recalc.HandicapIndex != tt.index {
+				t.Errorf("got %s %.2f, want %s %.2f", recalc.Method, recalc.HandicapIndex, tt.method, tt.index)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -400,10 +404,486 @@ func TestCalculateHandicapWithProvisional(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := CalculateHandicapWithProvisional(tt.differentials, tt.provisionalHandicap)
+			got := CalculateHandicapWithProvisional(tt.differentials, tt.provisionalHandicap, HandicapOptions{})
 			if math.Abs(got-tt.wantHandicap) > 0.05 {
 				t.Errorf("%s\ngot = %.1f, want = %.1f", tt.description, got, tt.wantHandicap)
 			}
 		})
 	}
 }
+
+func TestPlayingHandicapWithRounding(t *testing.T) {
+	tests := []struct {
+		name           string
+		courseHandicap float64
+		rounding       string
+		want           int
+	}{
+		// 10 * 0.95 = 9.5
+		{name: "nearest at 9.5", courseHandicap: 10, rounding: RoundingNearest, want: 10},
+		{name: "up at 9.5", courseHandicap: 10, rounding: RoundingUp, want: 10},
+		{name: "down at 9.5", courseHandicap: 10, rounding: RoundingDown, want: 9},
+		{name: "empty defaults to nearest", courseHandicap: 10, rounding: "", want: 10},
+		// 30 * 0.95 = 28.5
+		{name: "nearest at 28.5", courseHandicap: 30, rounding: RoundingNearest, want: 29},
+		{name: "up at 28.5", courseHandicap: 30, rounding: RoundingUp, want: 29},
+		{name: "down at 28.5", courseHandicap: 30, rounding: RoundingDown, want: 28},
+		// -10 * 0.95 = -9.5 (plus handicap)
+		{name: "nearest at -9.5", courseHandicap: -10, rounding: RoundingNearest, want: -10},
+		{name: "up at -9.5", courseHandicap: -10, rounding: RoundingUp, want: -9},
+		{name: "down at -9.5", courseHandicap: -10, rounding: RoundingDown, want: -10},
+		// Modes agree away from .5
+		{name: "down below .5", courseHandicap: 12, rounding: RoundingDown, want: 11},
+		{name: "down above .5", courseHandicap: 13, rounding: RoundingDown, want: 12},
+		{name: "up below .5", courseHandicap: 12, rounding: RoundingUp, want: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlayingHandicapWithRounding(tt.courseHandicap, 0.95, tt.rounding)
+			if got != tt.want {
+				t.Errorf("PlayingHandicapWithRounding(%v, 0.95, %q) = %d, want %d",
+					tt.courseHandicap, tt.rounding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateCourseAndPlayingHandicapForLeagueRounding(t *testing.T) {
+	course := models.Course{Par: 36, SlopeRating: 113, CourseRating: 36.0}
+
+	if _, got := CalculateCourseAndPlayingHandicapForLeague(10.0, course, models.League{PlayingHandicapRounding: RoundingDown}); got != 9 {
+		t.Errorf("down: playing handicap = %d, want 9", got)
+	}
+	if _, got := CalculateCourseAndPlayingHandicapForLeague(10.0, course, models.League{PlayingHandicapRounding: RoundingNearest}); got != 10 {
+		t.Errorf("nearest: playing handicap = %d, want 10", got)
+	}
+}
+
+func TestIsValidRounding(t *testing.T) {
+	for _, mode := range []string{"", RoundingNearest, RoundingUp, RoundingDown} {
+		if !IsValidRounding(mode) {
+			t.Errorf("IsValidRounding(%q) = false, want true", mode)
+		}
+	}
+	if IsValidRounding("truncate") {
+		t.Error("IsValidRounding(\"truncate\") = true, want false")
+	}
+}
+
+func TestValidateCourseHoles(t *testing.T) {
+	valid := models.Course{
+		ID:            "c1",
+		Name:          "North Nine",
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4, 4},
+		HoleHandicaps: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+	// Malformed: one par missing
+	shortPars := valid
+	shortPars.HolePars = []int{4, 3, 5, 4, 4, 3, 5, 4}
+	noPars := valid
+	noPars.HolePars = nil
+	nineScores := []int{5, 4, 6, 5, 5, 4, 6, 5, 5}
+
+	tests := []struct {
+		name       string
+		course     models.Course
+		holeScores []int
+		wantErr    bool
+	}{
+		{"matching scores", valid, nineScores, false},
+		{"course only", valid, nil, false},
+		{"too few scores", valid, nineScores[:8], true},
+		{"pars shorter than scores", shortPars, nineScores, true},
+		{"pars shorter than handicaps", shortPars, nil, true},
+		{"no pars", noPars, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCourseHoles(tt.course, tt.holeScores)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCourseHoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.course.ID) {
+				t.Errorf("error %q does not identify course %s", err, tt.course.ID)
+			}
+		})
+	}
+
+	// The malformed course would otherwise be accepted with no adjustment at all
+	blowup := []int{9, 9, 9, 9, 9, 9, 9, 9, 9}
+	adjusted := CalculateAdjustedGrossScores(blowup, shortPars, 0)
+	if adjusted[0] != 9 {
+		t.Fatalf("expected unadjusted scores from malformed course, got %v", adjusted)
+	}
+}
+
+func TestStandardSlope(t *testing.T) {
+	course := models.Course{Par: 36, SlopeRating: 131, CourseRating: 34.7}
+	score := models.Score{AdjustedGross: 49}
+
+	// A league with no baseline configured, or one set to 113, matches the standard formulas
+	for _, league := range []models.League{{}, {StandardSlope: 113}} {
+		slope := StandardSlope(league)
+		if slope != DefaultStandardSlope {
+			t.Fatalf("StandardSlope(%+v) = %d, want %d", league, slope, DefaultStandardSlope)
+		}
+		if got, want := CalculateLeagueDifferential(score, course, 0, slope), CalculateDifferential(score, course); got != want {
+			t.Errorf("differential = %v, want %v", got, want)
+		}
+		gotHC, gotPlaying := CalculateCourseAndPlayingHandicapForLeague(11.7, course, league)
+		wantHC, wantPlaying := CalculateCourseAndPlayingHandicap(11.7, course)
+		if gotHC != wantHC || gotPlaying != wantPlaying {
+			t.Errorf("course/playing handicap = %v/%d, want %v/%d", gotHC, gotPlaying, wantHC, wantPlaying)
+		}
+	}
+
+	// Doubling the baseline doubles the differential and halves the slope adjustment
+	league := models.League{StandardSlope: 226}
+	if got, want := CalculateLeagueDifferential(score, course, 0, StandardSlope(league)), 2*CalculateDifferential(score, course); math.Abs(got-want) > 1e-9 {
+		t.Errorf("differential with baseline 226 = %v, want %v", got, want)
+	}
+	courseHC, _ := CalculateCourseAndPlayingHandicapForLeague(11.7, course, league)
+	// (11.7 * 131 / 226) + (34.7 - 36)
+	if want := 11.7*131/226 - 1.3; math.Abs(courseHC-want) > 1e-9 {
+		t.Errorf("course handicap with baseline 226 = %v, want %v", courseHC, want)
+	}
+}
+
+func TestCalculateAdjustedGrossScoresStrokeCap(t *testing.T) {
+	course := models.Course{
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4, 4},
+		HoleHandicaps: []int{1, 7, 3, 5, 2, 9, 4, 6, 8},
+	}
+	// Blow-up holes everywhere so every hole is capped at net double bogey
+	grossScores := []int{15, 15, 15, 15, 15, 15, 15, 15, 15}
+
+	tests := []struct {
+		name           string
+		courseHandicap int
+		maxStrokes     int
+		want           []int
+	}{
+		{
+			// 28 on 9 holes would give the hardest hole a 4th stroke; the default cap keeps it at 3
+			name:           "28 with default cap",
+			courseHandicap: 28,
+			maxStrokes:     DefaultMaxStrokesPerHole,
+			want:           []int{9, 8, 10, 9, 9, 8, 10, 9, 9},
+		},
+		{
+			name:           "40 with default cap",
+			courseHandicap: 40,
+			maxStrokes:     DefaultMaxStrokesPerHole,
+			want:           []int{9, 8, 10, 9, 9, 8, 10, 9, 9},
+		},
+		{
+			// 40 on 9 holes: 4 strokes everywhere plus a 5th on stroke index 1-4
+			name:           "40 with cap of 5",
+			courseHandicap: 40,
+			maxStrokes:     5,
+			want:           []int{11, 9, 12, 10, 11, 9, 12, 10, 10},
+		},
+		{
+			name:           "40 with cap of 1",
+			courseHandicap: 40,
+			maxStrokes:     1,
+			want:           []int{7, 6, 8, 7, 7, 6, 8, 7, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateAdjustedGrossScoresWithCap(grossScores, course, tt.courseHandicap, tt.maxStrokes)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("hole %d: got %d, want %d", i+1, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	// The uncapped entry point applies the default cap
+	got := CalculateAdjustedGrossScores(grossScores, course, 28)
+	if got[0] != 9 {
+		t.Errorf("hole 1 with course handicap 28: got %d, want 9", got[0])
+	}
+}
+
+func TestMaxStrokesPerHole(t *testing.T) {
+	if got := MaxStrokesPerHole(models.League{}); got != DefaultMaxStrokesPerHole {
+		t.Errorf("unset: got %d, want %d", got, DefaultMaxStrokesPerHole)
+	}
+	if got := MaxStrokesPerHole(models.League{MaxStrokesPerHole: 4}); got != 4 {
+		t.Errorf("configured: got %d, want 4", got)
+	}
+}
+
+func TestValidateProvisionalHandicap(t *testing.T) {
+	defaults := models.League{}
+	for _, tt := range []struct {
+		value   float64
+		wantErr bool
+	}{
+		{-10, false},
+		{54, false},
+		{12.4, false},
+		{-10.1, true},
+		{54.1, true},
+		{-50, true},
+		{200, true},
+	} {
+		if err := ValidateProvisionalHandicap(defaults, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("default range, %.1f: err = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+
+	minHandicap, maxHandicap := 0.0, 36.0
+	custom := models.League{MinProvisionalHandicap: &minHandicap, MaxProvisionalHandicap: &maxHandicap}
+	if err := ValidateProvisionalHandicap(custom, 36); err != nil {
+		t.Errorf("custom range, upper bound: unexpected error %v", err)
+	}
+	if err := ValidateProvisionalHandicap(custom, -1); err == nil {
+		t.Error("custom range, plus handicap: expected error")
+	}
+	if err := ValidateProvisionalHandicap(custom, 40); err == nil {
+		t.Error("custom range, 40: expected error")
+	}
+}
+
+func TestCalculateHandicapProvisionalWeight(t *testing.T) {
+	provisional := 15.0
+	tests := []struct {
+		name          string
+		differentials []float64
+		weight        int
+		want          float64
+	}{
+		// Weight 2 is the default
+		{"weight 2, no rounds", nil, 2, 15.0},
+		{"weight 2, one round", []float64{12.0}, 2, 14.0},               // (2×15 + 12) / 3
+		{"weight 2, two rounds", []float64{12.0, 9.0}, 2, 12.0},         // (15 + 12 + 9) / 3
+		{"weight 2, three rounds", []float64{12.0, 9.0, 10.5}, 2, 10.5}, // (12 + 9 + 10.5) / 3
+		// Weight 3 keeps the provisional one round longer
+		{"weight 3, one round", []float64{11.0}, 3, 14.0},                    // (3×15 + 11) / 4
+		{"weight 3, two rounds", []float64{11.0, 9.0}, 3, 12.5},              // (2×15 + 11 + 9) / 4
+		{"weight 3, three rounds", []float64{11.0, 9.0, 13.0}, 3, 12.0},      // (15 + 11 + 9 + 13) / 4
+		{"weight 3, four rounds", []float64{11.0, 9.0, 13.0, 10.0}, 3, 10.0}, // best 3: (9 + 10 + 11) / 3
+		{"unset weight uses default", []float64{12.0}, 0, 14.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateHandicapWithProvisional(tt.differentials, provisional, HandicapOptions{ProvisionalWeight: tt.weight})
+			if got != tt.want {
+				t.Errorf("got %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateWeightedHandicap(t *testing.T) {
+	week := func(n int) time.Time {
+		return time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n)
+	}
+	// The player has improved steadily, so the newest rounds are the lowest
+	differentials := []Differential{
+		{Value: 20.0, Timestamp: week(0)},
+		{Value: 18.0, Timestamp: week(1)},
+		{Value: 16.0, Timestamp: week(2)},
+		{Value: 14.0, Timestamp: week(3)},
+		{Value: 12.0, Timestamp: week(4)},
+	}
+
+	undecayed := CalculateWeightedHandicap(differentials, 0)
+	if undecayed != 16.0 {
+		t.Errorf("no decay = %.1f, want the plain average 16.0", undecayed)
+	}
+
+	decayed := CalculateWeightedHandicap(differentials, 0.05)
+	if !(decayed < undecayed) {
+		t.Errorf("decayed = %.1f, want it below the undecayed %.1f as recent rounds count more", decayed, undecayed)
+	}
+	// Weights e^(-0.35k) for k weeks old: (12 + 14w + 16w² + 18w³ + 20w⁴) / (1 + w + w² + w³ + w⁴)
+	if decayed != 14.7 {
+		t.Errorf("decayed = %.1f, want 14.7", decayed)
+	}
+
+	// Order of the input does not matter; ages are measured from the newest round
+	reversed := []Differential{differentials[4], differentials[3], differentials[2], differentials[1], differentials[0]}
+	if got := CalculateWeightedHandicap(reversed, 0.05); got != decayed {
+		t.Errorf("reversed input = %.1f, want %.1f", got, decayed)
+	}
+
+	if got := CalculateWeightedHandicap(nil, 0.05); got != 0 {
+		t.Errorf("no rounds = %.1f, want 0", got)
+	}
+}
+
+func TestHandicapWindowBestFourOfEight(t *testing.T) {
+	league := models.League{HandicapWindowSize: 8, HandicapUsedCount: 4}
+	windowSize, usedCount := HandicapWindow(league)
+	if windowSize != 8 || usedCount != 4 {
+		t.Fatalf("window = %d/%d, want best 4 of 8", usedCount, windowSize)
+	}
+	if w, u := HandicapWindow(models.League{}); w != DefaultHandicapWindowSize || u != DefaultHandicapUsedCount {
+		t.Errorf("default window = %d/%d, want %d/%d", u, w, DefaultHandicapUsedCount, DefaultHandicapWindowSize)
+	}
+	if _, u := HandicapWindow(models.League{HandicapWindowSize: 2}); u != 2 {
+		t.Errorf("used count = %d, want it capped to the window of 2", u)
+	}
+
+	// Ten rounds, newest first; the two oldest are the best but fall outside the window
+	day := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	values := []float64{14.0, 10.0, 16.0, 12.0, 18.0, 11.0, 20.0, 13.0, 2.0, 3.0}
+	scores := make([]models.Score, len(values))
+	for i, v := range values {
+		scores[i] = models.Score{ID: fmt.Sprintf("s%d", i), Date: day.AddDate(0, 0, -7*i), HandicapDifferential: v}
+	}
+
+	selected := selectHandicapScores(scores, nil, false, windowSize)
+	if len(selected) != 8 || selected[7].ID != "s7" {
+		t.Fatalf("selected %d scores ending at %s, want the 8 most recent", len(selected), selected[len(selected)-1].ID)
+	}
+
+	differentials := make([]float64, len(selected))
+	for i, s := range selected {
+		differentials[i] = s.HandicapDifferential
+	}
+
+	// Best 4 of the last 8: 10, 11, 12, 13
+	if got := CalculateHandicapWithProvisional(differentials, 20.0, HandicapOptions{DropSchedule: DropScheduleForUsedCount(usedCount)}); got != 11.5 {
+		t.Errorf("best 4 of 8 = %.1f, want 11.5", got)
+	}
+	// The standard best 3 of the same window: 10, 11, 12
+	if got := CalculateHandicapWithProvisional(differentials, 20.0, HandicapOptions{}); got != 11.0 {
+		t.Errorf("best 3 of 8 = %.1f, want 11.0", got)
+	}
+	// With no more rounds than the used count, every round is averaged
+	if got := CalculateHandicapWithProvisional(differentials[:4], 20.0, HandicapOptions{DropSchedule: DropScheduleForUsedCount(usedCount)}); got != 13.0 {
+		t.Errorf("4 rounds = %.1f, want the average 13.0", got)
+	}
+}
+
+func TestHandicapDropSchedule(t *testing.T) {
+	// Differentials in the order the rounds were played, with a provisional of 10.0
+	played := []float64{12.0, 8.0, 15.0, 10.0, 20.0, 9.0, 14.0, 11.0, 7.0, 16.0}
+
+	tests := []struct {
+		rounds int
+		used   int
+		want   float64
+		why    string
+	}{
+		{1, 1, 10.7, "(2 × 10.0 + 12) / 3, blended with the provisional"},
+		{2, 2, 10.0, "(10.0 + 12 + 8) / 3, blended with the provisional"},
+		{3, 3, 11.7, "all 3: (12 + 8 + 15) / 3"},
+		{4, 3, 10.0, "best 3 of 4, 15 dropped: (8 + 10 + 12) / 3"},
+		{5, 3, 10.0, "best 3 of 5: (8 + 10 + 12) / 3"},
+		{6, 3, 9.0, "best 3 of 6: (8 + 9 + 10) / 3"},
+		{7, 3, 9.0, "best 3 of 7: (8 + 9 + 10) / 3"},
+		{8, 3, 9.0, "best 3 of 8: (8 + 9 + 10) / 3"},
+		{9, 3, 8.0, "best 3 of 9: (7 + 8 + 9) / 3"},
+		{10, 3, 8.0, "best 3 of 10: (7 + 8 + 9) / 3"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d rounds", tt.rounds), func(t *testing.T) {
+			if used := ScoresUsed(DefaultHandicapDropSchedule, tt.rounds); used != tt.used {
+				t.Errorf("ScoresUsed() = %d, want %d", used, tt.used)
+			}
+			differentials := played[:tt.rounds]
+			if got := CalculateHandicapWithProvisional(differentials, 10.0, HandicapOptions{}); got != tt.want {
+				t.Errorf("handicap = %.1f, want %.1f: %s", got, tt.want, tt.why)
+			}
+		})
+	}
+
+	// A league that counts every round until it has 5
+	noEarlyDrops := []int{1, 2, 3, 4, 3}
+	if err := ValidateHandicapDropSchedule(noEarlyDrops, DefaultHandicapWindowSize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := CalculateHandicapWithProvisional(played[:4], 10.0, HandicapOptions{DropSchedule: noEarlyDrops}); got != 11.3 {
+		t.Errorf("4 rounds with no drops = %.1f, want (12 + 8 + 15 + 10) / 4 = 11.3", got)
+	}
+	if got := CalculateHandicapWithProvisional(played[:5], 10.0, HandicapOptions{DropSchedule: noEarlyDrops}); got != 10.0 {
+		t.Errorf("5 rounds with no early drops = %.1f, want the best 3 = 10.0", got)
+	}
+
+	if got := HandicapDropSchedule(models.League{}); !slices.Equal(got, DefaultHandicapDropSchedule) {
+		t.Errorf("unset league schedule = %v, want %v", got, DefaultHandicapDropSchedule)
+	}
+	if got := HandicapDropSchedule(models.League{HandicapWindowSize: 8, HandicapUsedCount: 4}); !slices.Equal(got, []int{1, 2, 3, 4, 4}) {
+		t.Errorf("best 4 of 8 schedule = %v, want [1 2 3 4 4]", got)
+	}
+	for _, bad := range [][]int{{}, {2}, {1, 2, 0}, {1, 2, 3, 3, 3, 3}} {
+		if err := ValidateHandicapDropSchedule(bad, DefaultHandicapWindowSize); err == nil {
+			t.Errorf("%v: expected an error", bad)
+		}
+	}
+}
+
+func TestValidateCourseSlope(t *testing.T) {
+	course := absentPreviewCourse()
+	if err := ValidateCourse(course); err != nil {
+		t.Fatalf("valid course: unexpected error %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*models.Course)
+	}{
+		{"slope left unset", func(c *models.Course) { c.SlopeRating = 0 }},
+		{"slope below range", func(c *models.Course) { c.SlopeRating = MinSlopeRating - 1 }},
+		{"slope above range", func(c *models.Course) { c.SlopeRating = MaxSlopeRating + 1 }},
+		{"rating far above par", func(c *models.Course) { c.CourseRating = 72.0 }},
+		{"no par", func(c *models.Course) { c.Par = 0 }},
+		{"no hole pars", func(c *models.Course) { c.HolePars, c.HoleHandicaps = nil, nil }},
+		{"missing hole handicaps", func(c *models.Course) { c.HoleHandicaps = c.HoleHandicaps[:8] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := absentPreviewCourse()
+			tt.modify(&bad)
+			if err := ValidateCourse(bad); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestScoreDifferentialZeroSlope(t *testing.T) {
+	course := models.Course{CourseRating: 35.0, SlopeRating: 0}
+	got := CalculateDifferential(models.Score{AdjustedGross: 45}, course)
+	if math.IsNaN(got) || math.IsInf(got, 0) || got != 0 {
+		t.Errorf("differential with a zero slope = %v, want 0", got)
+	}
+}
+
+func TestInvalidSlopeGuards(t *testing.T) {
+	for _, slope := range []int{0, -113} {
+		t.Run(fmt.Sprintf("slope %d", slope), func(t *testing.T) {
+			if err := CheckSlopeRating(slope); !errors.Is(err, ErrInvalidSlopeRating) {
+				t.Errorf("CheckSlopeRating() = %v, want ErrInvalidSlopeRating", err)
+			}
+
+			diff := ScoreDifferentialWithSlope(45, 35.0, slope, DefaultStandardSlope)
+			if math.IsNaN(diff) || math.IsInf(diff, 0) || diff != 0 {
+				t.Errorf("differential = %v, want 0", diff)
+			}
+
+			courseHC := CourseHandicap(12.0, slope, 35.5, 36)
+			if math.IsNaN(courseHC) || math.IsInf(courseHC, 0) || courseHC != 0 {
+				t.Errorf("course handicap = %v, want 0", courseHC)
+			}
+		})
+	}
+
+	if courseHC := CourseHandicapWithSlope(12.0, 125, 35.5, 36, 0); courseHC != 0 {
+		t.Errorf("course handicap against a zero standard slope = %v, want 0", courseHC)
+	}
+	if err := CheckSlopeRating(113); err != nil {
+		t.Errorf("CheckSlopeRating(113) = %v, want nil", err)
+	}
+}
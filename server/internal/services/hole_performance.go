@@ -0,0 +1,124 @@
+package services
+
+import (
+	"math"
+
+	"golf-league-manager/internal/models"
+)
+
+// HolePerformance is how a player has fared on one hole of a course in match play
+type HolePerformance struct {
+	Hole      int     `json:"hole"`
+	Played    int     `json:"played"`
+	Won       int     `json:"won"`
+	Lost      int     `json:"lost"`
+	Halved    int     `json:"halved"`
+	WinRate   float64 `json:"winRate"` // Share of the matches played on the hole, 0 to 1
+	LossRate  float64 `json:"lossRate"`
+	HalveRate float64 `json:"halveRate"`
+}
+
+// CourseHolePerformance is a player's hole-by-hole match record on a course. The strongest
+// hole has the best record of wins less losses and the weakest the worst; both are 0 before
+// any match has been played.
+type CourseHolePerformance struct {
+	PlayerID      string            `json:"playerId"`
+	CourseID      string            `json:"courseId"`
+	Matches       int               `json:"matches"`
+	Holes         []HolePerformance `json:"holes"`
+	StrongestHole int               `json:"strongestHole"`
+	WeakestHole   int               `json:"weakestHole"`
+}
+
+// ComputeHolePerformance tallies who won each hole on net across a player's completed matches
+// on a course. scores holds the scorecards of both players in those matches; matches missing
+// either scorecard, disputed matches and absent players' matches are skipped. Holes are
+// decided the way the match was scored, from each scorecard's match strokes and concessions.
+func ComputeHolePerformance(matches []models.Match, scores []models.Score, playerID, courseID string) CourseHolePerformance {
+	scoresByMatch := make(map[string]map[string]models.Score)
+	for _, score := range scores {
+		if scoresByMatch[score.MatchID] == nil {
+			scoresByMatch[score.MatchID] = make(map[string]models.Score)
+		}
+		scoresByMatch[score.MatchID][score.PlayerID] = score
+	}
+
+	perf := CourseHolePerformance{PlayerID: playerID, CourseID: courseID, Holes: make([]HolePerformance, 0)}
+	for _, match := range matches {
+		if match.CourseID != courseID || match.Status != "completed" || match.Disputed || match.PlayerAAbsent || match.PlayerBAbsent {
+			continue
+		}
+		var opponentID string
+		switch playerID {
+		case match.PlayerAID:
+			opponentID = match.PlayerBID
+		case match.PlayerBID:
+			opponentID = match.PlayerAID
+		default:
+			continue
+		}
+		mine, ok := scoresByMatch[match.ID][playerID]
+		if !ok {
+			continue
+		}
+		theirs, ok := scoresByMatch[match.ID][opponentID]
+		if !ok || len(mine.HoleScores) != len(theirs.HoleScores) {
+			continue
+		}
+
+		perf.Matches++
+		concededToMe := concededHoleSet(mine.ConcededHoles)
+		concededToThem := concededHoleSet(theirs.ConcededHoles)
+		for i := range mine.HoleScores {
+			for len(perf.Holes) <= i {
+				perf.Holes = append(perf.Holes, HolePerformance{Hole: len(perf.Holes) + 1})
+			}
+			hole := &perf.Holes[i]
+			hole.Played++
+
+			myNet := mine.HoleScores[i] - strokeOn(mine.MatchStrokes, i)
+			theirNet := theirs.HoleScores[i] - strokeOn(theirs.MatchStrokes, i)
+			won, lost := holeWinner(myNet, theirNet, concededToMe[i], concededToThem[i])
+			switch {
+			case won:
+				hole.Won++
+			case lost:
+				hole.Lost++
+			default:
+				hole.Halved++
+			}
+		}
+	}
+
+	best, worst := 0, 0
+	for i := range perf.Holes {
+		hole := &perf.Holes[i]
+		hole.WinRate = holeRate(hole.Won, hole.Played)
+		hole.LossRate = holeRate(hole.Lost, hole.Played)
+		hole.HalveRate = holeRate(hole.Halved, hole.Played)
+		if holeMargin(*hole) > holeMargin(perf.Holes[best]) {
+			best = i
+		}
+		if holeMargin(*hole) < holeMargin(perf.Holes[worst]) {
+			worst = i
+		}
+	}
+	if len(perf.Holes) > 0 {
+		perf.StrongestHole = best + 1
+		perf.WeakestHole = worst + 1
+	}
+	return perf
+}
+
+// holeMargin is a hole's win rate less its loss rate
+func holeMargin(hole HolePerformance) float64 {
+	return hole.WinRate - hole.LossRate
+}
+
+// holeRate is count as a share of played, rounded to three places
+func holeRate(count, played int) float64 {
+	if played == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(played)*1000) / 1000
+}
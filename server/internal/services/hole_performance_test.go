@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComputeHolePerformance(t *testing.T) {
+	matches := []models.Match{
+		{ID: "m1", CourseID: "c1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"},
+		{ID: "m2", CourseID: "c1", PlayerAID: "p3", PlayerBID: "p1", Status: "completed"},
+		{ID: "m3", CourseID: "c2", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"},
+		{ID: "m4", CourseID: "c1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", Disputed: true},
+	}
+	scores := []models.Score{
+		// m1: p1 wins the first hole, halves the second and loses the third
+		{MatchID: "m1", PlayerID: "p1", HoleScores: []int{4, 3, 5}},
+		{MatchID: "m1", PlayerID: "p2", HoleScores: []int{5, 3, 4}},
+		// m2: p3's stroke on the first hole wins it; p1 wins the second and loses the third
+		{MatchID: "m2", PlayerID: "p3", HoleScores: []int{4, 4, 5}, MatchStrokes: []int{1, 0, 0}},
+		{MatchID: "m2", PlayerID: "p1", HoleScores: []int{4, 3, 6}},
+		{MatchID: "m3", PlayerID: "p1", HoleScores: []int{3, 3, 3}},
+		{MatchID: "m3", PlayerID: "p2", HoleScores: []int{6, 6, 6}},
+		{MatchID: "m4", PlayerID: "p1", HoleScores: []int{3, 3, 3}},
+		{MatchID: "m4", PlayerID: "p2", HoleScores: []int{6, 6, 6}},
+	}
+
+	perf := ComputeHolePerformance(matches, scores, "p1", "c1")
+	if perf.Matches != 2 || len(perf.Holes) != 3 {
+		t.Fatalf("got %d matches and %d holes, want 2 and 3", perf.Matches, len(perf.Holes))
+	}
+
+	want := []HolePerformance{
+		{Hole: 1, Played: 2, Won: 1, Lost: 1, WinRate: 0.5, LossRate: 0.5},
+		{Hole: 2, Played: 2, Won: 1, Halved: 1, WinRate: 0.5, HalveRate: 0.5},
+		{Hole: 3, Played: 2, Lost: 2, LossRate: 1},
+	}
+	for i := range want {
+		if perf.Holes[i] != want[i] {
+			t.Errorf("hole %d = %+v, want %+v", i+1, perf.Holes[i], want[i])
+		}
+	}
+	if perf.StrongestHole != 2 || perf.WeakestHole != 3 {
+		t.Errorf("strongest %d, weakest %d, want 2 and 3", perf.StrongestHole, perf.WeakestHole)
+	}
+}
+
+func TestComputeHolePerformanceNoMatches(t *testing.T) {
+	perf := ComputeHolePerformance(nil, nil, "p1", "c1")
+	if perf.Matches != 0 || len(perf.Holes) != 0 || perf.StrongestHole != 0 {
+		t.Errorf("perf = %+v, want an empty record", perf)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+const (
+	icalLineEnding = "\r\n"
+	// icalMaxLineLength is the longest content line allowed before folding (RFC 5545 3.1)
+	icalMaxLineLength = 75
+	icalDateFormat    = "20060102"
+	icalStampFormat   = "20060102T150405Z"
+)
+
+// BuildICS renders a player's upcoming matches as an iCalendar feed.
+// Each match not yet completed becomes an all-day VEVENT naming the opponent and course.
+// Opponent and course names are resolved the same way as BuildRemainingMatches.
+// generatedAt is used for the DTSTAMP of every event.
+func BuildICS(matches []models.Match, playerID string, players map[string]models.Player, courses map[string]models.Course, generatedAt time.Time) string {
+	var b strings.Builder
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Golf League Manager//Schedule//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+
+	stamp := generatedAt.UTC().Format(icalStampFormat)
+	for _, match := range BuildRemainingMatches(playerID, matches, players, courses) {
+		opponent := match.OpponentName
+		if opponent == "" {
+			opponent = "TBD"
+		}
+		date := match.MatchDate.UTC()
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:%s@golf-league-manager", match.MatchID))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART;VALUE=DATE:"+date.Format(icalDateFormat))
+		writeICSLine(&b, "DTEND;VALUE=DATE:"+date.AddDate(0, 0, 1).Format(icalDateFormat))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText("League match vs "+opponent))
+		if match.CourseName != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(match.CourseName))
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICSText escapes a TEXT property value (RFC 5545 3.3.11)
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeICSLine writes a content line, folding it onto continuation lines when it exceeds
+// the maximum length. Folds never split a multi-byte character.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icalMaxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString(icalLineEnding)
+		b.WriteString(" ")
+		line = line[cut:]
+		// Continuation lines start with a space that counts toward the limit
+		limit = icalMaxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString(icalLineEnding)
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildICS(t *testing.T) {
+	generatedAt := time.Date(2025, 4, 20, 15, 30, 0, 0, time.UTC)
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", CourseID: "c1", MatchDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Status: "scheduled"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p1", CourseID: "c2", MatchDate: time.Date(2025, 5, 8, 0, 0, 0, 0, time.UTC), Status: "scheduled"},
+		// Completed matches are left out of the feed
+		{ID: "m0", PlayerAID: "p1", PlayerBID: "p3", CourseID: "c1", MatchDate: time.Date(2025, 4, 24, 0, 0, 0, 0, time.UTC), Status: "completed"},
+	}
+	players := map[string]models.Player{
+		"p2": {ID: "p2", Name: "Smith, Bob"},
+		"p3": {ID: "p3", Name: "Carl"},
+	}
+	courses := map[string]models.Course{
+		"c1": {ID: "c1", Name: "Pines; North\\Back Nine"},
+		"c2": {ID: "c2", Name: "Lakeside"},
+	}
+
+	ics := BuildICS(matches, "p1", players, courses, generatedAt)
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") {
+		t.Errorf("feed does not start with a VCALENDAR header:\n%s", ics)
+	}
+	if !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("feed does not end with END:VCALENDAR:\n%s", ics)
+	}
+	if strings.Contains(strings.ReplaceAll(ics, "\r\n", ""), "\n") {
+		t.Error("feed contains bare LF line endings")
+	}
+
+	if got := strings.Count(ics, "BEGIN:VEVENT\r\n"); got != 2 {
+		t.Fatalf("got %d VEVENTs, want 2", got)
+	}
+	if got := strings.Count(ics, "END:VEVENT\r\n"); got != 2 {
+		t.Fatalf("got %d END:VEVENT lines, want 2", got)
+	}
+	if strings.Contains(ics, "UID:m0@") {
+		t.Error("completed match should not be in the feed")
+	}
+
+	wantLines := []string{
+		"UID:m1@golf-league-manager",
+		"DTSTAMP:20250420T153000Z",
+		"DTSTART;VALUE=DATE:20250501",
+		"DTEND;VALUE=DATE:20250502",
+		`SUMMARY:League match vs Smith\, Bob`,
+		`LOCATION:Pines\; North\\Back Nine`,
+		"UID:m2@golf-league-manager",
+		"DTSTART;VALUE=DATE:20250508",
+		"SUMMARY:League match vs Carl",
+		"LOCATION:Lakeside",
+	}
+	for _, line := range wantLines {
+		if !strings.Contains(ics, line+"\r\n") {
+			t.Errorf("feed missing line %q:\n%s", line, ics)
+		}
+	}
+
+	// Events are ordered by date
+	if strings.Index(ics, "UID:m1@") > strings.Index(ics, "UID:m2@") {
+		t.Error("events are not ordered by match date")
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Plain", "Plain"},
+		{"A, B", `A\, B`},
+		{"A; B", `A\; B`},
+		{`A\B`, `A\\B`},
+		{"Line one\nLine two", `Line one\nLine two`},
+		{"Line one\r\nLine two", `Line one\nLine two`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeICSText(tt.in); got != tt.want {
+			t.Errorf("escapeICSText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteICSLineFolding(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	writeICSLine(&b, line)
+
+	folded := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(folded) < 2 {
+		t.Fatalf("expected line to be folded, got %q", b.String())
+	}
+
+	var unfolded strings.Builder
+	for i, part := range folded {
+		if len(part) > icalMaxLineLength {
+			t.Errorf("folded line %d is %d octets, want at most %d", i, len(part), icalMaxLineLength)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(part, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			part = part[1:]
+		}
+		unfolded.WriteString(part)
+	}
+	if unfolded.String() != line {
+		t.Errorf("unfolded line = %q, want %q", unfolded.String(), line)
+	}
+}
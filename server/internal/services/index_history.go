@@ -0,0 +1,33 @@
+package services
+
+import (
+	"golf-league-manager/internal/models"
+)
+
+// AppendIndexHistory adds a point to a season player's index history. Recalculating after a
+// match day that is already in the history (for example when its scores are corrected)
+// replaces that day's point in place rather than adding another. The history keeps at most
+// limit points, dropping the oldest, so it never outgrows the season's schedule; a limit of
+// zero or less keeps only the newest point.
+func AppendIndexHistory(history []models.IndexPoint, point models.IndexPoint, limit int) []models.IndexPoint {
+	updated := make([]models.IndexPoint, len(history), len(history)+1)
+	copy(updated, history)
+
+	replaced := false
+	for i := range updated {
+		if updated[i].MatchDayID == point.MatchDayID {
+			updated[i] = point
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		updated = append(updated, point)
+	}
+
+	limit = max(limit, 1)
+	if len(updated) > limit {
+		updated = updated[len(updated)-limit:]
+	}
+	return updated
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func historyIDs(history []models.IndexPoint) []string {
+	ids := make([]string, len(history))
+	for i, p := range history {
+		ids[i] = p.MatchDayID
+	}
+	return ids
+}
+
+func TestAppendIndexHistoryAppendsEachRecalc(t *testing.T) {
+	start := time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	var history []models.IndexPoint
+	for i, index := range []float64{14.0, 13.6, 13.9} {
+		history = AppendIndexHistory(history, models.IndexPoint{
+			Date:          start.AddDate(0, 0, 7*i),
+			MatchDayID:    []string{"md1", "md2", "md3"}[i],
+			HandicapIndex: index,
+		}, 10)
+	}
+
+	if !equalIDs(historyIDs(history), []string{"md1", "md2", "md3"}) {
+		t.Fatalf("history = %v, want [md1 md2 md3]", historyIDs(history))
+	}
+	if history[2].HandicapIndex != 13.9 {
+		t.Errorf("latest index = %.1f, want 13.9", history[2].HandicapIndex)
+	}
+}
+
+func TestAppendIndexHistoryReplacesSameMatchDay(t *testing.T) {
+	history := []models.IndexPoint{
+		{MatchDayID: "md1", HandicapIndex: 14.0},
+		{MatchDayID: "md2", HandicapIndex: 13.6},
+	}
+
+	// Correcting md1's scores updates its point without reordering the history
+	history = AppendIndexHistory(history, models.IndexPoint{MatchDayID: "md1", HandicapIndex: 14.2}, 10)
+
+	if !equalIDs(historyIDs(history), []string{"md1", "md2"}) {
+		t.Fatalf("history = %v, want [md1 md2]", historyIDs(history))
+	}
+	if history[0].HandicapIndex != 14.2 {
+		t.Errorf("md1 index = %.1f, want 14.2", history[0].HandicapIndex)
+	}
+}
+
+func TestAppendIndexHistoryCapsAtLimit(t *testing.T) {
+	history := []models.IndexPoint{
+		{MatchDayID: "md1"},
+		{MatchDayID: "md2"},
+		{MatchDayID: "md3"},
+	}
+
+	history = AppendIndexHistory(history, models.IndexPoint{MatchDayID: "md4"}, 3)
+	if !equalIDs(historyIDs(history), []string{"md2", "md3", "md4"}) {
+		t.Errorf("history = %v, want oldest dropped: [md2 md3 md4]", historyIDs(history))
+	}
+
+	history = AppendIndexHistory(history, models.IndexPoint{MatchDayID: "md5"}, 0)
+	if !equalIDs(historyIDs(history), []string{"md5"}) {
+		t.Errorf("history = %v, want only the newest point with no limit", historyIDs(history))
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// IntegrityData is everything stored for one league that the integrity check looks at.
+// Players is the global player list, since players are not scoped to a league. Scores is
+// only read by CheckIntegrity; large leagues stream their scores through IntegrityChecker.
+type IntegrityData struct {
+	Scores        []models.Score
+	Matches       []models.Match
+	Courses       []models.Course
+	Players       []models.Player
+	Seasons       []models.Season
+	SeasonPlayers []models.SeasonPlayer
+	MatchDays     []models.MatchDay
+}
+
+// IntegrityIssue is a single record that points at something that does not exist
+type IntegrityIssue struct {
+	RecordID string `json:"recordId"`
+	Detail   string `json:"detail"`
+}
+
+// IntegrityReport lists the broken records found in a league, grouped by kind of problem
+type IntegrityReport struct {
+	LeagueID                   string           `json:"leagueId"`
+	ScoresMissingMatch         []IntegrityIssue `json:"scoresMissingMatch"`
+	ScoresMissingCourse        []IntegrityIssue `json:"scoresMissingCourse"`
+	MatchesMissingPlayer       []IntegrityIssue `json:"matchesMissingPlayer"`
+	SeasonPlayersMissingSeason []IntegrityIssue `json:"seasonPlayersMissingSeason"`
+	EmptyMatchDays             []IntegrityIssue `json:"emptyMatchDays"`
+	IssueCount                 int              `json:"issueCount"`
+}
+
+// CheckIntegrity finds orphaned records in a league's data: scores whose match or course is
+// gone, matches naming players that do not exist, season players whose season was deleted,
+// and match days with no matches scheduled. Each list is ordered by record ID.
+func CheckIntegrity(leagueID string, data IntegrityData) IntegrityReport {
+	checker := NewIntegrityChecker(leagueID, data)
+	for _, score := range data.Scores {
+		checker.CheckScore(score)
+	}
+	return checker.Report()
+}
+
+// IntegrityChecker runs the integrity check with scores fed in one at a time, so a league's
+// scores can be streamed through it instead of being loaded all at once
+type IntegrityChecker struct {
+	data      IntegrityData
+	matchIDs  map[string]bool
+	courseIDs map[string]bool
+	report    IntegrityReport
+}
+
+// NewIntegrityChecker prepares a check of the league's data. data.Scores is ignored; pass
+// each score to CheckScore instead.
+func NewIntegrityChecker(leagueID string, data IntegrityData) *IntegrityChecker {
+	matchIDs := make(map[string]bool, len(data.Matches))
+	for _, m := range data.Matches {
+		matchIDs[m.ID] = true
+	}
+	courseIDs := make(map[string]bool, len(data.Courses))
+	for _, c := range data.Courses {
+		courseIDs[c.ID] = true
+	}
+
+	return &IntegrityChecker{
+		data:      data,
+		matchIDs:  matchIDs,
+		courseIDs: courseIDs,
+		report: IntegrityReport{
+			LeagueID:                   leagueID,
+			ScoresMissingMatch:         []IntegrityIssue{},
+			ScoresMissingCourse:        []IntegrityIssue{},
+			MatchesMissingPlayer:       []IntegrityIssue{},
+			SeasonPlayersMissingSeason: []IntegrityIssue{},
+			EmptyMatchDays:             []IntegrityIssue{},
+		},
+	}
+}
+
+// CheckScore records the problems with one score, if any
+func (c *IntegrityChecker) CheckScore(score models.Score) {
+	if !c.matchIDs[score.MatchID] {
+		c.report.ScoresMissingMatch = append(c.report.ScoresMissingMatch, IntegrityIssue{
+			RecordID: score.ID,
+			Detail:   fmt.Sprintf("match %s does not exist", score.MatchID),
+		})
+	}
+	if !c.courseIDs[score.CourseID] {
+		c.report.ScoresMissingCourse = append(c.report.ScoresMissingCourse, IntegrityIssue{
+			RecordID: score.ID,
+			Detail:   fmt.Sprintf("course %s does not exist", score.CourseID),
+		})
+	}
+}
+
+// Report checks the rest of the league's data and returns everything found, including the
+// scores passed to CheckScore so far
+func (c *IntegrityChecker) Report() IntegrityReport {
+	data := c.data
+	playerIDs := make(map[string]bool, len(data.Players))
+	for _, p := range data.Players {
+		playerIDs[p.ID] = true
+	}
+	seasonIDs := make(map[string]bool, len(data.Seasons))
+	for _, s := range data.Seasons {
+		seasonIDs[s.ID] = true
+	}
+	matchDaysInUse := make(map[string]bool, len(data.MatchDays))
+	for _, m := range data.Matches {
+		matchDaysInUse[m.MatchDayID] = true
+	}
+
+	report := c.report
+	report.ScoresMissingMatch = append([]IntegrityIssue{}, c.report.ScoresMissingMatch...)
+	report.ScoresMissingCourse = append([]IntegrityIssue{}, c.report.ScoresMissingCourse...)
+
+	for _, match := range data.Matches {
+		for _, playerID := range []string{match.PlayerAID, match.PlayerBID} {
+			if !playerIDs[playerID] {
+				report.MatchesMissingPlayer = append(report.MatchesMissingPlayer, IntegrityIssue{
+					RecordID: match.ID,
+					Detail:   fmt.Sprintf("player %s does not exist", playerID),
+				})
+			}
+		}
+	}
+
+	for _, sp := range data.SeasonPlayers {
+		if !seasonIDs[sp.SeasonID] {
+			report.SeasonPlayersMissingSeason = append(report.SeasonPlayersMissingSeason, IntegrityIssue{
+				RecordID: sp.ID,
+				Detail:   fmt.Sprintf("season %s does not exist", sp.SeasonID),
+			})
+		}
+	}
+
+	for _, md := range data.MatchDays {
+		if !matchDaysInUse[md.ID] {
+			report.EmptyMatchDays = append(report.EmptyMatchDays, IntegrityIssue{
+				RecordID: md.ID,
+				Detail:   fmt.Sprintf("no matches scheduled on %s", md.Date.Format("2006-01-02")),
+			})
+		}
+	}
+
+	for _, issues := range [][]IntegrityIssue{
+		report.ScoresMissingMatch,
+		report.ScoresMissingCourse,
+		report.MatchesMissingPlayer,
+		report.SeasonPlayersMissingSeason,
+		report.EmptyMatchDays,
+	} {
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].RecordID < issues[j].RecordID })
+		report.IssueCount += len(issues)
+	}
+
+	return report
+}
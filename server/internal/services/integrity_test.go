@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func integrityFixture() IntegrityData {
+	return IntegrityData{
+		Courses: []models.Course{{ID: "c1"}},
+		Players: []models.Player{{ID: "p1"}, {ID: "p2"}},
+		Seasons: []models.Season{{ID: "s1"}},
+		MatchDays: []models.MatchDay{
+			{ID: "md1", Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Matches: []models.Match{
+			{ID: "m1", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "p2"},
+		},
+		Scores: []models.Score{
+			{ID: "sc1", MatchID: "m1", PlayerID: "p1", CourseID: "c1"},
+			{ID: "sc2", MatchID: "m1", PlayerID: "p2", CourseID: "c1"},
+		},
+		SeasonPlayers: []models.SeasonPlayer{
+			{ID: "sp1", SeasonID: "s1", PlayerID: "p1"},
+			{ID: "sp2", SeasonID: "s1", PlayerID: "p2"},
+		},
+	}
+}
+
+func TestCheckIntegrityClean(t *testing.T) {
+	report := CheckIntegrity("league-1", integrityFixture())
+
+	if report.IssueCount != 0 {
+		t.Errorf("issue count = %d, want 0: %+v", report.IssueCount, report)
+	}
+	if report.LeagueID != "league-1" {
+		t.Errorf("league ID = %q, want league-1", report.LeagueID)
+	}
+}
+
+func TestCheckIntegrityFindsOrphans(t *testing.T) {
+	data := integrityFixture()
+	// Score for a deleted match on a deleted course
+	data.Scores = append(data.Scores, models.Score{ID: "sc3", MatchID: "gone-match", PlayerID: "p1", CourseID: "gone-course"})
+	// Match with a deleted player
+	data.Matches = append(data.Matches, models.Match{ID: "m2", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "gone-player"})
+	// Roster entry left behind by a deleted season
+	data.SeasonPlayers = append(data.SeasonPlayers, models.SeasonPlayer{ID: "sp3", SeasonID: "gone-season", PlayerID: "p1"})
+	// Match day that never got matches
+	data.MatchDays = append(data.MatchDays, models.MatchDay{ID: "md2", Date: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)})
+
+	report := CheckIntegrity("league-1", data)
+
+	checks := []struct {
+		name   string
+		issues []IntegrityIssue
+		want   []string
+	}{
+		{"scores missing match", report.ScoresMissingMatch, []string{"sc3"}},
+		{"scores missing course", report.ScoresMissingCourse, []string{"sc3"}},
+		{"matches missing player", report.MatchesMissingPlayer, []string{"m2"}},
+		{"season players missing season", report.SeasonPlayersMissingSeason, []string{"sp3"}},
+		{"empty match days", report.EmptyMatchDays, []string{"md2"}},
+	}
+	for _, c := range checks {
+		got := make([]string, len(c.issues))
+		for i, issue := range c.issues {
+			got[i] = issue.RecordID
+		}
+		if !equalIDs(got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	if report.IssueCount != 5 {
+		t.Errorf("issue count = %d, want 5", report.IssueCount)
+	}
+	if detail := report.MatchesMissingPlayer[0].Detail; detail != "player gone-player does not exist" {
+		t.Errorf("detail = %q, want the missing player named", detail)
+	}
+}
+
+func TestIntegrityCheckerStreamsScores(t *testing.T) {
+	data := integrityFixture()
+	scores := append(data.Scores, models.Score{ID: "sc0", MatchID: "gone-match", PlayerID: "p1", CourseID: "c1"})
+	data.Scores = nil
+
+	checker := NewIntegrityChecker("league-1", data)
+	for _, score := range scores {
+		checker.CheckScore(score)
+	}
+	report := checker.Report()
+
+	if report.IssueCount != 1 || len(report.ScoresMissingMatch) != 1 || report.ScoresMissingMatch[0].RecordID != "sc0" {
+		t.Errorf("report = %+v, want only sc0 missing its match", report)
+	}
+	// Reporting again gives the same answer
+	if again := checker.Report(); again.IssueCount != report.IssueCount {
+		t.Errorf("second report issue count = %d, want %d", again.IssueCount, report.IssueCount)
+	}
+}
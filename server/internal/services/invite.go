@@ -0,0 +1,45 @@
+package services
+
+import (
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// Invite expiry windows
+const (
+	DefaultInviteExpiry = 7 * 24 * time.Hour
+	MaxInviteExpiry     = 30 * 24 * time.Hour
+)
+
+// RegenerateInvite replaces an invite with a fresh one carrying the same settings: the same
+// maximum uses and the same expiry window, counted from now. The new invite starts unused. The
+// old invite is returned revoked; one that was already revoked keeps its original revocation
+// time. IDs and tokens are supplied by the caller.
+func RegenerateInvite(old models.LeagueInvite, id, token, createdBy string, now time.Time) (revoked, fresh models.LeagueInvite) {
+	window := old.ExpiresAt.Sub(old.CreatedAt)
+	if window <= 0 {
+		window = DefaultInviteExpiry
+	}
+	if window > MaxInviteExpiry {
+		window = MaxInviteExpiry
+	}
+
+	fresh = models.LeagueInvite{
+		ID:        id,
+		LeagueID:  old.LeagueID,
+		Token:     token,
+		CreatedBy: createdBy,
+		ExpiresAt: now.Add(window),
+		MaxUses:   old.MaxUses,
+		UseCount:  0,
+		CreatedAt: now,
+	}
+
+	revoked = old
+	if revoked.RevokedAt == nil {
+		revokedAt := now
+		revoked.RevokedAt = &revokedAt
+	}
+	return revoked, fresh
+}
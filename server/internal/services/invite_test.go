@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestRegenerateInvite(t *testing.T) {
+	created := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 5, 20, 14, 30, 0, 0, time.UTC)
+
+	// Expired after a 10 day window with 3 of 12 uses taken
+	old := models.LeagueInvite{
+		ID:        "inv1",
+		LeagueID:  "l1",
+		Token:     "old-token",
+		CreatedBy: "admin1",
+		CreatedAt: created,
+		ExpiresAt: created.Add(10 * 24 * time.Hour),
+		MaxUses:   12,
+		UseCount:  3,
+	}
+
+	revoked, fresh := RegenerateInvite(old, "inv2", "new-token", "admin2", now)
+
+	if revoked.ID != "inv1" || revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(now) {
+		t.Errorf("old invite = %+v, want inv1 revoked at %v", revoked, now)
+	}
+	if fresh.ID != "inv2" || fresh.Token != "new-token" || fresh.LeagueID != "l1" || fresh.CreatedBy != "admin2" {
+		t.Errorf("new invite = %+v, want inv2 in l1 created by admin2", fresh)
+	}
+	if fresh.MaxUses != 12 || fresh.UseCount != 0 || fresh.RevokedAt != nil {
+		t.Errorf("new invite uses = %d/%d revoked %v, want 0/12 and active", fresh.UseCount, fresh.MaxUses, fresh.RevokedAt)
+	}
+	if want := now.Add(10 * 24 * time.Hour); !fresh.ExpiresAt.Equal(want) || !fresh.CreatedAt.Equal(now) {
+		t.Errorf("new invite expires %v, want %v", fresh.ExpiresAt, want)
+	}
+
+	// Regenerating an already revoked invite keeps its revocation time
+	earlier := now.Add(-time.Hour)
+	old.RevokedAt = &earlier
+	revoked, _ = RegenerateInvite(old, "inv3", "another-token", "admin1", now)
+	if !revoked.RevokedAt.Equal(earlier) {
+		t.Errorf("revoked at %v, want the original %v", revoked.RevokedAt, earlier)
+	}
+}
@@ -4,26 +4,31 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"golf-league-manager/internal/models"
 	"golf-league-manager/internal/persistence"
 )
 
-// HandicapRecalculationJob handles the weekly recalculation of all player handicaps
+// HandicapRecalculationJob handles the weekly recalculation of all player handicaps. A job is
+// used by one caller at a time.
 type HandicapRecalculationJob struct {
 	firestoreClient *persistence.FirestoreClient
+	seasonMatchDays map[string]int // Match days scheduled per season, loaded once per job
 }
 
 // NewHandicapRecalculationJob creates a new handicap recalculation job
 func NewHandicapRecalculationJob(fc *persistence.FirestoreClient) *HandicapRecalculationJob {
 	return &HandicapRecalculationJob{
 		firestoreClient: fc,
+		seasonMatchDays: make(map[string]int),
 	}
 }
 
 // Run executes the handicap recalculation for all active players in a league's active season
 func (job *HandicapRecalculationJob) Run(ctx context.Context, leagueID string) error {
 	log.Println("Starting handicap recalculation job...")
+	started := time.Now()
 
 	// Get the active season for the league
 	activeSeason, err := job.firestoreClient.GetActiveSeason(ctx, leagueID)
@@ -52,13 +57,15 @@ func (job *HandicapRecalculationJob) Run(ctx context.Context, leagueID string) e
 
 	successCount := 0
 	errorCount := 0
+	skippedCount := 0
 
 	// Recalculate handicap for each season player
 	for _, seasonPlayer := range seasonPlayers {
 		if !seasonPlayer.IsActive {
+			skippedCount++
 			continue
 		}
-		if err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, seasonPlayer, coursesMap); err != nil {
+		if _, err := job.RecalculateSeasonPlayerHandicap(ctx, leagueID, seasonPlayer, coursesMap); err != nil {
 			log.Printf("Error recalculating handicap for season player %s: %v", seasonPlayer.PlayerID, err)
 			errorCount++
 		} else {
@@ -67,57 +74,195 @@ func (job *HandicapRecalculationJob) Run(ctx context.Context, leagueID string) e
 	}
 
 	log.Printf("Handicap recalculation completed: %d successful, %d errors", successCount, errorCount)
+
+	// The stats are informational, so failing to save them does not fail the job
+	run := NewRecalcRun(leagueID, activeSeason.ID, started, time.Now(), successCount, errorCount, skippedCount)
+	_ = RecordRecalcRun(ctx, job.firestoreClient, run)
 	return nil
 }
 
-// RecalculateSeasonPlayerHandicap recalculates and updates a single season player's handicap index
-func (job *HandicapRecalculationJob) RecalculateSeasonPlayerHandicap(ctx context.Context, leagueID string, seasonPlayer models.SeasonPlayer, coursesMap map[string]models.Course) error {
-	// Get the last 5 non-absent scores for the player
-	// Absent rounds are not considered in handicap calculations
-	scores, err := job.firestoreClient.GetPlayerScoresForHandicap(ctx, leagueID, seasonPlayer.PlayerID, 5)
-	if err != nil {
-		return fmt.Errorf("failed to get player scores: %w", err)
+// Ways a season player's handicap index can be worked out, reported in HandicapRecalc.Method
+const (
+	HandicapMethodNone        = "none"        // Gross-only league, no index is kept
+	HandicapMethodProvisional = "provisional" // No scores yet
+	HandicapMethodBlended     = "blended"     // Provisional handicap averaged in as phantom rounds
+	HandicapMethodDecay       = "decay"       // Recency-weighted average
+	HandicapMethodAll         = "all"         // Average of every differential
+	HandicapMethodBest        = "best"        // Average of the best differentials in the window
+)
+
+// HandicapRecalc is a season player's recalculated handicap index and how it was worked out
+type HandicapRecalc struct {
+	PlayerID            string    `json:"playerId"`
+	SeasonID            string    `json:"seasonId"`
+	PreviousIndex       float64   `json:"previousIndex"`
+	HandicapIndex       float64   `json:"handicapIndex"`
+	ProvisionalHandicap float64   `json:"provisionalHandicap"`
+	Method              string    `json:"method"`
+	WindowSize          int       `json:"windowSize"`
+	ScoresCounted       int       `json:"scoresCounted"` // Rounds in the window with a usable differential
+	ScoresUsed          int       `json:"scoresUsed"`    // Best rounds averaged under the drop schedule
+	Differentials       []float64 `json:"differentials"` // Most recent first
+}
+
+// ComputeSeasonPlayerHandicap works out a season player's index from the scores in their
+// handicap window, most recent first, exactly as the recalculation job does
+func ComputeSeasonPlayerHandicap(seasonPlayer models.SeasonPlayer, league models.League, scores []models.Score, coursesMap map[string]models.Course) HandicapRecalc {
+	windowSize, _ := HandicapWindow(league)
+	recalc := HandicapRecalc{
+		PlayerID:            seasonPlayer.PlayerID,
+		SeasonID:            seasonPlayer.SeasonID,
+		PreviousIndex:       seasonPlayer.CurrentHandicapIndex,
+		ProvisionalHandicap: seasonPlayer.ProvisionalHandicap,
+		WindowSize:          windowSize,
+		Differentials:       make([]float64, 0, len(scores)),
+	}
+	// Gross-only leagues keep no handicap index
+	if !UsesHandicaps(league) {
+		recalc.Method = HandicapMethodNone
+		return recalc
 	}
 
 	// Extract differentials from scores
-	differentials := make([]float64, 0, len(scores))
+	datedDifferentials := make([]Differential, 0, len(scores))
 	for _, s := range scores {
 		course := coursesMap[s.CourseID]
 		diff := s.HandicapDifferential
 		if diff == 0 {
-			diff = CalculateDifferential(s, course)
+			if err := CheckSlopeRating(course.SlopeRating); err != nil {
+				log.Printf("Warning: skipping score %s for player %s, course %s: %v", s.ID, seasonPlayer.PlayerID, s.CourseID, err)
+				continue
+			}
+			diff = CalculateLeagueDifferential(s, course, 0, StandardSlope(league))
 		}
-		differentials = append(differentials, diff)
+		recalc.Differentials = append(recalc.Differentials, diff)
+		datedDifferentials = append(datedDifferentials, Differential{Value: diff, Timestamp: s.Date})
 	}
+	recalc.ScoresCounted = len(recalc.Differentials)
 
 	// Calculate league handicap using the centralized function
 	// Use the season player's provisional handicap
-	leagueHandicap := CalculateHandicapWithProvisional(differentials, seasonPlayer.ProvisionalHandicap)
+	opts := LeagueHandicapOptions(league)
+	weight := opts.ProvisionalWeight
+	recalc.HandicapIndex = CalculateHandicapWithProvisional(recalc.Differentials, seasonPlayer.ProvisionalHandicap, opts)
+	recalc.ScoresUsed = ScoresUsed(opts.DropSchedule, recalc.ScoresCounted)
 
-	// Log the calculation for debugging
-	scoreCount := len(scores)
+	// Leagues trying recency-weighted handicaps use them once the provisional has dropped out
+	scoreCount := recalc.ScoresCounted
 	switch {
+	case league.HandicapDecay > 0 && scoreCount > weight:
+		recalc.HandicapIndex = CalculateWeightedHandicap(datedDifferentials, league.HandicapDecay)
+		recalc.Method = HandicapMethodDecay
 	case scoreCount == 0:
+		recalc.Method = HandicapMethodProvisional
+	case scoreCount <= weight:
+		recalc.Method = HandicapMethodBlended
+	case scoreCount <= recalc.ScoresUsed:
+		recalc.Method = HandicapMethodAll
+	default:
+		recalc.Method = HandicapMethodBest
+	}
+	return recalc
+}
+
+// RecalculateSeasonPlayerHandicap recalculates and updates a single season player's handicap
+// index, returning the new index and how it was worked out
+func (job *HandicapRecalculationJob) RecalculateSeasonPlayerHandicap(ctx context.Context, leagueID string, seasonPlayer models.SeasonPlayer, coursesMap map[string]models.Course) (*HandicapRecalc, error) {
+	league, err := job.firestoreClient.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+	// Gross-only leagues keep no handicap index
+	if !UsesHandicaps(*league) {
+		recalc := ComputeSeasonPlayerHandicap(seasonPlayer, *league, nil, coursesMap)
+		return &recalc, nil
+	}
+
+	// Get the non-absent scores in the league's window of most recent rounds
+	// Absent rounds are not considered in handicap calculations
+	// When same-day rounds are collapsed or flagged rounds held back, fetch extra so a full
+	// window remains
+	windowSize, _ := HandicapWindow(*league)
+	fetchLimit := windowSize
+	if league.OneRoundPerDay || league.ExcludeFlaggedScores {
+		fetchLimit = windowSize * 2
+	}
+	scores, err := job.firestoreClient.GetPlayerScoresForHandicap(ctx, leagueID, seasonPlayer.PlayerID, fetchLimit, league.OfficialRoundsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player scores: %w", err)
+	}
+	if league.ExcludeFlaggedScores {
+		scores = excludeFlaggedScores(scores)
+	}
+	scores = selectHandicapScores(scores, coursesMap, league.OneRoundPerDay, windowSize)
+
+	recalc := ComputeSeasonPlayerHandicap(seasonPlayer, *league, scores, coursesMap)
+	leagueHandicap := recalc.HandicapIndex
+
+	// Log the calculation for debugging
+	scoreCount := recalc.ScoresCounted
+	switch recalc.Method {
+	case HandicapMethodProvisional:
 		log.Printf("Player %s: Using provisional handicap %.1f (0 scores)", seasonPlayer.PlayerID, seasonPlayer.ProvisionalHandicap)
-	case scoreCount == 1:
-		log.Printf("Player %s: 1 score - ((2 × %.1f) + %.1f) / 3 = %.1f", seasonPlayer.PlayerID, seasonPlayer.ProvisionalHandicap, differentials[0], leagueHandicap)
-	case scoreCount == 2:
-		log.Printf("Player %s: 2 scores - (%.1f + %.1f + %.1f) / 3 = %.1f", seasonPlayer.PlayerID, seasonPlayer.ProvisionalHandicap, differentials[0], differentials[1], leagueHandicap)
-	case scoreCount >= 3 && scoreCount <= 4:
+	case HandicapMethodBlended:
+		phantoms := ProvisionalWeight(*league) + 1 - scoreCount
+		log.Printf("Player %s: %d scores - ((%d × %.1f) + %v) / %d = %.1f", seasonPlayer.PlayerID, scoreCount, phantoms, seasonPlayer.ProvisionalHandicap, recalc.Differentials, phantoms+scoreCount, leagueHandicap)
+	case HandicapMethodDecay:
+		log.Printf("Player %s: %d scores - decay-weighted average (decay %.2f) = %.1f", seasonPlayer.PlayerID, scoreCount, league.HandicapDecay, leagueHandicap)
+	case HandicapMethodAll:
 		log.Printf("Player %s: %d scores - average all differentials = %.1f", seasonPlayer.PlayerID, scoreCount, leagueHandicap)
 	default:
-		log.Printf("Player %s: %d scores - drop 2 worst, average best 3 = %.1f", seasonPlayer.PlayerID, scoreCount, leagueHandicap)
+		log.Printf("Player %s: %d scores - average best %d of last %d = %.1f", seasonPlayer.PlayerID, scoreCount, recalc.ScoresUsed, windowSize, leagueHandicap)
 	}
 
 	// Update the season player's current handicap index
 	seasonPlayer.CurrentHandicapIndex = leagueHandicap
+
+	// Record the new index against the match day of the player's latest round this season. The
+	// history is informational, so failing to record it does not hold back the new index.
+	if len(scores) > 0 {
+		if err := job.recordIndexHistory(ctx, &seasonPlayer, scores[0], leagueHandicap); err != nil {
+			log.Printf("Warning: skipping index history for season player %s: %v", seasonPlayer.PlayerID, err)
+		}
+	}
+
 	if err := job.firestoreClient.UpdateSeasonPlayer(ctx, seasonPlayer); err != nil {
-		return fmt.Errorf("failed to update season player handicap: %w", err)
+		return nil, fmt.Errorf("failed to update season player handicap: %w", err)
 	}
 
 	log.Printf("Updated handicap for season player %s: league handicap index=%.1f",
 		seasonPlayer.PlayerID, leagueHandicap)
 
+	return &recalc, nil
+}
+
+// recordIndexHistory appends the player's index to their inline history, keyed by the match
+// day of the given score. Scores from earlier seasons add nothing to this season's history.
+// The history is capped at the number of match days scheduled for the season.
+func (job *HandicapRecalculationJob) recordIndexHistory(ctx context.Context, seasonPlayer *models.SeasonPlayer, latest models.Score, index float64) error {
+	match, err := job.firestoreClient.GetMatch(ctx, latest.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match for index history: %w", err)
+	}
+	if match.SeasonID != seasonPlayer.SeasonID {
+		return nil
+	}
+
+	matchDayCount, ok := job.seasonMatchDays[seasonPlayer.SeasonID]
+	if !ok {
+		matchDays, err := job.firestoreClient.ListMatchDaysBySeason(ctx, seasonPlayer.SeasonID)
+		if err != nil {
+			return fmt.Errorf("failed to list match days for index history: %w", err)
+		}
+		matchDayCount = len(matchDays)
+		job.seasonMatchDays[seasonPlayer.SeasonID] = matchDayCount
+	}
+
+	seasonPlayer.IndexHistory = AppendIndexHistory(seasonPlayer.IndexHistory, models.IndexPoint{
+		Date:          latest.Date,
+		MatchDayID:    match.MatchDayID,
+		HandicapIndex: index,
+	}, matchDayCount)
 	return nil
 }
 
@@ -152,6 +297,16 @@ func (proc *MatchCompletionProcessor) ProcessMatch(ctx context.Context, matchID
 		return fmt.Errorf("failed to get course: %w", err)
 	}
 
+	league, err := proc.firestoreClient.GetLeague(ctx, match.LeagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get league: %w", err)
+	}
+
+	season, err := proc.firestoreClient.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		return fmt.Errorf("failed to get season: %w", err)
+	}
+
 	// Get scores for both players
 	scoresA, err := proc.firestoreClient.GetPlayerMatchScores(ctx, matchID, match.PlayerAID)
 	if err != nil {
@@ -180,16 +335,34 @@ func (proc *MatchCompletionProcessor) ProcessMatch(ctx context.Context, matchID
 	}
 
 	// Calculate course and playing handicaps for this match
-	_, playingHandicapA := CalculateCourseAndPlayingHandicap(seasonPlayerA.CurrentHandicapIndex, *course)
-	_, playingHandicapB := CalculateCourseAndPlayingHandicap(seasonPlayerB.CurrentHandicapIndex, *course)
+	_, playingHandicapA := CalculateCourseAndPlayingHandicapForLeague(seasonPlayerA.CurrentHandicapIndex, *course, *league)
+	_, playingHandicapB := CalculateCourseAndPlayingHandicapForLeague(seasonPlayerB.CurrentHandicapIndex, *course, *league)
+
+	// Gross match days give no strokes
+	basis := MatchPlayNet
+	if match.MatchDayID != "" {
+		matchDay, err := proc.firestoreClient.GetMatchDay(ctx, match.MatchDayID)
+		if err != nil {
+			return fmt.Errorf("failed to get match day: %w", err)
+		}
+		basis = matchDay.MatchPlayBasis
+	}
 
-	// Assign strokes based on the difference in playing handicaps
-	strokes := AssignStrokes(match.PlayerAID, playingHandicapA, match.PlayerBID, playingHandicapB, *course)
+	// Assign strokes based on the difference in playing handicaps, unless the match was played
+	// off strokes the players agreed on
+	strokes := AssignStrokesForBasis(basis, match.PlayerAID, playingHandicapA, match.PlayerBID, playingHandicapB, *course)
 	strokesA := strokes[match.PlayerAID]
 	strokesB := strokes[match.PlayerBID]
+	if match.StrokesOverridden {
+		strokesA = overrideStrokes(match.StrokeOverride[match.PlayerAID], len(course.HolePars))
+		strokesB = overrideStrokes(match.StrokeOverride[match.PlayerBID], len(course.HolePars))
+	}
 
 	// Calculate match points
-	pointsA, pointsB := CalculateMatchPoints(scoresA[0], scoresB[0], strokesA, strokesB)
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(*season, LeagueMatchPointRules(*league), scoresA[0], scoresB[0], strokesA, strokesB)
+	if err != nil {
+		return fmt.Errorf("failed to score match %s: %w", matchID, err)
+	}
 
 	log.Printf("Match %s completed: Player A (%s, handicap %d) = %d points, Player B (%s, handicap %d) = %d points",
 		matchID, match.PlayerAID, playingHandicapA, pointsA, match.PlayerBID, playingHandicapB, pointsB)
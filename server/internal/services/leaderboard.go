@@ -0,0 +1,77 @@
+package services
+
+import (
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// LeaderboardEntry is one player's place on a match day leaderboard
+type LeaderboardEntry struct {
+	Rank       int    `json:"rank"` // Players on the same score share a rank
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Score      int    `json:"score"`
+}
+
+// MatchDayLeaderboard ranks a match day's field on gross and on net. Provisional is set when
+// fewer players played than the season's minimum field for statistics, so the results are
+// shown but shouldn't be read as a real field.
+type MatchDayLeaderboard struct {
+	MatchDayID  string             `json:"matchDayId"`
+	FieldSize   int                `json:"fieldSize"`
+	MinField    int                `json:"minField"`
+	Provisional bool               `json:"provisional"`
+	Gross       []LeaderboardEntry `json:"gross"`
+	Net         []LeaderboardEntry `json:"net"` // Net score after the full playing handicap
+}
+
+// BuildMatchDayLeaderboard ranks the players who played a match day, lowest score first, on
+// gross and on net. Absent players are left off, and a player with more than one round that
+// day, such as a regular match and a makeup, is listed once with their best round.
+func BuildMatchDayLeaderboard(season models.Season, matchDayID string, scores []models.Score) MatchDayLeaderboard {
+	played := make([]models.Score, 0, len(scores))
+	for _, score := range scores {
+		if !score.PlayerAbsent {
+			played = append(played, score)
+		}
+	}
+
+	return MatchDayLeaderboard{
+		MatchDayID:  matchDayID,
+		FieldSize:   FieldSize(scores),
+		MinField:    season.MinFieldForStats,
+		Provisional: FieldTooSmallForStats(season, scores),
+		Gross:       rankLeaderboard(played, func(s models.Score) int { return s.GrossScore }),
+		Net:         rankLeaderboard(played, func(s models.Score) int { return s.NetScore }),
+	}
+}
+
+// rankLeaderboard orders players by their lowest score on the given total, lowest first and
+// then by name, with equal totals sharing a rank (1, 2, 2, 4)
+func rankLeaderboard(scores []models.Score, total func(models.Score) int) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, len(scores))
+	byPlayer := make(map[string]int, len(scores))
+	for _, score := range scores {
+		if i, ok := byPlayer[score.PlayerID]; ok {
+			entries[i].Score = min(entries[i].Score, total(score))
+			continue
+		}
+		byPlayer[score.PlayerID] = len(entries)
+		entries = append(entries, LeaderboardEntry{PlayerID: score.PlayerID, PlayerName: score.PlayerName, Score: total(score)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score < entries[j].Score
+		}
+		return entries[i].PlayerName < entries[j].PlayerName
+	})
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	return entries
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func leaderboardScores() []models.Score {
+	return []models.Score{
+		{PlayerID: "p1", PlayerName: "Alice", GrossScore: 42, NetScore: 35},
+		{PlayerID: "p2", PlayerName: "Bob", GrossScore: 38, NetScore: 36},
+		{PlayerID: "p3", PlayerName: "Cara", GrossScore: 45, NetScore: 35},
+		{PlayerID: "p4", PlayerName: "Dev", GrossScore: 40, NetScore: 37},
+		{PlayerID: "p5", PlayerName: "Eve", GrossScore: 39, NetScore: 33, PlayerAbsent: true},
+	}
+}
+
+func TestBuildMatchDayLeaderboardAboveMinField(t *testing.T) {
+	board := BuildMatchDayLeaderboard(models.Season{MinFieldForStats: 4}, "md1", leaderboardScores())
+	if board.Provisional || board.FieldSize != 4 || board.MinField != 4 {
+		t.Errorf("board = %+v, want a final leaderboard of 4", board)
+	}
+
+	wantGross := []string{"p2", "p4", "p1", "p3"}
+	for i, entry := range board.Gross {
+		if entry.PlayerID != wantGross[i] || entry.Rank != i+1 {
+			t.Errorf("gross %d = %+v, want %s ranked %d", i, entry, wantGross[i], i+1)
+		}
+	}
+
+	// Alice and Cara tie on net and share first
+	if len(board.Net) != 4 || board.Net[0].PlayerID != "p1" || board.Net[1].PlayerID != "p3" ||
+		board.Net[0].Rank != 1 || board.Net[1].Rank != 1 || board.Net[2].Rank != 3 {
+		t.Errorf("net = %+v", board.Net)
+	}
+}
+
+func TestBuildMatchDayLeaderboardBelowMinField(t *testing.T) {
+	board := BuildMatchDayLeaderboard(models.Season{MinFieldForStats: 5}, "md1", leaderboardScores())
+	if !board.Provisional {
+		t.Error("expected a provisional leaderboard for a field of 4 under a minimum of 5")
+	}
+	if len(board.Gross) != 4 || len(board.Net) != 4 {
+		t.Errorf("provisional leaderboard should still list the field: %+v", board)
+	}
+}
+
+func TestBuildMatchDayLeaderboardOneEntryPerPlayer(t *testing.T) {
+	scores := append(leaderboardScores(),
+		// Bob's makeup round the same day: worse on gross, better on net
+		models.Score{PlayerID: "p2", PlayerName: "Bob", GrossScore: 41, NetScore: 34, Makeup: true},
+	)
+
+	board := BuildMatchDayLeaderboard(models.Season{}, "md1", scores)
+	if len(board.Gross) != 4 || len(board.Net) != 4 {
+		t.Fatalf("board = %+v, want each of the 4 players listed once", board)
+	}
+	if board.Gross[0].PlayerID != "p2" || board.Gross[0].Score != 38 {
+		t.Errorf("gross leader = %+v, want Bob on his best gross of 38", board.Gross[0])
+	}
+	if board.Net[0].PlayerID != "p2" || board.Net[0].Score != 34 {
+		t.Errorf("net leader = %+v, want Bob on his best net of 34", board.Net[0])
+	}
+}
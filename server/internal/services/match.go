@@ -1,24 +1,232 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"sort"
 
+	"golf-league-manager/internal/logger"
 	"golf-league-manager/internal/models"
 )
 
 // Constants for match scoring
 const (
-	holesPerRound = 9  // Number of holes in a 9-hole round
-	maxStrokes    = 18 // Maximum strokes that can be allocated (2 per hole)
+	holesPerRound = 9 // Number of holes in a 9-hole round
 )
 
+// Default match point values: 2 points per hole and 4 for the lower overall net, 22 on 9 holes
+const (
+	DefaultMatchPointsPerHole = 2
+	DefaultMatchOverallPoints = 4
+	MaxMatchPointsPerHole     = 10
+	MaxMatchOverallPoints     = 40
+)
+
+// MatchPointRules is what a match is worth: PerHole points for each hole won, split on a halved
+// hole, and Overall points for the lower net total, split on a tie. The overall points don't
+// depend on the number of holes, so an 18-hole match is worth 2×18+4 = 40 points by default.
+// With NoHalvedHolePoints a halved hole scores nothing for either player, so a match awards
+// PerHole fewer points for every halved hole. With Nassau an 18-hole match awards the Overall
+// points three times, for the front nine, back nine and total net, so it is worth 2×18+3×4 = 48.
+type MatchPointRules struct {
+	PerHole            int  `json:"perHole"`
+	Overall            int  `json:"overall"`
+	NoHalvedHolePoints bool `json:"noHalvedHolePoints"`
+	Nassau             bool `json:"nassau"`
+}
+
+// nassauHoles is the only match length the Nassau format applies to; shorter matches in a
+// Nassau season have a single overall block
+const nassauHoles = 18
+
+// overallBlocks returns how many blocks of Overall points a match over the given number of
+// holes awards
+func (r MatchPointRules) overallBlocks(holes int) int {
+	if r.Nassau && holes == nassauHoles {
+		return 3
+	}
+	return 1
+}
+
+// DefaultMatchPointRules are the points used when a league sets none
+var DefaultMatchPointRules = MatchPointRules{PerHole: DefaultMatchPointsPerHole, Overall: DefaultMatchOverallPoints}
+
+// Total returns the points at stake in a match over the given number of holes. When halved
+// holes score nothing this is the most a match can award.
+func (r MatchPointRules) Total(holes int) int {
+	return r.PerHole*holes + r.Overall*r.overallBlocks(holes)
+}
+
+// Rules for scoring a halved hole in match play
+const (
+	HalvedHoleSplit = "split" // Each player gets half the hole's points (default)
+	HalvedHoleNone  = "none"  // Neither player scores; only holes won outright count
+)
+
+// ValidateHalvedHoleRule checks a season's halved hole rule. An empty rule is accepted and
+// treated as HalvedHoleSplit.
+func ValidateHalvedHoleRule(season models.Season) error {
+	switch season.HalvedHoleRule {
+	case "", HalvedHoleSplit, HalvedHoleNone:
+		return nil
+	}
+	return fmt.Errorf("halvedHoleRule must be one of: %s, %s", HalvedHoleSplit, HalvedHoleNone)
+}
+
+// SeasonMatchPointRules applies the season's halved hole rule and Nassau mode to the league's
+// point values
+func SeasonMatchPointRules(rules MatchPointRules, season models.Season) MatchPointRules {
+	rules.NoHalvedHolePoints = season.HalvedHoleRule == HalvedHoleNone
+	rules.Nassau = season.NassauMode
+	return rules
+}
+
+// LeagueMatchPointRules returns the match point values the league plays for
+func LeagueMatchPointRules(league models.League) MatchPointRules {
+	rules := DefaultMatchPointRules
+	if league.MatchPointsPerHole > 0 {
+		rules.PerHole = league.MatchPointsPerHole
+	}
+	if league.MatchOverallPoints != nil {
+		rules.Overall = *league.MatchOverallPoints
+	}
+	return rules
+}
+
+// ValidateMatchPointRules checks a league's match point values. Both must be even so a halved
+// hole or a tied total splits into whole points; this also keeps every match total even, on 9
+// holes or 18, so a halved match splits cleanly.
+func ValidateMatchPointRules(rules MatchPointRules) error {
+	if rules.PerHole < 2 || rules.PerHole > MaxMatchPointsPerHole || rules.PerHole%2 != 0 {
+		return fmt.Errorf("matchPointsPerHole must be an even number between 2 and %d", MaxMatchPointsPerHole)
+	}
+	if rules.Overall < 0 || rules.Overall > MaxMatchOverallPoints || rules.Overall%2 != 0 {
+		return fmt.Errorf("matchOverallPoints must be an even number between 0 and %d", MaxMatchOverallPoints)
+	}
+	return nil
+}
+
+// Rules for scoring a match in which one player is absent
+const (
+	AbsentMatchRulePlayInflated  = "play-inflated"  // The absent player's inflated scorecard is played like any other
+	AbsentMatchRuleFixedFraction = "fixed-fraction" // The absent player gets a fixed share of the points, the present player the rest
+)
+
+// ValidateAbsentMatchRule checks a season's absent match rule and fraction.
+// An empty rule is accepted and treated as AbsentMatchRulePlayInflated.
+func ValidateAbsentMatchRule(season models.Season) error {
+	switch season.AbsentMatchRule {
+	case "", AbsentMatchRulePlayInflated:
+		return nil
+	case AbsentMatchRuleFixedFraction:
+		if season.AbsentPointsFraction < 0 || season.AbsentPointsFraction > 1 {
+			return fmt.Errorf("absentPointsFraction must be between 0 and 1")
+		}
+		return nil
+	default:
+		return fmt.Errorf("absentMatchRule must be one of: %s, %s", AbsentMatchRulePlayInflated, AbsentMatchRuleFixedFraction)
+	}
+}
+
+// absentMatchPoints returns the points for a match with exactly one absent player when the
+// season awards them as a fixed fraction of total. The absent player's share is rounded down
+// and the present player gets the rest. ok is false when the scorecards should be played
+// instead: under the play-inflated rule, or when neither or both players are absent.
+func absentMatchPoints(season models.Season, scoreA, scoreB models.Score, total int) (pointsA, pointsB int, ok bool) {
+	if season.AbsentMatchRule != AbsentMatchRuleFixedFraction || scoreA.PlayerAbsent == scoreB.PlayerAbsent {
+		return 0, 0, false
+	}
+
+	absentPoints := int(math.Floor(float64(total) * season.AbsentPointsFraction))
+	if scoreA.PlayerAbsent {
+		return absentPoints, total - absentPoints, true
+	}
+	return total - absentPoints, absentPoints, true
+}
+
+// ErrMatchPointsTotal means a match's points do not add up to the points at stake, which can
+// only happen when the scoring itself is broken
+var ErrMatchPointsTotal = errors.New("match points do not add up to the match total")
+
+// CheckMatchPointsTotal verifies that the points awarded in a match add up to total
+func CheckMatchPointsTotal(pointsA, pointsB, total int) error {
+	if pointsA+pointsB != total {
+		return fmt.Errorf("%w: %d + %d != %d", ErrMatchPointsTotal, pointsA, pointsB, total)
+	}
+	return nil
+}
+
+// ScoreMatchPointsWithRules awards the points for a match with both scorecards in, with the
+// league's match point values and the season's halved hole rule and Nassau mode: a fixed share
+// when the season's absent rule applies, otherwise by playing the scorecards with conceded
+// holes honored. The result is checked against the match total so a scoring bug fails loudly
+// instead of silently skewing standings. The match
+// total follows the number of holes on the scorecards, less the points not awarded on halved
+// holes when the season scores them as nothing.
+func ScoreMatchPointsWithRules(season models.Season, rules MatchPointRules, scoreA, scoreB models.Score, strokesA, strokesB []int) (pointsA, pointsB int, err error) {
+	total := SeasonMatchPointRules(rules, season).Total(len(scoreA.HoleScores))
+	return scoreMatchPoints(season, rules, scoreA, scoreB, strokesA, strokesB, total)
+}
+
+func scoreMatchPoints(season models.Season, rules MatchPointRules, scoreA, scoreB models.Score, strokesA, strokesB []int, total int) (pointsA, pointsB int, err error) {
+	rules = SeasonMatchPointRules(rules, season)
+
+	pointsA, pointsB, fixed := absentMatchPoints(season, scoreA, scoreB, total)
+	if !fixed {
+		var halved int
+		pointsA, pointsB, halved = playMatchPoints(scoreA, scoreB, strokesA, strokesB, rules)
+		if rules.NoHalvedHolePoints {
+			total -= rules.PerHole * halved
+		}
+	}
+
+	if err := CheckMatchPointsTotal(pointsA, pointsB, total); err != nil {
+		logger.Error("Match points invariant violated",
+			"match_id", scoreA.MatchID,
+			"points_a", pointsA,
+			"points_b", pointsB,
+			"expected_total", total,
+		)
+		return 0, 0, err
+	}
+	return pointsA, pointsB, nil
+}
+
+// CompleteMatchWithRules scores a match once both players' scorecards are in, with the league's
+// match point values. scores holds the match's scores keyed by player ID and must already
+// include every score submitted alongside them, so the points never depend on the order
+// submissions were processed. ok is false while a player's score is still missing.
+func CompleteMatchWithRules(season models.Season, rules MatchPointRules, match models.Match, scores map[string]models.Score, strokesA, strokesB []int) (completed models.Match, ok bool, err error) {
+	scoreA, hasA := scores[match.PlayerAID]
+	scoreB, hasB := scores[match.PlayerBID]
+	if !hasA || !hasB {
+		return match, false, nil
+	}
+
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(season, rules, scoreA, scoreB, strokesA, strokesB)
+	if err != nil {
+		return match, false, err
+	}
+
+	match.Status = "completed"
+	match.PlayerAPoints = pointsA
+	match.PlayerBPoints = pointsB
+	return match, true, nil
+}
+
 // AssignStrokes assigns strokes to holes based on playing handicap difference
 // Only the higher-handicap player receives strokes
-// Strokes are allocated in order of hole handicaps (1 → 9)
+// Strokes are allocated in order of hole handicaps (1 → 9, or 1 → 18 on an 18-hole course)
 func AssignStrokes(playerAID string, playerAPlayingHandicap int, playerBID string, playerBPlayingHandicap int, course models.Course) map[string][]int {
 	result := make(map[string][]int)
 
+	holesPerRound := len(course.HoleHandicaps)
+	if holesPerRound == 0 {
+		holesPerRound = 9
+	}
+	maxStrokes := 2 * holesPerRound
+
 	// Calculate handicap difference
 	diff := playerAPlayingHandicap - playerBPlayingHandicap
 
@@ -63,7 +271,7 @@ func AssignStrokes(playerAID string, playerAPlayingHandicap int, playerBID strin
 		return holes[i].handicap < holes[j].handicap
 	})
 
-	// Allocate strokes in order of hole handicaps
+	// Allocate strokes in order of hole handicaps, at most 2 per hole
 	for strokeNum := 0; strokeNum < strokesToAllocate && strokeNum < maxStrokes; strokeNum++ {
 		holeIdx := holes[strokeNum%holesPerRound].index
 		if receivingPlayerID == playerAID {
@@ -79,48 +287,160 @@ func AssignStrokes(playerAID string, playerAPlayingHandicap int, playerBID strin
 	return result
 }
 
-// CalculateMatchPoints calculates match play points for both players
-// Each 9-hole match = 22 points:
-// - 2 points per hole (best net wins; ties split 1-1)
-// - 4 points for overall lower net total
-func CalculateMatchPoints(scoreA, scoreB models.Score, strokesA, strokesB []int) (pointsA, pointsB int) {
-	if len(scoreA.HoleScores) != 9 || len(scoreB.HoleScores) != 9 {
-		return 0, 0
+// Match play bases: whether holes are compared on net scores, with strokes given to the higher
+// handicap, or on gross scores with no strokes at all
+const (
+	MatchPlayNet   = "net"
+	MatchPlayGross = "gross"
+)
+
+// ValidateMatchPlayBasis checks a match day's match play basis. Empty means net.
+func ValidateMatchPlayBasis(basis string) error {
+	switch basis {
+	case "", MatchPlayNet, MatchPlayGross:
+		return nil
 	}
+	return fmt.Errorf("match play basis must be %q or %q (got: %q)", MatchPlayNet, MatchPlayGross, basis)
+}
 
-	var totalNetA, totalNetB int
+// AssignStrokesForBasis assigns strokes for a match played on the given basis. Gross match play
+// gives no strokes whatever the difference in playing handicaps; anything else is net match play
+// and strokes are assigned by AssignStrokes.
+func AssignStrokesForBasis(basis string, playerAID string, playerAPlayingHandicap int, playerBID string, playerBPlayingHandicap int, course models.Course) map[string][]int {
+	if basis == MatchPlayGross {
+		return AssignStrokes(playerAID, 0, playerBID, 0, course)
+	}
+	return AssignStrokes(playerAID, playerAPlayingHandicap, playerBID, playerBPlayingHandicap, course)
+}
 
-	// Calculate points for each hole
-	for i := 0; i < 9; i++ {
+// CalculateMatchPointsWithRules calculates match points for any number of holes and the
+// league's point values, honoring holes conceded in match play. A hole listed in a player's
+// ConcededHoles is won by that player regardless of the numeric scores; if both players list
+// the same hole it is halved. The overall points are still decided on total net strokes, so
+// conceded holes must carry a stroke count (see FillConcededHoleScoresWithCap). Both scorecards
+// and stroke allocations must cover the same holes.
+func CalculateMatchPointsWithRules(scoreA, scoreB models.Score, strokesA, strokesB []int, rules MatchPointRules) (pointsA, pointsB int) {
+	pointsA, pointsB, _ = playMatchPoints(scoreA, scoreB, strokesA, strokesB, rules)
+	return pointsA, pointsB
+}
+
+// playMatchPoints plays the scorecards hole by hole for CalculateMatchPointsWithRules and also
+// returns how many holes were halved
+func playMatchPoints(scoreA, scoreB models.Score, strokesA, strokesB []int, rules MatchPointRules) (pointsA, pointsB, halved int) {
+	holes := len(scoreA.HoleScores)
+	if holes == 0 || len(scoreB.HoleScores) != holes || len(strokesA) < holes || len(strokesB) < holes {
+		return 0, 0, 0
+	}
+
+	concededToA := concededHoleSet(scoreA.ConcededHoles)
+	concededToB := concededHoleSet(scoreB.ConcededHoles)
+
+	var totalNetA, totalNetB, frontNetA, frontNetB int
+
+	for i := 0; i < holes; i++ {
 		netA := scoreA.HoleScores[i] - strokesA[i]
 		netB := scoreB.HoleScores[i] - strokesB[i]
 
 		totalNetA += netA
 		totalNetB += netB
+		if i < holes/2 {
+			frontNetA += netA
+			frontNetB += netB
+		}
+
+		wonA, wonB := holeWinner(netA, netB, concededToA[i], concededToB[i])
 
-		if netA < netB {
-			pointsA += 2
-		} else if netB < netA {
-			pointsB += 2
+		if wonA {
+			pointsA += rules.PerHole
+		} else if wonB {
+			pointsB += rules.PerHole
 		} else {
-			// Tie - each gets 1 point
-			pointsA++
-			pointsB++
+			halved++
+			if !rules.NoHalvedHolePoints {
+				pointsA += rules.PerHole / 2
+				pointsB += rules.PerHole / 2
+			}
 		}
 	}
 
-	// Award 4 points for lower total net score
-	if totalNetA < totalNetB {
-		pointsA += 4
-	} else if totalNetB < totalNetA {
-		pointsB += 4
-	} else {
-		// Tie - split the 4 points
-		pointsA += 2
-		pointsB += 2
+	// The overall points are split on a tie under either halved hole rule. A Nassau match
+	// awards them for the front and back nines as well as the total.
+	if rules.overallBlocks(holes) == 3 {
+		blockA, blockB := overallPoints(frontNetA, frontNetB, rules.Overall)
+		pointsA, pointsB = pointsA+blockA, pointsB+blockB
+		blockA, blockB = overallPoints(totalNetA-frontNetA, totalNetB-frontNetB, rules.Overall)
+		pointsA, pointsB = pointsA+blockA, pointsB+blockB
 	}
+	blockA, blockB := overallPoints(totalNetA, totalNetB, rules.Overall)
+	pointsA, pointsB = pointsA+blockA, pointsB+blockB
 
-	return pointsA, pointsB
+	return pointsA, pointsB, halved
+}
+
+// holeWinner decides a hole on net score, unless it was conceded to exactly one player
+func holeWinner(netA, netB int, concededToA, concededToB bool) (wonA, wonB bool) {
+	if concededToA || concededToB {
+		return concededToA && !concededToB, concededToB && !concededToA
+	}
+	return netA < netB, netB < netA
+}
+
+// overallPoints awards a block of overall points to the lower net score, split on a tie
+func overallPoints(netA, netB, points int) (pointsA, pointsB int) {
+	if netA < netB {
+		return points, 0
+	}
+	if netB < netA {
+		return 0, points
+	}
+	return points / 2, points / 2
+}
+
+// concededHoleSet converts 1-based conceded hole numbers into a set of 0-based hole indexes
+func concededHoleSet(holes []int) map[int]bool {
+	set := make(map[int]bool, len(holes))
+	for _, hole := range holes {
+		set[hole-1] = true
+	}
+	return set
+}
+
+// ValidateConcededHoles checks that conceded hole numbers are within the round and not repeated
+func ValidateConcededHoles(holes []int, numHoles int) error {
+	seen := make(map[int]bool, len(holes))
+	for _, hole := range holes {
+		if hole < 1 || hole > numHoles {
+			return fmt.Errorf("conceded hole %d is out of range 1-%d", hole, numHoles)
+		}
+		if seen[hole] {
+			return fmt.Errorf("conceded hole %d is listed more than once", hole)
+		}
+		seen[hole] = true
+	}
+	return nil
+}
+
+// FillConcededHoleScoresWithCap substitutes net par for conceded holes that were recorded
+// without a stroke count (0), so the round can still be used for handicap purposes. Holes with
+// a recorded score are left unchanged. Net par is the hole par plus the strokes the player
+// receives on that hole from their course handicap, limited to the league's maximum per hole.
+func FillConcededHoleScoresWithCap(holeScores []int, concededHoles []int, course models.Course, courseHandicap int, maxStrokesPerHole int) []int {
+	filled := make([]int, len(holeScores))
+	copy(filled, holeScores)
+
+	if len(course.HolePars) != len(holeScores) || len(course.HoleHandicaps) != len(holeScores) {
+		return filled
+	}
+
+	for _, hole := range concededHoles {
+		i := hole - 1
+		if i < 0 || i >= len(filled) || filled[i] > 0 {
+			continue
+		}
+		filled[i] = course.HolePars[i] + calculateStrokesForHole(courseHandicap, course.HoleHandicaps[i], len(filled), maxStrokesPerHole)
+	}
+
+	return filled
 }
 
 // HandleAbsence calculates handicap adjustment for absent player
@@ -173,6 +493,21 @@ func HandleAbsence(absentPlayer models.HandicapRecord, lastFiveScores []models.S
 	return math.Round(baseAdjustment*10) / 10
 }
 
+// DefaultAbsentPenaltyStrokes is the number of strokes over par plus handicap an absent
+// player is charged when the league does not configure its own
+const DefaultAbsentPenaltyStrokes = 3
+
+// MaxAbsentPenaltyStrokes is the largest configurable absent penalty
+const MaxAbsentPenaltyStrokes = 18
+
+// AbsentPenaltyStrokes returns the league's absent penalty, or the default when unset
+func AbsentPenaltyStrokes(league models.League) int {
+	if league.AbsentStrokesOverParPlusHandicap != nil {
+		return *league.AbsentStrokesOverParPlusHandicap
+	}
+	return DefaultAbsentPenaltyStrokes
+}
+
 // CalculateAbsentPlayerScores calculates the hole scores for an absent player.
 // According to the rules:
 // - Total gross score = playing handicap + par + 3
@@ -180,13 +515,19 @@ func HandleAbsence(absentPlayer models.HandicapRecord, lastFiveScores []models.S
 // - Strokes are distributed evenly across holes, with extra strokes on hardest holes
 // - Each hole score = par + applied strokes for that hole
 func CalculateAbsentPlayerScores(playingHandicap int, course models.Course) []int {
+	return CalculateAbsentPlayerScoresWithPenalty(playingHandicap, DefaultAbsentPenaltyStrokes, course)
+}
+
+// CalculateAbsentPlayerScoresWithPenalty calculates the hole scores for an absent player,
+// charging penaltyStrokes in place of the default +3
+func CalculateAbsentPlayerScoresWithPenalty(playingHandicap, penaltyStrokes int, course models.Course) []int {
 	numHoles := len(course.HolePars)
 	if numHoles == 0 {
 		numHoles = holesPerRound
 	}
 
-	// Total strokes above par to apply = playing handicap + 3
-	totalStrokesAbovePar := playingHandicap + 3
+	// Total strokes above par to apply = playing handicap + penalty
+	totalStrokesAbovePar := playingHandicap + penaltyStrokes
 
 	// Distribute strokes evenly, with extras going to holes based on hole handicap
 	holeScores := make([]int, numHoles)
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"golf-league-manager/internal/models"
+)
+
+// MatchStore finds and creates matches in storage
+type MatchStore interface {
+	FindMatch(ctx context.Context, matchDayID, playerAID, playerBID string) (*models.Match, error)
+	CreateMatch(ctx context.Context, match models.Match) error
+}
+
+// FindOrCreateMatch creates the match unless the same two players are already paired on its
+// match day, in which case the existing match is returned instead. This makes a retried
+// create safe. The returned bool reports whether a new match was created. Matches without a
+// match day are always created.
+func FindOrCreateMatch(ctx context.Context, store MatchStore, match models.Match) (models.Match, bool, error) {
+	if match.MatchDayID != "" {
+		existing, err := store.FindMatch(ctx, match.MatchDayID, match.PlayerAID, match.PlayerBID)
+		if err != nil {
+			return models.Match{}, false, err
+		}
+		if existing != nil {
+			return *existing, false, nil
+		}
+	}
+
+	if err := store.CreateMatch(ctx, match); err != nil {
+		return models.Match{}, false, err
+	}
+	return match, true, nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeMatchStore keeps matches in memory and counts creates
+type fakeMatchStore struct {
+	matches []models.Match
+	creates int
+}
+
+func (f *fakeMatchStore) FindMatch(ctx context.Context, matchDayID, playerAID, playerBID string) (*models.Match, error) {
+	for _, m := range f.matches {
+		if m.MatchDayID != matchDayID {
+			continue
+		}
+		if (m.PlayerAID == playerAID && m.PlayerBID == playerBID) ||
+			(m.PlayerAID == playerBID && m.PlayerBID == playerAID) {
+			match := m
+			return &match, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeMatchStore) CreateMatch(ctx context.Context, match models.Match) error {
+	f.matches = append(f.matches, match)
+	f.creates++
+	return nil
+}
+
+func TestFindOrCreateMatchRetry(t *testing.T) {
+	store := &fakeMatchStore{}
+	ctx := context.Background()
+
+	first, created, err := FindOrCreateMatch(ctx, store, models.Match{ID: "m1", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "p2"})
+	if err != nil || !created {
+		t.Fatalf("first create: created=%v err=%v, want created", created, err)
+	}
+
+	// A retry mints a new ID but describes the same matchup
+	retried, created, err := FindOrCreateMatch(ctx, store, models.Match{ID: "m2", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "p2"})
+	if err != nil {
+		t.Fatalf("retry: unexpected error: %v", err)
+	}
+	if created {
+		t.Error("retry created a duplicate match")
+	}
+	if retried.ID != first.ID {
+		t.Errorf("retry returned match %s, want original %s", retried.ID, first.ID)
+	}
+
+	// Same players in the other order are the same matchup
+	swapped, created, _ := FindOrCreateMatch(ctx, store, models.Match{ID: "m3", MatchDayID: "md1", PlayerAID: "p2", PlayerBID: "p1"})
+	if created || swapped.ID != first.ID {
+		t.Errorf("swapped retry: created=%v id=%s, want original %s", created, swapped.ID, first.ID)
+	}
+
+	if store.creates != 1 {
+		t.Errorf("store saw %d creates, want 1", store.creates)
+	}
+}
+
+func TestFindOrCreateMatchDifferentMatchDay(t *testing.T) {
+	store := &fakeMatchStore{}
+	ctx := context.Background()
+
+	FindOrCreateMatch(ctx, store, models.Match{ID: "m1", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "p2"})
+	_, created, err := FindOrCreateMatch(ctx, store, models.Match{ID: "m2", MatchDayID: "md2", PlayerAID: "p1", PlayerBID: "p2"})
+	if err != nil || !created {
+		t.Errorf("rematch on another day: created=%v err=%v, want created", created, err)
+	}
+}
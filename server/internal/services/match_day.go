@@ -0,0 +1,295 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// Limits for recorded playing conditions
+const (
+	maxConditionsLength = 500
+	minTemperatureF     = -20
+	maxTemperatureF     = 130
+	maxWindMph          = 100
+)
+
+// MatchDayConditions holds the optional playing conditions that can be supplied when
+// creating or updating a match day. Nil fields leave the existing value unchanged.
+type MatchDayConditions struct {
+	Conditions   *string `json:"conditions"`
+	TemperatureF *int    `json:"temperatureF"`
+	WindMph      *int    `json:"windMph"`
+}
+
+// ApplyMatchDayConditions validates and applies playing conditions to a match day.
+// Conditions cannot be changed once a match day is locked.
+func ApplyMatchDayConditions(matchDay *models.MatchDay, conditions MatchDayConditions) error {
+	if matchDay.Status == "locked" {
+		return fmt.Errorf("cannot update conditions for a locked match day")
+	}
+
+	if conditions.Conditions != nil && len(*conditions.Conditions) > maxConditionsLength {
+		return fmt.Errorf("conditions must be %d characters or less", maxConditionsLength)
+	}
+	if conditions.TemperatureF != nil && (*conditions.TemperatureF < minTemperatureF || *conditions.TemperatureF > maxTemperatureF) {
+		return fmt.Errorf("temperature must be between %d and %d", minTemperatureF, maxTemperatureF)
+	}
+	if conditions.WindMph != nil && (*conditions.WindMph < 0 || *conditions.WindMph > maxWindMph) {
+		return fmt.Errorf("wind speed must be between 0 and %d", maxWindMph)
+	}
+
+	if conditions.Conditions != nil {
+		matchDay.Conditions = *conditions.Conditions
+	}
+	if conditions.TemperatureF != nil {
+		temperature := *conditions.TemperatureF
+		matchDay.TemperatureF = &temperature
+	}
+	if conditions.WindMph != nil {
+		wind := *conditions.WindMph
+		matchDay.WindMph = &wind
+	}
+
+	return nil
+}
+
+// MatchDaysToLock returns the match days that should be locked once scores are entered for
+// current: unlocked days in the same season that fall before it. Days from other seasons are
+// never included, even when the seasons overlap in dates.
+func MatchDaysToLock(current models.MatchDay, matchDays []models.MatchDay) []models.MatchDay {
+	toLock := make([]models.MatchDay, 0)
+	for _, md := range matchDays {
+		if md.ID == current.ID || md.SeasonID != current.SeasonID {
+			continue
+		}
+		if md.Date.Before(current.Date) && md.Status != "locked" {
+			toLock = append(toLock, md)
+		}
+	}
+	return toLock
+}
+
+// MatchDayStatusAfterScoreEntry returns the status a match day should have once scores have been
+// entered for it. Locked and completed days keep their status. Otherwise the day is completed,
+// unless the season requires complete match days and some match is still missing a player's
+// score, in which case it stays scheduled. scores is keyed by match ID and then player ID.
+func MatchDayStatusAfterScoreEntry(season models.Season, matchDay models.MatchDay, matches []models.Match, scores map[string]map[string]models.Score) string {
+	if matchDay.Status == "locked" || matchDay.Status == "completed" {
+		return matchDay.Status
+	}
+	if season.RequireCompleteMatchDays {
+		for _, match := range matches {
+			matchScores := scores[match.ID]
+			if _, ok := matchScores[match.PlayerAID]; !ok {
+				return "scheduled"
+			}
+			if _, ok := matchScores[match.PlayerBID]; !ok {
+				return "scheduled"
+			}
+		}
+	}
+	return "completed"
+}
+
+// DoubleBookedPlayers returns the players who appear in more than one slot across a match
+// day's matches, including a player matched against themselves, in order of first appearance.
+// Empty player IDs are ignored.
+func DoubleBookedPlayers(matches []models.Match) []string {
+	counts := make(map[string]int)
+	order := make([]string, 0)
+	for _, match := range matches {
+		for _, playerID := range []string{match.PlayerAID, match.PlayerBID} {
+			if playerID == "" {
+				continue
+			}
+			if counts[playerID] == 0 {
+				order = append(order, playerID)
+			}
+			counts[playerID]++
+		}
+	}
+
+	doubleBooked := make([]string, 0)
+	for _, playerID := range order {
+		if counts[playerID] > 1 {
+			doubleBooked = append(doubleBooked, playerID)
+		}
+	}
+	return doubleBooked
+}
+
+// ValidateMatchDayMatchups checks that no player is scheduled more than once on a match day
+func ValidateMatchDayMatchups(matches []models.Match) error {
+	if doubleBooked := DoubleBookedPlayers(matches); len(doubleBooked) > 0 {
+		return fmt.Errorf("players scheduled more than once on this match day: %s", strings.Join(doubleBooked, ", "))
+	}
+	return nil
+}
+
+// DuplicateMatchDay copies a match day's course, match play basis and pairings to a new date,
+// so a recurring setup can be scheduled again without re-entering it. The copy is a fresh
+// scheduled day: it gets new IDs from newID, and no points, absences, disputes or playing
+// conditions carry over. Matches keep their own course when it differs from the day's.
+func DuplicateMatchDay(source models.MatchDay, matches []models.Match, date time.Time, now time.Time, newID func() string) (models.MatchDay, []models.Match) {
+	matchDay := models.MatchDay{
+		ID:        newID(),
+		LeagueID:  source.LeagueID,
+		SeasonID:  source.SeasonID,
+		Date:      date,
+		CourseID:  source.CourseID,
+		Status:    "scheduled",
+		CreatedAt: now,
+
+		MatchPlayBasis: source.MatchPlayBasis,
+	}
+
+	copies := make([]models.Match, 0, len(matches))
+	for _, match := range matches {
+		courseID := match.CourseID
+		if courseID == "" {
+			courseID = source.CourseID
+		}
+		copies = append(copies, models.Match{
+			ID:          newID(),
+			LeagueID:    matchDay.LeagueID,
+			SeasonID:    matchDay.SeasonID,
+			MatchDayID:  matchDay.ID,
+			PlayerAID:   match.PlayerAID,
+			PlayerBID:   match.PlayerBID,
+			PlayerAName: match.PlayerAName,
+			PlayerBName: match.PlayerBName,
+			CourseID:    courseID,
+			MatchDate:   date,
+			Status:      "scheduled",
+		})
+	}
+	return matchDay, copies
+}
+
+// ChangeMatchDayCourse moves a match day and all of its matches to another course, for when a
+// course closes at short notice. Scores already entered move with them and are rebuilt on the
+// new course from the hole scores as entered, with the index each player brought into the
+// match: course and playing handicaps, strokes, adjusted gross and net scores all follow the
+// new course, and completed matches are scored again off the new strokes. seasonPlayers
+// supplies the index of a player with no score yet, and unestablished marks the players
+// without an established handicap, as in score entry. The day's PCC is then recomputed across
+// the field and each non-absent differential recalculated against the new course's rating and
+// slope, then re-checked for high round review. Locked match days cannot be moved, and neither
+// can scores onto a course with a different number of holes. The caller saves the results and
+// recalculates indices.
+func ChangeMatchDayCourse(season models.Season, league models.League, matchDay models.MatchDay, matches []models.Match, scores []models.Score, course models.Course, seasonPlayers map[string]models.SeasonPlayer, unestablished map[string]bool) (models.MatchDay, []models.Match, []models.Score, error) {
+	if matchDay.Status == "locked" {
+		return models.MatchDay{}, nil, nil, fmt.Errorf("cannot change the course of a locked match day")
+	}
+	usesHandicaps := UsesHandicaps(league)
+	if len(scores) > 0 && usesHandicaps {
+		if err := CheckSlopeRating(course.SlopeRating); err != nil {
+			return models.MatchDay{}, nil, nil, err
+		}
+	}
+	for _, score := range scores {
+		if !score.PlayerAbsent && len(score.HoleScores) != len(course.HolePars) {
+			return models.MatchDay{}, nil, nil, fmt.Errorf("course %s has %d holes but scores were entered for %d", course.Name, len(course.HolePars), len(score.HoleScores))
+		}
+	}
+
+	matchDay.CourseID = course.ID
+
+	moved := make([]models.Match, len(matches))
+	for i, match := range matches {
+		match.CourseID = course.ID
+		moved[i] = match
+	}
+	if len(scores) == 0 {
+		return matchDay, moved, []models.Score{}, nil
+	}
+
+	byMatch := make(map[string]map[string]models.Score, len(moved))
+	for _, score := range scores {
+		if byMatch[score.MatchID] == nil {
+			byMatch[score.MatchID] = make(map[string]models.Score, 2)
+		}
+		byMatch[score.MatchID][score.PlayerID] = score
+	}
+
+	rules := LeagueMatchPointRules(league)
+	rebuilt := make(map[string]map[string]models.Score, len(byMatch))
+	for i, match := range moved {
+		existing := byMatch[match.ID]
+		if len(existing) == 0 {
+			continue
+		}
+		indexA := MatchScoreIndex(match.PlayerAID, existing, seasonPlayers)
+		indexB := MatchScoreIndex(match.PlayerBID, existing, seasonPlayers)
+		handicapA, handicapB := MatchHandicaps(match, course, league, matchDay.MatchPlayBasis, indexA, indexB)
+		if match.StrokesOverridden {
+			ApplyStrokeOverride(match, len(course.HolePars), match.StrokeOverride, &handicapA, &handicapB)
+		}
+
+		matchScores := make(map[string]models.Score, len(existing))
+		for playerID, handicap := range map[string]PlayerMatchHandicap{match.PlayerAID: handicapA, match.PlayerBID: handicapB} {
+			old, ok := existing[playerID]
+			if !ok {
+				continue
+			}
+			handicap.Unestablished = unestablished[playerID]
+			card := ScoreCard{
+				HoleScores:    old.HoleScores,
+				ConcededHoles: old.ConcededHoles,
+				PlayerAbsent:  old.PlayerAbsent,
+				Makeup:        old.Makeup,
+				Substitute:    old.Substitute,
+			}
+			score, err := BuildMatchScore(match, course, league, playerID, card, handicap)
+			if err != nil {
+				return models.MatchDay{}, nil, nil, fmt.Errorf("score %s: %w", old.ID, err)
+			}
+			score.ID = old.ID
+			score.PlayerName = old.PlayerName
+			score.LeagueID = old.LeagueID
+			score.Date = old.Date
+			score.WeekNumber = old.WeekNumber
+			score.ReviewedAt = old.ReviewedAt
+			score.Official = old.Official
+			matchScores[playerID] = score
+		}
+		rebuilt[match.ID] = matchScores
+
+		if match.Status == "completed" {
+			completed, ok, err := CompleteMatchWithRules(season, rules, match, matchScores, handicapA.Strokes, handicapB.Strokes)
+			if err != nil {
+				return models.MatchDay{}, nil, nil, fmt.Errorf("match %s: %w", match.ID, err)
+			}
+			if ok {
+				moved[i] = completed
+			}
+		}
+	}
+
+	rescored := make([]models.Score, len(scores))
+	for i, score := range scores {
+		rebuiltScore, ok := rebuilt[score.MatchID][score.PlayerID]
+		if !ok {
+			return models.MatchDay{}, nil, nil, fmt.Errorf("score %s is not for a match on this match day", score.ID)
+		}
+		rescored[i] = rebuiltScore
+	}
+	if !usesHandicaps {
+		return matchDay, moved, rescored, nil
+	}
+
+	courses := map[string]models.Course{course.ID: course}
+	standardSlope := StandardSlope(league)
+	matchDay.PCC = ComputeSeasonPCC(season, rescored, courses, standardSlope)
+	for i := range rescored {
+		if rescored[i].PlayerAbsent {
+			continue
+		}
+		rescored[i].HandicapDifferential = CalculateLeagueDifferential(rescored[i], course, matchDay.PCC, standardSlope)
+		FlagHighRound(league, &rescored[i])
+	}
+	return matchDay, moved, rescored, nil
+}
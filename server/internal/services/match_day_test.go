@@ -0,0 +1,417 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestApplyMatchDayConditions(t *testing.T) {
+	t.Run("sets conditions on a new match day", func(t *testing.T) {
+		matchDay := models.MatchDay{Status: "scheduled"}
+
+		err := ApplyMatchDayConditions(&matchDay, MatchDayConditions{
+			Conditions:   stringPtr("windy, firm greens"),
+			TemperatureF: intPtr(58),
+			WindMph:      intPtr(22),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if matchDay.Conditions != "windy, firm greens" {
+			t.Errorf("Conditions = %q, want %q", matchDay.Conditions, "windy, firm greens")
+		}
+		if matchDay.TemperatureF == nil || *matchDay.TemperatureF != 58 {
+			t.Errorf("TemperatureF = %v, want 58", matchDay.TemperatureF)
+		}
+		if matchDay.WindMph == nil || *matchDay.WindMph != 22 {
+			t.Errorf("WindMph = %v, want 22", matchDay.WindMph)
+		}
+	})
+
+	t.Run("partial update keeps other fields", func(t *testing.T) {
+		matchDay := models.MatchDay{
+			Status:       "scheduled",
+			Conditions:   "calm",
+			TemperatureF: intPtr(70),
+			WindMph:      intPtr(5),
+		}
+
+		err := ApplyMatchDayConditions(&matchDay, MatchDayConditions{WindMph: intPtr(30)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if matchDay.Conditions != "calm" {
+			t.Errorf("Conditions = %q, want unchanged %q", matchDay.Conditions, "calm")
+		}
+		if matchDay.TemperatureF == nil || *matchDay.TemperatureF != 70 {
+			t.Errorf("TemperatureF = %v, want unchanged 70", matchDay.TemperatureF)
+		}
+		if matchDay.WindMph == nil || *matchDay.WindMph != 30 {
+			t.Errorf("WindMph = %v, want 30", matchDay.WindMph)
+		}
+	})
+
+	t.Run("completed match day accepts conditions", func(t *testing.T) {
+		matchDay := models.MatchDay{Status: "completed"}
+
+		if err := ApplyMatchDayConditions(&matchDay, MatchDayConditions{Conditions: stringPtr("rain delay")}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if matchDay.Conditions != "rain delay" {
+			t.Errorf("Conditions = %q, want %q", matchDay.Conditions, "rain delay")
+		}
+	})
+
+	tests := []struct {
+		name       string
+		matchDay   models.MatchDay
+		conditions MatchDayConditions
+	}{
+		{
+			name:       "locked match day",
+			matchDay:   models.MatchDay{Status: "locked"},
+			conditions: MatchDayConditions{Conditions: stringPtr("sunny")},
+		},
+		{
+			name:       "temperature too low",
+			matchDay:   models.MatchDay{Status: "scheduled"},
+			conditions: MatchDayConditions{TemperatureF: intPtr(-40)},
+		},
+		{
+			name:       "temperature too high",
+			matchDay:   models.MatchDay{Status: "scheduled"},
+			conditions: MatchDayConditions{TemperatureF: intPtr(150)},
+		},
+		{
+			name:       "negative wind",
+			matchDay:   models.MatchDay{Status: "scheduled"},
+			conditions: MatchDayConditions{WindMph: intPtr(-1)},
+		},
+		{
+			name:       "wind too high",
+			matchDay:   models.MatchDay{Status: "scheduled"},
+			conditions: MatchDayConditions{WindMph: intPtr(120)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchDay := tt.matchDay
+			if err := ApplyMatchDayConditions(&matchDay, tt.conditions); err == nil {
+				t.Error("expected error, got nil")
+			}
+			if matchDay.Conditions != "" || matchDay.TemperatureF != nil || matchDay.WindMph != nil {
+				t.Errorf("match day was modified despite error: %+v", matchDay)
+			}
+		})
+	}
+}
+
+func TestMatchDaysToLockOverlappingSeasons(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Spring and summer seasons overlap in June
+	current := models.MatchDay{ID: "spring-4", SeasonID: "spring", Date: day(22), Status: "completed"}
+	matchDays := []models.MatchDay{
+		current,
+		{ID: "spring-1", SeasonID: "spring", Date: day(1), Status: "locked"},
+		{ID: "spring-2", SeasonID: "spring", Date: day(8), Status: "completed"},
+		{ID: "spring-3", SeasonID: "spring", Date: day(15), Status: "scheduled"},
+		{ID: "spring-5", SeasonID: "spring", Date: day(29), Status: "scheduled"},
+		{ID: "summer-1", SeasonID: "summer", Date: day(10), Status: "completed"},
+		{ID: "summer-2", SeasonID: "summer", Date: day(17), Status: "scheduled"},
+		{ID: "summer-3", SeasonID: "summer", Date: day(24), Status: "scheduled"},
+	}
+
+	toLock := MatchDaysToLock(current, matchDays)
+
+	got := make(map[string]bool, len(toLock))
+	for _, md := range toLock {
+		got[md.ID] = true
+	}
+	want := []string{"spring-2", "spring-3"}
+	if len(toLock) != len(want) {
+		t.Fatalf("got %d match days to lock, want %d: %v", len(toLock), len(want), got)
+	}
+	for _, id := range want {
+		if !got[id] {
+			t.Errorf("expected %s to be locked", id)
+		}
+	}
+	for id := range got {
+		if id[:6] == "summer" {
+			t.Errorf("match day %s from another season would be locked", id)
+		}
+	}
+}
+
+func TestMatchDayStatusAfterScoreEntryPartialDay(t *testing.T) {
+	matchDay := models.MatchDay{ID: "md1", Status: "scheduled"}
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p4"},
+	}
+	// m2 only has player A's score so far
+	partial := map[string]map[string]models.Score{
+		"m1": {"p1": {}, "p2": {}},
+		"m2": {"p3": {}},
+	}
+	complete := map[string]map[string]models.Score{
+		"m1": {"p1": {}, "p2": {}},
+		"m2": {"p3": {}, "p4": {}},
+	}
+
+	tests := []struct {
+		name    string
+		require bool
+		scores  map[string]map[string]models.Score
+		want    string
+	}{
+		{"partial day completes by default", false, partial, "completed"},
+		{"partial day stays open when complete days are required", true, partial, "scheduled"},
+		{"match with no scores keeps the day open", true, map[string]map[string]models.Score{"m1": {"p1": {}, "p2": {}}}, "scheduled"},
+		{"fully scored day completes when complete days are required", true, complete, "completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season := models.Season{RequireCompleteMatchDays: tt.require}
+			if got := MatchDayStatusAfterScoreEntry(season, matchDay, matches, tt.scores); got != tt.want {
+				t.Errorf("status = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	locked := models.MatchDay{ID: "md1", Status: "locked"}
+	if got := MatchDayStatusAfterScoreEntry(models.Season{RequireCompleteMatchDays: true}, locked, matches, partial); got != "locked" {
+		t.Errorf("locked day status = %q, want it to stay locked", got)
+	}
+}
+
+func TestValidateMatchDayMatchups(t *testing.T) {
+	valid := []models.Match{
+		{PlayerAID: "p1", PlayerBID: "p2"},
+		{PlayerAID: "p3", PlayerBID: "p4"},
+	}
+	if err := ValidateMatchDayMatchups(valid); err != nil {
+		t.Errorf("valid matchups: unexpected error %v", err)
+	}
+
+	// p1 and p4 are both placed in two matches
+	doubleBooked := []models.Match{
+		{PlayerAID: "p1", PlayerBID: "p2"},
+		{PlayerAID: "p3", PlayerBID: "p4"},
+		{PlayerAID: "p4", PlayerBID: "p1"},
+	}
+	if got := DoubleBookedPlayers(doubleBooked); len(got) != 2 || got[0] != "p1" || got[1] != "p4" {
+		t.Errorf("double booked = %v, want [p1 p4]", got)
+	}
+	err := ValidateMatchDayMatchups(doubleBooked)
+	if err == nil || !strings.Contains(err.Error(), "p1, p4") {
+		t.Errorf("err = %v, want it to list p1, p4", err)
+	}
+
+	// A player matched against themselves is also scheduled twice
+	if got := DoubleBookedPlayers([]models.Match{{PlayerAID: "p5", PlayerBID: "p5"}}); len(got) != 1 || got[0] != "p5" {
+		t.Errorf("self match: double booked = %v, want [p5]", got)
+	}
+}
+
+func TestDuplicateMatchDay(t *testing.T) {
+	lastWeek := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	nextWeek := lastWeek.AddDate(0, 0, 14)
+	now := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	source := models.MatchDay{ID: "md1", LeagueID: "l1", SeasonID: "s1", Date: lastWeek, CourseID: "c1", Status: "locked", Conditions: "windy", PCC: 2}
+	matches := []models.Match{
+		{ID: "m1", MatchDayID: "md1", PlayerAID: "p1", PlayerAName: "Alice", PlayerBID: "p2", PlayerBName: "Bob", CourseID: "c1",
+			MatchDate: lastWeek, Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8, PlayerBAbsent: true, Disputed: true},
+		{ID: "m2", MatchDayID: "md1", PlayerAID: "p3", PlayerBID: "p4", CourseID: "c2", MatchDate: lastWeek, Status: "completed"},
+	}
+
+	next := 0
+	newID := func() string {
+		next++
+		return "new-" + string(rune('0'+next))
+	}
+	day, copies := DuplicateMatchDay(source, matches, nextWeek, now, newID)
+
+	if day.ID == source.ID || day.Status != "scheduled" || !day.Date.Equal(nextWeek) || !day.CreatedAt.Equal(now) {
+		t.Errorf("day = %+v, want a new scheduled day on %v", day, nextWeek)
+	}
+	if day.CourseID != "c1" || day.SeasonID != "s1" || day.LeagueID != "l1" {
+		t.Errorf("day = %+v, want the same league, season and course", day)
+	}
+	if day.Conditions != "" || day.PCC != 0 {
+		t.Errorf("day = %+v, want no playing conditions carried over", day)
+	}
+
+	if len(copies) != 2 {
+		t.Fatalf("got %d matches, want 2", len(copies))
+	}
+	for i, match := range copies {
+		orig := matches[i]
+		if match.PlayerAID != orig.PlayerAID || match.PlayerBID != orig.PlayerBID || match.CourseID != orig.CourseID {
+			t.Errorf("match %d = %+v, want the pairing %s v %s on %s", i, match, orig.PlayerAID, orig.PlayerBID, orig.CourseID)
+		}
+		if match.ID == orig.ID || match.MatchDayID != day.ID || !match.MatchDate.Equal(nextWeek) || match.Status != "scheduled" {
+			t.Errorf("match %d = %+v, want a new scheduled match on the new day", i, match)
+		}
+		if match.PlayerAPoints != 0 || match.PlayerBPoints != 0 || match.PlayerBAbsent || match.Disputed {
+			t.Errorf("match %d = %+v, want no result carried over", i, match)
+		}
+	}
+	if copies[0].PlayerAName != "Alice" || copies[0].PlayerBName != "Bob" {
+		t.Errorf("names = %q v %q, want Alice v Bob", copies[0].PlayerAName, copies[0].PlayerBName)
+	}
+
+	// Changing the copy leaves last week's schedule alone
+	copies[0].PlayerBID = "p9"
+	if matches[0].PlayerBID != "p2" || matches[0].ID != "m1" || matches[0].Status != "completed" {
+		t.Errorf("source match changed: %+v", matches[0])
+	}
+}
+
+func TestChangeMatchDayCourseBeforeScores(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c2"
+	day := models.MatchDay{ID: "md1", CourseID: "c1", Status: "scheduled", PCC: 1}
+	matches := []models.Match{{ID: "m1", CourseID: "c1"}, {ID: "m2", CourseID: "c3"}}
+
+	moved, movedMatches, scores, err := ChangeMatchDayCourse(models.Season{}, models.League{}, day, matches, nil, course, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if moved.CourseID != "c2" || moved.PCC != 1 {
+		t.Errorf("match day = %+v, want course c2 with its PCC untouched", moved)
+	}
+	for _, match := range movedMatches {
+		if match.CourseID != "c2" {
+			t.Errorf("match %s on course %s, want c2", match.ID, match.CourseID)
+		}
+	}
+	if matches[0].CourseID != "c1" {
+		t.Error("input matches should not be modified")
+	}
+	if len(scores) != 0 {
+		t.Errorf("scores = %+v, want none", scores)
+	}
+}
+
+func TestChangeMatchDayCourseRescoresEnteredScores(t *testing.T) {
+	oldCourse := absentPreviewCourse()
+	oldCourse.ID = "c1"
+	newCourse := models.Course{
+		ID:            "c2",
+		Par:           36,
+		CourseRating:  37.2,
+		SlopeRating:   131,
+		HolePars:      []int{4, 4, 4, 3, 5, 4, 3, 4, 5},
+		HoleHandicaps: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+	league := models.League{}
+
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", CourseID: "c1", Status: "completed"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p4", CourseID: "c1", Status: "scheduled"},
+	}
+	a, b := MatchHandicaps(matches[0], oldCourse, league, MatchPlayNet, 12, 9)
+	scoreA, err := BuildMatchScore(matches[0], oldCourse, league, "p1", ScoreCard{HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}}, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoreB, err := BuildMatchScore(matches[0], oldCourse, league, "p2", ScoreCard{HoleScores: []int{5, 4, 6, 9, 5, 4, 6, 5, 5}}, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoreA.ID, scoreB.ID = "s1", "s2"
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, scoreA, scoreB, a.Strokes, b.Strokes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches[0].PlayerAPoints, matches[0].PlayerBPoints = pointsA, pointsB
+	absent := models.Score{ID: "s3", MatchID: "m2", PlayerID: "p3", CourseID: "c1", PlayerAbsent: true, HandicapIndex: 20}
+	scores := []models.Score{scoreA, scoreB, absent}
+	seasonPlayers := map[string]models.SeasonPlayer{"p4": {PlayerID: "p4", CurrentHandicapIndex: 5}}
+	day := models.MatchDay{ID: "md1", CourseID: "c1", Status: "completed"}
+
+	moved, movedMatches, rescored, err := ChangeMatchDayCourse(models.Season{}, league, day, matches, scores, newCourse, seasonPlayers, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if moved.CourseID != "c2" {
+		t.Errorf("match day course = %s, want c2", moved.CourseID)
+	}
+
+	wantA, wantB := MatchHandicaps(movedMatches[0], newCourse, league, MatchPlayNet, 12, 9)
+	for i, want := range []PlayerMatchHandicap{wantA, wantB} {
+		score := rescored[i]
+		if score.ID != scores[i].ID || score.CourseID != "c2" {
+			t.Errorf("score %s on course %s, want %s on c2", score.ID, score.CourseID, scores[i].ID)
+		}
+		if score.CourseHandicap != int(math.Round(want.CourseHandicap)) || score.CourseHandicap == scores[i].CourseHandicap {
+			t.Errorf("score %s course handicap = %d, want %v on the new course (was %d)", score.ID, score.CourseHandicap, want.CourseHandicap, scores[i].CourseHandicap)
+		}
+		if !reflect.DeepEqual(score.MatchStrokes, want.Strokes) {
+			t.Errorf("score %s strokes = %v, want %v", score.ID, score.MatchStrokes, want.Strokes)
+		}
+		adjusted := 0
+		for _, hole := range CalculateAdjustedGrossScoresWithCap(score.HoleScores, newCourse, score.CourseHandicap, DefaultMaxStrokesPerHole) {
+			adjusted += hole
+		}
+		if score.AdjustedGross != adjusted {
+			t.Errorf("score %s adjusted gross = %d, want %d on the new course", score.ID, score.AdjustedGross, adjusted)
+		}
+		if want := CalculateLeagueDifferential(score, newCourse, moved.PCC, DefaultStandardSlope); score.HandicapDifferential != want {
+			t.Errorf("score %s differential = %v, want %v", score.ID, score.HandicapDifferential, want)
+		}
+	}
+
+	wantPointsA, wantPointsB, err := ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, rescored[0], rescored[1], wantA.Strokes, wantB.Strokes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movedMatches[0].PlayerAPoints != wantPointsA || movedMatches[0].PlayerBPoints != wantPointsB {
+		t.Errorf("points = %d-%d, want %d-%d off the new strokes", movedMatches[0].PlayerAPoints, movedMatches[0].PlayerBPoints, wantPointsA, wantPointsB)
+	}
+	if movedMatches[1].Status != "scheduled" {
+		t.Errorf("match m2 status = %s, want it left scheduled", movedMatches[1].Status)
+	}
+
+	if !rescored[2].PlayerAbsent || rescored[2].HandicapDifferential != 0 || rescored[2].CourseID != "c2" {
+		t.Errorf("absent score = %+v, want it moved with no differential", rescored[2])
+	}
+}
+
+func TestChangeMatchDayCourseRejected(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c2"
+
+	locked := models.MatchDay{ID: "md1", CourseID: "c1", Status: "locked"}
+	if _, _, _, err := ChangeMatchDayCourse(models.Season{}, models.League{}, locked, nil, nil, course, nil, nil); err == nil {
+		t.Error("expected an error moving a locked match day")
+	}
+
+	day := models.MatchDay{ID: "md1", CourseID: "c1", Status: "completed"}
+	eighteen := []models.Score{{ID: "s1", HoleScores: make([]int, 18), AdjustedGross: 90}}
+	if _, _, _, err := ChangeMatchDayCourse(models.Season{}, models.League{}, day, nil, eighteen, course, nil, nil); err == nil {
+		t.Error("expected an error moving 18-hole scores onto a 9-hole course")
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import "golf-league-manager/internal/models"
+
+// Hole results in a match detail
+const (
+	HoleWonByA = "A"
+	HoleWonByB = "B"
+	HoleHalved = "halved"
+)
+
+// MatchDetailPlayer is one side of a match detail. The handicap figures are those used for the
+// round when the player's scorecard is in, and their current season index otherwise.
+type MatchDetailPlayer struct {
+	PlayerID        string        `json:"playerId"`
+	PlayerName      string        `json:"playerName"`
+	HandicapIndex   float64       `json:"handicapIndex"`
+	CourseHandicap  int           `json:"courseHandicap"`
+	PlayingHandicap int           `json:"playingHandicap"`
+	Score           *models.Score `json:"score,omitempty"` // Scorecard, with match strokes and net per hole
+}
+
+// MatchHoleResult is one hole of a match as scored
+type MatchHoleResult struct {
+	Hole     int    `json:"hole"`
+	Par      int    `json:"par"`
+	Handicap int    `json:"handicap"` // Stroke index of the hole
+	GrossA   int    `json:"grossA"`
+	GrossB   int    `json:"grossB"`
+	StrokesA int    `json:"strokesA"`
+	StrokesB int    `json:"strokesB"`
+	NetA     int    `json:"netA"`
+	NetB     int    `json:"netB"`
+	Winner   string `json:"winner"` // A, B or halved
+	Conceded bool   `json:"conceded,omitempty"`
+}
+
+// MatchDetail is everything about a match in one place: the players, the course, both
+// scorecards, who won each hole and the points recorded
+type MatchDetail struct {
+	Match   models.Match      `json:"match"`
+	PlayerA MatchDetailPlayer `json:"playerA"`
+	PlayerB MatchDetailPlayer `json:"playerB"`
+	Course  models.Course     `json:"course"`
+	Holes   []MatchHoleResult `json:"holes"` // Empty until both scorecards are in
+	PointsA int               `json:"pointsA"`
+	PointsB int               `json:"pointsB"`
+}
+
+// BuildMatchDetail assembles a match's detail from its stored scores. Holes are replayed from
+// each scorecard's stored match strokes, the same way the match was scored, so a completed
+// match's hole winners agree with its points; a scorecard without match strokes is treated as
+// receiving none. The points are the ones recorded on the match.
+func BuildMatchDetail(match models.Match, course models.Course, playerA, playerB MatchDetailPlayer, scores []models.Score) MatchDetail {
+	detail := MatchDetail{
+		Match:   match,
+		PlayerA: playerA,
+		PlayerB: playerB,
+		Course:  course,
+		Holes:   make([]MatchHoleResult, 0),
+		PointsA: match.PlayerAPoints,
+		PointsB: match.PlayerBPoints,
+	}
+
+	for i := range scores {
+		switch scores[i].PlayerID {
+		case match.PlayerAID:
+			detail.PlayerA = withScore(detail.PlayerA, scores[i])
+		case match.PlayerBID:
+			detail.PlayerB = withScore(detail.PlayerB, scores[i])
+		}
+	}
+
+	scoreA, scoreB := detail.PlayerA.Score, detail.PlayerB.Score
+	if scoreA == nil || scoreB == nil || len(scoreA.HoleScores) != len(scoreB.HoleScores) {
+		return detail
+	}
+
+	concededToA := concededHoleSet(scoreA.ConcededHoles)
+	concededToB := concededHoleSet(scoreB.ConcededHoles)
+	for i := range scoreA.HoleScores {
+		hole := MatchHoleResult{
+			Hole:     i + 1,
+			GrossA:   scoreA.HoleScores[i],
+			GrossB:   scoreB.HoleScores[i],
+			StrokesA: strokeOn(scoreA.MatchStrokes, i),
+			StrokesB: strokeOn(scoreB.MatchStrokes, i),
+			Conceded: concededToA[i] || concededToB[i],
+		}
+		if i < len(course.HolePars) {
+			hole.Par = course.HolePars[i]
+		}
+		if i < len(course.HoleHandicaps) {
+			hole.Handicap = course.HoleHandicaps[i]
+		}
+		hole.NetA = hole.GrossA - hole.StrokesA
+		hole.NetB = hole.GrossB - hole.StrokesB
+
+		wonA, wonB := holeWinner(hole.NetA, hole.NetB, concededToA[i], concededToB[i])
+		switch {
+		case wonA:
+			hole.Winner = HoleWonByA
+		case wonB:
+			hole.Winner = HoleWonByB
+		default:
+			hole.Winner = HoleHalved
+		}
+		detail.Holes = append(detail.Holes, hole)
+	}
+	return detail
+}
+
+// withScore attaches a player's scorecard and the handicap figures used for it
+func withScore(player MatchDetailPlayer, score models.Score) MatchDetailPlayer {
+	player.Score = &score
+	player.HandicapIndex = score.HandicapIndex
+	player.CourseHandicap = score.CourseHandicap
+	player.PlayingHandicap = score.PlayingHandicap
+	if player.PlayerName == "" {
+		player.PlayerName = score.PlayerName
+	}
+	return player
+}
+
+// strokeOn returns the strokes received on a hole, or none past the end of the strokes
+func strokeOn(strokes []int, hole int) int {
+	if hole < len(strokes) {
+		return strokes[hole]
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildMatchDetailHoleWinners(t *testing.T) {
+	match, scores := pointsAuditMatch(t, "m1")
+	course := absentPreviewCourse()
+	playerA := MatchDetailPlayer{PlayerID: "p1", PlayerName: "Alice"}
+	playerB := MatchDetailPlayer{PlayerID: "p2", PlayerName: "Bob"}
+
+	detail := BuildMatchDetail(match, course, playerA, playerB, scores)
+
+	// Player B gets a stroke on the first hole, halving it
+	want := []string{HoleHalved, HoleHalved, HoleWonByB, HoleWonByA, HoleHalved, HoleWonByA, HoleHalved, HoleWonByB, HoleWonByA}
+	if len(detail.Holes) != len(want) {
+		t.Fatalf("got %d holes, want %d", len(detail.Holes), len(want))
+	}
+	for i, hole := range detail.Holes {
+		if hole.Winner != want[i] {
+			t.Errorf("hole %d: winner %q, want %q", i+1, hole.Winner, want[i])
+		}
+	}
+	first := detail.Holes[0]
+	if first.GrossB != 5 || first.StrokesB != 1 || first.NetB != 4 || first.Par != 4 || first.Handicap != 3 {
+		t.Errorf("hole 1 = %+v", first)
+	}
+
+	// The replayed holes agree with the points the match was scored with
+	if detail.PointsA != 14 || detail.PointsB != 8 || detail.PointsA != match.PlayerAPoints {
+		t.Errorf("points = %d-%d, want 14-8", detail.PointsA, detail.PointsB)
+	}
+	if detail.PlayerA.Score == nil || detail.PlayerB.Score == nil || detail.PlayerA.PlayerName != "Alice" {
+		t.Errorf("players not filled in: %+v, %+v", detail.PlayerA, detail.PlayerB)
+	}
+}
+
+func TestBuildMatchDetailConcededHole(t *testing.T) {
+	match, scores := pointsAuditMatch(t, "m1")
+	scores[1].ConcededHoles = []int{4}
+
+	detail := BuildMatchDetail(match, absentPreviewCourse(), MatchDetailPlayer{PlayerID: "p1"}, MatchDetailPlayer{PlayerID: "p2"}, scores)
+	if hole := detail.Holes[3]; hole.Winner != HoleWonByB || !hole.Conceded {
+		t.Errorf("hole 4 = %+v, want conceded to B", hole)
+	}
+}
+
+func TestBuildMatchDetailMissingScorecard(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+	scores := []models.Score{{PlayerID: "p1", PlayerName: "Alice", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}, HandicapIndex: 8.4}}
+
+	detail := BuildMatchDetail(match, absentPreviewCourse(), MatchDetailPlayer{PlayerID: "p1"}, MatchDetailPlayer{PlayerID: "p2", HandicapIndex: 12.1}, scores)
+	if len(detail.Holes) != 0 {
+		t.Errorf("got %d holes, want none until both scorecards are in", len(detail.Holes))
+	}
+	if detail.PlayerA.PlayerName != "Alice" || detail.PlayerA.HandicapIndex != 8.4 || detail.PlayerB.HandicapIndex != 12.1 {
+		t.Errorf("players = %+v, %+v", detail.PlayerA, detail.PlayerB)
+	}
+}
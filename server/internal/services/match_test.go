@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -211,7 +212,7 @@ func TestCalculateMatchPoints(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotA, gotB := CalculateMatchPoints(tt.scoreA, tt.scoreB, tt.strokesA, tt.strokesB)
+			gotA, gotB := CalculateMatchPointsWithRules(tt.scoreA, tt.scoreB, tt.strokesA, tt.strokesB, DefaultMatchPointRules)
 			if gotA != tt.wantPointsA {
 				t.Errorf("Player A points = %v, want %v", gotA, tt.wantPointsA)
 			}
@@ -283,3 +284,317 @@ func TestHandleAbsence(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateMatchPointsConcededHoles(t *testing.T) {
+	noStrokes := []int{0, 0, 0, 0, 0, 0, 0, 0, 0}
+	holesA := []int{4, 4, 4, 4, 4, 4, 4, 4, 4}
+	holesB := []int{5, 4, 4, 4, 4, 4, 4, 4, 4}
+
+	tests := []struct {
+		name        string
+		concededA   []int
+		concededB   []int
+		wantPointsA int
+		wantPointsB int
+	}{
+		{
+			name:        "no concessions",
+			wantPointsA: 2 + 8 + 4,
+			wantPointsB: 8,
+		},
+		{
+			// B lost hole 1 on the card but A conceded it
+			name:        "conceded hole overrides the scores",
+			concededB:   []int{1},
+			wantPointsA: 8 + 4,
+			wantPointsB: 2 + 8,
+		},
+		{
+			name:        "conceded to both players is halved",
+			concededA:   []int{2},
+			concededB:   []int{2},
+			wantPointsA: 2 + 8 + 4,
+			wantPointsB: 8,
+		},
+		{
+			name:        "hole conceded to A",
+			concededA:   []int{5},
+			wantPointsA: 2 + 2 + 7 + 4,
+			wantPointsB: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scoreA := models.Score{HoleScores: holesA, ConcededHoles: tt.concededA}
+			scoreB := models.Score{HoleScores: holesB, ConcededHoles: tt.concededB}
+
+			gotA, gotB := CalculateMatchPointsWithRules(scoreA, scoreB, noStrokes, noStrokes, DefaultMatchPointRules)
+			if gotA != tt.wantPointsA || gotB != tt.wantPointsB {
+				t.Errorf("points = %d-%d, want %d-%d", gotA, gotB, tt.wantPointsA, tt.wantPointsB)
+			}
+			if gotA+gotB != 22 {
+				t.Errorf("total points = %d, want 22", gotA+gotB)
+			}
+		})
+	}
+}
+
+func TestFillConcededHoleScoresWithCap(t *testing.T) {
+	course := models.Course{
+		Par:           36,
+		CourseRating:  35.0,
+		SlopeRating:   113,
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4, 4},
+		HoleHandicaps: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+	courseHandicap := 3 // Strokes on holes with stroke index 1-3
+
+	t.Run("recorded strokes on conceded holes are kept", func(t *testing.T) {
+		holeScores := []int{5, 4, 6, 5, 5, 4, 6, 5, 5}
+
+		filled := FillConcededHoleScoresWithCap(holeScores, []int{2, 7}, course, courseHandicap, DefaultMaxStrokesPerHole)
+
+		for i := range holeScores {
+			if filled[i] != holeScores[i] {
+				t.Errorf("hole %d = %d, want %d", i+1, filled[i], holeScores[i])
+			}
+		}
+
+		// Handicap totals are the same as without the concessions
+		withConcessions := CalculateAdjustedGrossScores(filled, course, courseHandicap)
+		withoutConcessions := CalculateAdjustedGrossScores(holeScores, course, courseHandicap)
+		for i := range withConcessions {
+			if withConcessions[i] != withoutConcessions[i] {
+				t.Errorf("adjusted hole %d = %d, want %d", i+1, withConcessions[i], withoutConcessions[i])
+			}
+		}
+	})
+
+	t.Run("missing strokes on conceded holes become net par", func(t *testing.T) {
+		holeScores := []int{5, 0, 6, 5, 5, 4, 0, 5, 5}
+
+		filled := FillConcededHoleScoresWithCap(holeScores, []int{2, 7}, course, courseHandicap, DefaultMaxStrokesPerHole)
+
+		// Hole 2: par 3 + 1 stroke (index 2); hole 7: par 5 + 0 strokes (index 7)
+		if filled[1] != 4 {
+			t.Errorf("hole 2 = %d, want net par 4", filled[1])
+		}
+		if filled[6] != 5 {
+			t.Errorf("hole 7 = %d, want net par 5", filled[6])
+		}
+		if holeScores[1] != 0 {
+			t.Error("input hole scores must not be modified")
+		}
+	})
+
+	t.Run("missing strokes on other holes are left alone", func(t *testing.T) {
+		holeScores := []int{5, 0, 6, 5, 5, 4, 6, 5, 5}
+
+		filled := FillConcededHoleScoresWithCap(holeScores, []int{7}, course, courseHandicap, DefaultMaxStrokesPerHole)
+		if filled[1] != 0 {
+			t.Errorf("hole 2 = %d, want 0", filled[1])
+		}
+	})
+}
+
+func TestValidateConcededHoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		holes   []int
+		wantErr bool
+	}{
+		{name: "none", holes: nil},
+		{name: "valid", holes: []int{1, 9}},
+		{name: "zero", holes: []int{0}, wantErr: true},
+		{name: "past last hole", holes: []int{10}, wantErr: true},
+		{name: "duplicate", holes: []int{3, 3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConcededHoles(tt.holes, 9)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConcededHoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScoreMatchPointsTotalGuard(t *testing.T) {
+	scoreA := models.Score{MatchID: "m1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+	scoreB := models.Score{MatchID: "m1", HoleScores: []int{5, 3, 4, 5, 4, 4, 5, 3, 5}}
+	strokes := make([]int, 9)
+
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, scoreA, scoreB, strokes, strokes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pointsA+pointsB != 22 {
+		t.Errorf("points = %d-%d, want a 22 point total", pointsA, pointsB)
+	}
+
+	// A match total that disagrees with the scoring rules must trip the guard
+	_, _, err = scoreMatchPoints(models.Season{}, DefaultMatchPointRules, scoreA, scoreB, strokes, strokes, 20)
+	if !errors.Is(err, ErrMatchPointsTotal) {
+		t.Errorf("inconsistent total: err = %v, want ErrMatchPointsTotal", err)
+	}
+
+	// A malformed scorecard earns no points at all, which is also caught
+	short := models.Score{MatchID: "m1", HoleScores: []int{4, 3, 5}}
+	if _, _, err := ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, short, scoreB, strokes, strokes); !errors.Is(err, ErrMatchPointsTotal) {
+		t.Errorf("short scorecard: err = %v, want ErrMatchPointsTotal", err)
+	}
+}
+
+func TestCompleteMatchSinglePayload(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+	strokes := make([]int, 9)
+
+	// Both players' scores arrive in the same submission
+	scores := map[string]models.Score{
+		"p1": {MatchID: "m1", PlayerID: "p1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}},
+		"p2": {MatchID: "m1", PlayerID: "p2", HoleScores: []int{5, 3, 4, 5, 4, 4, 5, 3, 5}},
+	}
+
+	completed, ok, err := CompleteMatchWithRules(models.Season{}, DefaultMatchPointRules, match, scores, strokes, strokes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || completed.Status != "completed" {
+		t.Fatalf("ok = %v, status = %q, want a completed match", ok, completed.Status)
+	}
+	wantA, wantB, _ := ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, scores["p1"], scores["p2"], strokes, strokes)
+	if completed.PlayerAPoints != wantA || completed.PlayerBPoints != wantB || wantA+wantB != 22 {
+		t.Errorf("points = %d-%d, want %d-%d", completed.PlayerAPoints, completed.PlayerBPoints, wantA, wantB)
+	}
+
+	// With only one scorecard in, the match is left alone
+	delete(scores, "p2")
+	pending, ok, err := CompleteMatchWithRules(models.Season{}, DefaultMatchPointRules, match, scores, strokes, strokes)
+	if err != nil || ok || pending.Status != "scheduled" {
+		t.Errorf("one score: ok = %v, status = %q, err = %v, want the match left scheduled", ok, pending.Status, err)
+	}
+}
+
+func TestMatchPointRulesTotals(t *testing.T) {
+	nine := []int{4, 3, 5, 4, 4, 3, 5, 4, 4}
+	if total := DefaultMatchPointRules.Total(len(nine)); total != 22 {
+		t.Errorf("9-hole default total = %d, want 22", total)
+	}
+
+	// The overall points stay the same whatever the number of holes
+	rules := MatchPointRules{PerHole: 2, Overall: 2}
+	if err := ValidateMatchPointRules(rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total := rules.Total(18); total != 38 {
+		t.Errorf("18-hole total = %d, want 38", total)
+	}
+
+	course := models.Course{
+		HolePars:      append(append([]int{}, nine...), nine...),
+		HoleHandicaps: []int{5, 15, 1, 9, 11, 17, 3, 7, 13, 6, 16, 2, 10, 12, 18, 4, 8, 14},
+	}
+	scoreA := models.Score{MatchID: "m1", HoleScores: []int{5, 4, 6, 5, 4, 3, 6, 5, 5, 4, 4, 6, 5, 5, 4, 5, 4, 5}}
+	scoreB := models.Score{MatchID: "m1", HoleScores: []int{4, 3, 5, 4, 5, 3, 5, 4, 4, 5, 3, 5, 4, 4, 3, 6, 4, 4}}
+	strokes := AssignStrokes("pA", 10, "pB", 2, course)
+	if len(strokes["pA"]) != 18 || sumStrokes(strokes["pA"]) != 8 {
+		t.Fatalf("strokes = %v, want 8 strokes across 18 holes", strokes["pA"])
+	}
+
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(models.Season{}, rules, scoreA, scoreB, strokes["pA"], strokes["pB"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pointsA+pointsB != 38 {
+		t.Errorf("points = %d-%d, want a 38 point total", pointsA, pointsB)
+	}
+
+	// A halved 18-hole match splits evenly
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(models.Season{}, rules, scoreA, scoreA, make([]int, 18), make([]int, 18))
+	if err != nil || pointsA != 19 || pointsB != 19 {
+		t.Errorf("halved match = %d-%d (err %v), want 19-19", pointsA, pointsB, err)
+	}
+
+	for _, bad := range []MatchPointRules{{PerHole: 3, Overall: 4}, {PerHole: 2, Overall: 5}, {PerHole: 0, Overall: 4}, {PerHole: 2, Overall: -2}} {
+		if err := ValidateMatchPointRules(bad); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+
+	overall := 0
+	if got := LeagueMatchPointRules(models.League{MatchPointsPerHole: 4, MatchOverallPoints: &overall}); got != (MatchPointRules{PerHole: 4, Overall: 0}) {
+		t.Errorf("league rules = %+v, want 4 per hole and no overall points", got)
+	}
+	if got := LeagueMatchPointRules(models.League{}); got != DefaultMatchPointRules {
+		t.Errorf("unset league rules = %+v, want the defaults", got)
+	}
+}
+
+func TestHalvedHoleRule(t *testing.T) {
+	card := models.Score{MatchID: "m1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+	noStrokes := make([]int, 9)
+
+	// Every hole halved and the totals tied
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(models.Season{HalvedHoleRule: HalvedHoleSplit}, DefaultMatchPointRules, card, card, noStrokes, noStrokes)
+	if err != nil || pointsA != 11 || pointsB != 11 {
+		t.Errorf("split: all-tie match = %d-%d (err %v), want 11-11", pointsA, pointsB, err)
+	}
+
+	none := models.Season{HalvedHoleRule: HalvedHoleNone}
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(none, DefaultMatchPointRules, card, card, noStrokes, noStrokes)
+	if err != nil || pointsA != 2 || pointsB != 2 {
+		t.Errorf("none: all-tie match = %d-%d (err %v), want only the overall points split 2-2", pointsA, pointsB, err)
+	}
+
+	// One hole won outright: 2 for the hole plus 4 overall, and the eight halves score nothing
+	winner := card
+	winner.HoleScores = []int{3, 3, 5, 4, 4, 3, 5, 4, 4}
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(none, DefaultMatchPointRules, winner, card, noStrokes, noStrokes)
+	if err != nil || pointsA != 6 || pointsB != 0 {
+		t.Errorf("none: one hole won = %d-%d (err %v), want 6-0", pointsA, pointsB, err)
+	}
+
+	if err := ValidateHalvedHoleRule(models.Season{HalvedHoleRule: "replay"}); err == nil {
+		t.Error("expected an error for an unknown halved hole rule")
+	}
+	if err := ValidateHalvedHoleRule(models.Season{}); err != nil {
+		t.Errorf("empty rule: unexpected error %v", err)
+	}
+}
+
+func TestNassauMode(t *testing.T) {
+	nassau := models.Season{NassauMode: true}
+	if total := SeasonMatchPointRules(DefaultMatchPointRules, nassau).Total(18); total != 48 {
+		t.Errorf("18-hole Nassau total = %d, want 48", total)
+	}
+
+	// A wins the front nine 36-38 but loses the back 45-36 and the total 81-74
+	scoreA := models.Score{MatchID: "m1", HoleScores: []int{4, 4, 4, 4, 4, 4, 4, 4, 4, 5, 5, 5, 5, 5, 5, 5, 5, 5}}
+	scoreB := models.Score{MatchID: "m1", HoleScores: []int{5, 5, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4}}
+	noStrokes := make([]int, 18)
+
+	// Holes: A wins 2 and halves 7 (11), B wins 9 and halves 7 (25). The front block goes to A
+	// and the back and total blocks to B.
+	pointsA, pointsB, err := ScoreMatchPointsWithRules(nassau, DefaultMatchPointRules, scoreA, scoreB, noStrokes, noStrokes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pointsA != 15 || pointsB != 33 {
+		t.Errorf("Nassau points = %d-%d, want 15-33", pointsA, pointsB)
+	}
+
+	// Without Nassau mode there is a single overall block
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(models.Season{}, DefaultMatchPointRules, scoreA, scoreB, noStrokes, noStrokes)
+	if err != nil || pointsA != 11 || pointsB != 29 {
+		t.Errorf("standard points = %d-%d (err %v), want 11-29", pointsA, pointsB, err)
+	}
+
+	// A 9-hole match in a Nassau season is scored as usual
+	nine := models.Score{MatchID: "m2", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(nassau, DefaultMatchPointRules, nine, nine, make([]int, 9), make([]int, 9))
+	if err != nil || pointsA != 11 || pointsB != 11 {
+		t.Errorf("9-hole Nassau season match = %d-%d (err %v), want 11-11", pointsA, pointsB, err)
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// DatedMatch is a match on a calendar date with the name of the course it is played on
+type DatedMatch struct {
+	models.Match
+	CourseName string `json:"courseName"`
+}
+
+// MatchDateRange returns the start of the UTC calendar day holding date and the start of the
+// next day. Match dates are stored as UTC days, so a match is on the day when its date falls in
+// [start, end).
+func MatchDateRange(date time.Time) (start, end time.Time) {
+	date = date.UTC()
+	start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// MatchesOnDate returns the matches played on date, from any season, with their course names,
+// ordered by course and then player A's name so each course's matches read together
+func MatchesOnDate(matches []models.Match, courses map[string]models.Course, date time.Time) []DatedMatch {
+	start, end := MatchDateRange(date)
+
+	dated := make([]DatedMatch, 0)
+	for _, match := range matches {
+		if match.MatchDate.Before(start) || !match.MatchDate.Before(end) {
+			continue
+		}
+		dated = append(dated, DatedMatch{Match: match, CourseName: courses[match.CourseID].Name})
+	}
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		if dated[i].CourseName != dated[j].CourseName {
+			return dated[i].CourseName < dated[j].CourseName
+		}
+		return dated[i].PlayerAName < dated[j].PlayerAName
+	})
+
+	return dated
+}
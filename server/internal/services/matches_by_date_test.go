@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestMatchesOnDateConcurrentSeasons(t *testing.T) {
+	day := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	courses := map[string]models.Course{
+		"c1": {ID: "c1", Name: "North Nine"},
+		"c2": {ID: "c2", Name: "East Nine"},
+	}
+	// The Thursday night and senior leagues run side by side and both play on the 12th
+	matches := []models.Match{
+		{ID: "m1", SeasonID: "thursday", CourseID: "c1", MatchDate: day, PlayerAName: "Bob", PlayerBName: "Cara"},
+		{ID: "m2", SeasonID: "seniors", CourseID: "c2", MatchDate: day, PlayerAName: "Dan", PlayerBName: "Eve"},
+		{ID: "m3", SeasonID: "thursday", CourseID: "c1", MatchDate: day, PlayerAName: "Alice", PlayerBName: "Fay"},
+		{ID: "m4", SeasonID: "thursday", CourseID: "c1", MatchDate: day.AddDate(0, 0, 7), PlayerAName: "Alice", PlayerBName: "Bob"},
+		{ID: "m5", SeasonID: "seniors", CourseID: "c2", MatchDate: day.AddDate(0, 0, -1), PlayerAName: "Dan", PlayerBName: "Gus"},
+	}
+
+	got := MatchesOnDate(matches, courses, day.Add(15*time.Hour))
+
+	wantIDs := []string{"m2", "m3", "m1"}
+	if len(got) != len(wantIDs) {
+		t.Fatalf("got %d matches, want %d: %+v", len(got), len(wantIDs), got)
+	}
+	for i, id := range wantIDs {
+		if got[i].ID != id {
+			t.Errorf("match %d = %s, want %s", i, got[i].ID, id)
+		}
+	}
+	if got[0].CourseName != "East Nine" || got[0].SeasonID != "seniors" || got[0].PlayerBName != "Eve" {
+		t.Errorf("first match = %+v, want Dan vs Eve in seniors on East Nine", got[0])
+	}
+	if got[1].CourseName != "North Nine" || got[1].SeasonID != "thursday" {
+		t.Errorf("second match = %+v, want thursday on North Nine", got[1])
+	}
+}
+
+func TestMatchesOnDateNone(t *testing.T) {
+	matches := []models.Match{{ID: "m1", MatchDate: time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)}}
+	got := MatchesOnDate(matches, nil, time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC))
+	if got == nil || len(got) != 0 {
+		t.Errorf("got %v, want an empty list", got)
+	}
+}
+
+func TestMatchDateRange(t *testing.T) {
+	start, end := MatchDateRange(time.Date(2025, 6, 12, 23, 30, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("range = %v to %v, want the 12th to the 13th", start, end)
+	}
+}
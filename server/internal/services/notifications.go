@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// NotificationType identifies a kind of notification a player can opt out of
+type NotificationType string
+
+// Notification types
+const (
+	NotificationMatchupReminder NotificationType = "matchup_reminder"
+	NotificationResultsPosted   NotificationType = "results_posted"
+	NotificationHandicapChanged NotificationType = "handicap_changed"
+)
+
+// NotificationPrefsStore reads stored notification preferences. A nil result means the
+// player has not saved any.
+type NotificationPrefsStore interface {
+	GetNotificationPrefs(ctx context.Context, leagueID, playerID string) (*models.NotificationPrefs, error)
+}
+
+// DefaultNotificationPrefs returns the preferences for a player who has not chosen any:
+// every notification is on
+func DefaultNotificationPrefs(leagueID, playerID string) models.NotificationPrefs {
+	return models.NotificationPrefs{
+		LeagueID:         leagueID,
+		PlayerID:         playerID,
+		MatchupReminders: true,
+		ResultsPosted:    true,
+		HandicapChanged:  true,
+	}
+}
+
+// LoadNotificationPrefs returns a player's stored preferences, or the defaults when none are stored
+func LoadNotificationPrefs(ctx context.Context, store NotificationPrefsStore, leagueID, playerID string) (models.NotificationPrefs, error) {
+	prefs, err := store.GetNotificationPrefs(ctx, leagueID, playerID)
+	if err != nil {
+		return models.NotificationPrefs{}, err
+	}
+	if prefs == nil {
+		return DefaultNotificationPrefs(leagueID, playerID), nil
+	}
+	return *prefs, nil
+}
+
+// WantsNotification reports whether the preferences allow a notification of the given type
+func WantsNotification(prefs models.NotificationPrefs, notification NotificationType) bool {
+	switch notification {
+	case NotificationMatchupReminder:
+		return prefs.MatchupReminders
+	case NotificationResultsPosted:
+		return prefs.ResultsPosted
+	case NotificationHandicapChanged:
+		return prefs.HandicapChanged
+	default:
+		return true
+	}
+}
+
+// NotificationRecipients narrows a list of players to those who want a notification.
+// Anything that sends notifications should pass its recipients through this first.
+func NotificationRecipients(ctx context.Context, store NotificationPrefsStore, leagueID string, playerIDs []string, notification NotificationType) ([]string, error) {
+	recipients := make([]string, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		prefs, err := LoadNotificationPrefs(ctx, store, leagueID, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load notification preferences for player %s: %w", playerID, err)
+		}
+		if WantsNotification(prefs, notification) {
+			recipients = append(recipients, playerID)
+		}
+	}
+	return recipients, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeNotificationPrefsStore holds saved preferences keyed by player ID
+type fakeNotificationPrefsStore struct {
+	prefs map[string]models.NotificationPrefs
+}
+
+func (f *fakeNotificationPrefsStore) GetNotificationPrefs(ctx context.Context, leagueID, playerID string) (*models.NotificationPrefs, error) {
+	prefs, ok := f.prefs[playerID]
+	if !ok || prefs.LeagueID != leagueID {
+		return nil, nil
+	}
+	return &prefs, nil
+}
+
+func TestLoadNotificationPrefsDefaults(t *testing.T) {
+	store := &fakeNotificationPrefsStore{}
+
+	prefs, err := LoadNotificationPrefs(context.Background(), store, "league-1", "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, notification := range []NotificationType{NotificationMatchupReminder, NotificationResultsPosted, NotificationHandicapChanged} {
+		if !WantsNotification(prefs, notification) {
+			t.Errorf("default prefs opt out of %s, want all on", notification)
+		}
+	}
+	if prefs.LeagueID != "league-1" || prefs.PlayerID != "p1" {
+		t.Errorf("default prefs keyed to %s/%s, want league-1/p1", prefs.LeagueID, prefs.PlayerID)
+	}
+}
+
+func TestNotificationRecipientsRespectsOptOut(t *testing.T) {
+	optedOut := DefaultNotificationPrefs("league-1", "p2")
+	optedOut.ResultsPosted = false
+	store := &fakeNotificationPrefsStore{prefs: map[string]models.NotificationPrefs{"p2": optedOut}}
+	ctx := context.Background()
+	players := []string{"p1", "p2", "p3"}
+
+	results, err := NotificationRecipients(ctx, store, "league-1", players, NotificationResultsPosted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !equalIDs(results, []string{"p1", "p3"}) {
+		t.Errorf("results recipients = %v, want [p1 p3]", results)
+	}
+
+	// Opting out of one type leaves the others on
+	reminders, err := NotificationRecipients(ctx, store, "league-1", players, NotificationMatchupReminder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !equalIDs(reminders, players) {
+		t.Errorf("reminder recipients = %v, want %v", reminders, players)
+	}
+
+	// Preferences are per league
+	otherLeague, err := NotificationRecipients(ctx, store, "league-2", players, NotificationResultsPosted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !equalIDs(otherLeague, players) {
+		t.Errorf("other league recipients = %v, want %v", otherLeague, players)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// Modes for balancing generated pairings
+const (
+	PairingModeSimilar = "similar" // Pair players with the closest handicaps
+	PairingModeMixed   = "mixed"   // Pair the lowest handicaps with the highest
+)
+
+// Pairing is one proposed match between two season players. Bye is set, with no player B,
+// when the player sits out because the field is odd.
+type Pairing struct {
+	PlayerAID    string  `json:"playerAId"`
+	PlayerAName  string  `json:"playerAName"`
+	PlayerAIndex float64 `json:"playerAIndex"`
+	PlayerBID    string  `json:"playerBId,omitempty"`
+	PlayerBName  string  `json:"playerBName,omitempty"`
+	PlayerBIndex float64 `json:"playerBIndex"`
+	IndexGap     float64 `json:"indexGap"` // Difference between the two players' indexes
+	Bye          bool    `json:"bye"`
+}
+
+// ValidatePairingMode checks a pairing mode
+func ValidatePairingMode(mode string) error {
+	switch mode {
+	case PairingModeSimilar, PairingModeMixed:
+		return nil
+	}
+	return fmt.Errorf("mode must be one of: %s, %s", PairingModeSimilar, PairingModeMixed)
+}
+
+// GenerateBalancedPairings pairs a season's active players by the index they take into their
+// next match. "similar" pairs neighbours in handicap order, so the closest handicaps meet;
+// "mixed" pairs from the outside in, lowest against highest. With an odd number of players one
+// takes a bye, chosen from the players with the fewest byes so far (byes, keyed by player ID)
+// so it rotates through the field: the highest handicap among them under "similar", and the
+// one nearest the middle under "mixed", to leave as small a gap as possible in the pairings.
+// Players on equal indexes are ordered by name so the result is repeatable. An unknown mode
+// returns no pairings.
+func GenerateBalancedPairings(seasonPlayers []models.SeasonPlayer, mode string, byes map[string]int) []Pairing {
+	players := make([]models.SeasonPlayer, 0, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		if sp.IsActive {
+			players = append(players, sp)
+		}
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := EffectiveHandicapIndex(players[i]), EffectiveHandicapIndex(players[j])
+		if a != b {
+			return a < b
+		}
+		if players[i].PlayerName != players[j].PlayerName {
+			return players[i].PlayerName < players[j].PlayerName
+		}
+		return players[i].PlayerID < players[j].PlayerID
+	})
+
+	pairings := make([]Pairing, 0, (len(players)+1)/2)
+	if err := ValidatePairingMode(mode); err != nil {
+		return pairings
+	}
+
+	var bye *models.SeasonPlayer
+	if len(players)%2 == 1 {
+		i := byePosition(players, mode, byes)
+		sitting := players[i]
+		bye = &sitting
+		players = append(players[:i:i], players[i+1:]...)
+	}
+
+	switch mode {
+	case PairingModeSimilar:
+		for i := 0; i+1 < len(players); i += 2 {
+			pairings = append(pairings, newPairing(players[i], players[i+1]))
+		}
+	case PairingModeMixed:
+		for i, j := 0, len(players)-1; i < j; i, j = i+1, j-1 {
+			pairings = append(pairings, newPairing(players[i], players[j]))
+		}
+	}
+
+	if bye != nil {
+		pairings = append(pairings, Pairing{
+			PlayerAID:    bye.PlayerID,
+			PlayerAName:  bye.PlayerName,
+			PlayerAIndex: EffectiveHandicapIndex(*bye),
+			Bye:          true,
+		})
+	}
+	return pairings
+}
+
+// byePosition picks who sits out an odd field from players in handicap order: among those with
+// the fewest byes, the highest handicap under "similar" and the one nearest the middle under
+// "mixed", looking above the middle before below it
+func byePosition(players []models.SeasonPlayer, mode string, byes map[string]int) int {
+	fewest := byes[players[0].PlayerID]
+	for _, sp := range players {
+		fewest = min(fewest, byes[sp.PlayerID])
+	}
+
+	if mode == PairingModeMixed {
+		half := len(players) / 2
+		for offset := 0; offset <= half; offset++ {
+			for _, i := range []int{half + offset, half - offset} {
+				if i >= 0 && i < len(players) && byes[players[i].PlayerID] == fewest {
+					return i
+				}
+			}
+		}
+	}
+	for i := len(players) - 1; i > 0; i-- {
+		if byes[players[i].PlayerID] == fewest {
+			return i
+		}
+	}
+	return 0
+}
+
+// SeasonByes counts, for each active season player, the match days so far on which they had
+// no match, which is how a bye shows in the schedule. Match days with no matches at all are
+// not counted.
+func SeasonByes(seasonPlayers []models.SeasonPlayer, matches []models.Match) map[string]int {
+	playedOn := make(map[string]map[string]bool)
+	for _, match := range matches {
+		if match.MatchDayID == "" {
+			continue
+		}
+		if playedOn[match.MatchDayID] == nil {
+			playedOn[match.MatchDayID] = make(map[string]bool)
+		}
+		playedOn[match.MatchDayID][match.PlayerAID] = true
+		playedOn[match.MatchDayID][match.PlayerBID] = true
+	}
+
+	byes := make(map[string]int, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		for _, players := range playedOn {
+			if !players[sp.PlayerID] {
+				byes[sp.PlayerID]++
+			}
+		}
+	}
+	return byes
+}
+
+// newPairing pairs two season players, the lower index as player A
+func newPairing(a, b models.SeasonPlayer) Pairing {
+	indexA, indexB := EffectiveHandicapIndex(a), EffectiveHandicapIndex(b)
+	return Pairing{
+		PlayerAID:    a.PlayerID,
+		PlayerAName:  a.PlayerName,
+		PlayerAIndex: indexA,
+		PlayerBID:    b.PlayerID,
+		PlayerBName:  b.PlayerName,
+		PlayerBIndex: indexB,
+		IndexGap:     math.Round((indexB-indexA)*10) / 10,
+	}
+}
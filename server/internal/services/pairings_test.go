@@ -0,0 +1,136 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func pairingPlayers(indexes ...float64) []models.SeasonPlayer {
+	players := make([]models.SeasonPlayer, len(indexes))
+	for i, index := range indexes {
+		id := string(rune('a' + i))
+		players[i] = models.SeasonPlayer{PlayerID: id, PlayerName: id, CurrentHandicapIndex: index, IsActive: true}
+	}
+	return players
+}
+
+func pairedIDs(pairings []Pairing) [][2]string {
+	ids := make([][2]string, len(pairings))
+	for i, p := range pairings {
+		ids[i] = [2]string{p.PlayerAID, p.PlayerBID}
+	}
+	return ids
+}
+
+func TestGenerateBalancedPairingsSimilar(t *testing.T) {
+	// Sorted by index: d 2.0, b 5.5, f 9.1, a 12.0, c 18.4, e 25.0
+	players := pairingPlayers(12.0, 5.5, 18.4, 2.0, 25.0, 9.1)
+
+	pairings := GenerateBalancedPairings(players, PairingModeSimilar, nil)
+	want := [][2]string{{"d", "b"}, {"f", "a"}, {"c", "e"}}
+	got := pairedIDs(pairings)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pairing %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if pairings[0].IndexGap != 3.5 {
+		t.Errorf("gap = %v, want 3.5", pairings[0].IndexGap)
+	}
+}
+
+func TestGenerateBalancedPairingsMixed(t *testing.T) {
+	players := pairingPlayers(12.0, 5.5, 18.4, 2.0, 25.0, 9.1)
+
+	got := pairedIDs(GenerateBalancedPairings(players, PairingModeMixed, nil))
+	want := [][2]string{{"d", "e"}, {"b", "c"}, {"f", "a"}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pairing %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGenerateBalancedPairingsOddField(t *testing.T) {
+	// Sorted by index: a 4, b 8, c 12, d 16, e 20; the inactive player is left out
+	players := pairingPlayers(4, 8, 12, 16, 20)
+	players = append(players, models.SeasonPlayer{PlayerID: "z", CurrentHandicapIndex: 10})
+
+	similar := GenerateBalancedPairings(players, PairingModeSimilar, nil)
+	if len(similar) != 3 || !similar[2].Bye || similar[2].PlayerAID != "e" {
+		t.Errorf("similar = %+v, want the highest handicap on a bye", similar)
+	}
+	if got := pairedIDs(similar[:2]); got[0] != [2]string{"a", "b"} || got[1] != [2]string{"c", "d"} {
+		t.Errorf("similar pairs = %v", got)
+	}
+
+	mixed := GenerateBalancedPairings(players, PairingModeMixed, nil)
+	if len(mixed) != 3 || !mixed[2].Bye || mixed[2].PlayerAID != "c" {
+		t.Errorf("mixed = %+v, want the middle player on a bye", mixed)
+	}
+	if got := pairedIDs(mixed[:2]); got[0] != [2]string{"a", "e"} || got[1] != [2]string{"b", "d"} {
+		t.Errorf("mixed pairs = %v", got)
+	}
+}
+
+func TestGenerateBalancedPairingsUsesProvisional(t *testing.T) {
+	players := []models.SeasonPlayer{
+		{PlayerID: "new", ProvisionalHandicap: 6, IsActive: true},
+		{PlayerID: "low", CurrentHandicapIndex: 5, IsActive: true},
+		{PlayerID: "high", CurrentHandicapIndex: 30, IsActive: true},
+		{PlayerID: "mid", CurrentHandicapIndex: 28, IsActive: true},
+	}
+	got := pairedIDs(GenerateBalancedPairings(players, PairingModeSimilar, nil))
+	if got[0] != [2]string{"low", "new"} {
+		t.Errorf("pairings = %v, want the new player's provisional used", got)
+	}
+}
+
+func TestGenerateBalancedPairingsRotatesBye(t *testing.T) {
+	// Sorted by index: a 4, b 8, c 12, d 16, e 20
+	players := pairingPlayers(4, 8, 12, 16, 20)
+
+	// e and c have sat out once; the bye moves to the next in line for each mode
+	byes := map[string]int{"e": 1, "c": 1}
+	similar := GenerateBalancedPairings(players, PairingModeSimilar, byes)
+	if len(similar) != 3 || !similar[2].Bye || similar[2].PlayerAID != "d" {
+		t.Fatalf("similar = %+v, want d on a bye", similar)
+	}
+	if got := pairedIDs(similar[:2]); got[0] != [2]string{"a", "b"} || got[1] != [2]string{"c", "e"} {
+		t.Errorf("similar pairs = %v", got)
+	}
+
+	mixed := GenerateBalancedPairings(players, PairingModeMixed, byes)
+	if len(mixed) != 3 || !mixed[2].Bye || mixed[2].PlayerAID != "d" {
+		t.Fatalf("mixed = %+v, want d, the nearest the middle without a bye, to sit out", mixed)
+	}
+	if got := pairedIDs(mixed[:2]); got[0] != [2]string{"a", "e"} || got[1] != [2]string{"b", "c"} {
+		t.Errorf("mixed pairs = %v", got)
+	}
+}
+
+func TestSeasonByes(t *testing.T) {
+	players := pairingPlayers(4, 8, 12)
+	players = append(players, models.SeasonPlayer{PlayerID: "z"})
+	matches := []models.Match{
+		{MatchDayID: "md1", PlayerAID: "a", PlayerBID: "b"},
+		{MatchDayID: "md2", PlayerAID: "a", PlayerBID: "c"},
+		{MatchDayID: "md3", PlayerAID: "b", PlayerBID: "c"},
+		{MatchDayID: "md3", PlayerAID: "a", PlayerBID: "x"},
+	}
+
+	byes := SeasonByes(players, matches)
+	want := map[string]int{"b": 1, "c": 1}
+	if len(byes) != len(want) {
+		t.Fatalf("byes = %v, want %v", byes, want)
+	}
+	for playerID, count := range want {
+		if byes[playerID] != count {
+			t.Errorf("%s: %d byes, want %d", playerID, byes[playerID], count)
+		}
+	}
+}
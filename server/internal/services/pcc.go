@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"golf-league-manager/internal/models"
+)
+
+// PCC (Playing Conditions Calculation) bounds and tuning
+const (
+	// MinPCC is the largest downward adjustment (conditions easier than expected)
+	MinPCC = -1
+	// MaxPCC is the largest upward adjustment (conditions harder than expected)
+	MaxPCC = 3
+	// pccMinimumScores is the smallest field that can produce an adjustment
+	pccMinimumScores = 4
+	// pccExpectedExcess is how far above their index a player's differential is expected to be
+	// on a normal day. An index averages the best rounds, so a typical round sits above it.
+	pccExpectedExcess = 2.0
+)
+
+// ComputePCC calculates the playing conditions adjustment for a single match day.
+// Each player's raw differential is compared to the handicap index they carried into the
+// round (their season baseline). When the field's average excess over that baseline is
+// well above or below what a normal day produces, the difference is rounded and clamped
+// to the MinPCC..MaxPCC range. A positive PCC means the day played harder than expected.
+// Absent players, scores on unknown courses and fields smaller than pccMinimumScores
+// produce no adjustment.
+func ComputePCC(scores []models.Score, courses map[string]models.Course) int {
+	return ComputePCCWithSlope(scores, courses, DefaultStandardSlope)
+}
+
+// ComputePCCWithSlope computes the playing conditions adjustment for a league whose handicap
+// system uses a non-standard baseline slope
+func ComputePCCWithSlope(scores []models.Score, courses map[string]models.Course, standardSlope int) int {
+	var totalExcess float64
+	count := 0
+
+	for _, score := range scores {
+		if score.PlayerAbsent || score.AdjustedGross == 0 {
+			continue
+		}
+		course, ok := courses[score.CourseID]
+		if !ok || course.SlopeRating == 0 {
+			continue
+		}
+
+		// Always use the raw differential; the stored one may already include a PCC
+		diff := CalculateLeagueDifferential(score, course, 0, standardSlope)
+		totalExcess += diff - score.HandicapIndex
+		count++
+	}
+
+	if count < pccMinimumScores {
+		return 0
+	}
+
+	adjustment := totalExcess/float64(count) - pccExpectedExcess
+	pcc := int(math.Round(adjustment))
+
+	if pcc < MinPCC {
+		return MinPCC
+	}
+	if pcc > MaxPCC {
+		return MaxPCC
+	}
+	return pcc
+}
+
+// ValidateMinFieldForStats checks a season's minimum field for match day statistics
+func ValidateMinFieldForStats(season models.Season) error {
+	if season.MinFieldForStats < 0 {
+		return fmt.Errorf("minFieldForStats cannot be negative")
+	}
+	return nil
+}
+
+// FieldSize counts the players who played a match day, leaving out absent players
+func FieldSize(scores []models.Score) int {
+	players := make(map[string]bool, len(scores))
+	for _, score := range scores {
+		if !score.PlayerAbsent {
+			players[score.PlayerID] = true
+		}
+	}
+	return len(players)
+}
+
+// FieldTooSmallForStats reports whether fewer players played a match day than the season's
+// minimum field for statistics. A minimum of 0 never counts as too small.
+func FieldTooSmallForStats(season models.Season, scores []models.Score) bool {
+	return FieldSize(scores) < season.MinFieldForStats
+}
+
+// ComputeSeasonPCC computes a match day's playing conditions adjustment, skipping it (0) when
+// the field is smaller than the season's minimum field for statistics
+func ComputeSeasonPCC(season models.Season, scores []models.Score, courses map[string]models.Course, standardSlope int) int {
+	if FieldTooSmallForStats(season, scores) {
+		return 0
+	}
+	return ComputePCCWithSlope(scores, courses, standardSlope)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func pccCourses() map[string]models.Course {
+	return map[string]models.Course{
+		"c1": {ID: "c1", Par: 36, CourseRating: 35.0, SlopeRating: 113},
+	}
+}
+
+// fieldScores builds scores where each player's raw differential is index + excess
+func fieldScores(indexes []float64, excess float64) []models.Score {
+	scores := make([]models.Score, 0, len(indexes))
+	for _, index := range indexes {
+		scores = append(scores, models.Score{
+			CourseID:      "c1",
+			HandicapIndex: index,
+			AdjustedGross: int(math.Round(35.0 + index + excess)),
+		})
+	}
+	return scores
+}
+
+func TestComputePCC(t *testing.T) {
+	courses := pccCourses()
+	indexes := []float64{4, 8, 10, 12, 15, 18}
+
+	tests := []struct {
+		name   string
+		scores []models.Score
+		want   int
+	}{
+		{
+			name:   "normal day",
+			scores: fieldScores(indexes, 2),
+			want:   0,
+		},
+		{
+			name:   "easy day",
+			scores: fieldScores(indexes, -1),
+			want:   -1,
+		},
+		{
+			name:   "difficult day",
+			scores: fieldScores(indexes, 4),
+			want:   2,
+		},
+		{
+			name:   "brutal day is capped",
+			scores: fieldScores(indexes, 12),
+			want:   3,
+		},
+		{
+			name:   "too few scores",
+			scores: fieldScores(indexes[:3], 12),
+			want:   0,
+		},
+		{
+			name: "absent players are ignored",
+			scores: append(fieldScores(indexes[:3], 12), models.Score{
+				CourseID:      "c1",
+				HandicapIndex: 10,
+				AdjustedGross: 80,
+				PlayerAbsent:  true,
+			}),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputePCC(tt.scores, courses); got != tt.want {
+				t.Errorf("ComputePCC() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputePCCIgnoresStoredDifferential(t *testing.T) {
+	scores := fieldScores([]float64{4, 8, 10, 12}, 5)
+	// Stored differentials already adjusted by a previous PCC must not feed back in
+	for i := range scores {
+		scores[i].HandicapDifferential = 0.1
+	}
+
+	if got := ComputePCC(scores, pccCourses()); got != 3 {
+		t.Errorf("ComputePCC() = %d, want 3", got)
+	}
+}
+
+func TestCalculateLeagueDifferentialWithPCC(t *testing.T) {
+	course := models.Course{CourseRating: 35.0, SlopeRating: 113}
+	score := models.Score{AdjustedGross: 50}
+
+	tests := []struct {
+		pcc  int
+		want float64
+	}{
+		{pcc: 0, want: 15.0},
+		{pcc: -1, want: 16.0},
+		{pcc: 3, want: 12.0},
+	}
+
+	for _, tt := range tests {
+		got := CalculateLeagueDifferential(score, course, tt.pcc, DefaultStandardSlope)
+		if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("pcc %d: got %.2f, want %.2f", tt.pcc, got, tt.want)
+		}
+	}
+
+	if raw := CalculateDifferential(score, course); CalculateLeagueDifferential(score, course, 0, DefaultStandardSlope) != raw {
+		t.Errorf("zero PCC should match the unadjusted differential %.2f", raw)
+	}
+}
+
+func TestComputeSeasonPCCMinField(t *testing.T) {
+	courses := pccCourses()
+	scores := fieldScores([]float64{4, 8, 10, 12, 15, 18}, 4)
+	for i := range scores {
+		scores[i].PlayerID = string(rune('a' + i))
+	}
+	scores = append(scores, models.Score{PlayerID: "absent", PlayerAbsent: true})
+
+	// Six players played; the absent player doesn't count toward the field
+	if got := FieldSize(scores); got != 6 {
+		t.Fatalf("FieldSize = %d, want 6", got)
+	}
+
+	tests := []struct {
+		name     string
+		minField int
+		want     int
+	}{
+		{"no minimum", 0, 2},
+		{"at the minimum", 6, 2},
+		{"below the minimum", 7, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season := models.Season{MinFieldForStats: tt.minField}
+			if got := ComputeSeasonPCC(season, scores, courses, DefaultStandardSlope); got != tt.want {
+				t.Errorf("ComputeSeasonPCC = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
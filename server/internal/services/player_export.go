@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// ErrPlayerRecordAccess means the requester may not see another player's record
+var ErrPlayerRecordAccess = errors.New("access denied: can only view own records")
+
+// CheckPlayerRecordAccess allows players to see their own records and league admins to see
+// anyone's
+func CheckPlayerRecordAccess(requesterID, playerID string, isAdmin bool) error {
+	if requesterID == playerID || isAdmin {
+		return nil
+	}
+	return ErrPlayerRecordAccess
+}
+
+// PlayerExportStore reads everything held about a player in a league
+type PlayerExportStore interface {
+	GetPlayer(ctx context.Context, playerID string) (*models.Player, error)
+	ListPlayerMemberships(ctx context.Context, leagueID, playerID string) ([]models.LeagueMember, error)
+	ListLeagueSeasonPlayers(ctx context.Context, leagueID string) ([]models.SeasonPlayer, error)
+	ListPlayerLeagueScores(ctx context.Context, leagueID, playerID string) ([]models.Score, error)
+	GetPlayerLeagueMatches(ctx context.Context, leagueID, playerID string) ([]models.Match, error)
+}
+
+// PlayerExport is a single document holding a player's full record in a league
+type PlayerExport struct {
+	ExportedAt    time.Time             `json:"exportedAt"`
+	LeagueID      string                `json:"leagueId"`
+	Player        models.Player         `json:"player"`
+	Memberships   []models.LeagueMember `json:"memberships"`
+	SeasonPlayers []models.SeasonPlayer `json:"seasonPlayers"`
+	Scores        []models.Score        `json:"scores"`
+	Matches       []models.Match        `json:"matches"`
+}
+
+// ExportPlayerRecord gathers a player's profile, memberships, season enrollments, scores and
+// matches in a league across every season. Scores and matches are ordered oldest first.
+func ExportPlayerRecord(ctx context.Context, store PlayerExportStore, leagueID, playerID string, now time.Time) (*PlayerExport, error) {
+	player, err := store.GetPlayer(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	memberships, err := store.ListPlayerMemberships(ctx, leagueID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	leagueSeasonPlayers, err := store.ListLeagueSeasonPlayers(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list season players: %w", err)
+	}
+	seasonPlayers := make([]models.SeasonPlayer, 0)
+	for _, sp := range leagueSeasonPlayers {
+		if sp.PlayerID == playerID {
+			seasonPlayers = append(seasonPlayers, sp)
+		}
+	}
+
+	scores, err := store.ListPlayerLeagueScores(ctx, leagueID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scores: %w", err)
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Date.Before(scores[j].Date)
+	})
+
+	matches, err := store.GetPlayerLeagueMatches(ctx, leagueID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches: %w", err)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].MatchDate.Before(matches[j].MatchDate)
+	})
+
+	return &PlayerExport{
+		ExportedAt:    now,
+		LeagueID:      leagueID,
+		Player:        *player,
+		Memberships:   memberships,
+		SeasonPlayers: seasonPlayers,
+		Scores:        scores,
+		Matches:       matches,
+	}, nil
+}
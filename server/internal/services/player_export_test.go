@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakePlayerExportStore serves one league's records from memory
+type fakePlayerExportStore struct {
+	players       map[string]models.Player
+	members       []models.LeagueMember
+	seasonPlayers []models.SeasonPlayer
+	scores        []models.Score
+	matches       []models.Match
+}
+
+func (f *fakePlayerExportStore) GetPlayer(ctx context.Context, playerID string) (*models.Player, error) {
+	player, ok := f.players[playerID]
+	if !ok {
+		return nil, errors.New("player not found")
+	}
+	return &player, nil
+}
+
+func (f *fakePlayerExportStore) ListPlayerMemberships(ctx context.Context, leagueID, playerID string) ([]models.LeagueMember, error) {
+	var members []models.LeagueMember
+	for _, m := range f.members {
+		if m.LeagueID == leagueID && m.PlayerID == playerID {
+			members = append(members, m)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakePlayerExportStore) ListLeagueSeasonPlayers(ctx context.Context, leagueID string) ([]models.SeasonPlayer, error) {
+	return f.seasonPlayers, nil
+}
+
+func (f *fakePlayerExportStore) ListPlayerLeagueScores(ctx context.Context, leagueID, playerID string) ([]models.Score, error) {
+	var scores []models.Score
+	for _, s := range f.scores {
+		if s.PlayerID == playerID {
+			scores = append(scores, s)
+		}
+	}
+	return scores, nil
+}
+
+func (f *fakePlayerExportStore) GetPlayerLeagueMatches(ctx context.Context, leagueID, playerID string) ([]models.Match, error) {
+	var matches []models.Match
+	for _, m := range f.matches {
+		if m.PlayerAID == playerID || m.PlayerBID == playerID {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+func TestExportPlayerRecordTwoSeasons(t *testing.T) {
+	spring := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	summer := time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	store := &fakePlayerExportStore{
+		players: map[string]models.Player{"p1": {ID: "p1", Name: "Alice"}, "p2": {ID: "p2", Name: "Bob"}},
+		members: []models.LeagueMember{
+			{ID: "lm1", LeagueID: "l1", PlayerID: "p1", Role: "player"},
+			{ID: "lm2", LeagueID: "l1", PlayerID: "p2", Role: "player"},
+		},
+		seasonPlayers: []models.SeasonPlayer{
+			{ID: "sp1", SeasonID: "spring", PlayerID: "p1"},
+			{ID: "sp2", SeasonID: "summer", PlayerID: "p1"},
+			{ID: "sp3", SeasonID: "summer", PlayerID: "p2"},
+		},
+		scores: []models.Score{
+			{ID: "s-summer", PlayerID: "p1", Date: summer},
+			{ID: "s-spring", PlayerID: "p1", Date: spring},
+			{ID: "s-bob", PlayerID: "p2", Date: summer},
+		},
+		matches: []models.Match{
+			{ID: "m-summer", SeasonID: "summer", PlayerAID: "p2", PlayerBID: "p1", MatchDate: summer, Status: "scheduled"},
+			{ID: "m-spring", SeasonID: "spring", PlayerAID: "p1", PlayerBID: "p3", MatchDate: spring, Status: "completed"},
+			{ID: "m-other", SeasonID: "summer", PlayerAID: "p2", PlayerBID: "p3", MatchDate: summer},
+		},
+	}
+
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	export, err := ExportPlayerRecord(context.Background(), store, "l1", "p1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if export.Player.Name != "Alice" || export.LeagueID != "l1" || !export.ExportedAt.Equal(now) {
+		t.Errorf("export header = %s in %s at %v, want Alice in l1 at %v", export.Player.Name, export.LeagueID, export.ExportedAt, now)
+	}
+	if len(export.Memberships) != 1 || export.Memberships[0].ID != "lm1" {
+		t.Errorf("memberships = %+v, want only lm1", export.Memberships)
+	}
+	if len(export.SeasonPlayers) != 2 || export.SeasonPlayers[0].ID != "sp1" || export.SeasonPlayers[1].ID != "sp2" {
+		t.Errorf("season players = %+v, want sp1 and sp2", export.SeasonPlayers)
+	}
+	if len(export.Scores) != 2 || export.Scores[0].ID != "s-spring" || export.Scores[1].ID != "s-summer" {
+		t.Errorf("scores = %+v, want s-spring then s-summer", export.Scores)
+	}
+	if len(export.Matches) != 2 || export.Matches[0].ID != "m-spring" || export.Matches[1].ID != "m-summer" {
+		t.Errorf("matches = %+v, want m-spring then m-summer", export.Matches)
+	}
+}
+
+func TestCheckPlayerRecordAccess(t *testing.T) {
+	if err := CheckPlayerRecordAccess("p1", "p1", false); err != nil {
+		t.Errorf("own record: unexpected error %v", err)
+	}
+	if err := CheckPlayerRecordAccess("admin", "p1", true); err != nil {
+		t.Errorf("admin: unexpected error %v", err)
+	}
+	if err := CheckPlayerRecordAccess("p2", "p1", false); !errors.Is(err, ErrPlayerRecordAccess) {
+		t.Errorf("another player: err = %v, want ErrPlayerRecordAccess", err)
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+)
+
+// PlayerNameStore updates denormalized player name fields in storage
+type PlayerNameStore interface {
+	UpdateDenormalizedPlayerName(ctx context.Context, collection, idField, nameField, playerID, name string) (int, error)
+}
+
+// playerNameField identifies a denormalized player name stored alongside a player ID
+type playerNameField struct {
+	collection string
+	idField    string
+	nameField  string
+}
+
+// denormalizedPlayerNames lists every place a player's name is copied for display
+var denormalizedPlayerNames = []playerNameField{
+	{collection: "season_players", idField: "player_id", nameField: "player_name"},
+	{collection: "matches", idField: "player_a_id", nameField: "player_a_name"},
+	{collection: "matches", idField: "player_b_id", nameField: "player_b_name"},
+	{collection: "scores", idField: "player_id", nameField: "player_name"},
+	{collection: "bulletin_messages", idField: "player_id", nameField: "player_name"},
+	{collection: "achievements", idField: "player_id", nameField: "player_name"},
+}
+
+// ApplyMatchPlayerNames fills the denormalized player names on a match from a player ID → name lookup.
+// Players missing from the lookup keep their current name.
+func ApplyMatchPlayerNames(match *models.Match, names map[string]string) {
+	if name, ok := names[match.PlayerAID]; ok {
+		match.PlayerAName = name
+	}
+	if name, ok := names[match.PlayerBID]; ok {
+		match.PlayerBName = name
+	}
+}
+
+// PropagatePlayerName rewrites a renamed player's denormalized name on their season player,
+// match, score, bulletin message and achievement records. It is best-effort: a failure on one
+// collection, including individual writes that fail, is logged with the number of records that
+// were updated, and the remaining collections are still updated. The returned error joins all
+// failures.
+func PropagatePlayerName(ctx context.Context, store PlayerNameStore, playerID, newName string) error {
+	var errs []error
+	for _, field := range denormalizedPlayerNames {
+		count, err := store.UpdateDenormalizedPlayerName(ctx, field.collection, field.idField, field.nameField, playerID, newName)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to propagate player name",
+				"player_id", playerID,
+				"collection", field.collection,
+				"field", field.nameField,
+				"updated", count,
+				"error", err,
+			)
+			errs = append(errs, fmt.Errorf("%s.%s: %w", field.collection, field.nameField, err))
+			continue
+		}
+		logger.InfoContext(ctx, "Propagated player name",
+			"player_id", playerID,
+			"collection", field.collection,
+			"field", field.nameField,
+			"updated", count,
+		)
+	}
+	return errors.Join(errs...)
+}
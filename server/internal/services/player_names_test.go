@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestApplyMatchPlayerNames(t *testing.T) {
+	names := map[string]string{
+		"p1": "Alice",
+		"p2": "Bob",
+	}
+
+	t.Run("names written on match creation", func(t *testing.T) {
+		match := models.Match{PlayerAID: "p1", PlayerBID: "p2"}
+
+		ApplyMatchPlayerNames(&match, names)
+
+		if match.PlayerAName != "Alice" || match.PlayerBName != "Bob" {
+			t.Errorf("names = %q/%q, want Alice/Bob", match.PlayerAName, match.PlayerBName)
+		}
+	})
+
+	t.Run("matchup change replaces names", func(t *testing.T) {
+		match := models.Match{PlayerAID: "p2", PlayerBID: "p1", PlayerAName: "Alice", PlayerBName: "Bob"}
+
+		ApplyMatchPlayerNames(&match, names)
+
+		if match.PlayerAName != "Bob" || match.PlayerBName != "Alice" {
+			t.Errorf("names = %q/%q, want Bob/Alice", match.PlayerAName, match.PlayerBName)
+		}
+	})
+
+	t.Run("unknown player keeps existing name", func(t *testing.T) {
+		match := models.Match{PlayerAID: "p1", PlayerBID: "p9", PlayerBName: "Zed"}
+
+		ApplyMatchPlayerNames(&match, names)
+
+		if match.PlayerAName != "Alice" || match.PlayerBName != "Zed" {
+			t.Errorf("names = %q/%q, want Alice/Zed", match.PlayerAName, match.PlayerBName)
+		}
+	})
+}
+
+func TestBuildRemainingMatchesUsesDenormalizedNames(t *testing.T) {
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", PlayerBName: "Bob", Status: "scheduled"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p1", Status: "scheduled"},
+	}
+	// Only the opponent without a denormalized name needs a lookup
+	players := map[string]models.Player{
+		"p3": {ID: "p3", Name: "Carl"},
+	}
+
+	remaining := BuildRemainingMatches("p1", matches, players, nil)
+
+	if len(remaining) != 2 {
+		t.Fatalf("got %d remaining matches, want 2", len(remaining))
+	}
+	if remaining[0].OpponentName != "Bob" {
+		t.Errorf("m1 opponent = %q, want Bob", remaining[0].OpponentName)
+	}
+	if remaining[1].OpponentName != "Carl" {
+		t.Errorf("m2 opponent = %q, want Carl", remaining[1].OpponentName)
+	}
+}
+
+// fakePlayerNameStore records denormalized name fields in memory, keyed by collection then document
+type fakePlayerNameStore struct {
+	docs   map[string]map[string]map[string]string
+	failOn string
+}
+
+func (f *fakePlayerNameStore) UpdateDenormalizedPlayerName(ctx context.Context, collection, idField, nameField, playerID, name string) (int, error) {
+	if collection == f.failOn {
+		return 0, errors.New("unavailable")
+	}
+	count := 0
+	for _, doc := range f.docs[collection] {
+		if doc[idField] == playerID {
+			doc[nameField] = name
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newFakePlayerNameStore() *fakePlayerNameStore {
+	return &fakePlayerNameStore{
+		docs: map[string]map[string]map[string]string{
+			"season_players": {
+				"sp1": {"player_id": "p1", "player_name": "Al"},
+				"sp2": {"player_id": "p2", "player_name": "Bob"},
+			},
+			"matches": {
+				"m1": {"player_a_id": "p1", "player_a_name": "Al", "player_b_id": "p2", "player_b_name": "Bob"},
+				"m2": {"player_a_id": "p2", "player_a_name": "Bob", "player_b_id": "p1", "player_b_name": "Al"},
+			},
+			"scores": {
+				"s1": {"player_id": "p1", "player_name": "Al"},
+				"s2": {"player_id": "p2", "player_name": "Bob"},
+			},
+			"bulletin_messages": {
+				"b1": {"player_id": "p1", "player_name": "Al"},
+			},
+		},
+	}
+}
+
+func TestPropagatePlayerName(t *testing.T) {
+	store := newFakePlayerNameStore()
+
+	if err := PropagatePlayerName(context.Background(), store, "p1", "Alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := []struct {
+		collection, doc, field, want string
+	}{
+		{"season_players", "sp1", "player_name", "Alice"},
+		{"season_players", "sp2", "player_name", "Bob"},
+		{"matches", "m1", "player_a_name", "Alice"},
+		{"matches", "m1", "player_b_name", "Bob"},
+		{"matches", "m2", "player_a_name", "Bob"},
+		{"matches", "m2", "player_b_name", "Alice"},
+		{"scores", "s1", "player_name", "Alice"},
+		{"scores", "s2", "player_name", "Bob"},
+		{"bulletin_messages", "b1", "player_name", "Alice"},
+	}
+	for _, c := range checks {
+		if got := store.docs[c.collection][c.doc][c.field]; got != c.want {
+			t.Errorf("%s/%s %s = %q, want %q", c.collection, c.doc, c.field, got, c.want)
+		}
+	}
+}
+
+func TestPropagatePlayerNamePartialFailure(t *testing.T) {
+	store := newFakePlayerNameStore()
+	store.failOn = "matches"
+
+	err := PropagatePlayerName(context.Background(), store, "p1", "Alice")
+	if err == nil {
+		t.Fatal("expected error for failed collection, got nil")
+	}
+
+	// Other collections are still updated
+	if got := store.docs["scores"]["s1"]["player_name"]; got != "Alice" {
+		t.Errorf("score name = %q, want Alice", got)
+	}
+	if got := store.docs["bulletin_messages"]["b1"]["player_name"]; got != "Alice" {
+		t.Errorf("bulletin name = %q, want Alice", got)
+	}
+	if got := store.docs["matches"]["m1"]["player_a_name"]; got != "Al" {
+		t.Errorf("match name = %q, want unchanged Al", got)
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UserLookupTimeout bounds how long a new player's profile lookup may take before falling back
+// to a placeholder name
+const UserLookupTimeout = 5 * time.Second
+
+// ErrUserLookupUnavailable means the user's profile couldn't be fetched from the identity
+// provider, so no player could be created for them
+var ErrUserLookupUnavailable = errors.New("user service is unavailable")
+
+// UserProfile is the part of a signed-in user's identity profile used to create their player
+type UserProfile struct {
+	Name  string
+	Email string
+}
+
+// UserLookup fetches a user's profile from the identity provider
+type UserLookup func(ctx context.Context, userID string) (UserProfile, error)
+
+// PlayerProvisionStore finds and creates players by their identity provider user ID
+type PlayerProvisionStore interface {
+	GetPlayerByClerkID(ctx context.Context, clerkUserID string) (*models.Player, error)
+	CreatePlayer(ctx context.Context, player models.Player) error
+}
+
+// FallbackDisplayName is the name given to a new player whose profile couldn't be looked up in
+// time, built from the end of their user ID so it stays recognisable until they rename themselves
+func FallbackDisplayName(userID string) string {
+	suffix := userID
+	if len(suffix) > 6 {
+		suffix = suffix[len(suffix)-6:]
+	}
+	return "Player " + suffix
+}
+
+// LookupUserProfile calls lookup with the given timeout. When the lookup times out, the user ID
+// is all we have, so a profile with a fallback display name and no email is returned with
+// fallback set. Any other failure, or the caller's own context ending, is returned wrapped in
+// ErrUserLookupUnavailable.
+func LookupUserProfile(ctx context.Context, lookup UserLookup, userID string, timeout time.Duration) (profile UserProfile, fallback bool, err error) {
+	lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		profile UserProfile
+		err     error
+	}
+	done := make(chan result, 1) // Buffered so a lookup that ignores its context doesn't leak
+	go func() {
+		p, err := lookup(lookupCtx, userID)
+		done <- result{p, err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-lookupCtx.Done():
+		res.err = lookupCtx.Err()
+	}
+	if res.err == nil {
+		return res.profile, false, nil
+	}
+
+	if ctx.Err() == nil && errors.Is(lookupCtx.Err(), context.DeadlineExceeded) {
+		logger.WarnContext(ctx, "User lookup timed out, using fallback display name",
+			"user_id", userID,
+			"timeout", timeout.String(),
+		)
+		return UserProfile{Name: FallbackDisplayName(userID)}, true, nil
+	}
+	return UserProfile{}, false, fmt.Errorf("%w: %v", ErrUserLookupUnavailable, res.err)
+}
+
+// EnsurePlayer returns the player for a signed-in user, creating one from their profile when
+// they don't have one yet. The profile is only looked up for new players, so existing players
+// aren't affected when the identity provider is down.
+func EnsurePlayer(ctx context.Context, store PlayerProvisionStore, lookup UserLookup, userID string, timeout time.Duration, now time.Time) (*models.Player, error) {
+	if player, err := store.GetPlayerByClerkID(ctx, userID); err == nil {
+		return player, nil
+	}
+
+	profile, _, err := LookupUserProfile(ctx, lookup, userID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	player := &models.Player{
+		ID:          uuid.New().String(),
+		Name:        profile.Name,
+		Email:       profile.Email,
+		ClerkUserID: userID,
+		Active:      true,
+		CreatedAt:   now,
+	}
+	if err := store.CreatePlayer(ctx, *player); err != nil {
+		return nil, fmt.Errorf("failed to create player: %w", err)
+	}
+	return player, nil
+}
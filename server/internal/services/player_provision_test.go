@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+type fakePlayerProvisionStore struct {
+	players map[string]models.Player // By Clerk user ID
+	created []models.Player
+}
+
+func (f *fakePlayerProvisionStore) GetPlayerByClerkID(ctx context.Context, clerkUserID string) (*models.Player, error) {
+	p, ok := f.players[clerkUserID]
+	if !ok {
+		return nil, errors.New("player not found")
+	}
+	return &p, nil
+}
+
+func (f *fakePlayerProvisionStore) CreatePlayer(ctx context.Context, player models.Player) error {
+	f.players[player.ClerkUserID] = player
+	f.created = append(f.created, player)
+	return nil
+}
+
+// hangingLookup simulates an identity provider that never answers
+func hangingLookup(ctx context.Context, userID string) (UserProfile, error) {
+	<-ctx.Done()
+	return UserProfile{}, ctx.Err()
+}
+
+func TestEnsurePlayerFallsBackWhenLookupTimesOut(t *testing.T) {
+	store := &fakePlayerProvisionStore{players: make(map[string]models.Player)}
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	player, err := EnsurePlayer(context.Background(), store, hangingLookup, "user_2abcdef123456", 10*time.Millisecond, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if player.Name != "Player 123456" || player.Email != "" || player.ClerkUserID != "user_2abcdef123456" {
+		t.Errorf("player = %+v, want the fallback name for the user", player)
+	}
+	if len(store.created) != 1 || store.created[0].ID == "" || !store.created[0].CreatedAt.Equal(now) {
+		t.Errorf("created = %+v, want the new player saved", store.created)
+	}
+}
+
+func TestEnsurePlayerSkipsLookupForExistingPlayer(t *testing.T) {
+	store := &fakePlayerProvisionStore{players: map[string]models.Player{
+		"user_1": {ID: "p1", Name: "Alice", ClerkUserID: "user_1"},
+	}}
+	lookup := func(ctx context.Context, userID string) (UserProfile, error) {
+		t.Error("lookup called for an existing player")
+		return UserProfile{}, nil
+	}
+
+	player, err := EnsurePlayer(context.Background(), store, lookup, "user_1", time.Second, time.Now())
+	if err != nil || player.ID != "p1" {
+		t.Errorf("player = %+v, err = %v, want the existing player", player, err)
+	}
+	if len(store.created) != 0 {
+		t.Errorf("created %d players, want none", len(store.created))
+	}
+}
+
+func TestEnsurePlayerUsesLookedUpProfile(t *testing.T) {
+	store := &fakePlayerProvisionStore{players: make(map[string]models.Player)}
+	lookup := func(ctx context.Context, userID string) (UserProfile, error) {
+		return UserProfile{Name: "Bob Jones", Email: "bob@example.com"}, nil
+	}
+
+	player, err := EnsurePlayer(context.Background(), store, lookup, "user_2", time.Second, time.Now())
+	if err != nil || player.Name != "Bob Jones" || player.Email != "bob@example.com" || !player.Active {
+		t.Errorf("player = %+v, err = %v", player, err)
+	}
+}
+
+func TestEnsurePlayerFailsWhenLookupErrors(t *testing.T) {
+	store := &fakePlayerProvisionStore{players: make(map[string]models.Player)}
+	lookup := func(ctx context.Context, userID string) (UserProfile, error) {
+		return UserProfile{}, errors.New("503 from upstream")
+	}
+
+	_, err := EnsurePlayer(context.Background(), store, lookup, "user_3", time.Second, time.Now())
+	if !errors.Is(err, ErrUserLookupUnavailable) {
+		t.Errorf("err = %v, want ErrUserLookupUnavailable", err)
+	}
+	if len(store.created) != 0 {
+		t.Errorf("created %d players, want none", len(store.created))
+	}
+}
+
+func TestLookupUserProfileDoesNotFallBackWhenCallerCancels(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, fallback, err := LookupUserProfile(ctx, hangingLookup, "user_4", time.Second)
+	if !errors.Is(err, ErrUserLookupUnavailable) || fallback {
+		t.Errorf("fallback = %v, err = %v, want ErrUserLookupUnavailable", fallback, err)
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// RemainingMatch is a match a player still needs to play, enriched with opponent and course details
+type RemainingMatch struct {
+	MatchID      string    `json:"matchId"`
+	MatchDayID   string    `json:"matchDayId"`
+	MatchDate    time.Time `json:"matchDate"`
+	OpponentID   string    `json:"opponentId"`
+	OpponentName string    `json:"opponentName"`
+	CourseID     string    `json:"courseId"`
+	CourseName   string    `json:"courseName"`
+}
+
+// BuildRemainingMatches returns the player's matches that are not yet completed, ordered by date.
+// Opponent names come from the match when denormalized, otherwise from the players lookup;
+// course names come from the courses lookup.
+func BuildRemainingMatches(playerID string, matches []models.Match, players map[string]models.Player, courses map[string]models.Course) []RemainingMatch {
+	remaining := make([]RemainingMatch, 0, len(matches))
+	for _, match := range matches {
+		if match.Status == "completed" {
+			continue
+		}
+
+		var opponentID, opponentName string
+		switch playerID {
+		case match.PlayerAID:
+			opponentID, opponentName = match.PlayerBID, match.PlayerBName
+		case match.PlayerBID:
+			opponentID, opponentName = match.PlayerAID, match.PlayerAName
+		default:
+			continue
+		}
+
+		entry := RemainingMatch{
+			MatchID:      match.ID,
+			MatchDayID:   match.MatchDayID,
+			MatchDate:    match.MatchDate,
+			OpponentID:   opponentID,
+			OpponentName: opponentName,
+			CourseID:     match.CourseID,
+		}
+		if opponent, ok := players[opponentID]; ok && entry.OpponentName == "" {
+			entry.OpponentName = opponent.Name
+		}
+		if course, ok := courses[match.CourseID]; ok {
+			entry.CourseName = course.Name
+		}
+		remaining = append(remaining, entry)
+	}
+
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].MatchDate.Before(remaining[j].MatchDate)
+	})
+
+	return remaining
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildRemainingMatches(t *testing.T) {
+	week := func(n int) time.Time {
+		return time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n)
+	}
+
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", CourseID: "c1", MatchDate: week(0), Status: "completed"},
+		{ID: "m4", PlayerAID: "p4", PlayerBID: "p1", CourseID: "c2", MatchDate: week(3), Status: "scheduled"},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p1", CourseID: "c1", MatchDate: week(1), Status: "completed"},
+		{ID: "m3", PlayerAID: "p1", PlayerBID: "p3", CourseID: "c1", MatchDate: week(2), Status: "scheduled"},
+		// Match the player is not part of
+		{ID: "m5", PlayerAID: "p2", PlayerBID: "p3", CourseID: "c1", MatchDate: week(2), Status: "scheduled"},
+	}
+	players := map[string]models.Player{
+		"p2": {ID: "p2", Name: "Bob"},
+		"p3": {ID: "p3", Name: "Carl"},
+		"p4": {ID: "p4", Name: "Dana"},
+	}
+	courses := map[string]models.Course{
+		"c1": {ID: "c1", Name: "North Nine"},
+		"c2": {ID: "c2", Name: "South Nine"},
+	}
+
+	remaining := BuildRemainingMatches("p1", matches, players, courses)
+
+	if len(remaining) != 2 {
+		t.Fatalf("got %d remaining matches, want 2", len(remaining))
+	}
+
+	want := []RemainingMatch{
+		{MatchID: "m3", MatchDate: week(2), OpponentID: "p3", OpponentName: "Carl", CourseID: "c1", CourseName: "North Nine"},
+		{MatchID: "m4", MatchDate: week(3), OpponentID: "p4", OpponentName: "Dana", CourseID: "c2", CourseName: "South Nine"},
+	}
+	for i, w := range want {
+		got := remaining[i]
+		if got.MatchID != w.MatchID || !got.MatchDate.Equal(w.MatchDate) {
+			t.Errorf("position %d: got match %s on %s, want %s on %s", i, got.MatchID, got.MatchDate, w.MatchID, w.MatchDate)
+		}
+		if got.OpponentID != w.OpponentID || got.OpponentName != w.OpponentName {
+			t.Errorf("position %d: got opponent %s (%s), want %s (%s)", i, got.OpponentID, got.OpponentName, w.OpponentID, w.OpponentName)
+		}
+		if got.CourseName != w.CourseName {
+			t.Errorf("position %d: got course %q, want %q", i, got.CourseName, w.CourseName)
+		}
+	}
+}
+
+func TestBuildRemainingMatchesAllCompleted(t *testing.T) {
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed"},
+	}
+
+	remaining := BuildRemainingMatches("p1", matches, nil, nil)
+	if remaining == nil || len(remaining) != 0 {
+		t.Errorf("expected empty, non-nil result, got %v", remaining)
+	}
+}
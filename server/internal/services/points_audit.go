@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// AuditActionMatchPointsRepaired is recorded when a points audit restores a match's points to
+// what its scores produce
+const AuditActionMatchPointsRepaired = "match_points_repaired"
+
+// MatchPointsStore reads a match with everything needed to score it again
+type MatchPointsStore interface {
+	GetMatch(ctx context.Context, matchID string) (*models.Match, error)
+	GetMatchScores(ctx context.Context, matchID string) ([]models.Score, error)
+	GetSeason(ctx context.Context, seasonID string) (*models.Season, error)
+	GetLeague(ctx context.Context, leagueID string) (*models.League, error)
+}
+
+// RecomputeMatchPoints scores a completed match again from its stored scores, with the strokes
+// each player received when the scores were entered. ok is false when the match can't be
+// checked: a player's score is missing, or a score predates recorded match strokes.
+func RecomputeMatchPoints(season models.Season, league models.League, match models.Match, scores []models.Score) (pointsA, pointsB int, ok bool, err error) {
+	byPlayer := make(map[string]models.Score, len(scores))
+	for _, score := range scores {
+		byPlayer[score.PlayerID] = score
+	}
+	scoreA, hasA := byPlayer[match.PlayerAID]
+	scoreB, hasB := byPlayer[match.PlayerBID]
+	if !hasA || !hasB || len(scoreA.MatchStrokes) == 0 || len(scoreB.MatchStrokes) == 0 {
+		return 0, 0, false, nil
+	}
+
+	pointsA, pointsB, err = ScoreMatchPointsWithRules(season, LeagueMatchPointRules(league), scoreA, scoreB, scoreA.MatchStrokes, scoreB.MatchStrokes)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return pointsA, pointsB, true, nil
+}
+
+// VerifyMatchPoints checks a completed match's stored points against a fresh computation from
+// its scores and returns the recomputed points. A match that can't be recomputed is reported
+// as consistent with its stored points, since there is nothing to compare against.
+func VerifyMatchPoints(ctx context.Context, store MatchPointsStore, matchID string) (consistent bool, pointsA, pointsB int, err error) {
+	match, err := store.GetMatch(ctx, matchID)
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match.Status != "completed" {
+		return false, 0, 0, fmt.Errorf("match %s is not completed", matchID)
+	}
+	season, err := store.GetSeason(ctx, match.SeasonID)
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("failed to get season: %w", err)
+	}
+	league, err := store.GetLeague(ctx, match.LeagueID)
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("failed to get league: %w", err)
+	}
+	scores, err := store.GetMatchScores(ctx, matchID)
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("failed to get match scores: %w", err)
+	}
+
+	pointsA, pointsB, ok, err := RecomputeMatchPoints(*season, *league, *match, scores)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if !ok {
+		return true, match.PlayerAPoints, match.PlayerBPoints, nil
+	}
+	return pointsA == match.PlayerAPoints && pointsB == match.PlayerBPoints, pointsA, pointsB, nil
+}
+
+// PointsMismatch is a completed match whose stored points differ from what its scores produce
+type PointsMismatch struct {
+	MatchID             string `json:"matchId"`
+	MatchDayID          string `json:"matchDayId"`
+	PlayerAID           string `json:"playerAId"`
+	PlayerBID           string `json:"playerBId"`
+	StoredPlayerAPoints int    `json:"storedPlayerAPoints"`
+	StoredPlayerBPoints int    `json:"storedPlayerBPoints"`
+	PlayerAPoints       int    `json:"playerAPoints"` // Recomputed from the scores
+	PlayerBPoints       int    `json:"playerBPoints"`
+}
+
+// PointsAuditReport lists a season's completed matches whose points no longer match their
+// scores. Unverified holds the matches that couldn't be recomputed.
+type PointsAuditReport struct {
+	SeasonID   string           `json:"seasonId"`
+	Checked    int              `json:"checked"`
+	Mismatched []PointsMismatch `json:"mismatched"`
+	Unverified []string         `json:"unverified"`
+	Repaired   int              `json:"repaired"`
+}
+
+// AuditSeasonMatchPoints recomputes the points of every completed match in a season from its
+// scores (scoresByMatch, keyed by match ID) and reports the matches whose stored points differ.
+// Matches that aren't completed are ignored.
+func AuditSeasonMatchPoints(season models.Season, league models.League, matches []models.Match, scoresByMatch map[string][]models.Score) (PointsAuditReport, error) {
+	report := PointsAuditReport{
+		SeasonID:   season.ID,
+		Mismatched: make([]PointsMismatch, 0),
+		Unverified: make([]string, 0),
+	}
+
+	for _, match := range matches {
+		if match.Status != "completed" {
+			continue
+		}
+		report.Checked++
+
+		pointsA, pointsB, ok, err := RecomputeMatchPoints(season, league, match, scoresByMatch[match.ID])
+		if err != nil {
+			return PointsAuditReport{}, fmt.Errorf("match %s: %w", match.ID, err)
+		}
+		if !ok {
+			report.Unverified = append(report.Unverified, match.ID)
+			continue
+		}
+		if pointsA == match.PlayerAPoints && pointsB == match.PlayerBPoints {
+			continue
+		}
+		report.Mismatched = append(report.Mismatched, PointsMismatch{
+			MatchID:             match.ID,
+			MatchDayID:          match.MatchDayID,
+			PlayerAID:           match.PlayerAID,
+			PlayerBID:           match.PlayerBID,
+			StoredPlayerAPoints: match.PlayerAPoints,
+			StoredPlayerBPoints: match.PlayerBPoints,
+			PlayerAPoints:       pointsA,
+			PlayerBPoints:       pointsB,
+		})
+	}
+	return report, nil
+}
+
+// RepairMatchPoints returns the mismatched matches with their recomputed points restored, along
+// with an audit entry for each; the caller saves both and assigns the entries' IDs.
+func RepairMatchPoints(matches []models.Match, mismatches []PointsMismatch, actorID string, now time.Time) ([]models.Match, []models.AuditEntry) {
+	byID := make(map[string]models.Match, len(matches))
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+
+	repaired := make([]models.Match, 0, len(mismatches))
+	entries := make([]models.AuditEntry, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		match, ok := byID[mismatch.MatchID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, models.AuditEntry{
+			LeagueID:   match.LeagueID,
+			Action:     AuditActionMatchPointsRepaired,
+			EntityType: "match",
+			EntityID:   match.ID,
+			ActorID:    actorID,
+			Before:     fmt.Sprintf("%d-%d", match.PlayerAPoints, match.PlayerBPoints),
+			After:      fmt.Sprintf("%d-%d", mismatch.PlayerAPoints, mismatch.PlayerBPoints),
+			CreatedAt:  now,
+		})
+		match.PlayerAPoints = mismatch.PlayerAPoints
+		match.PlayerBPoints = mismatch.PlayerBPoints
+		repaired = append(repaired, match)
+	}
+	return repaired, entries
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeMatchPointsStore serves one season's matches and scores from memory
+type fakeMatchPointsStore struct {
+	matches map[string]models.Match
+	scores  map[string][]models.Score
+}
+
+func (f *fakeMatchPointsStore) GetMatch(ctx context.Context, matchID string) (*models.Match, error) {
+	match, ok := f.matches[matchID]
+	if !ok {
+		return nil, errors.New("match not found")
+	}
+	return &match, nil
+}
+
+func (f *fakeMatchPointsStore) GetMatchScores(ctx context.Context, matchID string) ([]models.Score, error) {
+	return f.scores[matchID], nil
+}
+
+func (f *fakeMatchPointsStore) GetSeason(ctx context.Context, seasonID string) (*models.Season, error) {
+	return &models.Season{ID: seasonID, LeagueID: "l1"}, nil
+}
+
+func (f *fakeMatchPointsStore) GetLeague(ctx context.Context, leagueID string) (*models.League, error) {
+	return &models.League{ID: leagueID}, nil
+}
+
+// pointsAuditMatch returns a completed match scored from its scores, with player B receiving
+// a stroke on the first hole
+func pointsAuditMatch(t *testing.T, id string) (models.Match, []models.Score) {
+	t.Helper()
+	strokesA := make([]int, 9)
+	strokesB := make([]int, 9)
+	strokesB[0] = 1
+
+	match := models.Match{ID: id, LeagueID: "l1", SeasonID: "s1", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+	scores := []models.Score{
+		{ID: id + "-a", MatchID: id, PlayerID: "p1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}, MatchStrokes: strokesA},
+		{ID: id + "-b", MatchID: id, PlayerID: "p2", HoleScores: []int{5, 3, 4, 5, 4, 4, 5, 3, 5}, MatchStrokes: strokesB},
+	}
+	byPlayer := map[string]models.Score{"p1": scores[0], "p2": scores[1]}
+
+	completed, ok, err := CompleteMatchWithRules(models.Season{}, DefaultMatchPointRules, match, byPlayer, strokesA, strokesB)
+	if err != nil || !ok {
+		t.Fatalf("failed to complete match: ok = %v, err = %v", ok, err)
+	}
+	return completed, scores
+}
+
+func TestAuditSeasonMatchPointsTamperedMatch(t *testing.T) {
+	good, goodScores := pointsAuditMatch(t, "m-good")
+	tampered, tamperedScores := pointsAuditMatch(t, "m-tampered")
+	wantA, wantB := tampered.PlayerAPoints, tampered.PlayerBPoints
+	tampered.PlayerAPoints, tampered.PlayerBPoints = wantB+2, wantA-2
+
+	legacy, legacyScores := pointsAuditMatch(t, "m-legacy")
+	for i := range legacyScores {
+		legacyScores[i].MatchStrokes = nil
+	}
+	scheduled := models.Match{ID: "m-scheduled", SeasonID: "s1", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+
+	matches := []models.Match{good, tampered, legacy, scheduled}
+	scoresByMatch := map[string][]models.Score{
+		good.ID:     goodScores,
+		tampered.ID: tamperedScores,
+		legacy.ID:   legacyScores,
+	}
+
+	report, err := AuditSeasonMatchPoints(models.Season{ID: "s1"}, models.League{}, matches, scoresByMatch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Checked != 3 {
+		t.Errorf("checked %d matches, want 3", report.Checked)
+	}
+	if len(report.Unverified) != 1 || report.Unverified[0] != legacy.ID {
+		t.Errorf("unverified = %v, want only %s", report.Unverified, legacy.ID)
+	}
+	if len(report.Mismatched) != 1 {
+		t.Fatalf("mismatched = %+v, want only the tampered match", report.Mismatched)
+	}
+	mismatch := report.Mismatched[0]
+	if mismatch.MatchID != tampered.ID || mismatch.PlayerAPoints != wantA || mismatch.PlayerBPoints != wantB ||
+		mismatch.StoredPlayerAPoints != tampered.PlayerAPoints || mismatch.StoredPlayerBPoints != tampered.PlayerBPoints {
+		t.Errorf("mismatch = %+v, want %s recomputed as %d-%d", mismatch, tampered.ID, wantA, wantB)
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repaired, entries := RepairMatchPoints(matches, report.Mismatched, "admin", now)
+	if len(repaired) != 1 || repaired[0].ID != tampered.ID || repaired[0].PlayerAPoints != wantA || repaired[0].PlayerBPoints != wantB {
+		t.Errorf("repaired = %+v, want %s at %d-%d", repaired, tampered.ID, wantA, wantB)
+	}
+	if len(entries) != 1 || entries[0].Action != AuditActionMatchPointsRepaired || entries[0].EntityID != tampered.ID || entries[0].ActorID != "admin" {
+		t.Errorf("audit entries = %+v, want one repair of %s by admin", entries, tampered.ID)
+	}
+
+	// Once repaired the season audits clean
+	matches[1] = repaired[0]
+	report, err = AuditSeasonMatchPoints(models.Season{ID: "s1"}, models.League{}, matches, scoresByMatch)
+	if err != nil || len(report.Mismatched) != 0 {
+		t.Errorf("after repair: mismatched = %+v, err = %v, want none", report.Mismatched, err)
+	}
+}
+
+func TestVerifyMatchPoints(t *testing.T) {
+	good, goodScores := pointsAuditMatch(t, "m-good")
+	tampered, tamperedScores := pointsAuditMatch(t, "m-tampered")
+	wantA, wantB := tampered.PlayerAPoints, tampered.PlayerBPoints
+	tampered.PlayerAPoints, tampered.PlayerBPoints = 11, 11
+
+	store := &fakeMatchPointsStore{
+		matches: map[string]models.Match{good.ID: good, tampered.ID: tampered},
+		scores:  map[string][]models.Score{good.ID: goodScores, tampered.ID: tamperedScores},
+	}
+	ctx := context.Background()
+
+	consistent, pointsA, pointsB, err := VerifyMatchPoints(ctx, store, good.ID)
+	if err != nil || !consistent || pointsA != good.PlayerAPoints || pointsB != good.PlayerBPoints {
+		t.Errorf("good match: consistent = %v, points %d-%d, err = %v", consistent, pointsA, pointsB, err)
+	}
+
+	consistent, pointsA, pointsB, err = VerifyMatchPoints(ctx, store, tampered.ID)
+	if err != nil || consistent || pointsA != wantA || pointsB != wantB {
+		t.Errorf("tampered match: consistent = %v, points %d-%d, err = %v, want inconsistent and %d-%d", consistent, pointsA, pointsB, err, wantA, wantB)
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sort"
+)
+
+// DefaultPromotionCount is how many players move between adjacent divisions when not configured
+const DefaultPromotionCount = 2
+
+// PromotionConfig controls how finishing positions translate into division moves
+type PromotionConfig struct {
+	// DivisionOrder lists divisions from top to bottom. When empty, divisions are ordered by name.
+	DivisionOrder []string
+	// PromoteCount is how many players at the top of each division below the first move up
+	PromoteCount int
+	// RelegateCount is how many players at the bottom of each division above the last move down
+	RelegateCount int
+}
+
+// DivisionMove is a single player moving between divisions for next season
+type DivisionMove struct {
+	PlayerID     string `json:"playerId"`
+	PlayerName   string `json:"playerName"`
+	Rank         int    `json:"rank"`
+	FromDivision string `json:"fromDivision"`
+	ToDivision   string `json:"toDivision"`
+}
+
+// PromotionResult lists who moves up and who moves down
+type PromotionResult struct {
+	DivisionOrder []string       `json:"divisionOrder"`
+	Promoted      []DivisionMove `json:"promoted"`
+	Relegated     []DivisionMove `json:"relegated"`
+}
+
+// ComputePromotionRelegation determines division moves from each division's final standings.
+// Standings must already be ordered, as returned by ComputeStandings; cutoffs follow that
+// order, so ties at a cutoff are settled by the standings tiebreakers. A player is never
+// both promoted and relegated when a division is too small for both counts.
+// Divisions missing from a non-empty DivisionOrder are ignored.
+func ComputePromotionRelegation(standingsByDivision map[string][]StandingsEntry, config PromotionConfig) PromotionResult {
+	order := config.DivisionOrder
+	if len(order) == 0 {
+		order = make([]string, 0, len(standingsByDivision))
+		for division := range standingsByDivision {
+			order = append(order, division)
+		}
+		sort.Strings(order)
+	}
+
+	result := PromotionResult{
+		DivisionOrder: order,
+		Promoted:      []DivisionMove{},
+		Relegated:     []DivisionMove{},
+	}
+
+	for i, division := range order {
+		standings := standingsByDivision[division]
+
+		promoted := 0
+		if i > 0 {
+			promoted = min(max(config.PromoteCount, 0), len(standings))
+			for _, entry := range standings[:promoted] {
+				result.Promoted = append(result.Promoted, divisionMove(entry, division, order[i-1]))
+			}
+		}
+
+		if i < len(order)-1 {
+			relegated := min(max(config.RelegateCount, 0), len(standings)-promoted)
+			for _, entry := range standings[len(standings)-relegated:] {
+				result.Relegated = append(result.Relegated, divisionMove(entry, division, order[i+1]))
+			}
+		}
+	}
+
+	return result
+}
+
+func divisionMove(entry StandingsEntry, from, to string) DivisionMove {
+	return DivisionMove{
+		PlayerID:     entry.PlayerID,
+		PlayerName:   entry.PlayerName,
+		Rank:         entry.Rank,
+		FromDivision: from,
+		ToDivision:   to,
+	}
+}
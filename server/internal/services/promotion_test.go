@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+)
+
+func promotionFixture() map[string][]StandingsEntry {
+	return map[string][]StandingsEntry{
+		"A": {
+			{Rank: 1, PlayerID: "a1"},
+			{Rank: 2, PlayerID: "a2"},
+			{Rank: 3, PlayerID: "a3"},
+			{Rank: 4, PlayerID: "a4"},
+			{Rank: 5, PlayerID: "a5"},
+		},
+		"B": {
+			{Rank: 1, PlayerID: "b1"},
+			{Rank: 2, PlayerID: "b2"},
+			{Rank: 3, PlayerID: "b3"},
+			{Rank: 4, PlayerID: "b4"},
+			{Rank: 5, PlayerID: "b5"},
+		},
+	}
+}
+
+func movedIDs(moves []DivisionMove) []string {
+	ids := make([]string, len(moves))
+	for i, m := range moves {
+		ids[i] = m.PlayerID
+	}
+	return ids
+}
+
+func equalIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestComputePromotionRelegation(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        PromotionConfig
+		wantPromoted  []string
+		wantRelegated []string
+	}{
+		{
+			name:          "two up two down",
+			config:        PromotionConfig{PromoteCount: 2, RelegateCount: 2},
+			wantPromoted:  []string{"b1", "b2"},
+			wantRelegated: []string{"a4", "a5"},
+		},
+		{
+			name:          "one up three down",
+			config:        PromotionConfig{PromoteCount: 1, RelegateCount: 3},
+			wantPromoted:  []string{"b1"},
+			wantRelegated: []string{"a3", "a4", "a5"},
+		},
+		{
+			name:          "explicit order puts B on top",
+			config:        PromotionConfig{DivisionOrder: []string{"B", "A"}, PromoteCount: 1, RelegateCount: 1},
+			wantPromoted:  []string{"a1"},
+			wantRelegated: []string{"b5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ComputePromotionRelegation(promotionFixture(), tt.config)
+
+			if got := movedIDs(result.Promoted); !equalIDs(got, tt.wantPromoted) {
+				t.Errorf("promoted = %v, want %v", got, tt.wantPromoted)
+			}
+			if got := movedIDs(result.Relegated); !equalIDs(got, tt.wantRelegated) {
+				t.Errorf("relegated = %v, want %v", got, tt.wantRelegated)
+			}
+		})
+	}
+}
+
+func TestComputePromotionRelegationDivisions(t *testing.T) {
+	result := ComputePromotionRelegation(promotionFixture(), PromotionConfig{PromoteCount: 1, RelegateCount: 1})
+
+	if !equalIDs(result.DivisionOrder, []string{"A", "B"}) {
+		t.Errorf("division order = %v, want [A B]", result.DivisionOrder)
+	}
+	if len(result.Promoted) != 1 || result.Promoted[0].FromDivision != "B" || result.Promoted[0].ToDivision != "A" {
+		t.Errorf("promotion = %+v, want B -> A", result.Promoted)
+	}
+	if len(result.Relegated) != 1 || result.Relegated[0].FromDivision != "A" || result.Relegated[0].ToDivision != "B" {
+		t.Errorf("relegation = %+v, want A -> B", result.Relegated)
+	}
+}
+
+func TestComputePromotionRelegationSmallMiddleDivision(t *testing.T) {
+	standings := map[string][]StandingsEntry{
+		"A": {{Rank: 1, PlayerID: "a1"}, {Rank: 2, PlayerID: "a2"}},
+		"B": {{Rank: 1, PlayerID: "b1"}, {Rank: 2, PlayerID: "b2"}, {Rank: 3, PlayerID: "b3"}},
+		"C": {{Rank: 1, PlayerID: "c1"}, {Rank: 2, PlayerID: "c2"}},
+	}
+
+	result := ComputePromotionRelegation(standings, PromotionConfig{PromoteCount: 2, RelegateCount: 2})
+
+	// B can only relegate the one player not already promoted
+	if got, want := movedIDs(result.Promoted), []string{"b1", "b2", "c1", "c2"}; !equalIDs(got, want) {
+		t.Errorf("promoted = %v, want %v", got, want)
+	}
+	if got, want := movedIDs(result.Relegated), []string{"a1", "a2", "b3"}; !equalIDs(got, want) {
+		t.Errorf("relegated = %v, want %v", got, want)
+	}
+}
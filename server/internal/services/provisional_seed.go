@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+// ProvisionalSeedStore looks up a player's other leagues, how many rounds towards their
+// handicap they've posted in each and their index in each league's active season
+type ProvisionalSeedStore interface {
+	GetPlayerLeagues(ctx context.Context, playerID string) ([]models.League, error)
+	CountPlayerHandicapScores(ctx context.Context, leagueID, playerID string, officialOnly bool) (int, error)
+	GetActiveSeason(ctx context.Context, leagueID string) (*models.Season, error)
+	GetSeasonPlayer(ctx context.Context, seasonID, playerID string) (*models.SeasonPlayer, error)
+}
+
+// ProvisionalSeed is a provisional handicap taken from a player's index in another league
+type ProvisionalSeed struct {
+	Provisional    float64 `json:"provisional"`
+	SourceLeagueID string  `json:"sourceLeagueId"`
+	RoundsPosted   int     `json:"roundsPosted"` // Rounds behind the index in the source league
+}
+
+// SeedProvisionalFromOtherLeagues finds a provisional handicap for a player new to league from
+// their index in another league they belong to. Only leagues where the player is established
+// and on the active season's roster count; when there are several, the one with the most
+// rounds posted wins. The index is kept within the new league's provisional range. ok is false
+// when the league hasn't opted in or no other league has an established index.
+func SeedProvisionalFromOtherLeagues(ctx context.Context, store ProvisionalSeedStore, league models.League, playerID string) (seed ProvisionalSeed, ok bool, err error) {
+	if !league.SeedProvisionalFromOtherLeagues {
+		return ProvisionalSeed{}, false, nil
+	}
+
+	leagues, err := store.GetPlayerLeagues(ctx, playerID)
+	if err != nil {
+		return ProvisionalSeed{}, false, fmt.Errorf("failed to get player leagues: %w", err)
+	}
+
+	for _, other := range leagues {
+		if other.ID == league.ID {
+			continue
+		}
+		rounds, err := store.CountPlayerHandicapScores(ctx, other.ID, playerID, other.OfficialRoundsOnly)
+		if err != nil {
+			return ProvisionalSeed{}, false, fmt.Errorf("failed to count scores in league %s: %w", other.ID, err)
+		}
+		if !IsEstablished(other, rounds) || (ok && rounds <= seed.RoundsPosted) {
+			continue
+		}
+
+		season, err := store.GetActiveSeason(ctx, other.ID)
+		if errors.Is(err, persistence.ErrNoActiveSeason) {
+			continue
+		}
+		if err != nil {
+			return ProvisionalSeed{}, false, fmt.Errorf("failed to get active season for league %s: %w", other.ID, err)
+		}
+		sp, err := store.GetSeasonPlayer(ctx, season.ID, playerID)
+		if err != nil {
+			// Not on the roster this season, so there is no current index to take
+			continue
+		}
+
+		seed = ProvisionalSeed{Provisional: sp.CurrentHandicapIndex, SourceLeagueID: other.ID, RoundsPosted: rounds}
+		ok = true
+	}
+	if !ok {
+		return ProvisionalSeed{}, false, nil
+	}
+
+	minHandicap, maxHandicap := ProvisionalHandicapRange(league)
+	seed.Provisional = math.Max(minHandicap, math.Min(maxHandicap, seed.Provisional))
+	return seed, true, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+type fakeProvisionalSeedStore struct {
+	leagues       []models.League
+	rounds        map[string]int                 // By league ID
+	activeSeasons map[string]string              // League ID to active season ID
+	seasonPlayers map[string]models.SeasonPlayer // By season ID
+}
+
+func (f *fakeProvisionalSeedStore) GetPlayerLeagues(ctx context.Context, playerID string) ([]models.League, error) {
+	return f.leagues, nil
+}
+
+func (f *fakeProvisionalSeedStore) CountPlayerHandicapScores(ctx context.Context, leagueID, playerID string, officialOnly bool) (int, error) {
+	return f.rounds[leagueID], nil
+}
+
+func (f *fakeProvisionalSeedStore) GetActiveSeason(ctx context.Context, leagueID string) (*models.Season, error) {
+	seasonID, ok := f.activeSeasons[leagueID]
+	if !ok {
+		return nil, persistence.ErrNoActiveSeason
+	}
+	return &models.Season{ID: seasonID, LeagueID: leagueID, Active: true}, nil
+}
+
+func (f *fakeProvisionalSeedStore) GetSeasonPlayer(ctx context.Context, seasonID, playerID string) (*models.SeasonPlayer, error) {
+	sp, ok := f.seasonPlayers[seasonID]
+	if !ok {
+		return nil, errors.New("season player not found")
+	}
+	return &sp, nil
+}
+
+func TestSeedProvisionalFromOtherLeagues(t *testing.T) {
+	newLeague := models.League{ID: "l2", SeedProvisionalFromOtherLeagues: true}
+	store := &fakeProvisionalSeedStore{
+		leagues:       []models.League{{ID: "l1"}, newLeague},
+		rounds:        map[string]int{"l1": 8},
+		activeSeasons: map[string]string{"l1": "s1"},
+		seasonPlayers: map[string]models.SeasonPlayer{"s1": {PlayerID: "p1", ProvisionalHandicap: 18, CurrentHandicapIndex: 12.4}},
+	}
+
+	seed, ok, err := SeedProvisionalFromOtherLeagues(context.Background(), store, newLeague, "p1")
+	if err != nil || !ok {
+		t.Fatalf("ok = %v, err = %v, want a seed", ok, err)
+	}
+	if seed.Provisional != 12.4 || seed.SourceLeagueID != "l1" || seed.RoundsPosted != 8 {
+		t.Errorf("seed = %+v, want 12.4 from l1", seed)
+	}
+}
+
+func TestSeedProvisionalFromOtherLeaguesRequiresOptInAndEstablishedIndex(t *testing.T) {
+	store := &fakeProvisionalSeedStore{
+		leagues:       []models.League{{ID: "l1"}, {ID: "l3"}},
+		rounds:        map[string]int{"l1": 2, "l3": 9}, // Only the provisional rounds in l1; no active season in l3
+		activeSeasons: map[string]string{"l1": "s1"},
+		seasonPlayers: map[string]models.SeasonPlayer{"s1": {PlayerID: "p1", CurrentHandicapIndex: 15}},
+	}
+
+	if _, ok, err := SeedProvisionalFromOtherLeagues(context.Background(), store, models.League{ID: "l2"}, "p1"); ok || err != nil {
+		t.Errorf("league not opted in: ok = %v, err = %v, want no seed", ok, err)
+	}
+	if _, ok, err := SeedProvisionalFromOtherLeagues(context.Background(), store, models.League{ID: "l2", SeedProvisionalFromOtherLeagues: true}, "p1"); ok || err != nil {
+		t.Errorf("no established index: ok = %v, err = %v, want no seed", ok, err)
+	}
+}
+
+func TestSeedProvisionalFromOtherLeaguesPrefersMostRoundsAndClamps(t *testing.T) {
+	maxProvisional := 20.0
+	newLeague := models.League{ID: "l9", SeedProvisionalFromOtherLeagues: true, MaxProvisionalHandicap: &maxProvisional}
+	store := &fakeProvisionalSeedStore{
+		leagues:       []models.League{{ID: "l1"}, {ID: "l2"}},
+		rounds:        map[string]int{"l1": 4, "l2": 12},
+		activeSeasons: map[string]string{"l1": "s1", "l2": "s2"},
+		seasonPlayers: map[string]models.SeasonPlayer{
+			"s1": {PlayerID: "p1", CurrentHandicapIndex: 18.5},
+			"s2": {PlayerID: "p1", CurrentHandicapIndex: 24.3},
+		},
+	}
+
+	seed, ok, err := SeedProvisionalFromOtherLeagues(context.Background(), store, newLeague, "p1")
+	if err != nil || !ok {
+		t.Fatalf("ok = %v, err = %v, want a seed", ok, err)
+	}
+	if seed.SourceLeagueID != "l2" || seed.Provisional != 20 {
+		t.Errorf("seed = %+v, want l2's index clamped to 20", seed)
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+)
+
+// RecalcRunStore saves the stats of a league's latest handicap recalculation
+type RecalcRunStore interface {
+	SaveRecalcRun(ctx context.Context, run *models.RecalcRun) error
+}
+
+// NewRecalcRun builds the stats of a recalculation from its start and finish times and how
+// many season players succeeded, failed or were skipped as inactive. The per-player average
+// covers the players actually processed.
+func NewRecalcRun(leagueID, seasonID string, started, finished time.Time, succeeded, failed, skipped int) models.RecalcRun {
+	duration := finished.Sub(started)
+	processed := succeeded + failed
+
+	run := models.RecalcRun{
+		LeagueID:         leagueID,
+		SeasonID:         seasonID,
+		StartedAt:        started,
+		FinishedAt:       finished,
+		DurationMs:       duration.Milliseconds(),
+		PlayersProcessed: processed,
+		PlayersSucceeded: succeeded,
+		PlayersFailed:    failed,
+		PlayersSkipped:   skipped,
+	}
+	if processed > 0 {
+		run.AvgPlayerMs = float64(duration.Microseconds()) / 1000 / float64(processed)
+	}
+	return run
+}
+
+// RecordRecalcRun logs a recalculation's timing and saves it as the league's last run. A
+// failure to save is logged and returned; the recalculation itself has already finished.
+func RecordRecalcRun(ctx context.Context, store RecalcRunStore, run models.RecalcRun) error {
+	logger.InfoContext(ctx, "Handicap recalculation finished",
+		"league_id", run.LeagueID,
+		"season_id", run.SeasonID,
+		"duration_ms", run.DurationMs,
+		"avg_player_ms", run.AvgPlayerMs,
+		"players_processed", run.PlayersProcessed,
+		"players_succeeded", run.PlayersSucceeded,
+		"players_failed", run.PlayersFailed,
+		"players_skipped", run.PlayersSkipped,
+	)
+
+	if err := store.SaveRecalcRun(ctx, &run); err != nil {
+		logger.WarnContext(ctx, "Failed to record handicap recalculation stats",
+			"league_id", run.LeagueID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
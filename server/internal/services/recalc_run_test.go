@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeRecalcRunStore keeps the last saved run per league
+type fakeRecalcRunStore struct {
+	runs map[string]models.RecalcRun
+	err  error
+}
+
+func (f *fakeRecalcRunStore) SaveRecalcRun(ctx context.Context, run *models.RecalcRun) error {
+	if f.err != nil {
+		return f.err
+	}
+	run.ID = run.LeagueID
+	f.runs[run.LeagueID] = *run
+	return nil
+}
+
+func TestRecordRecalcRun(t *testing.T) {
+	store := &fakeRecalcRunStore{runs: map[string]models.RecalcRun{}}
+	started := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	finished := started.Add(1200 * time.Millisecond)
+
+	run := NewRecalcRun("l1", "s1", started, finished, 7, 1, 2)
+	if err := RecordRecalcRun(context.Background(), store, run); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, ok := store.runs["l1"]
+	if !ok {
+		t.Fatal("no run saved for l1")
+	}
+	if saved.ID != "l1" || saved.SeasonID != "s1" {
+		t.Errorf("saved run keyed %s for season %s, want l1 for s1", saved.ID, saved.SeasonID)
+	}
+	if saved.PlayersProcessed != 8 || saved.PlayersSucceeded != 7 || saved.PlayersFailed != 1 || saved.PlayersSkipped != 2 {
+		t.Errorf("counts = %d processed, %d ok, %d failed, %d skipped, want 8, 7, 1, 2",
+			saved.PlayersProcessed, saved.PlayersSucceeded, saved.PlayersFailed, saved.PlayersSkipped)
+	}
+	if saved.DurationMs != 1200 || saved.AvgPlayerMs != 150 {
+		t.Errorf("timing = %dms total, %.1fms per player, want 1200 and 150", saved.DurationMs, saved.AvgPlayerMs)
+	}
+	if !saved.StartedAt.Equal(started) || !saved.FinishedAt.Equal(finished) {
+		t.Errorf("run spans %v to %v, want %v to %v", saved.StartedAt, saved.FinishedAt, started, finished)
+	}
+}
+
+func TestNewRecalcRunNoPlayers(t *testing.T) {
+	now := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	run := NewRecalcRun("l1", "s1", now, now.Add(5*time.Millisecond), 0, 0, 3)
+	if run.PlayersProcessed != 0 || run.AvgPlayerMs != 0 || run.DurationMs != 5 {
+		t.Errorf("run = %+v, want no players processed, no average and 5ms", run)
+	}
+}
+
+func TestRecordRecalcRunSaveError(t *testing.T) {
+	store := &fakeRecalcRunStore{runs: map[string]models.RecalcRun{}, err: errors.New("unavailable")}
+	now := time.Now()
+	if err := RecordRecalcRun(context.Background(), store, NewRecalcRun("l1", "s1", now, now, 1, 0, 0)); err == nil {
+		t.Error("expected the save error to be returned")
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"golf-league-manager/internal/models"
+)
+
+// Chunking defaults for differential recomputation
+const (
+	DefaultRecomputeChunkSize = 200
+	MaxRecomputeChunkSize     = 500
+)
+
+// differentialTolerance ignores floating point noise when comparing stored differentials
+const differentialTolerance = 1e-9
+
+// errRecomputeChunkLimit stops score iteration once MaxChunks chunks have been saved
+var errRecomputeChunkLimit = errors.New("recompute chunk limit reached")
+
+// DifferentialStore streams a league's scores and saves corrected ones
+type DifferentialStore interface {
+	IterateLeagueScoresAfter(ctx context.Context, leagueID, afterID string, fn func(models.Score) error) error
+	BatchUpsertScores(ctx context.Context, scores []models.Score) error
+}
+
+// RecomputeOptions controls where a recompute starts and how much it does per call
+type RecomputeOptions struct {
+	Cursor    string // ID of the last score processed by a previous run; empty starts from the beginning
+	ChunkSize int    // Scores read and saved per chunk
+	MaxChunks int    // Chunks to process before returning; zero processes everything
+
+	StandardSlope int // League baseline slope; zero uses DefaultStandardSlope
+}
+
+// RecomputeResult summarizes a recompute run. When Done is false, pass NextCursor back as
+// the cursor to continue where this run stopped.
+type RecomputeResult struct {
+	Processed  int    `json:"processed"`
+	Updated    int    `json:"updated"`
+	Skipped    int    `json:"skipped"` // Scores on courses that no longer exist
+	NextCursor string `json:"nextCursor"`
+	Done       bool   `json:"done"`
+}
+
+// RecomputeDifferentials recalculates HandicapDifferential for every score in a league from
+// its stored adjusted gross and the course's current rating and slope, including the
+// playing conditions adjustment of the match day it was played on (pccByMatch, keyed by
+// match ID) and the league's baseline slope. Absent scores never carry a differential and
+// are left alone.
+// Scores are streamed from the store and processed in chunks, and only scores whose
+// differential changed are saved.
+// Each chunk is saved before the cursor moves past it, so a failed run can be resumed from
+// the returned NextCursor without redoing or skipping work.
+func RecomputeDifferentials(ctx context.Context, store DifferentialStore, leagueID string, courses map[string]models.Course, pccByMatch map[string]int, opts RecomputeOptions) (RecomputeResult, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultRecomputeChunkSize
+	}
+	if chunkSize > MaxRecomputeChunkSize {
+		chunkSize = MaxRecomputeChunkSize
+	}
+
+	standardSlope := opts.StandardSlope
+	if standardSlope <= 0 {
+		standardSlope = DefaultStandardSlope
+	}
+
+	result := RecomputeResult{NextCursor: opts.Cursor}
+	chunk := make([]models.Score, 0, chunkSize)
+	chunks := 0
+
+	// saveChunk saves the scores in the current chunk whose differential changed and moves
+	// the cursor past it
+	saveChunk := func() error {
+		changed := make([]models.Score, 0, len(chunk))
+		skipped := 0
+		for _, score := range chunk {
+			if score.PlayerAbsent {
+				continue
+			}
+			course, ok := courses[score.CourseID]
+			if !ok || course.SlopeRating == 0 {
+				skipped++
+				continue
+			}
+
+			diff := CalculateLeagueDifferential(score, course, pccByMatch[score.MatchID], standardSlope)
+			if math.Abs(diff-score.HandicapDifferential) > differentialTolerance {
+				score.HandicapDifferential = diff
+				changed = append(changed, score)
+			}
+		}
+
+		if err := store.BatchUpsertScores(ctx, changed); err != nil {
+			return fmt.Errorf("failed to save recomputed scores after %q: %w", result.NextCursor, err)
+		}
+		result.Processed += len(chunk)
+		result.Updated += len(changed)
+		result.Skipped += skipped
+
+		if len(chunk) > 0 {
+			result.NextCursor = chunk[len(chunk)-1].ID
+		}
+		chunk = chunk[:0]
+		chunks++
+		return nil
+	}
+
+	var saveErr error
+	err := store.IterateLeagueScoresAfter(ctx, leagueID, opts.Cursor, func(score models.Score) error {
+		chunk = append(chunk, score)
+		if len(chunk) < chunkSize {
+			return nil
+		}
+		if saveErr = saveChunk(); saveErr != nil {
+			return saveErr
+		}
+		if opts.MaxChunks > 0 && chunks >= opts.MaxChunks {
+			return errRecomputeChunkLimit
+		}
+		return nil
+	})
+	switch {
+	case saveErr != nil:
+		return result, saveErr
+	case errors.Is(err, errRecomputeChunkLimit):
+		return result, nil
+	case err != nil:
+		return result, fmt.Errorf("failed to list scores after %q: %w", result.NextCursor, err)
+	}
+
+	// The last chunk is whatever is left over once the scores run out
+	if err := saveChunk(); err != nil {
+		return result, err
+	}
+	result.Done = true
+	result.NextCursor = ""
+	return result, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeDifferentialStore streams scores held in memory, ordered by ID
+type fakeDifferentialStore struct {
+	scores    map[string]models.Score
+	saves     int
+	failSaves int // Number of upcoming saves to fail
+}
+
+func (f *fakeDifferentialStore) IterateLeagueScoresAfter(ctx context.Context, leagueID, afterID string, fn func(models.Score) error) error {
+	ids := make([]string, 0, len(f.scores))
+	for id, score := range f.scores {
+		if score.LeagueID == leagueID && id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := fn(f.scores[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeDifferentialStore) BatchUpsertScores(ctx context.Context, scores []models.Score) error {
+	if f.failSaves > 0 {
+		f.failSaves--
+		return errors.New("unavailable")
+	}
+	f.saves++
+	for _, score := range scores {
+		f.scores[score.ID] = score
+	}
+	return nil
+}
+
+func seedRecomputeStore() (*fakeDifferentialStore, map[string]models.Course) {
+	courses := map[string]models.Course{
+		"c1": {ID: "c1", CourseRating: 35.0, SlopeRating: 113},
+		"c2": {ID: "c2", CourseRating: 36.2, SlopeRating: 130},
+	}
+	store := &fakeDifferentialStore{scores: make(map[string]models.Score)}
+	for i := 0; i < 7; i++ {
+		id := fmt.Sprintf("s%02d", i)
+		courseID := "c1"
+		if i%2 == 1 {
+			courseID = "c2"
+		}
+		store.scores[id] = models.Score{
+			ID:                   id,
+			LeagueID:             "league-1",
+			MatchID:              fmt.Sprintf("m%d", i%3),
+			CourseID:             courseID,
+			AdjustedGross:        40 + i,
+			HandicapDifferential: 99.9, // Stale value from before the fix
+		}
+	}
+	// Absent rounds keep no differential
+	store.scores["s07"] = models.Score{ID: "s07", LeagueID: "league-1", CourseID: "c1", AdjustedGross: 45, PlayerAbsent: true}
+	// Another league's scores are untouched
+	store.scores["s08"] = models.Score{ID: "s08", LeagueID: "league-2", CourseID: "c1", AdjustedGross: 45, HandicapDifferential: 99.9}
+	return store, courses
+}
+
+func TestRecomputeDifferentials(t *testing.T) {
+	store, courses := seedRecomputeStore()
+	pccByMatch := map[string]int{"m1": 2}
+
+	result, err := RecomputeDifferentials(context.Background(), store, "league-1", courses, pccByMatch, RecomputeOptions{ChunkSize: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Done || result.NextCursor != "" {
+		t.Errorf("result = %+v, want done with no cursor", result)
+	}
+	if result.Processed != 8 || result.Updated != 7 {
+		t.Errorf("processed/updated = %d/%d, want 8/7", result.Processed, result.Updated)
+	}
+
+	for id, score := range store.scores {
+		switch {
+		case score.LeagueID != "league-1":
+			if score.HandicapDifferential != 99.9 {
+				t.Errorf("%s from another league was changed", id)
+			}
+		case score.PlayerAbsent:
+			if score.HandicapDifferential != 0 {
+				t.Errorf("absent score %s got differential %.1f", id, score.HandicapDifferential)
+			}
+		default:
+			want := CalculateLeagueDifferential(score, courses[score.CourseID], pccByMatch[score.MatchID], DefaultStandardSlope)
+			if score.HandicapDifferential != want {
+				t.Errorf("%s differential = %.2f, want %.2f", id, score.HandicapDifferential, want)
+			}
+		}
+	}
+
+	// A second run finds nothing left to correct
+	again, err := RecomputeDifferentials(context.Background(), store, "league-1", courses, pccByMatch, RecomputeOptions{ChunkSize: 3})
+	if err != nil || again.Updated != 0 {
+		t.Errorf("second run updated %d scores (err %v), want 0", again.Updated, err)
+	}
+}
+
+func TestRecomputeDifferentialsResume(t *testing.T) {
+	store, courses := seedRecomputeStore()
+	ctx := context.Background()
+
+	// Stop after the first chunk
+	first, err := RecomputeDifferentials(ctx, store, "league-1", courses, nil, RecomputeOptions{ChunkSize: 3, MaxChunks: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Done || first.NextCursor != "s02" || first.Processed != 3 {
+		t.Fatalf("first run = %+v, want 3 processed and cursor s02", first)
+	}
+
+	// The next chunk fails to save; the cursor must not move past it
+	store.failSaves = 1
+	failed, err := RecomputeDifferentials(ctx, store, "league-1", courses, nil, RecomputeOptions{Cursor: first.NextCursor, ChunkSize: 3})
+	if err == nil {
+		t.Fatal("expected save error, got nil")
+	}
+	if failed.NextCursor != "s02" {
+		t.Errorf("cursor after failure = %q, want s02", failed.NextCursor)
+	}
+
+	resumed, err := RecomputeDifferentials(ctx, store, "league-1", courses, nil, RecomputeOptions{Cursor: failed.NextCursor, ChunkSize: 3})
+	if err != nil {
+		t.Fatalf("unexpected error on resume: %v", err)
+	}
+	if !resumed.Done || resumed.Processed != 5 {
+		t.Errorf("resumed run = %+v, want done with 5 processed", resumed)
+	}
+
+	for id, score := range store.scores {
+		if score.LeagueID == "league-1" && !score.PlayerAbsent && score.HandicapDifferential == 99.9 {
+			t.Errorf("%s was never recomputed", id)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// LastMatchResult is a player's result in their most recent completed match
+type LastMatchResult struct {
+	MatchID        string    `json:"matchId"`
+	Date           time.Time `json:"date"`
+	OpponentID     string    `json:"opponentId"`
+	OpponentName   string    `json:"opponentName"`
+	Points         int       `json:"points"`
+	OpponentPoints int       `json:"opponentPoints"`
+}
+
+// RosterEntry is a season player with their current index and latest result
+type RosterEntry struct {
+	models.SeasonPlayer
+	HandicapIndex float64          `json:"handicapIndex"`
+	MatchesPlayed int              `json:"matchesPlayed"`
+	LastMatch     *LastMatchResult `json:"lastMatch"` // nil until the player has a completed match
+}
+
+// BuildSeasonRoster lists the active players in a season with the index they carry into their
+// next match, the number of matches they have played and their most recent result. Matches are
+// counted the same way as in standings: only completed matches with points recorded and not
+// under dispute. The roster is ordered by player name.
+func BuildSeasonRoster(seasonPlayers []models.SeasonPlayer, matches []models.Match) []RosterEntry {
+	names := make(map[string]string, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		names[sp.PlayerID] = sp.PlayerName
+	}
+
+	roster := make([]RosterEntry, 0, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		entry := RosterEntry{SeasonPlayer: sp, HandicapIndex: EffectiveHandicapIndex(sp)}
+
+		for _, match := range matches {
+			if match.Status != "completed" || match.Disputed {
+				continue
+			}
+			if match.PlayerAPoints == 0 && match.PlayerBPoints == 0 {
+				continue
+			}
+
+			var result LastMatchResult
+			switch sp.PlayerID {
+			case match.PlayerAID:
+				result = LastMatchResult{OpponentID: match.PlayerBID, OpponentName: match.PlayerBName, Points: match.PlayerAPoints, OpponentPoints: match.PlayerBPoints}
+			case match.PlayerBID:
+				result = LastMatchResult{OpponentID: match.PlayerAID, OpponentName: match.PlayerAName, Points: match.PlayerBPoints, OpponentPoints: match.PlayerAPoints}
+			default:
+				continue
+			}
+			result.MatchID = match.ID
+			result.Date = match.MatchDate
+			if result.OpponentName == "" {
+				result.OpponentName = names[result.OpponentID]
+			}
+
+			entry.MatchesPlayed++
+			if entry.LastMatch == nil || result.Date.After(entry.LastMatch.Date) {
+				entry.LastMatch = &result
+			}
+		}
+
+		roster = append(roster, entry)
+	}
+
+	sort.SliceStable(roster, func(i, j int) bool {
+		return SeasonPlayerLess(roster[i].SeasonPlayer, roster[j].SeasonPlayer)
+	})
+	return roster
+}
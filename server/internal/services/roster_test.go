@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildSeasonRoster(t *testing.T) {
+	week1 := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+
+	seasonPlayers := []models.SeasonPlayer{
+		{PlayerID: "p2", PlayerName: "Bob", IsActive: true, ProvisionalHandicap: 18.0},
+		{PlayerID: "p1", PlayerName: "Alice", IsActive: true, CurrentHandicapIndex: 8.4},
+		{PlayerID: "p3", PlayerName: "Cara", IsActive: true, CurrentHandicapIndex: 12.0},
+		{PlayerID: "p4", PlayerName: "Dan", IsActive: false},
+	}
+	matches := []models.Match{
+		{ID: "m2", PlayerAID: "p2", PlayerBID: "p1", PlayerBName: "Alice", MatchDate: week2, Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8},
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", MatchDate: week1, Status: "completed", PlayerAPoints: 12, PlayerBPoints: 10},
+		// Scheduled and disputed matches don't count
+		{ID: "m3", PlayerAID: "p3", PlayerBID: "p4", MatchDate: week1, Status: "scheduled"},
+		{ID: "m4", PlayerAID: "p1", PlayerBID: "p3", MatchDate: week2.AddDate(0, 0, 7), Status: "completed", Disputed: true, PlayerAPoints: 11, PlayerBPoints: 11},
+	}
+
+	roster := BuildSeasonRoster(seasonPlayers, matches)
+	if len(roster) != 3 {
+		t.Fatalf("got %d players, want 3 active players", len(roster))
+	}
+	if roster[0].PlayerID != "p1" || roster[1].PlayerID != "p2" || roster[2].PlayerID != "p3" {
+		t.Errorf("roster order = %s, %s, %s, want p1, p2, p3", roster[0].PlayerID, roster[1].PlayerID, roster[2].PlayerID)
+	}
+
+	alice := roster[0]
+	if alice.HandicapIndex != 8.4 || alice.MatchesPlayed != 2 {
+		t.Errorf("alice = index %.1f, %d played, want 8.4 and 2", alice.HandicapIndex, alice.MatchesPlayed)
+	}
+	if alice.LastMatch == nil || alice.LastMatch.MatchID != "m2" || alice.LastMatch.Points != 8 || alice.LastMatch.OpponentPoints != 14 || alice.LastMatch.OpponentName != "Bob" {
+		t.Errorf("alice last match = %+v, want m2 lost 8-14 to Bob", alice.LastMatch)
+	}
+
+	bob := roster[1]
+	if bob.HandicapIndex != 18.0 || bob.LastMatch == nil || bob.LastMatch.Points != 14 || bob.LastMatch.OpponentName != "Alice" {
+		t.Errorf("bob = index %.1f, last match %+v, want provisional 18.0 and a 14 point win over Alice", bob.HandicapIndex, bob.LastMatch)
+	}
+
+	cara := roster[2]
+	if cara.MatchesPlayed != 0 || cara.LastMatch != nil {
+		t.Errorf("cara = %d played, last match %+v, want no completed matches", cara.MatchesPlayed, cara.LastMatch)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// Sandbagging report thresholds
+const (
+	SandbagNetUnderPar = 3.0 // Average net strokes under par that gets a player flagged
+	SandbagMinRounds   = 3   // Rounds needed before a player can be flagged
+)
+
+// SandbagFlag describes a player whose net scores are consistently better than their
+// handicap predicts
+type SandbagFlag struct {
+	PlayerID          string  `json:"playerId"`
+	PlayerName        string  `json:"playerName"`
+	HandicapIndex     float64 `json:"handicapIndex"`
+	Rounds            int     `json:"rounds"`
+	AverageNetToPar   float64 `json:"averageNetToPar"`   // Negative when the player beats par net
+	RoundsUnderTarget int     `json:"roundsUnderTarget"` // Rounds at least SandbagNetUnderPar under par net
+}
+
+// DetectSandbaggers flags active season players whose net scores sit well under par. A player
+// playing to their handicap averages a little over par net, so a player is flagged once they
+// have SandbagMinRounds rounds, average at least SandbagNetUnderPar strokes under par net and
+// were that far under in most of their rounds. Net is gross less the playing handicap used in
+// the round. Absent rounds and rounds on unknown courses are ignored. Flags are ordered from
+// the largest gap down.
+func DetectSandbaggers(seasonPlayers []models.SeasonPlayer, scores []models.Score, courses map[string]models.Course) []SandbagFlag {
+	netToPar := make(map[string][]int)
+	for _, score := range scores {
+		if score.PlayerAbsent {
+			continue
+		}
+		course, ok := courses[score.CourseID]
+		if !ok || course.Par == 0 {
+			continue
+		}
+		net := score.GrossScore - score.PlayingHandicap
+		netToPar[score.PlayerID] = append(netToPar[score.PlayerID], net-course.Par)
+	}
+
+	flags := make([]SandbagFlag, 0)
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		rounds := netToPar[sp.PlayerID]
+		if len(rounds) < SandbagMinRounds {
+			continue
+		}
+
+		total, under := 0, 0
+		for _, r := range rounds {
+			total += r
+			if float64(r) <= -SandbagNetUnderPar {
+				under++
+			}
+		}
+		average := float64(total) / float64(len(rounds))
+		if average > -SandbagNetUnderPar || under*2 <= len(rounds) {
+			continue
+		}
+
+		flags = append(flags, SandbagFlag{
+			PlayerID:          sp.PlayerID,
+			PlayerName:        sp.PlayerName,
+			HandicapIndex:     EffectiveHandicapIndex(sp),
+			Rounds:            len(rounds),
+			AverageNetToPar:   math.Round(average*10) / 10,
+			RoundsUnderTarget: under,
+		})
+	}
+
+	sort.SliceStable(flags, func(i, j int) bool {
+		return flags[i].AverageNetToPar < flags[j].AverageNetToPar
+	})
+	return flags
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestDetectSandbaggers(t *testing.T) {
+	courses := map[string]models.Course{"c1": {ID: "c1", Par: 36}}
+	seasonPlayers := []models.SeasonPlayer{
+		{PlayerID: "sandbagger", PlayerName: "Sam", IsActive: true, CurrentHandicapIndex: 18.0},
+		{PlayerID: "honest", PlayerName: "Hank", IsActive: true, CurrentHandicapIndex: 12.0},
+		{PlayerID: "newcomer", PlayerName: "Nia", IsActive: true, CurrentHandicapIndex: 20.0},
+	}
+
+	round := func(playerID string, gross, playingHandicap int) models.Score {
+		return models.Score{PlayerID: playerID, CourseID: "c1", GrossScore: gross, PlayingHandicap: playingHandicap}
+	}
+	scores := []models.Score{
+		// Plays off 9 but shoots near scratch every week: 3-5 under par net
+		round("sandbagger", 41, 9),
+		round("sandbagger", 40, 9),
+		round("sandbagger", 42, 9),
+		round("sandbagger", 41, 9),
+		// Plays to handicap, a little over par net with one good night
+		round("honest", 44, 6),
+		round("honest", 40, 6),
+		round("honest", 36, 6),
+		round("honest", 45, 6),
+		// A great night counts for nothing until there are enough rounds
+		round("newcomer", 38, 10),
+		round("newcomer", 39, 10),
+		// Absent rounds are ignored
+		{PlayerID: "honest", CourseID: "c1", GrossScore: 20, PlayingHandicap: 6, PlayerAbsent: true},
+	}
+
+	flags := DetectSandbaggers(seasonPlayers, scores, courses)
+	if len(flags) != 1 {
+		t.Fatalf("got %d flags (%+v), want only the sandbagger", len(flags), flags)
+	}
+	flag := flags[0]
+	if flag.PlayerID != "sandbagger" || flag.Rounds != 4 || flag.RoundsUnderTarget != 4 {
+		t.Errorf("flag = %+v, want sandbagger with 4 of 4 rounds under target", flag)
+	}
+	if flag.AverageNetToPar != -4.0 || flag.HandicapIndex != 18.0 {
+		t.Errorf("flag = %+v, want an average of -4.0 net to par off 18.0", flag)
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"golf-league-manager/internal/models"
+)
+
+// Scenario describes what the trailing player in a match in progress needs on the remaining holes
+type Scenario struct {
+	ThroughHole      int    `json:"throughHole"`
+	HolesRemaining   int    `json:"holesRemaining"`
+	PointsA          int    `json:"pointsA"` // Points earned so far: holes, plus a decided Nassau front nine
+	PointsB          int    `json:"pointsB"`
+	NetA             int    `json:"netA"` // Net strokes so far
+	NetB             int    `json:"netB"`
+	TrailingPlayerID string `json:"trailingPlayerId"` // Empty when the match is level on points and net
+	PointsAvailable  int    `json:"pointsAvailable"`  // Hole points plus the overall points still to play for
+
+	// Points the trailing player still needs from the remaining pool
+	PointsToWin int  `json:"pointsToWin"`
+	PointsToTie int  `json:"pointsToTie"`
+	CanWin      bool `json:"canWin"`
+	CanTie      bool `json:"canTie"`
+
+	// Most net strokes the trailing player can take over the remaining holes, relative to the
+	// opponent's net strokes on those holes, and still win or tie the overall net points.
+	// Negative values are strokes that must be made up.
+	MaxStrokesToWinOverall int `json:"maxStrokesToWinOverall"`
+	MaxStrokesToTieOverall int `json:"maxStrokesToTieOverall"`
+}
+
+// ComputeScenario projects a match over the given number of holes in progress after
+// throughHole holes, under the league's match point values and the season's halved hole rule
+// and Nassau mode. A match with no holes given is taken to be 9 holes. The trailing player is
+// the one behind on points, or on net strokes when points are level.
+func ComputeScenario(scoreA, scoreB models.Score, strokesA, strokesB []int, throughHole, holes int, rules MatchPointRules) Scenario {
+	if holes <= 0 {
+		holes = holesPerRound
+	}
+	throughHole = min(throughHole, holes, len(scoreA.HoleScores), len(scoreB.HoleScores), len(strokesA), len(strokesB))
+	if throughHole < 0 {
+		throughHole = 0
+	}
+
+	scenario := Scenario{
+		ThroughHole:    throughHole,
+		HolesRemaining: holes - throughHole,
+	}
+
+	var frontNetA, frontNetB int
+	for i := 0; i < throughHole; i++ {
+		netA := scoreA.HoleScores[i] - strokesA[i]
+		netB := scoreB.HoleScores[i] - strokesB[i]
+
+		scenario.NetA += netA
+		scenario.NetB += netB
+		if i < holes/2 {
+			frontNetA += netA
+			frontNetB += netB
+		}
+
+		if netA < netB {
+			scenario.PointsA += rules.PerHole
+		} else if netB < netA {
+			scenario.PointsB += rules.PerHole
+		} else if !rules.NoHalvedHolePoints {
+			scenario.PointsA += rules.PerHole / 2
+			scenario.PointsB += rules.PerHole / 2
+		}
+	}
+
+	// A Nassau front nine is settled once it has been played; the back nine and total are not
+	overallBlocks := rules.overallBlocks(holes)
+	if overallBlocks == 3 && throughHole >= holes/2 {
+		blockA, blockB := overallPoints(frontNetA, frontNetB, rules.Overall)
+		scenario.PointsA += blockA
+		scenario.PointsB += blockB
+		overallBlocks--
+	}
+	scenario.PointsAvailable = scenario.HolesRemaining*rules.PerHole + overallBlocks*rules.Overall
+
+	trailingIsA := scenario.PointsA < scenario.PointsB ||
+		(scenario.PointsA == scenario.PointsB && scenario.NetA > scenario.NetB)
+	trailingIsB := scenario.PointsB < scenario.PointsA ||
+		(scenario.PointsA == scenario.PointsB && scenario.NetB > scenario.NetA)
+
+	trailingPoints, leadingPoints := scenario.PointsA, scenario.PointsB
+	trailingNet, leadingNet := scenario.NetA, scenario.NetB
+	switch {
+	case trailingIsA:
+		scenario.TrailingPlayerID = scoreA.PlayerID
+	case trailingIsB:
+		scenario.TrailingPlayerID = scoreB.PlayerID
+		trailingPoints, leadingPoints = scenario.PointsB, scenario.PointsA
+		trailingNet, leadingNet = scenario.NetB, scenario.NetA
+	}
+
+	// Every point the trailing player takes from the pool is one the leader doesn't, so they
+	// draw level halfway between the gap and the pool
+	gap := leadingPoints - trailingPoints + scenario.PointsAvailable
+	scenario.PointsToWin = max(gap/2+1, 0)
+	scenario.PointsToTie = max((gap+1)/2, 0)
+	scenario.CanWin = scenario.PointsToWin <= scenario.PointsAvailable
+	scenario.CanTie = scenario.PointsToTie <= scenario.PointsAvailable
+
+	deficit := trailingNet - leadingNet
+	scenario.MaxStrokesToTieOverall = -deficit
+	scenario.MaxStrokesToWinOverall = -deficit - 1
+
+	return scenario
+}
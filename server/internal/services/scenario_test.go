@@ -0,0 +1,152 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComputeScenario(t *testing.T) {
+	noStrokes := make([]int, holesPerRound)
+
+	tests := []struct {
+		name         string
+		holesA       []int
+		holesB       []int
+		throughHole  int
+		wantTrailing string
+		wantPointsA  int
+		wantPointsB  int
+		wantToWin    int
+		wantToTie    int
+		wantCanWin   bool
+		wantCanTie   bool
+		wantMaxWin   int
+		wantMaxTie   int
+	}{
+		{
+			// B wins holes 1-3, holes 4-5 halved: A is 3 down with 4 to play
+			name:         "3 down with 4 to play",
+			holesA:       []int{5, 5, 5, 4, 4},
+			holesB:       []int{4, 4, 4, 4, 4},
+			throughHole:  5,
+			wantTrailing: "A",
+			wantPointsA:  2,
+			wantPointsB:  8,
+			wantToWin:    10,
+			wantToTie:    9,
+			wantCanWin:   true,
+			wantCanTie:   true,
+			wantMaxWin:   -4,
+			wantMaxTie:   -3,
+		},
+		{
+			// A wins holes 1-7 and halves hole 8: B cannot catch up with one hole left
+			name:         "match decided",
+			holesA:       []int{4, 4, 4, 4, 4, 4, 4, 4},
+			holesB:       []int{5, 5, 5, 5, 5, 5, 5, 4},
+			throughHole:  8,
+			wantTrailing: "B",
+			wantPointsA:  15,
+			wantPointsB:  1,
+			wantToWin:    11,
+			wantToTie:    10,
+			wantCanWin:   false,
+			wantCanTie:   false,
+			wantMaxWin:   -8,
+			wantMaxTie:   -7,
+		},
+		{
+			name:         "level after holes halved",
+			holesA:       []int{4, 5},
+			holesB:       []int{4, 5},
+			throughHole:  2,
+			wantTrailing: "",
+			wantPointsA:  2,
+			wantPointsB:  2,
+			wantToWin:    10,
+			wantToTie:    9,
+			wantCanWin:   true,
+			wantCanTie:   true,
+			wantMaxWin:   -1,
+			wantMaxTie:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scoreA := models.Score{PlayerID: "A", HoleScores: tt.holesA}
+			scoreB := models.Score{PlayerID: "B", HoleScores: tt.holesB}
+
+			got := ComputeScenario(scoreA, scoreB, noStrokes, noStrokes, tt.throughHole, holesPerRound, DefaultMatchPointRules)
+
+			if got.HolesRemaining != holesPerRound-tt.throughHole {
+				t.Errorf("HolesRemaining = %d, want %d", got.HolesRemaining, holesPerRound-tt.throughHole)
+			}
+			if got.TrailingPlayerID != tt.wantTrailing {
+				t.Errorf("TrailingPlayerID = %q, want %q", got.TrailingPlayerID, tt.wantTrailing)
+			}
+			if got.PointsA != tt.wantPointsA || got.PointsB != tt.wantPointsB {
+				t.Errorf("points = %d-%d, want %d-%d", got.PointsA, got.PointsB, tt.wantPointsA, tt.wantPointsB)
+			}
+			if got.PointsToWin != tt.wantToWin || got.PointsToTie != tt.wantToTie {
+				t.Errorf("points to win/tie = %d/%d, want %d/%d", got.PointsToWin, got.PointsToTie, tt.wantToWin, tt.wantToTie)
+			}
+			if got.CanWin != tt.wantCanWin || got.CanTie != tt.wantCanTie {
+				t.Errorf("can win/tie = %v/%v, want %v/%v", got.CanWin, got.CanTie, tt.wantCanWin, tt.wantCanTie)
+			}
+			if got.MaxStrokesToWinOverall != tt.wantMaxWin || got.MaxStrokesToTieOverall != tt.wantMaxTie {
+				t.Errorf("max strokes to win/tie = %d/%d, want %d/%d",
+					got.MaxStrokesToWinOverall, got.MaxStrokesToTieOverall, tt.wantMaxWin, tt.wantMaxTie)
+			}
+		})
+	}
+}
+
+func TestComputeScenarioWithStrokes(t *testing.T) {
+	// B receives a stroke on holes 1 and 2, turning two gross losses into halves
+	strokesA := make([]int, holesPerRound)
+	strokesB := []int{1, 1, 0, 0, 0, 0, 0, 0, 0}
+
+	scoreA := models.Score{PlayerID: "A", HoleScores: []int{4, 4, 4}}
+	scoreB := models.Score{PlayerID: "B", HoleScores: []int{5, 5, 5}}
+
+	got := ComputeScenario(scoreA, scoreB, strokesA, strokesB, 3, holesPerRound, DefaultMatchPointRules)
+
+	if got.PointsA != 4 || got.PointsB != 2 {
+		t.Errorf("points = %d-%d, want 4-2", got.PointsA, got.PointsB)
+	}
+	if got.NetA != 12 || got.NetB != 13 {
+		t.Errorf("net = %d-%d, want 12-13", got.NetA, got.NetB)
+	}
+	if got.TrailingPlayerID != "B" {
+		t.Errorf("TrailingPlayerID = %q, want B", got.TrailingPlayerID)
+	}
+}
+
+func TestComputeScenarioLeagueRules(t *testing.T) {
+	noStrokes := make([]int, 18)
+	scoreA := models.Score{PlayerID: "A", HoleScores: []int{4, 4, 4, 4, 4, 4, 4, 4, 4, 4}}
+	scoreB := models.Score{PlayerID: "B", HoleScores: []int{5, 4, 4, 4, 4, 4, 4, 4, 4, 5}}
+
+	// 18 holes at 4 points a hole and 6 overall, halved holes scoring nothing: A has won holes
+	// 1 and 10 and 8 holes were halved
+	rules := MatchPointRules{PerHole: 4, Overall: 6, NoHalvedHolePoints: true}
+	got := ComputeScenario(scoreA, scoreB, noStrokes, noStrokes, 10, 18, rules)
+	if got.HolesRemaining != 8 || got.PointsA != 8 || got.PointsB != 0 {
+		t.Errorf("scenario = %+v, want A 8-0 with 8 to play", got)
+	}
+	if got.PointsAvailable != 8*4+6 || got.PointsToWin != 24 || got.PointsToTie != 23 || !got.CanWin {
+		t.Errorf("scenario = %+v, want B needing 24 of 38 to win", got)
+	}
+
+	// Under Nassau the front nine's overall points are settled at the turn
+	rules = MatchPointRules{PerHole: 2, Overall: 4, Nassau: true}
+	got = ComputeScenario(scoreA, scoreB, noStrokes, noStrokes, 10, 18, rules)
+	if got.PointsA != 2*2+8+4 || got.PointsB != 8 {
+		t.Errorf("points = %d-%d, want 16-8 with the front nine settled", got.PointsA, got.PointsB)
+	}
+	if got.PointsAvailable != 8*2+2*4 {
+		t.Errorf("points available = %d, want 24 with the back nine and total still open", got.PointsAvailable)
+	}
+}
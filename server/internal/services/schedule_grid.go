@@ -0,0 +1,124 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// ScheduleGridColumn is one match day in a season's schedule grid
+type ScheduleGridColumn struct {
+	MatchDayID string    `json:"matchDayId"`
+	Date       time.Time `json:"date"`
+}
+
+// ScheduleGridCell is who a player faces on one match day. Bye is set when the player has no
+// match that day.
+type ScheduleGridCell struct {
+	MatchID      string `json:"matchId,omitempty"`
+	OpponentID   string `json:"opponentId,omitempty"`
+	OpponentName string `json:"opponentName,omitempty"`
+	Bye          bool   `json:"bye"`
+}
+
+// ScheduleGridRow is one player's schedule, with a cell for each column of the grid
+type ScheduleGridRow struct {
+	PlayerID   string             `json:"playerId"`
+	PlayerName string             `json:"playerName"`
+	Cells      []ScheduleGridCell `json:"cells"`
+}
+
+// ScheduleGrid lays a season's schedule out with match days as columns and players as rows
+type ScheduleGrid struct {
+	SeasonID  string               `json:"seasonId"`
+	MatchDays []ScheduleGridColumn `json:"matchDays"`
+	Players   []ScheduleGridRow    `json:"players"`
+}
+
+// BuildScheduleGrid groups a season's matches by match day into a grid of who plays whom. Columns
+// are ordered by date and rows by player name. Every active season player gets a row, as does
+// anyone who appears in a match without being on the active roster, so no scheduled match is
+// hidden. Matches without a match day are grouped by their date.
+func BuildScheduleGrid(seasonID string, seasonPlayers []models.SeasonPlayer, matches []models.Match) ScheduleGrid {
+	names := make(map[string]string)
+	var rows []models.SeasonPlayer
+	for _, sp := range seasonPlayers {
+		if !sp.IsActive {
+			continue
+		}
+		names[sp.PlayerID] = sp.PlayerName
+		rows = append(rows, sp)
+	}
+
+	columnIndex := make(map[string]int)
+	var columns []ScheduleGridColumn
+	for _, match := range matches {
+		key := scheduleGridKey(match)
+		if _, ok := columnIndex[key]; !ok {
+			columnIndex[key] = len(columns)
+			columns = append(columns, ScheduleGridColumn{MatchDayID: match.MatchDayID, Date: match.MatchDate})
+		}
+		for _, p := range []struct{ id, name string }{{match.PlayerAID, match.PlayerAName}, {match.PlayerBID, match.PlayerBName}} {
+			if _, ok := names[p.id]; !ok && p.id != "" {
+				names[p.id] = p.name
+				rows = append(rows, models.SeasonPlayer{PlayerID: p.id, PlayerName: p.name})
+			}
+		}
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].Date.Before(columns[j].Date)
+	})
+	for i, column := range columns {
+		columnIndex[scheduleGridKey(models.Match{MatchDayID: column.MatchDayID, MatchDate: column.Date})] = i
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return SeasonPlayerLess(rows[i], rows[j])
+	})
+
+	cells := make(map[string][]ScheduleGridCell, len(rows))
+	for _, sp := range rows {
+		playerCells := make([]ScheduleGridCell, len(columns))
+		for i := range playerCells {
+			playerCells[i].Bye = true
+		}
+		cells[sp.PlayerID] = playerCells
+	}
+	for _, match := range matches {
+		col := columnIndex[scheduleGridKey(match)]
+		for _, side := range [][2]string{{match.PlayerAID, match.PlayerBID}, {match.PlayerBID, match.PlayerAID}} {
+			playerID, opponentID := side[0], side[1]
+			playerCells, ok := cells[playerID]
+			if !ok || !playerCells[col].Bye {
+				continue
+			}
+			opponentName := names[opponentID]
+			if opponentName == "" {
+				opponentName = match.PlayerAName
+				if opponentID == match.PlayerBID {
+					opponentName = match.PlayerBName
+				}
+			}
+			playerCells[col] = ScheduleGridCell{MatchID: match.ID, OpponentID: opponentID, OpponentName: opponentName}
+		}
+	}
+
+	grid := ScheduleGrid{
+		SeasonID:  seasonID,
+		MatchDays: make([]ScheduleGridColumn, 0, len(columns)),
+		Players:   make([]ScheduleGridRow, 0, len(rows)),
+	}
+	grid.MatchDays = append(grid.MatchDays, columns...)
+	for _, sp := range rows {
+		grid.Players = append(grid.Players, ScheduleGridRow{PlayerID: sp.PlayerID, PlayerName: names[sp.PlayerID], Cells: cells[sp.PlayerID]})
+	}
+	return grid
+}
+
+// scheduleGridKey identifies the column a match belongs in
+func scheduleGridKey(match models.Match) string {
+	if match.MatchDayID != "" {
+		return match.MatchDayID
+	}
+	return match.MatchDate.Format("2006-01-02")
+}
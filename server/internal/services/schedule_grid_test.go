@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildScheduleGridRoundRobin(t *testing.T) {
+	week1 := time.Date(2025, 5, 1, 18, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week1.AddDate(0, 0, 14)
+
+	seasonPlayers := []models.SeasonPlayer{
+		{PlayerID: "p3", PlayerName: "Cara", IsActive: true},
+		{PlayerID: "p1", PlayerName: "Alice", IsActive: true},
+		{PlayerID: "p2", PlayerName: "Bob", IsActive: true},
+		{PlayerID: "p4", PlayerName: "Dan", IsActive: false},
+	}
+	// Three players, one match a week, so each player sits out once
+	matches := []models.Match{
+		{ID: "m3", MatchDayID: "md3", MatchDate: week3, PlayerAID: "p2", PlayerBID: "p3"},
+		{ID: "m1", MatchDayID: "md1", MatchDate: week1, PlayerAID: "p1", PlayerBID: "p2"},
+		{ID: "m2", MatchDayID: "md2", MatchDate: week2, PlayerAID: "p3", PlayerBID: "p1"},
+	}
+
+	grid := BuildScheduleGrid("s1", seasonPlayers, matches)
+
+	if len(grid.MatchDays) != 3 || grid.MatchDays[0].MatchDayID != "md1" || grid.MatchDays[1].MatchDayID != "md2" || grid.MatchDays[2].MatchDayID != "md3" {
+		t.Fatalf("columns = %+v, want md1, md2, md3", grid.MatchDays)
+	}
+	if len(grid.Players) != 3 {
+		t.Fatalf("got %d rows, want 3 active players", len(grid.Players))
+	}
+
+	want := map[string][]string{
+		"p1": {"p2", "p3", ""},
+		"p2": {"p1", "", "p3"},
+		"p3": {"", "p1", "p2"},
+	}
+	wantOrder := []string{"p1", "p2", "p3"}
+	for i, row := range grid.Players {
+		if row.PlayerID != wantOrder[i] {
+			t.Errorf("row %d = %s, want %s", i, row.PlayerID, wantOrder[i])
+			continue
+		}
+		for col, cell := range row.Cells {
+			opponent := want[row.PlayerID][col]
+			if opponent == "" {
+				if !cell.Bye || cell.OpponentID != "" {
+					t.Errorf("%s week %d = %+v, want a bye", row.PlayerID, col+1, cell)
+				}
+				continue
+			}
+			if cell.Bye || cell.OpponentID != opponent || cell.OpponentName == "" {
+				t.Errorf("%s week %d = %+v, want %s", row.PlayerID, col+1, cell, opponent)
+			}
+		}
+	}
+
+	if cell := grid.Players[0].Cells[1]; cell.MatchID != "m2" || cell.OpponentName != "Cara" {
+		t.Errorf("alice week 2 = %+v, want m2 against Cara", cell)
+	}
+}
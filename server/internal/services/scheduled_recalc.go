@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golf-league-manager/internal/logger"
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+// LeagueLister lists every league in the deployment
+type LeagueLister interface {
+	ListAllLeagues(ctx context.Context) ([]models.League, error)
+}
+
+// ScheduledRecalcResult reports what a scheduled recalculation did in each league
+type ScheduledRecalcResult struct {
+	Recalculated   []string          `json:"recalculated"`   // League IDs whose active season was recalculated
+	NoActiveSeason []string          `json:"noActiveSeason"` // League IDs skipped for having no active season
+	Failed         map[string]string `json:"failed"`         // Error by league ID
+}
+
+// RecalculateAllLeagues runs recalc for every league's active season. Leagues without an
+// active season are skipped, and one league failing doesn't stop the others; only failing to
+// list the leagues is an error.
+func RecalculateAllLeagues(ctx context.Context, store LeagueLister, recalc func(ctx context.Context, leagueID string) error) (ScheduledRecalcResult, error) {
+	result := ScheduledRecalcResult{
+		Recalculated:   []string{},
+		NoActiveSeason: []string{},
+		Failed:         map[string]string{},
+	}
+
+	leagues, err := store.ListAllLeagues(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list leagues: %w", err)
+	}
+
+	for _, league := range leagues {
+		err := recalc(ctx, league.ID)
+		switch {
+		case err == nil:
+			result.Recalculated = append(result.Recalculated, league.ID)
+		case errors.Is(err, persistence.ErrNoActiveSeason):
+			result.NoActiveSeason = append(result.NoActiveSeason, league.ID)
+		default:
+			logger.ErrorContext(ctx, "Scheduled handicap recalculation failed",
+				"league_id", league.ID,
+				"error", err,
+			)
+			result.Failed[league.ID] = err.Error()
+		}
+	}
+
+	logger.InfoContext(ctx, "Scheduled handicap recalculation finished",
+		"leagues", len(leagues),
+		"recalculated", len(result.Recalculated),
+		"no_active_season", len(result.NoActiveSeason),
+		"failed", len(result.Failed),
+	)
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golf-league-manager/internal/models"
+	"golf-league-manager/internal/persistence"
+)
+
+// fakeLeagueLister serves a fixed list of leagues
+type fakeLeagueLister struct {
+	leagues []models.League
+	err     error
+}
+
+func (f *fakeLeagueLister) ListAllLeagues(ctx context.Context) ([]models.League, error) {
+	return f.leagues, f.err
+}
+
+func TestRecalculateAllLeagues(t *testing.T) {
+	store := &fakeLeagueLister{leagues: []models.League{{ID: "l1"}, {ID: "l2"}, {ID: "l3"}, {ID: "l4"}}}
+
+	var ran []string
+	recalc := func(ctx context.Context, leagueID string) error {
+		ran = append(ran, leagueID)
+		switch leagueID {
+		case "l2":
+			return fmt.Errorf("failed to get active season: %w", persistence.ErrNoActiveSeason)
+		case "l3":
+			return errors.New("failed to list courses")
+		}
+		return nil
+	}
+
+	result, err := RecalculateAllLeagues(context.Background(), store, recalc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ran) != 4 {
+		t.Errorf("ran %v, want every league", ran)
+	}
+	if len(result.Recalculated) != 2 || result.Recalculated[0] != "l1" || result.Recalculated[1] != "l4" {
+		t.Errorf("recalculated = %v, want l1 and l4", result.Recalculated)
+	}
+	if len(result.NoActiveSeason) != 1 || result.NoActiveSeason[0] != "l2" {
+		t.Errorf("no active season = %v, want l2", result.NoActiveSeason)
+	}
+	if len(result.Failed) != 1 || result.Failed["l3"] == "" {
+		t.Errorf("failed = %v, want l3", result.Failed)
+	}
+}
+
+func TestRecalculateAllLeaguesListError(t *testing.T) {
+	store := &fakeLeagueLister{err: errors.New("unavailable")}
+	called := false
+	_, err := RecalculateAllLeagues(context.Background(), store, func(ctx context.Context, leagueID string) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("err = %v, called = %v; want an error and no recalculation", err, called)
+	}
+}
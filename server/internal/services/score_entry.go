@@ -0,0 +1,273 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"golf-league-manager/internal/models"
+)
+
+// ErrNotMatchParticipant means a player tried to report a score for a match they are not in
+var ErrNotMatchParticipant = errors.New("player is not in this match")
+
+// ScoreEntryRef identifies the match and player a submitted score is for
+type ScoreEntryRef struct {
+	MatchID  string
+	PlayerID string
+}
+
+// CheckScoreEntryPlayers rejects a match day submission in a season with strict score entry
+// when any score is for a player who is not in its match, or for a match that isn't on the day,
+// so nothing from it is saved. Lenient seasons always pass; the bad scores are reported one by
+// one and the rest are saved.
+func CheckScoreEntryPlayers(season models.Season, matches map[string]models.Match, entries []ScoreEntryRef) error {
+	if !season.StrictScoreEntry {
+		return nil
+	}
+
+	var bad []string
+	for _, entry := range entries {
+		match, ok := matches[entry.MatchID]
+		if !ok || (entry.PlayerID != match.PlayerAID && entry.PlayerID != match.PlayerBID) {
+			bad = append(bad, fmt.Sprintf("%s in match %s", entry.PlayerID, entry.MatchID))
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("%w: %s", ErrNotMatchParticipant, strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// ScoreCard is the hole-by-hole card a player turns in for a match
+type ScoreCard struct {
+	HoleScores    []int
+	ConcededHoles []int // Holes (1-based) conceded to the player; a 0 score on these is filled with net par
+	PlayerAbsent  bool
+	Makeup        bool // Played as a makeup for a missed match
+	Substitute    bool // Played by a substitute standing in for the player
+}
+
+// PlayerMatchHandicap is one player's handicaps and stroke allocation for a match
+type PlayerMatchHandicap struct {
+	HandicapIndex   float64
+	CourseHandicap  float64
+	PlayingHandicap int
+	Strokes         []int // Strokes received on each hole
+	Unestablished   bool  // No established handicap yet; capped at par + 5 in leagues using the unestablished adjustment
+}
+
+// MatchHandicaps works out both players' course and playing handicaps on the match's course
+// and the strokes each receives, from the indexes they bring into the match. In a gross-only
+// league every handicap is 0 and nobody receives strokes; on a gross match play basis the
+// handicaps are kept for net scores and differentials but nobody receives strokes.
+func MatchHandicaps(match models.Match, course models.Course, league models.League, basis string, indexA, indexB float64) (a, b PlayerMatchHandicap) {
+	if !UsesHandicaps(league) {
+		indexA, indexB = 0, 0
+	}
+	courseA, playingA := CalculateCourseAndPlayingHandicapForLeague(indexA, course, league)
+	courseB, playingB := CalculateCourseAndPlayingHandicapForLeague(indexB, course, league)
+	strokes := AssignStrokesForBasis(basis, match.PlayerAID, playingA, match.PlayerBID, playingB, course)
+
+	a = PlayerMatchHandicap{HandicapIndex: indexA, CourseHandicap: courseA, PlayingHandicap: playingA, Strokes: strokes[match.PlayerAID]}
+	b = PlayerMatchHandicap{HandicapIndex: indexB, CourseHandicap: courseB, PlayingHandicap: playingB, Strokes: strokes[match.PlayerBID]}
+	return a, b
+}
+
+// BuildMatchScore computes a player's score for a match from their card: conceded holes are
+// filled in, net double bogey is applied (or the par + 5 cap for unestablished players when the
+// league uses it), and the differential (without any playing conditions adjustment) and match
+// net scores are calculated. Absent players, and every player in a gross-only league, get no
+// differential. The caller fills in the score's ID, player name and league.
+func BuildMatchScore(match models.Match, course models.Course, league models.League, playerID string, card ScoreCard, handicap PlayerMatchHandicap) (models.Score, error) {
+	courseHandicap := int(math.Round(handicap.CourseHandicap))
+
+	var holeScores, adjustedScores []int
+	var totalGross, totalAdjusted int
+	var differential float64
+
+	if card.PlayerAbsent {
+		holeScores = CalculateAbsentPlayerScoresWithPenalty(handicap.PlayingHandicap, AbsentPenaltyStrokes(league), course)
+		for _, sc := range holeScores {
+			totalGross += sc
+		}
+		adjustedScores = make([]int, len(holeScores))
+		copy(adjustedScores, holeScores)
+		totalAdjusted = totalGross
+	} else {
+		if err := ValidateConcededHoles(card.ConcededHoles, len(card.HoleScores)); err != nil {
+			return models.Score{}, err
+		}
+		holeScores = FillConcededHoleScoresWithCap(card.HoleScores, card.ConcededHoles, course, courseHandicap, MaxStrokesPerHole(league))
+		for _, sc := range holeScores {
+			totalGross += sc
+		}
+		if league.UnestablishedAdjustment && handicap.Unestablished {
+			adjustedScores = CalculateAdjustedGrossScoresParCap(holeScores, course)
+		} else {
+			adjustedScores = CalculateAdjustedGrossScoresWithCap(holeScores, course, courseHandicap, MaxStrokesPerHole(league))
+		}
+		for _, sc := range adjustedScores {
+			totalAdjusted += sc
+		}
+		if UsesHandicaps(league) {
+			differential = CalculateLeagueDifferential(models.Score{AdjustedGross: totalAdjusted}, course, 0, StandardSlope(league))
+		}
+	}
+
+	// Calculate Net Hole Scores & Match Net Score
+	netHoleScores := make([]int, len(holeScores))
+	matchNetScore := 0
+	for i, gross := range holeScores {
+		netHoleScores[i] = gross - handicap.Strokes[i]
+		matchNetScore += netHoleScores[i]
+	}
+
+	score := models.Score{
+		MatchID:                 match.ID,
+		PlayerID:                playerID,
+		Date:                    match.MatchDate,
+		CourseID:                match.CourseID,
+		HoleScores:              holeScores,
+		HoleAdjustedGrossScores: adjustedScores,
+		MatchNetHoleScores:      netHoleScores,
+		GrossScore:              totalGross,
+		NetScore:                totalGross - handicap.PlayingHandicap,
+		MatchNetScore:           matchNetScore,
+		AdjustedGross:           totalAdjusted,
+		HandicapDifferential:    differential,
+		HandicapIndex:           handicap.HandicapIndex,
+		CourseHandicap:          courseHandicap,
+		PlayingHandicap:         handicap.PlayingHandicap,
+		StrokesReceived:         handicap.PlayingHandicap, // Strokes received generally equals playing handicap
+		MatchStrokes:            handicap.Strokes,
+		PlayerAbsent:            card.PlayerAbsent,
+		Makeup:                  card.Makeup,
+		Substitute:              card.Substitute,
+		Official:                OfficialRound(card.Makeup, card.Substitute),
+	}
+	if !card.PlayerAbsent {
+		score.ConcededHoles = card.ConcededHoles
+	}
+	score.DisplayNetScore = DisplayNetScore(score, course, league)
+	return score, nil
+}
+
+// MaxNetScoreFloorUnderPar is the furthest under par a league can set its displayed net floor
+const MaxNetScoreFloorUnderPar = 36
+
+// DisplayNetScore is a round's net score for display: gross less the full playing handicap,
+// raised to the league's floor relative to par when one is set, so low handicaps on a good day
+// don't show a confusing net far under par. The floor is applied only when scores are shown;
+// the stored NetScore stays raw, so rankings never see false ties and a change to the floor
+// applies to every round. It is not the match net score, which takes off only the strokes
+// received in the match; the floor never changes match points or differentials.
+func DisplayNetScore(score models.Score, course models.Course, league models.League) int {
+	net := score.NetScore
+	if league.NetScoreFloorToPar != nil {
+		if floor := course.Par + *league.NetScoreFloorToPar; net < floor {
+			net = floor
+		}
+	}
+	return net
+}
+
+// SetDisplayNetScores fills in DisplayNetScore on scores about to be shown. A score whose course
+// is unknown shows its raw net.
+func SetDisplayNetScores(scores []models.Score, courses map[string]models.Course, league models.League) {
+	for i := range scores {
+		course, ok := courses[scores[i].CourseID]
+		if !ok {
+			scores[i].DisplayNetScore = scores[i].NetScore
+			continue
+		}
+		scores[i].DisplayNetScore = DisplayNetScore(scores[i], course, league)
+	}
+}
+
+// MatchScoreIndex returns the handicap index a player carries into a match: the index on the
+// score they already have for it, so corrections keep the original strokes, or otherwise their
+// effective season index
+func MatchScoreIndex(playerID string, existing map[string]models.Score, seasonPlayers map[string]models.SeasonPlayer) float64 {
+	if score, ok := existing[playerID]; ok {
+		return score.HandicapIndex
+	}
+	if sp, ok := seasonPlayers[playerID]; ok {
+		return EffectiveHandicapIndex(sp)
+	}
+	return 0
+}
+
+// SelfReport is the outcome of a player reporting their own score for a match
+type SelfReport struct {
+	Score     models.Score `json:"score"`
+	Match     models.Match `json:"match"`
+	Completed bool         `json:"completed"` // Both players have reported and points were awarded
+}
+
+// SelfReportScore builds the score a player reports for their own match and, when the opponent
+// has already reported, scores the match. existing holds the match's saved scores keyed by
+// player ID; a player correcting their score keeps its ID, which is otherwise left for the
+// caller to assign. The match day supplies the playing conditions adjustment and the match
+// play basis; unestablished says whether the reporting player has an established handicap.
+func SelfReportScore(season models.Season, league models.League, match models.Match, course models.Course, seasonPlayers map[string]models.SeasonPlayer, existing map[string]models.Score, playerID string, card ScoreCard, matchDay models.MatchDay, unestablished bool) (SelfReport, error) {
+	if playerID != match.PlayerAID && playerID != match.PlayerBID {
+		return SelfReport{}, ErrNotMatchParticipant
+	}
+
+	holeScores := card.HoleScores
+	if card.PlayerAbsent {
+		holeScores = nil
+	}
+	if err := ValidateCourseHoles(course, holeScores); err != nil {
+		return SelfReport{}, err
+	}
+
+	indexA := MatchScoreIndex(match.PlayerAID, existing, seasonPlayers)
+	indexB := MatchScoreIndex(match.PlayerBID, existing, seasonPlayers)
+	handicapA, handicapB := MatchHandicaps(match, course, league, matchDay.MatchPlayBasis, indexA, indexB)
+	if match.StrokesOverridden {
+		ApplyStrokeOverride(match, len(course.HolePars), match.StrokeOverride, &handicapA, &handicapB)
+	}
+
+	handicap, playerName := handicapA, match.PlayerAName
+	if playerID == match.PlayerBID {
+		handicap, playerName = handicapB, match.PlayerBName
+	}
+	if playerName == "" {
+		playerName = seasonPlayers[playerID].PlayerName
+	}
+	handicap.Unestablished = unestablished
+
+	score, err := BuildMatchScore(match, course, league, playerID, card, handicap)
+	if err != nil {
+		return SelfReport{}, err
+	}
+	score.ID = existing[playerID].ID
+	score.PlayerName = playerName
+	score.LeagueID = match.LeagueID
+	if !score.PlayerAbsent && UsesHandicaps(league) {
+		score.HandicapDifferential = CalculateLeagueDifferential(score, course, matchDay.PCC, StandardSlope(league))
+		FlagHighRound(league, &score)
+	}
+
+	scores := make(map[string]models.Score, 2)
+	for id, s := range existing {
+		scores[id] = s
+	}
+	scores[playerID] = score
+
+	completed, ok, err := CompleteMatchWithRules(season, LeagueMatchPointRules(league), match, scores, handicapA.Strokes, handicapB.Strokes)
+	if err != nil {
+		return SelfReport{}, err
+	}
+	return SelfReport{Score: score, Match: completed, Completed: ok}, nil
+}
+
+// OfficialRound reports whether a round counts as official: makeup and substitute rounds don't.
+// Leagues counting only official rounds leave the others out of handicaps.
+func OfficialRound(makeup, substitute bool) *bool {
+	official := !makeup && !substitute
+	return &official
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"golf-league-manager/internal/models"
@@ -161,7 +163,7 @@ func TestMatchPointsWithScoreUpdates(t *testing.T) {
 	strokesA := make([]int, 9)
 	strokesB := make([]int, 9)
 
-	initialPointsA, initialPointsB := CalculateMatchPoints(initialScoreA, initialScoreB, strokesA, strokesB)
+	initialPointsA, initialPointsB := CalculateMatchPointsWithRules(initialScoreA, initialScoreB, strokesA, strokesB, DefaultMatchPointRules)
 
 	// Player A should have won significantly
 	if initialPointsA <= initialPointsB {
@@ -174,7 +176,7 @@ func TestMatchPointsWithScoreUpdates(t *testing.T) {
 		HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}, // Total: 36 (same as A)
 	}
 
-	updatedPointsA, updatedPointsB := CalculateMatchPoints(initialScoreA, updatedScoreB, strokesA, strokesB)
+	updatedPointsA, updatedPointsB := CalculateMatchPointsWithRules(initialScoreA, updatedScoreB, strokesA, strokesB, DefaultMatchPointRules)
 
 	// Should now be tied
 	if updatedPointsA != updatedPointsB {
@@ -296,7 +298,7 @@ func TestAbsentPlayerInMatchPoints(t *testing.T) {
 	scorePresent := models.Score{HoleScores: presentScores}
 	scoreAbsent := models.Score{HoleScores: absentScores}
 
-	pointsPresent, pointsAbsent := CalculateMatchPoints(scorePresent, scoreAbsent, strokes["present"], strokes["absent"])
+	pointsPresent, pointsAbsent := CalculateMatchPointsWithRules(scorePresent, scoreAbsent, strokes["present"], strokes["absent"], DefaultMatchPointRules)
 
 	// Present player should have more points (absent player has inflated scores)
 	if pointsPresent <= pointsAbsent {
@@ -309,3 +311,270 @@ func TestAbsentPlayerInMatchPoints(t *testing.T) {
 		t.Errorf("Total points should be 22, got %d", pointsPresent+pointsAbsent)
 	}
 }
+
+func TestGrossOnlyLeague(t *testing.T) {
+	course := absentPreviewCourse()
+	useHandicaps := false
+	league := models.League{UseHandicaps: &useHandicaps}
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+
+	if UsesHandicaps(league) || !UsesHandicaps(models.League{}) {
+		t.Fatal("UseHandicaps=false should be gross-only and unset should use handicaps")
+	}
+	if courseHC, playingHC := CalculateCourseAndPlayingHandicapForLeague(18.0, course, league); courseHC != 0 || playingHC != 0 {
+		t.Errorf("course/playing handicap = %.1f/%d, want 0/0", courseHC, playingHC)
+	}
+
+	// A 20 stroke gap in index would normally give plenty of strokes
+	handicapA, handicapB := MatchHandicaps(match, course, league, MatchPlayNet, 24.0, 4.0)
+	if sumStrokes(handicapA.Strokes) != 0 || sumStrokes(handicapB.Strokes) != 0 {
+		t.Errorf("strokes = %v / %v, want none", handicapA.Strokes, handicapB.Strokes)
+	}
+
+	cardA := ScoreCard{HoleScores: []int{5, 4, 6, 5, 4, 4, 6, 5, 5}}
+	cardB := ScoreCard{HoleScores: []int{4, 3, 5, 4, 5, 3, 5, 4, 4}}
+	scoreA, err := BuildMatchScore(match, course, league, "pA", cardA, handicapA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scoreB, err := BuildMatchScore(match, course, league, "pB", cardB, handicapB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scoreA.HandicapDifferential != 0 || scoreA.HandicapIndex != 0 || scoreA.NetScore != scoreA.GrossScore {
+		t.Errorf("score A = %+v, want no differential, no index and net equal to gross", scoreA)
+	}
+
+	completed, ok, err := CompleteMatchWithRules(models.Season{}, DefaultMatchPointRules, match, map[string]models.Score{"pA": scoreA, "pB": scoreB}, handicapA.Strokes, handicapB.Strokes)
+	if err != nil || !ok {
+		t.Fatalf("ok = %v, err = %v, want a completed match", ok, err)
+	}
+	noStrokes := make([]int, len(course.HolePars))
+	wantA, wantB := CalculateMatchPointsWithRules(models.Score{HoleScores: cardA.HoleScores}, models.Score{HoleScores: cardB.HoleScores}, noStrokes, noStrokes, DefaultMatchPointRules)
+	if completed.PlayerAPoints != wantA || completed.PlayerBPoints != wantB {
+		t.Errorf("points = %d-%d, want %d-%d from the raw scores", completed.PlayerAPoints, completed.PlayerBPoints, wantA, wantB)
+	}
+	if completed.PlayerBPoints <= completed.PlayerAPoints {
+		t.Errorf("points = %d-%d, want the lower gross player B to win", completed.PlayerAPoints, completed.PlayerBPoints)
+	}
+}
+
+func TestMatchPlayBasis(t *testing.T) {
+	course := absentPreviewCourse()
+	league := models.League{}
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+
+	// Player A is the better golfer on the raw card, but gives away 12 strokes playing net
+	cardA := ScoreCard{HoleScores: []int{4, 4, 5, 3, 4, 4, 5, 3, 4}}
+	cardB := ScoreCard{HoleScores: []int{5, 5, 6, 4, 5, 5, 6, 4, 5}}
+
+	points := func(basis string) (int, int, PlayerMatchHandicap) {
+		handicapA, handicapB := MatchHandicaps(match, course, league, basis, 4.0, 16.0)
+		scoreA, err := BuildMatchScore(match, course, league, "pA", cardA, handicapA)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", basis, err)
+		}
+		scoreB, err := BuildMatchScore(match, course, league, "pB", cardB, handicapB)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", basis, err)
+		}
+		pointsA, pointsB := CalculateMatchPointsWithRules(scoreA, scoreB, handicapA.Strokes, handicapB.Strokes, DefaultMatchPointRules)
+		return pointsA, pointsB, handicapB
+	}
+
+	netA, netB, netHandicapB := points(MatchPlayNet)
+	if sumStrokes(netHandicapB.Strokes) == 0 {
+		t.Fatal("net: player B should receive strokes")
+	}
+	if netA >= netB {
+		t.Errorf("net points = %d-%d, want player B to win on strokes", netA, netB)
+	}
+
+	grossA, grossB, grossHandicapB := points(MatchPlayGross)
+	if sumStrokes(grossHandicapB.Strokes) != 0 {
+		t.Errorf("gross: strokes = %v, want none", grossHandicapB.Strokes)
+	}
+	if grossHandicapB.PlayingHandicap != netHandicapB.PlayingHandicap {
+		t.Errorf("gross: playing handicap = %d, want it kept at %d", grossHandicapB.PlayingHandicap, netHandicapB.PlayingHandicap)
+	}
+	if grossA != 22 || grossB != 0 {
+		t.Errorf("gross points = %d-%d, want 22-0 on the raw card", grossA, grossB)
+	}
+
+	if err := ValidateMatchPlayBasis("scramble"); err == nil {
+		t.Error("expected an error for an unknown match play basis")
+	}
+	for _, basis := range []string{"", MatchPlayNet, MatchPlayGross} {
+		if err := ValidateMatchPlayBasis(basis); err != nil {
+			t.Errorf("%q: unexpected error %v", basis, err)
+		}
+	}
+}
+
+func TestDisplayNetScoreFloor(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c1"
+	match := models.Match{ID: "m1", CourseID: "c1", PlayerAID: "pA", PlayerBID: "pB"}
+	// A scratch-ish card two under par for a player getting 8 strokes would net 10 under
+	card := ScoreCard{HoleScores: []int{4, 3, 4, 4, 4, 3, 4, 4, 4}}
+	handicap := PlayerMatchHandicap{HandicapIndex: 8.0, CourseHandicap: 8.0, PlayingHandicap: 8, Strokes: make([]int, 9)}
+
+	unclamped, err := BuildMatchScore(match, course, models.League{}, "pA", card, handicap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unclamped.GrossScore != 34 || unclamped.NetScore != 26 || unclamped.DisplayNetScore != 26 {
+		t.Fatalf("gross/net/display = %d/%d/%d, want 34/26/26 without a floor", unclamped.GrossScore, unclamped.NetScore, unclamped.DisplayNetScore)
+	}
+
+	evenPar := 0
+	league := models.League{NetScoreFloorToPar: &evenPar}
+	clamped, err := BuildMatchScore(match, course, league, "pA", card, handicap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clamped.DisplayNetScore != course.Par {
+		t.Errorf("display net = %d, want it held at par %d", clamped.DisplayNetScore, course.Par)
+	}
+	// Only the display net is floored; the stored net, match net and differential are untouched
+	if clamped.NetScore != 26 || clamped.MatchNetScore != unclamped.MatchNetScore || clamped.HandicapDifferential != unclamped.HandicapDifferential {
+		t.Errorf("net/match net/differential = %d/%d/%.1f, want 26/%d/%.1f", clamped.NetScore, clamped.MatchNetScore, clamped.HandicapDifferential, unclamped.MatchNetScore, unclamped.HandicapDifferential)
+	}
+
+	twoUnder := -2
+	if net := DisplayNetScore(models.Score{NetScore: 26}, course, models.League{NetScoreFloorToPar: &twoUnder}); net != course.Par-2 {
+		t.Errorf("net = %d, want %d with a floor of two under par", net, course.Par-2)
+	}
+	if net := DisplayNetScore(models.Score{NetScore: 39}, course, league); net != 39 {
+		t.Errorf("net = %d, want 39 above the floor", net)
+	}
+
+	// Stored scores pick up the league's current floor when served
+	scores := []models.Score{{CourseID: "c1", NetScore: 26}, {CourseID: "gone", NetScore: 25}}
+	SetDisplayNetScores(scores, map[string]models.Course{"c1": course}, league)
+	if scores[0].DisplayNetScore != course.Par || scores[1].DisplayNetScore != 25 {
+		t.Errorf("display nets = %d/%d, want %d and the raw 25 for an unknown course", scores[0].DisplayNetScore, scores[1].DisplayNetScore, course.Par)
+	}
+}
+
+func TestCheckScoreEntryPlayers(t *testing.T) {
+	matches := map[string]models.Match{
+		"m1": {ID: "m1", PlayerAID: "pA", PlayerBID: "pB"},
+		"m2": {ID: "m2", PlayerAID: "pC", PlayerBID: "pD"},
+	}
+	// pC's score was filed under the wrong match
+	entries := []ScoreEntryRef{
+		{MatchID: "m1", PlayerID: "pA"},
+		{MatchID: "m1", PlayerID: "pC"},
+		{MatchID: "m2", PlayerID: "pD"},
+	}
+
+	if err := CheckScoreEntryPlayers(models.Season{}, matches, entries); err != nil {
+		t.Errorf("lenient: unexpected error %v", err)
+	}
+
+	strict := models.Season{StrictScoreEntry: true}
+	err := CheckScoreEntryPlayers(strict, matches, entries)
+	if !errors.Is(err, ErrNotMatchParticipant) {
+		t.Fatalf("strict: err = %v, want ErrNotMatchParticipant", err)
+	}
+	if !strings.Contains(err.Error(), "pC in match m1") {
+		t.Errorf("strict: err = %q, want it to name pC in m1", err)
+	}
+
+	if err := CheckScoreEntryPlayers(strict, matches, []ScoreEntryRef{{MatchID: "m9", PlayerID: "pA"}}); !errors.Is(err, ErrNotMatchParticipant) {
+		t.Errorf("strict unknown match: err = %v, want ErrNotMatchParticipant", err)
+	}
+	if err := CheckScoreEntryPlayers(strict, matches, entries[:1]); err != nil {
+		t.Errorf("strict clean submission: unexpected error %v", err)
+	}
+}
+
+func TestUnestablishedAdjustmentBlowUpRound(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	league := models.League{UnestablishedAdjustment: true}
+	// A double-digit blow-up on the par 5 and a triple on the par 3
+	card := ScoreCard{HoleScores: []int{5, 6, 12, 5, 4, 3, 6, 4, 5}}
+
+	established, _ := MatchHandicaps(match, course, league, MatchPlayNet, 4.0, 4.0)
+	unestablished := established
+	unestablished.Unestablished = true
+
+	estScore, err := BuildMatchScore(match, course, league, "pA", card, established)
+	if err != nil {
+		t.Fatalf("established: unexpected error: %v", err)
+	}
+	newScore, err := BuildMatchScore(match, course, league, "pA", card, unestablished)
+	if err != nil {
+		t.Fatalf("unestablished: unexpected error: %v", err)
+	}
+
+	// Net double bogey: par + 2 plus the strokes received on the hole
+	wantEst := CalculateAdjustedGrossScoresWithCap(card.HoleScores, course, estScore.CourseHandicap, DefaultMaxStrokesPerHole)
+	// Par + 5: the 12 on the par 5 becomes a 10; the 6 on the par 3 stands
+	wantNew := []int{5, 6, 10, 5, 4, 3, 6, 4, 5}
+	for i := range card.HoleScores {
+		if estScore.HoleAdjustedGrossScores[i] != wantEst[i] {
+			t.Errorf("established hole %d = %d, want net double bogey %d", i+1, estScore.HoleAdjustedGrossScores[i], wantEst[i])
+		}
+		if newScore.HoleAdjustedGrossScores[i] != wantNew[i] {
+			t.Errorf("unestablished hole %d = %d, want %d", i+1, newScore.HoleAdjustedGrossScores[i], wantNew[i])
+		}
+	}
+	if newScore.AdjustedGross != 48 || estScore.AdjustedGross >= newScore.AdjustedGross {
+		t.Errorf("adjusted gross = %d established, %d unestablished; want the par + 5 cap to give 48, above net double bogey",
+			estScore.AdjustedGross, newScore.AdjustedGross)
+	}
+	if newScore.GrossScore != estScore.GrossScore || newScore.HandicapDifferential <= estScore.HandicapDifferential {
+		t.Errorf("differentials = %.1f established, %.1f unestablished; want the looser cap to count more of the round",
+			estScore.HandicapDifferential, newScore.HandicapDifferential)
+	}
+
+	// Leagues without the option use net double bogey for everyone
+	plain, err := BuildMatchScore(match, course, models.League{}, "pA", card, unestablished)
+	if err != nil || plain.AdjustedGross != estScore.AdjustedGross {
+		t.Errorf("without the option: adjusted gross = %d (err %v), want net double bogey %d", plain.AdjustedGross, err, estScore.AdjustedGross)
+	}
+}
+
+func TestIsEstablished(t *testing.T) {
+	league := models.League{}
+	if IsEstablished(league, DefaultProvisionalWeight) {
+		t.Errorf("%d rounds should not be established while the provisional still counts", DefaultProvisionalWeight)
+	}
+	if !IsEstablished(league, DefaultProvisionalWeight+1) {
+		t.Errorf("%d rounds should be established", DefaultProvisionalWeight+1)
+	}
+}
+
+func TestBuildMatchScoreOfficialRounds(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	handicapA, _ := MatchHandicaps(match, course, models.League{}, MatchPlayNet, 12.0, 8.0)
+	holes := []int{5, 4, 6, 5, 4, 4, 6, 5, 5}
+
+	tests := []struct {
+		name string
+		card ScoreCard
+		want bool
+	}{
+		{"regular round", ScoreCard{HoleScores: holes}, true},
+		{"makeup round", ScoreCard{HoleScores: holes, Makeup: true}, false},
+		{"substitute round", ScoreCard{HoleScores: holes, Substitute: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := BuildMatchScore(match, course, models.League{}, "pA", tt.card, handicapA)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if score.Official == nil || *score.Official != tt.want {
+				t.Errorf("Official = %v, want %v", score.Official, tt.want)
+			}
+			if score.Makeup != tt.card.Makeup || score.Substitute != tt.card.Substitute {
+				t.Errorf("makeup/substitute = %v/%v, want %v/%v", score.Makeup, score.Substitute, tt.card.Makeup, tt.card.Substitute)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// High round flagging bounds
+const (
+	// DefaultHighRoundFlagMargin is how far a round's differential must exceed the index the
+	// player carried into it before the round is flagged for review
+	DefaultHighRoundFlagMargin = 15.0
+	// MaxHighRoundFlagMargin is the largest margin a league may set
+	MaxHighRoundFlagMargin = 54.0
+)
+
+// HighRoundFlagMargin returns the league's margin for flagging high rounds, or the default
+func HighRoundFlagMargin(league models.League) float64 {
+	if league.HighRoundFlagMargin > 0 {
+		return league.HighRoundFlagMargin
+	}
+	return DefaultHighRoundFlagMargin
+}
+
+// ValidateHighRoundFlagMargin checks a league's high round margin. Zero uses the default.
+func ValidateHighRoundFlagMargin(margin float64) error {
+	if margin < 0 || margin > MaxHighRoundFlagMargin {
+		return fmt.Errorf("highRoundFlagMargin must be between 0 and %g", MaxHighRoundFlagMargin)
+	}
+	return nil
+}
+
+// FlagHighRound marks a score for review when its differential exceeds the index the player
+// carried into the round by at least the league's margin, which usually means a card was
+// entered wrongly. A score an admin has already reviewed stays cleared, and absent rounds and
+// gross-only leagues, which have no differentials, are never flagged.
+func FlagHighRound(league models.League, score *models.Score) {
+	score.FlaggedForReview = false
+	if score.PlayerAbsent || score.ReviewedAt != nil || !UsesHandicaps(league) {
+		return
+	}
+	score.FlaggedForReview = score.HandicapDifferential-score.HandicapIndex >= HighRoundFlagMargin(league)
+}
+
+// ReviewFlaggedScore clears a score's review flag, letting it count toward handicaps again in
+// leagues that hold flagged scores back
+func ReviewFlaggedScore(score *models.Score, now time.Time) {
+	score.FlaggedForReview = false
+	score.ReviewedAt = &now
+}
+
+// excludeFlaggedScores drops scores still waiting for review
+func excludeFlaggedScores(scores []models.Score) []models.Score {
+	kept := make([]models.Score, 0, len(scores))
+	for _, score := range scores {
+		if !score.FlaggedForReview {
+			kept = append(kept, score)
+		}
+	}
+	return kept
+}
+
+// SeasonFlaggedScores returns the flagged scores that belong to the season's matches, most
+// recent first
+func SeasonFlaggedScores(flagged []models.Score, matches []models.Match) []models.Score {
+	inSeason := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		inSeason[match.ID] = true
+	}
+	scores := make([]models.Score, 0)
+	for _, score := range flagged {
+		if score.FlaggedForReview && inSeason[score.MatchID] {
+			scores = append(scores, score)
+		}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Date.After(scores[j].Date)
+	})
+	return scores
+}
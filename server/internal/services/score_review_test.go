@@ -0,0 +1,108 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestFlagHighRound(t *testing.T) {
+	league := models.League{}
+	reviewedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		score models.Score
+		want  bool
+	}{
+		{"wildly high round", models.Score{HandicapIndex: 8.2, HandicapDifferential: 31.5}, true},
+		{"exactly at margin", models.Score{HandicapIndex: 10, HandicapDifferential: 25}, true},
+		{"ordinary bad day", models.Score{HandicapIndex: 10, HandicapDifferential: 18.4}, false},
+		{"good round", models.Score{HandicapIndex: 10, HandicapDifferential: 4.1}, false},
+		{"absent round", models.Score{HandicapIndex: 2, HandicapDifferential: 40, PlayerAbsent: true}, false},
+		{"already reviewed", models.Score{HandicapIndex: 8.2, HandicapDifferential: 31.5, ReviewedAt: &reviewedAt}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := tt.score
+			FlagHighRound(league, &score)
+			if score.FlaggedForReview != tt.want {
+				t.Errorf("FlaggedForReview = %v, want %v", score.FlaggedForReview, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlagHighRoundLeagueMargin(t *testing.T) {
+	score := models.Score{HandicapIndex: 10, HandicapDifferential: 18.4}
+	FlagHighRound(models.League{HighRoundFlagMargin: 8}, &score)
+	if !score.FlaggedForReview {
+		t.Error("expected round 8.4 over the index to be flagged with a margin of 8")
+	}
+
+	// A re-entered card that is no longer out of line clears the flag
+	score.HandicapDifferential = 12
+	FlagHighRound(models.League{HighRoundFlagMargin: 8}, &score)
+	if score.FlaggedForReview {
+		t.Error("expected corrected round to be unflagged")
+	}
+
+	useHandicaps := false
+	score.HandicapDifferential = 40
+	FlagHighRound(models.League{UseHandicaps: &useHandicaps}, &score)
+	if score.FlaggedForReview {
+		t.Error("gross-only leagues should never flag rounds")
+	}
+}
+
+func TestValidateHighRoundFlagMargin(t *testing.T) {
+	for _, margin := range []float64{0, 10, MaxHighRoundFlagMargin} {
+		if err := ValidateHighRoundFlagMargin(margin); err != nil {
+			t.Errorf("margin %g: unexpected error %v", margin, err)
+		}
+	}
+	for _, margin := range []float64{-1, MaxHighRoundFlagMargin + 1} {
+		if err := ValidateHighRoundFlagMargin(margin); err == nil {
+			t.Errorf("margin %g: expected error", margin)
+		}
+	}
+}
+
+func TestReviewFlaggedScoreCountsAgain(t *testing.T) {
+	scores := []models.Score{
+		{ID: "s1", HandicapIndex: 8.2, HandicapDifferential: 31.5},
+		{ID: "s2", HandicapIndex: 8.2, HandicapDifferential: 9.1},
+	}
+	for i := range scores {
+		FlagHighRound(models.League{}, &scores[i])
+	}
+	if kept := excludeFlaggedScores(scores); len(kept) != 1 || kept[0].ID != "s2" {
+		t.Fatalf("kept = %+v, want only s2", kept)
+	}
+
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	ReviewFlaggedScore(&scores[0], now)
+	FlagHighRound(models.League{}, &scores[0])
+	if scores[0].FlaggedForReview || scores[0].ReviewedAt == nil || !scores[0].ReviewedAt.Equal(now) {
+		t.Errorf("reviewed score = %+v, want cleared and reviewed at %v", scores[0], now)
+	}
+	if kept := excludeFlaggedScores(scores); len(kept) != 2 {
+		t.Errorf("kept %d scores after review, want 2", len(kept))
+	}
+}
+
+func TestSeasonFlaggedScores(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC) }
+	flagged := []models.Score{
+		{ID: "old", MatchID: "m1", Date: day(1), FlaggedForReview: true},
+		{ID: "other-season", MatchID: "m9", Date: day(5), FlaggedForReview: true},
+		{ID: "new", MatchID: "m2", Date: day(8), FlaggedForReview: true},
+	}
+	matches := []models.Match{{ID: "m1"}, {ID: "m2"}}
+
+	got := SeasonFlaggedScores(flagged, matches)
+	if len(got) != 2 || got[0].ID != "new" || got[1].ID != "old" {
+		t.Errorf("got %+v, want new then old", got)
+	}
+}
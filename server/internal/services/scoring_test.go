@@ -191,7 +191,7 @@ func TestMatchScoringWithStrokeDifference(t *testing.T) {
 			scoreA := models.Score{HoleScores: tt.playerAGrossScores}
 			scoreB := models.Score{HoleScores: tt.playerBGrossScores}
 
-			gotA, gotB := CalculateMatchPoints(scoreA, scoreB, strokes["playerA"], strokes["playerB"])
+			gotA, gotB := CalculateMatchPointsWithRules(scoreA, scoreB, strokes["playerA"], strokes["playerB"], DefaultMatchPointRules)
 
 			if gotA != tt.wantPlayerAPoints || gotB != tt.wantPlayerBPoints {
 				t.Errorf("%s: got A=%d, B=%d, want A=%d, B=%d",
@@ -396,7 +396,7 @@ func TestScoringSystemIntegrity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pointsA, pointsB := CalculateMatchPoints(tt.scoreA, tt.scoreB, tt.strokesA, tt.strokesB)
+			pointsA, pointsB := CalculateMatchPointsWithRules(tt.scoreA, tt.scoreB, tt.strokesA, tt.strokesB, DefaultMatchPointRules)
 
 			total := pointsA + pointsB
 			if total != 22 {
@@ -581,3 +581,119 @@ func TestAbsentPlayerScoreFormula(t *testing.T) {
 		}
 	}
 }
+
+// Test the absent penalty across league configurations
+func TestCalculateAbsentPlayerScoresWithPenalty(t *testing.T) {
+	course := models.Course{
+		Par:           36,
+		HolePars:      []int{4, 3, 5, 4, 4, 3, 5, 4, 4},
+		HoleHandicaps: []int{1, 7, 3, 5, 2, 9, 4, 6, 8}, // HC 1..5 at indices 0,4,2,6,3
+	}
+
+	five := 5
+	tests := []struct {
+		name       string
+		league     models.League
+		wantScores []int
+	}{
+		{
+			name:   "default league charges +3",
+			league: models.League{},
+			// 7 + 3 = 10 strokes: 1 per hole + 1 extra on HC 1
+			wantScores: []int{6, 4, 6, 5, 5, 4, 6, 5, 5},
+		},
+		{
+			name:   "league configured for +5",
+			league: models.League{AbsentStrokesOverParPlusHandicap: &five},
+			// 7 + 5 = 12 strokes: 1 per hole + extras on HC 1, 2, 3
+			wantScores: []int{6, 4, 7, 5, 6, 4, 6, 5, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			penalty := AbsentPenaltyStrokes(tt.league)
+			gotScores := CalculateAbsentPlayerScoresWithPenalty(7, penalty, course)
+
+			total := 0
+			for i, s := range gotScores {
+				total += s
+				if s != tt.wantScores[i] {
+					t.Errorf("Hole %d: got %d, want %d", i+1, s, tt.wantScores[i])
+				}
+			}
+			if want := 7 + course.Par + penalty; total != want {
+				t.Errorf("total gross = %d, want %d", total, want)
+			}
+		})
+	}
+}
+
+func TestAbsentMatchPoints(t *testing.T) {
+	present := models.Score{PlayerID: "p1", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+	absent := models.Score{PlayerID: "p2", HoleScores: []int{9, 8, 10, 9, 9, 8, 10, 9, 9}, PlayerAbsent: true}
+
+	t.Run("play inflated", func(t *testing.T) {
+		for _, rule := range []string{"", AbsentMatchRulePlayInflated} {
+			season := models.Season{AbsentMatchRule: rule, AbsentPointsFraction: 0.25}
+			if _, _, ok := absentMatchPoints(season, present, absent, DefaultMatchPointRules.Total(holesPerRound)); ok {
+				t.Errorf("rule %q: expected the scorecards to be played", rule)
+			}
+		}
+	})
+
+	t.Run("fixed fraction", func(t *testing.T) {
+		season := models.Season{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0.25}
+
+		// 22 * 0.25 = 5.5, rounded down for the absent player
+		pointsA, pointsB, ok := absentMatchPoints(season, present, absent, DefaultMatchPointRules.Total(holesPerRound))
+		if !ok || pointsA != 17 || pointsB != 5 {
+			t.Errorf("present A: got %d-%d (ok=%v), want 17-5", pointsA, pointsB, ok)
+		}
+		pointsA, pointsB, ok = absentMatchPoints(season, absent, present, DefaultMatchPointRules.Total(holesPerRound))
+		if !ok || pointsA != 5 || pointsB != 17 {
+			t.Errorf("absent A: got %d-%d (ok=%v), want 5-17", pointsA, pointsB, ok)
+		}
+
+		// The inflated scorecard would have earned the absent player nothing, but the fraction still applies
+		strokes := make([]int, 9)
+		if a, b := CalculateMatchPointsWithRules(present, absent, strokes, strokes, DefaultMatchPointRules); a != 22 || b != 0 {
+			t.Fatalf("played scorecards = %d-%d, want 22-0", a, b)
+		}
+	})
+
+	t.Run("fixed fraction needs exactly one absent player", func(t *testing.T) {
+		season := models.Season{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0.25}
+		if _, _, ok := absentMatchPoints(season, present, present, DefaultMatchPointRules.Total(holesPerRound)); ok {
+			t.Error("no absent player: expected the scorecards to be played")
+		}
+		if _, _, ok := absentMatchPoints(season, absent, absent, DefaultMatchPointRules.Total(holesPerRound)); ok {
+			t.Error("both absent: expected the scorecards to be played")
+		}
+	})
+}
+
+func TestValidateAbsentMatchRule(t *testing.T) {
+	valid := []models.Season{
+		{},
+		{AbsentMatchRule: AbsentMatchRulePlayInflated},
+		{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0},
+		{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 0.25},
+	}
+	for _, season := range valid {
+		if err := ValidateAbsentMatchRule(season); err != nil {
+			t.Errorf("%+v: unexpected error %v", season, err)
+		}
+	}
+
+	invalid := []models.Season{
+		{AbsentMatchRule: "forfeit"},
+		{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: 1.5},
+		{AbsentMatchRule: AbsentMatchRuleFixedFraction, AbsentPointsFraction: -0.1},
+	}
+	for _, season := range invalid {
+		if err := ValidateAbsentMatchRule(season); err == nil {
+			t.Errorf("%+v: expected an error", season)
+		}
+	}
+}
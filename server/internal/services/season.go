@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// ValidateSeasonBatch checks a set of seasons to be created together against each other and
+// against the league's existing seasons. Every season needs a name and an end date after its
+// start date, date ranges must not overlap (a season may start the instant another ends),
+// and at most one season may be active: a batch can't mark one active while the league
+// already has an active season.
+func ValidateSeasonBatch(existing []models.Season, batch []models.Season) error {
+	if len(batch) == 0 {
+		return fmt.Errorf("at least one season is required")
+	}
+
+	activeCount := 0
+	for i, season := range batch {
+		if season.Name == "" {
+			return fmt.Errorf("season %d: name is required", i+1)
+		}
+		if !season.EndDate.After(season.StartDate) {
+			return fmt.Errorf("season %q: end date must be after start date", season.Name)
+		}
+		if season.Active {
+			activeCount++
+		}
+	}
+	if activeCount > 1 {
+		return fmt.Errorf("only one season can be marked active, got %d", activeCount)
+	}
+	if activeCount == 1 {
+		for _, season := range existing {
+			if season.Active {
+				return fmt.Errorf("season %q is already active; deactivate it before adding another active season", season.Name)
+			}
+		}
+	}
+
+	all := make([]models.Season, 0, len(existing)+len(batch))
+	all = append(all, existing...)
+	all = append(all, batch...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].StartDate.Before(all[j].StartDate)
+	})
+
+	for i := 1; i < len(all); i++ {
+		prev, curr := all[i-1], all[i]
+		if curr.StartDate.Before(prev.EndDate) {
+			return fmt.Errorf("season %q overlaps with season %q", curr.Name, prev.Name)
+		}
+	}
+
+	return nil
+}
+
+// SeasonPlayerLess orders season players alphabetically by name (case-insensitive), then by
+// enrollment time and finally by player ID so the roster order is fully deterministic
+func SeasonPlayerLess(a, b models.SeasonPlayer) bool {
+	nameA, nameB := strings.ToLower(a.PlayerName), strings.ToLower(b.PlayerName)
+	if nameA != nameB {
+		return nameA < nameB
+	}
+	if !a.AddedAt.Equal(b.AddedAt) {
+		return a.AddedAt.Before(b.AddedAt)
+	}
+	return a.PlayerID < b.PlayerID
+}
+
+// DefaultSeason returns the active season created with a new league when its admin asks for
+// one, so scores can be entered straight away. It spans the calendar year of now, from January 1
+// to December 31, and is named after the year. The caller fills in the ID.
+func DefaultSeason(leagueID string, now time.Time) models.Season {
+	year := now.Year()
+	return models.Season{
+		LeagueID:  leagueID,
+		Name:      fmt.Sprintf("%d Season", year),
+		StartDate: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Active:    true,
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// SeasonProgress summarizes how far through its schedule a season is, for a league homepage
+type SeasonProgress struct {
+	SeasonID           string          `json:"seasonId"`
+	TotalMatchDays     int             `json:"totalMatchDays"`
+	CompletedMatchDays int             `json:"completedMatchDays"` // Completed or locked
+	RemainingMatchDays int             `json:"remainingMatchDays"`
+	NextMatchDate      *time.Time      `json:"nextMatchDate"` // Earliest match day still scheduled; nil when none are left
+	Leader             *StandingsEntry `json:"leader"`        // Top of the standings; nil until someone has points
+	LeaderTied         bool            `json:"leaderTied"`    // Another player shares the lead
+	RoundsPosted       int             `json:"roundsPosted"`  // Non-absent scores entered so far
+}
+
+// BuildSeasonProgress assembles a season's progress from its match days, its ranked standings
+// and the scores entered on its match days
+func BuildSeasonProgress(seasonID string, matchDays []models.MatchDay, standings []StandingsEntry, scores []models.Score) SeasonProgress {
+	progress := SeasonProgress{SeasonID: seasonID, TotalMatchDays: len(matchDays)}
+
+	for _, md := range matchDays {
+		if md.Status == "completed" || md.Status == "locked" {
+			progress.CompletedMatchDays++
+			continue
+		}
+		if progress.NextMatchDate == nil || md.Date.Before(*progress.NextMatchDate) {
+			date := md.Date
+			progress.NextMatchDate = &date
+		}
+	}
+	progress.RemainingMatchDays = progress.TotalMatchDays - progress.CompletedMatchDays
+
+	if len(standings) > 0 && standings[0].TotalPoints > 0 {
+		leader := standings[0]
+		progress.Leader = &leader
+		progress.LeaderTied = len(standings) > 1 && standings[1].Rank == leader.Rank
+	}
+
+	for _, score := range scores {
+		if !score.PlayerAbsent {
+			progress.RoundsPosted++
+		}
+	}
+	return progress
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestBuildSeasonProgressMidSeason(t *testing.T) {
+	week := func(n int) time.Time { return time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*(n-1)) }
+	matchDays := []models.MatchDay{
+		{ID: "md5", Date: week(5), Status: "scheduled"},
+		{ID: "md1", Date: week(1), Status: "locked"},
+		{ID: "md4", Date: week(4), Status: "scheduled"},
+		{ID: "md2", Date: week(2), Status: "locked"},
+		{ID: "md3", Date: week(3), Status: "completed"},
+	}
+
+	roster := []StandingsEntry{{PlayerID: "p1", PlayerName: "Alice"}, {PlayerID: "p2", PlayerName: "Bob"}, {PlayerID: "p3", PlayerName: "Cara"}}
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 15, PlayerBPoints: 7},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p1", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11},
+		{ID: "m3", PlayerAID: "p2", PlayerBID: "p3", Status: "completed", PlayerAPoints: 12, PlayerBPoints: 10},
+	}
+	standings := ComputeStandings(roster, matches)
+
+	scores := []models.Score{
+		{ID: "s1", PlayerID: "p1"}, {ID: "s2", PlayerID: "p2"},
+		{ID: "s3", PlayerID: "p3"}, {ID: "s4", PlayerID: "p1"},
+		{ID: "s5", PlayerID: "p2"}, {ID: "s6", PlayerID: "p3", PlayerAbsent: true},
+	}
+
+	progress := BuildSeasonProgress("s1", matchDays, standings, scores)
+	if progress.TotalMatchDays != 5 || progress.CompletedMatchDays != 3 || progress.RemainingMatchDays != 2 {
+		t.Errorf("match days = %d total, %d completed, %d remaining, want 5, 3, 2",
+			progress.TotalMatchDays, progress.CompletedMatchDays, progress.RemainingMatchDays)
+	}
+	if progress.NextMatchDate == nil || !progress.NextMatchDate.Equal(week(4)) {
+		t.Errorf("next match date = %v, want %v", progress.NextMatchDate, week(4))
+	}
+	if progress.Leader == nil || progress.Leader.PlayerID != "p1" || progress.Leader.TotalPoints != 26 || progress.LeaderTied {
+		t.Errorf("leader = %+v, tied = %v, want p1 alone on 26 points", progress.Leader, progress.LeaderTied)
+	}
+	if progress.RoundsPosted != 5 {
+		t.Errorf("rounds posted = %d, want 5", progress.RoundsPosted)
+	}
+}
+
+func TestBuildSeasonProgressBeforeAndAfter(t *testing.T) {
+	roster := []StandingsEntry{{PlayerID: "p1", PlayerName: "Alice"}, {PlayerID: "p2", PlayerName: "Bob"}}
+	opening := time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	// Nobody has points before the first week, so there is no leader yet
+	progress := BuildSeasonProgress("s1", []models.MatchDay{{ID: "md1", Date: opening, Status: "scheduled"}}, ComputeStandings(roster, nil), nil)
+	if progress.Leader != nil || progress.CompletedMatchDays != 0 || progress.NextMatchDate == nil {
+		t.Errorf("before the season: %+v, want no leader and the opening date next", progress)
+	}
+
+	// A finished season with a shared lead has no next date
+	tied := []models.Match{{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11}}
+	progress = BuildSeasonProgress("s1", []models.MatchDay{{ID: "md1", Date: opening, Status: "locked"}}, ComputeStandings(roster, tied), nil)
+	if progress.NextMatchDate != nil || progress.RemainingMatchDays != 0 || progress.Leader == nil || !progress.LeaderTied {
+		t.Errorf("after the season: %+v, want no next date and a tied leader", progress)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// ErrResetNotConfirmed means a season reset was requested without confirming the season's ID
+var ErrResetNotConfirmed = errors.New("season reset not confirmed: confirm must be the season ID")
+
+// SeasonResetStore reads a season's schedule and results and clears them
+type SeasonResetStore interface {
+	GetSeasonMatches(ctx context.Context, seasonID string) ([]models.Match, error)
+	ListMatchDaysBySeason(ctx context.Context, seasonID string) ([]models.MatchDay, error)
+	ListSeasonPlayers(ctx context.Context, seasonID string) ([]models.SeasonPlayer, error)
+	GetMatchScores(ctx context.Context, matchID string) ([]models.Score, error)
+	DeleteScore(ctx context.Context, scoreID string) error
+	DeleteSeasonAchievements(ctx context.Context, seasonID string) (int, error)
+	DeleteSeasonFoursomesMatches(ctx context.Context, seasonID string) (int, error)
+	UpdateMatch(ctx context.Context, match models.Match) error
+	UpdateMatchDay(ctx context.Context, matchDay models.MatchDay) error
+	UpdateSeasonPlayer(ctx context.Context, seasonPlayer models.SeasonPlayer) error
+}
+
+// SeasonResetResult counts what a season reset cleared
+type SeasonResetResult struct {
+	ScoresDeleted       int `json:"scoresDeleted"`
+	AchievementsDeleted int `json:"achievementsDeleted"`
+	FoursomesDeleted    int `json:"foursomesDeleted"`
+	MatchesReset        int `json:"matchesReset"`
+	MatchDaysReset      int `json:"matchDaysReset"`
+	PlayersReset        int `json:"playersReset"`
+}
+
+// ResetSeason wipes a season's results while keeping its schedule and roster: every score,
+// achievement and foursomes match is deleted, matches go back to scheduled with no points, absences, disputes,
+// confirmations or agreed strokes, match days go back to scheduled with no playing conditions
+// adjustment, and each season player's index goes back to their provisional handicap. confirm
+// must equal the season's ID. Archived seasons can't be reset. A reset that fails part way can
+// simply be run again.
+func ResetSeason(ctx context.Context, store SeasonResetStore, season models.Season, confirm string) (SeasonResetResult, error) {
+	var result SeasonResetResult
+	if confirm != season.ID {
+		return result, ErrResetNotConfirmed
+	}
+	if err := CheckSeasonWritable(season); err != nil {
+		return result, err
+	}
+
+	matches, err := store.GetSeasonMatches(ctx, season.ID)
+	if err != nil {
+		return result, fmt.Errorf("failed to get season matches: %w", err)
+	}
+	for _, match := range matches {
+		scores, err := store.GetMatchScores(ctx, match.ID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get scores for match %s: %w", match.ID, err)
+		}
+		for _, score := range scores {
+			if err := store.DeleteScore(ctx, score.ID); err != nil {
+				return result, fmt.Errorf("failed to delete score %s: %w", score.ID, err)
+			}
+			result.ScoresDeleted++
+		}
+
+		match.Status = "scheduled"
+		match.PlayerAPoints = 0
+		match.PlayerBPoints = 0
+		match.PlayerAAbsent = false
+		match.PlayerBAbsent = false
+		match.Disputed = false
+		match.PlayerAConfirmed = false
+		match.PlayerBConfirmed = false
+		match.StrokesOverridden = false
+		match.StrokeOverride = nil
+		if err := store.UpdateMatch(ctx, match); err != nil {
+			return result, fmt.Errorf("failed to reset match %s: %w", match.ID, err)
+		}
+		result.MatchesReset++
+	}
+
+	// Achievements were detected on the deleted scores
+	deleted, err := store.DeleteSeasonAchievements(ctx, season.ID)
+	result.AchievementsDeleted = deleted
+	if err != nil {
+		return result, fmt.Errorf("failed to delete season achievements: %w", err)
+	}
+
+	// Foursomes matches are stored whole with their team scores and points
+	deleted, err = store.DeleteSeasonFoursomesMatches(ctx, season.ID)
+	result.FoursomesDeleted = deleted
+	if err != nil {
+		return result, fmt.Errorf("failed to delete season foursomes matches: %w", err)
+	}
+
+	matchDays, err := store.ListMatchDaysBySeason(ctx, season.ID)
+	if err != nil {
+		return result, fmt.Errorf("failed to list match days: %w", err)
+	}
+	for _, matchDay := range matchDays {
+		matchDay.Status = "scheduled"
+		matchDay.PCC = 0
+		if err := store.UpdateMatchDay(ctx, matchDay); err != nil {
+			return result, fmt.Errorf("failed to reset match day %s: %w", matchDay.ID, err)
+		}
+		result.MatchDaysReset++
+	}
+
+	seasonPlayers, err := store.ListSeasonPlayers(ctx, season.ID)
+	if err != nil {
+		return result, fmt.Errorf("failed to get season players: %w", err)
+	}
+	for _, sp := range seasonPlayers {
+		sp.CurrentHandicapIndex = sp.ProvisionalHandicap
+		sp.IndexHistory = nil
+		if err := store.UpdateSeasonPlayer(ctx, sp); err != nil {
+			return result, fmt.Errorf("failed to reset season player %s: %w", sp.PlayerID, err)
+		}
+		result.PlayersReset++
+	}
+
+	return result, nil
+}
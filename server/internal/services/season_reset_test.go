@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// fakeSeasonResetStore keeps one season's records in memory
+type fakeSeasonResetStore struct {
+	matches       map[string]models.Match
+	matchDays     map[string]models.MatchDay
+	seasonPlayers map[string]models.SeasonPlayer
+	scores        map[string]models.Score
+	achievements  map[string]models.Achievement
+	foursomes     map[string]models.FoursomesMatch
+}
+
+func (f *fakeSeasonResetStore) GetSeasonMatches(ctx context.Context, seasonID string) ([]models.Match, error) {
+	var matches []models.Match
+	for _, m := range f.matches {
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+func (f *fakeSeasonResetStore) ListMatchDaysBySeason(ctx context.Context, seasonID string) ([]models.MatchDay, error) {
+	var matchDays []models.MatchDay
+	for _, md := range f.matchDays {
+		matchDays = append(matchDays, md)
+	}
+	return matchDays, nil
+}
+
+func (f *fakeSeasonResetStore) ListSeasonPlayers(ctx context.Context, seasonID string) ([]models.SeasonPlayer, error) {
+	var seasonPlayers []models.SeasonPlayer
+	for _, sp := range f.seasonPlayers {
+		seasonPlayers = append(seasonPlayers, sp)
+	}
+	return seasonPlayers, nil
+}
+
+func (f *fakeSeasonResetStore) GetMatchScores(ctx context.Context, matchID string) ([]models.Score, error) {
+	var scores []models.Score
+	for _, s := range f.scores {
+		if s.MatchID == matchID {
+			scores = append(scores, s)
+		}
+	}
+	return scores, nil
+}
+
+func (f *fakeSeasonResetStore) DeleteScore(ctx context.Context, scoreID string) error {
+	delete(f.scores, scoreID)
+	return nil
+}
+
+func (f *fakeSeasonResetStore) DeleteSeasonAchievements(ctx context.Context, seasonID string) (int, error) {
+	deleted := 0
+	for id, a := range f.achievements {
+		if a.SeasonID == seasonID {
+			delete(f.achievements, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (f *fakeSeasonResetStore) DeleteSeasonFoursomesMatches(ctx context.Context, seasonID string) (int, error) {
+	deleted := 0
+	for id, m := range f.foursomes {
+		if m.SeasonID == seasonID {
+			delete(f.foursomes, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (f *fakeSeasonResetStore) UpdateMatch(ctx context.Context, match models.Match) error {
+	f.matches[match.ID] = match
+	return nil
+}
+
+func (f *fakeSeasonResetStore) UpdateMatchDay(ctx context.Context, matchDay models.MatchDay) error {
+	f.matchDays[matchDay.ID] = matchDay
+	return nil
+}
+
+func (f *fakeSeasonResetStore) UpdateSeasonPlayer(ctx context.Context, seasonPlayer models.SeasonPlayer) error {
+	f.seasonPlayers[seasonPlayer.ID] = seasonPlayer
+	return nil
+}
+
+func TestResetSeasonKeepsSchedule(t *testing.T) {
+	week1 := time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC)
+	store := &fakeSeasonResetStore{
+		matches: map[string]models.Match{
+			"m1": {ID: "m1", MatchDayID: "md1", PlayerAID: "p1", PlayerBID: "p2", CourseID: "c1", MatchDate: week1, Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8, PlayerBAbsent: true,
+				PlayerAConfirmed: true, PlayerBConfirmed: true, StrokesOverridden: true, StrokeOverride: map[string][]int{"p1": {1, 0, 1, 0, 0, 0, 1, 0, 0}}},
+			"m2": {ID: "m2", MatchDayID: "md2", PlayerAID: "p2", PlayerBID: "p1", CourseID: "c1", MatchDate: week1.AddDate(0, 0, 7), Status: "scheduled"},
+		},
+		matchDays: map[string]models.MatchDay{
+			"md1": {ID: "md1", Date: week1, CourseID: "c1", Status: "locked", PCC: 1, Conditions: "windy"},
+			"md2": {ID: "md2", Date: week1.AddDate(0, 0, 7), CourseID: "c1", Status: "scheduled"},
+		},
+		seasonPlayers: map[string]models.SeasonPlayer{
+			"sp1": {ID: "sp1", PlayerID: "p1", ProvisionalHandicap: 12.0, CurrentHandicapIndex: 9.4, IndexHistory: []models.IndexPoint{{HandicapIndex: 9.4}}},
+			"sp2": {ID: "sp2", PlayerID: "p2", ProvisionalHandicap: 20.0, CurrentHandicapIndex: 21.3},
+		},
+		scores: map[string]models.Score{
+			"s1": {ID: "s1", MatchID: "m1", PlayerID: "p1"},
+			"s2": {ID: "s2", MatchID: "m1", PlayerID: "p2"},
+		},
+		achievements: map[string]models.Achievement{
+			"a1": {ID: "a1", SeasonID: "s1", ScoreID: "s1", PlayerID: "p1", Type: "eagle"},
+			"a2": {ID: "a2", SeasonID: "other", PlayerID: "p1", Type: "hole-in-one"},
+		},
+		foursomes: map[string]models.FoursomesMatch{
+			"f1": {ID: "f1", SeasonID: "s1", TeamAPoints: 14, TeamBPoints: 8},
+			"f2": {ID: "f2", SeasonID: "other", TeamAPoints: 11, TeamBPoints: 11},
+		},
+	}
+	season := models.Season{ID: "s1"}
+
+	if _, err := ResetSeason(context.Background(), store, season, "wrong"); !errors.Is(err, ErrResetNotConfirmed) {
+		t.Fatalf("unconfirmed reset: err = %v, want ErrResetNotConfirmed", err)
+	}
+	if len(store.scores) != 2 {
+		t.Fatal("an unconfirmed reset deleted scores")
+	}
+
+	result, err := ResetSeason(context.Background(), store, season, "s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != (SeasonResetResult{ScoresDeleted: 2, AchievementsDeleted: 1, FoursomesDeleted: 1, MatchesReset: 2, MatchDaysReset: 2, PlayersReset: 2}) {
+		t.Errorf("result = %+v, want 2 of everything, 1 achievement and 1 foursomes match", result)
+	}
+
+	// Results are cleared
+	if len(store.scores) != 0 {
+		t.Errorf("%d scores left, want none", len(store.scores))
+	}
+	if _, ok := store.achievements["a1"]; ok {
+		t.Error("the season's achievement was not deleted")
+	}
+	if _, ok := store.achievements["a2"]; !ok {
+		t.Error("another season's achievement was deleted")
+	}
+	if _, ok := store.foursomes["f1"]; ok {
+		t.Error("the season's foursomes match was not deleted")
+	}
+	if _, ok := store.foursomes["f2"]; !ok {
+		t.Error("another season's foursomes match was deleted")
+	}
+	m1 := store.matches["m1"]
+	if m1.Status != "scheduled" || m1.PlayerAPoints != 0 || m1.PlayerBPoints != 0 || m1.PlayerBAbsent {
+		t.Errorf("m1 = %+v, want a scheduled match with no result", m1)
+	}
+	if m1.PlayerAConfirmed || m1.PlayerBConfirmed || m1.StrokesOverridden || m1.StrokeOverride != nil {
+		t.Errorf("m1 = %+v, want confirmations and agreed strokes cleared", m1)
+	}
+	if md1 := store.matchDays["md1"]; md1.Status != "scheduled" || md1.PCC != 0 {
+		t.Errorf("md1 = %+v, want a scheduled day with no PCC", md1)
+	}
+	if sp1 := store.seasonPlayers["sp1"]; sp1.CurrentHandicapIndex != 12.0 || len(sp1.IndexHistory) != 0 {
+		t.Errorf("sp1 = %+v, want index back at the 12.0 provisional with no history", sp1)
+	}
+	if sp2 := store.seasonPlayers["sp2"]; sp2.CurrentHandicapIndex != 20.0 {
+		t.Errorf("sp2 index = %.1f, want the 20.0 provisional", sp2.CurrentHandicapIndex)
+	}
+
+	// The schedule survives
+	if len(store.matches) != 2 || len(store.matchDays) != 2 || len(store.seasonPlayers) != 2 {
+		t.Fatalf("schedule changed: %d matches, %d match days, %d players", len(store.matches), len(store.matchDays), len(store.seasonPlayers))
+	}
+	if m1.PlayerAID != "p1" || m1.PlayerBID != "p2" || m1.MatchDayID != "md1" || !m1.MatchDate.Equal(week1) || m1.CourseID != "c1" {
+		t.Errorf("m1 = %+v, want the original pairing, day and course", m1)
+	}
+	if md1 := store.matchDays["md1"]; !md1.Date.Equal(week1) || md1.CourseID != "c1" || md1.Conditions != "windy" {
+		t.Errorf("md1 = %+v, want its date, course and conditions kept", md1)
+	}
+}
+
+func TestResetSeasonArchived(t *testing.T) {
+	store := &fakeSeasonResetStore{}
+	season := models.Season{ID: "s1", Archived: true}
+	if _, err := ResetSeason(context.Background(), store, season, "s1"); !errors.Is(err, ErrSeasonArchived) {
+		t.Errorf("err = %v, want ErrSeasonArchived", err)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"sort"
 	"testing"
 	"time"
 
@@ -322,3 +323,141 @@ func TestPlayerInMultipleLeagues(t *testing.T) {
 
 	t.Log("Player successfully verified in multiple leagues with independent handicap tracking")
 }
+
+func TestValidateSeasonBatch(t *testing.T) {
+	date := func(month, day int) time.Time {
+		return time.Date(2025, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+
+	spring := models.Season{Name: "Spring 2025", StartDate: date(3, 1), EndDate: date(6, 1), Active: true}
+	summer := models.Season{Name: "Summer 2025", StartDate: date(6, 1), EndDate: date(9, 1)}
+	fall := models.Season{Name: "Fall 2025", StartDate: date(9, 1), EndDate: date(11, 15)}
+
+	tests := []struct {
+		name     string
+		existing []models.Season
+		batch    []models.Season
+		wantErr  bool
+	}{
+		{
+			name:  "three consecutive seasons",
+			batch: []models.Season{spring, summer, fall},
+		},
+		{
+			name:  "seasons supplied out of order",
+			batch: []models.Season{fall, spring, summer},
+		},
+		{
+			name:    "overlapping ranges in batch",
+			batch:   []models.Season{spring, {Name: "Early Summer", StartDate: date(5, 15), EndDate: date(8, 1)}},
+			wantErr: true,
+		},
+		{
+			name:     "overlaps an existing season",
+			existing: []models.Season{{Name: "Winter 2025", StartDate: date(1, 1), EndDate: date(3, 15)}},
+			batch:    []models.Season{spring},
+			wantErr:  true,
+		},
+		{
+			name:     "follows an existing season",
+			existing: []models.Season{{Name: "Winter 2025", StartDate: date(1, 1), EndDate: date(3, 1)}},
+			batch:    []models.Season{spring, summer},
+		},
+		{
+			name:     "active season while one is already active",
+			existing: []models.Season{{Name: "Winter 2025", StartDate: date(1, 1), EndDate: date(3, 1), Active: true}},
+			batch:    []models.Season{spring, summer},
+			wantErr:  true,
+		},
+		{
+			name:     "inactive seasons while one is already active",
+			existing: []models.Season{{Name: "Winter 2025", StartDate: date(1, 1), EndDate: date(3, 1), Active: true}},
+			batch:    []models.Season{summer, fall},
+		},
+		{
+			name: "more than one active season",
+			batch: []models.Season{spring, {
+				Name: "Summer 2025", StartDate: date(6, 1), EndDate: date(9, 1), Active: true,
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			batch:   []models.Season{{Name: "Backwards", StartDate: date(6, 1), EndDate: date(3, 1)}},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			batch:   []models.Season{{StartDate: date(3, 1), EndDate: date(6, 1)}},
+			wantErr: true,
+		},
+		{
+			name:    "empty batch",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSeasonBatch(tt.existing, tt.batch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSeasonBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeasonPlayerLess(t *testing.T) {
+	added := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	roster := []models.SeasonPlayer{
+		{PlayerID: "p4", PlayerName: "dana", AddedAt: added},
+		{PlayerID: "p2", PlayerName: "Bob", AddedAt: added.Add(time.Hour)},
+		{PlayerID: "p3", PlayerName: "Bob", AddedAt: added},
+		{PlayerID: "p1", PlayerName: "alice", AddedAt: added.Add(2 * time.Hour)},
+		{PlayerID: "p6", PlayerName: "Carl", AddedAt: added},
+		{PlayerID: "p5", PlayerName: "Carl", AddedAt: added},
+	}
+	want := []string{"p1", "p3", "p2", "p5", "p6", "p4"}
+
+	// Every starting order must produce the same roster
+	for shift := 0; shift < len(roster); shift++ {
+		shuffled := append(append([]models.SeasonPlayer{}, roster[shift:]...), roster[:shift]...)
+		sort.Slice(shuffled, func(i, j int) bool {
+			return SeasonPlayerLess(shuffled[i], shuffled[j])
+		})
+
+		for i, id := range want {
+			if shuffled[i].PlayerID != id {
+				t.Errorf("shift %d, position %d: got %s, want %s", shift, i, shuffled[i].PlayerID, id)
+			}
+		}
+	}
+}
+
+func TestDefaultSeason(t *testing.T) {
+	now := time.Date(2026, 3, 14, 18, 30, 0, 0, time.UTC)
+	season := DefaultSeason("league-1", now)
+
+	if season.LeagueID != "league-1" {
+		t.Errorf("LeagueID = %q, want league-1", season.LeagueID)
+	}
+	if !season.Active {
+		t.Error("default season should be active")
+	}
+	if season.Name != "2026 Season" {
+		t.Errorf("Name = %q, want 2026 Season", season.Name)
+	}
+	wantStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	if !season.StartDate.Equal(wantStart) || !season.EndDate.Equal(wantEnd) {
+		t.Errorf("season spans %v to %v, want %v to %v", season.StartDate, season.EndDate, wantStart, wantEnd)
+	}
+	if season.StartDate.After(now) || season.EndDate.Before(now) {
+		t.Errorf("season %v to %v does not cover %v", season.StartDate, season.EndDate, now)
+	}
+	// A fresh league's only season passes the batch checks on its own
+	if err := ValidateSeasonBatch(nil, []models.Season{season}); err != nil {
+		t.Errorf("default season fails validation: %v", err)
+	}
+}
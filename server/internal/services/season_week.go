@@ -0,0 +1,41 @@
+package services
+
+import (
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// SeasonWeekNumbers numbers match days by week of their season, keyed by match day ID: a
+// season's earliest match day is week 1, the next week 2 and so on. Each season is numbered
+// separately.
+func SeasonWeekNumbers(matchDays []models.MatchDay) map[string]int {
+	sorted := make([]models.MatchDay, len(matchDays))
+	copy(sorted, matchDays)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	weeks := make(map[string]int, len(sorted))
+	bySeason := make(map[string]int)
+	for _, md := range sorted {
+		bySeason[md.SeasonID]++
+		weeks[md.ID] = bySeason[md.SeasonID]
+	}
+	return weeks
+}
+
+// ScoresForWeek keeps the scores played in the given week of their season, most recent first.
+// Scores saved before week numbers were recorded have none and never match.
+func ScoresForWeek(scores []models.Score, week int) []models.Score {
+	filtered := make([]models.Score, 0)
+	for _, score := range scores {
+		if score.WeekNumber == week {
+			filtered = append(filtered, score)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Date.After(filtered[j].Date)
+	})
+	return filtered
+}
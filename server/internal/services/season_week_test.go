@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestSeasonWeekNumbers(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 0, 0, 0, 0, time.UTC) }
+	matchDays := []models.MatchDay{
+		{ID: "md3", SeasonID: "s1", Date: day(15)},
+		{ID: "md1", SeasonID: "s1", Date: day(1)},
+		{ID: "other1", SeasonID: "s2", Date: day(8)},
+		{ID: "md2", SeasonID: "s1", Date: day(8)},
+	}
+
+	weeks := SeasonWeekNumbers(matchDays)
+	want := map[string]int{"md1": 1, "md2": 2, "md3": 3, "other1": 1}
+	for id, week := range want {
+		if weeks[id] != week {
+			t.Errorf("%s: week %d, want %d", id, weeks[id], week)
+		}
+	}
+}
+
+func TestScoresForWeek(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 0, 0, 0, 0, time.UTC) }
+	scores := []models.Score{
+		{ID: "w1", WeekNumber: 1, Date: day(1)},
+		{ID: "w2-early", WeekNumber: 2, Date: day(8)},
+		{ID: "w3", WeekNumber: 3, Date: day(15)},
+		{ID: "w2-late", WeekNumber: 2, Date: day(9)},
+		{ID: "legacy", Date: day(2)},
+	}
+
+	got := ScoresForWeek(scores, 2)
+	if len(got) != 2 || got[0].ID != "w2-late" || got[1].ID != "w2-early" {
+		t.Errorf("week 2 = %+v, want w2-late then w2-early", got)
+	}
+	if got := ScoresForWeek(scores, 4); len(got) != 0 {
+		t.Errorf("week 4 = %+v, want none", got)
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// ErrStandingsNotFinal means a season's standings don't match its results yet, so playoffs
+// can't be seeded from them
+var ErrStandingsNotFinal = errors.New("standings are not final")
+
+// SeedingDivergence is a player whose standings change when every result is scored afresh
+type SeedingDivergence struct {
+	PlayerID       string `json:"playerId"`
+	PlayerName     string `json:"playerName"`
+	Points         int    `json:"points"`
+	ExpectedPoints int    `json:"expectedPoints"` // Points with every result scored from its scores
+	MatchesPlayed  int    `json:"matchesPlayed"`
+	ExpectedPlayed int    `json:"expectedPlayed"`
+}
+
+// SeedingCheck compares a season's standings with standings recomputed from scratch. Ready is
+// true only when nothing is left unprocessed and no player's points or matches differ.
+type SeedingCheck struct {
+	Ready              bool                `json:"ready"`
+	UnprocessedMatches []string            `json:"unprocessedMatches"` // Both scores are in but the match was never scored
+	Diverged           []SeedingDivergence `json:"diverged"`
+}
+
+// CheckSeedingStandings recomputes a season's standings from scratch, scoring every match that
+// has both scores (scoresByMatch, keyed by match ID) from those scores, and compares them with
+// the standings from the matches' stored results. Disputed matches are left out of both, and
+// matches that can't be recomputed count as stored, as do matches whose points an admin
+// corrected (corrected, keyed by match ID), since a correction is the authoritative result.
+// A season on weekly position points is seeded from standings ranked afresh from each match
+// day's scores rather than from stored match results, so there is nothing to compare and it
+// is always ready.
+func CheckSeedingStandings(season models.Season, league models.League, roster []StandingsEntry, matches []models.Match, scoresByMatch map[string][]models.Score, corrected map[string]bool) (SeedingCheck, error) {
+	check := SeedingCheck{
+		UnprocessedMatches: make([]string, 0),
+		Diverged:           make([]SeedingDivergence, 0),
+	}
+	if len(season.WeeklyPointsTable) > 0 {
+		check.Ready = true
+		return check, nil
+	}
+
+	expected := make([]models.Match, len(matches))
+	for i, match := range matches {
+		expected[i] = match
+		if match.Disputed || corrected[match.ID] {
+			continue
+		}
+		pointsA, pointsB, ok, err := RecomputeMatchPoints(season, league, match, scoresByMatch[match.ID])
+		if err != nil {
+			return SeedingCheck{}, fmt.Errorf("match %s: %w", match.ID, err)
+		}
+		if !ok {
+			continue
+		}
+		if match.Status != "completed" {
+			check.UnprocessedMatches = append(check.UnprocessedMatches, match.ID)
+		}
+		expected[i].Status = "completed"
+		expected[i].PlayerAPoints = pointsA
+		expected[i].PlayerBPoints = pointsB
+	}
+
+	current := make(map[string]StandingsEntry, len(roster))
+	for _, entry := range ComputeStandings(roster, matches) {
+		current[entry.PlayerID] = entry
+	}
+	for _, entry := range ComputeStandings(roster, expected) {
+		stored := current[entry.PlayerID]
+		if stored.TotalPoints == entry.TotalPoints && stored.MatchesPlayed == entry.MatchesPlayed {
+			continue
+		}
+		check.Diverged = append(check.Diverged, SeedingDivergence{
+			PlayerID:       entry.PlayerID,
+			PlayerName:     entry.PlayerName,
+			Points:         stored.TotalPoints,
+			ExpectedPoints: entry.TotalPoints,
+			MatchesPlayed:  stored.MatchesPlayed,
+			ExpectedPlayed: entry.MatchesPlayed,
+		})
+	}
+
+	check.Ready = len(check.UnprocessedMatches) == 0 && len(check.Diverged) == 0
+	return check, nil
+}
+
+// SeedPlayoffs returns the top size players of the final standings as playoff seeds, in seed
+// order, refusing with ErrStandingsNotFinal when the seeding check found unprocessed or
+// inconsistent results. A size of zero or less seeds every ranked player.
+func SeedPlayoffs(check SeedingCheck, standings SeasonStandings, size int) ([]StandingsEntry, error) {
+	if !check.Ready {
+		return nil, fmt.Errorf("%w: %d unprocessed matches, %d players with diverging points",
+			ErrStandingsNotFinal, len(check.UnprocessedMatches), len(check.Diverged))
+	}
+	seeds := standings.Standings
+	if size > 0 && size < len(seeds) {
+		seeds = seeds[:size]
+	}
+	return seeds, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestSeedingRefusedUntilMatchProcessed(t *testing.T) {
+	roster := []StandingsEntry{
+		{PlayerID: "p1", PlayerName: "Alice"},
+		{PlayerID: "p2", PlayerName: "Bob"},
+	}
+	week1, week1Scores := pointsAuditMatch(t, "m1")
+	week2, week2Scores := pointsAuditMatch(t, "m2")
+	// Week 2's scores are in but the match was never scored
+	week2.Status = "scheduled"
+	week2.PlayerAPoints, week2.PlayerBPoints = 0, 0
+
+	matches := []models.Match{week1, week2}
+	scoresByMatch := map[string][]models.Score{"m1": week1Scores, "m2": week2Scores}
+	season := models.Season{ID: "s1"}
+
+	check, err := CheckSeedingStandings(season, models.League{}, roster, matches, scoresByMatch, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if check.Ready || len(check.UnprocessedMatches) != 1 || check.UnprocessedMatches[0] != "m2" {
+		t.Fatalf("check = %+v, want m2 reported as unprocessed", check)
+	}
+	if len(check.Diverged) != 2 {
+		t.Errorf("diverged = %+v, want both players", check.Diverged)
+	}
+	for _, d := range check.Diverged {
+		if d.MatchesPlayed != 1 || d.ExpectedPlayed != 2 {
+			t.Errorf("%s: played %d, expected %d, want 1 and 2", d.PlayerID, d.MatchesPlayed, d.ExpectedPlayed)
+		}
+	}
+
+	standings := QualifyStandings(ComputeStandings(roster, matches), 0)
+	if _, err := SeedPlayoffs(check, standings, 2); !errors.Is(err, ErrStandingsNotFinal) {
+		t.Errorf("err = %v, want ErrStandingsNotFinal", err)
+	}
+
+	// Once week 2 is processed the standings are final and the seeds follow them
+	byPlayer := map[string]models.Score{"p1": week2Scores[0], "p2": week2Scores[1]}
+	processed, ok, err := CompleteMatchWithRules(season, DefaultMatchPointRules, week2, byPlayer, week2Scores[0].MatchStrokes, week2Scores[1].MatchStrokes)
+	if err != nil || !ok {
+		t.Fatalf("failed to process week 2: ok = %v, err = %v", ok, err)
+	}
+	matches[1] = processed
+
+	check, err = CheckSeedingStandings(season, models.League{}, roster, matches, scoresByMatch, nil)
+	if err != nil || !check.Ready {
+		t.Fatalf("check = %+v, err = %v, want ready", check, err)
+	}
+	standings = QualifyStandings(ComputeStandings(roster, matches), 0)
+	seeds, err := SeedPlayoffs(check, standings, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seeds) != 1 || seeds[0].PlayerID != standings.Standings[0].PlayerID || seeds[0].MatchesPlayed != 2 {
+		t.Errorf("seeds = %+v, want the standings leader after 2 matches", seeds)
+	}
+}
+
+func TestSeedingIgnoresDisputedMatches(t *testing.T) {
+	roster := []StandingsEntry{{PlayerID: "p1", PlayerName: "Alice"}, {PlayerID: "p2", PlayerName: "Bob"}}
+	match, scores := pointsAuditMatch(t, "m1")
+	match.Status = "scheduled"
+	match.Disputed = true
+
+	check, err := CheckSeedingStandings(models.Season{}, models.League{}, roster, []models.Match{match}, map[string][]models.Score{"m1": scores}, nil)
+	if err != nil || !check.Ready {
+		t.Errorf("check = %+v, err = %v, want a disputed match left out", check, err)
+	}
+}
+
+func TestSeedingKeepsCorrectedPoints(t *testing.T) {
+	roster := []StandingsEntry{{PlayerID: "p1", PlayerName: "Alice"}, {PlayerID: "p2", PlayerName: "Bob"}}
+	match, scores := pointsAuditMatch(t, "m1")
+	// An admin moved two points from A to B after the match was scored
+	match.PlayerAPoints -= 2
+	match.PlayerBPoints += 2
+	scoresByMatch := map[string][]models.Score{"m1": scores}
+
+	check, err := CheckSeedingStandings(models.Season{}, models.League{}, roster, []models.Match{match}, scoresByMatch, nil)
+	if err != nil || check.Ready || len(check.Diverged) != 2 {
+		t.Fatalf("check = %+v, err = %v, want an uncorrected change reported", check, err)
+	}
+
+	corrected := CorrectedMatchIDs([]models.AuditEntry{
+		{Action: AuditActionMatchPointsCorrected, EntityType: "match", EntityID: "m1"},
+	})
+	check, err = CheckSeedingStandings(models.Season{}, models.League{}, roster, []models.Match{match}, scoresByMatch, corrected)
+	if err != nil || !check.Ready {
+		t.Errorf("check = %+v, err = %v, want the corrected points accepted", check, err)
+	}
+}
+
+func TestSeedingWeeklyPointsSeason(t *testing.T) {
+	roster := []StandingsEntry{{PlayerID: "p1", PlayerName: "Alice"}, {PlayerID: "p2", PlayerName: "Bob"}}
+	match, scores := pointsAuditMatch(t, "m1")
+	// An unprocessed match doesn't change position standings, which are ranked from the scores
+	match.Status = "scheduled"
+	match.PlayerAPoints, match.PlayerBPoints = 0, 0
+	season := models.Season{WeeklyPointsTable: []int{10, 7}}
+
+	check, err := CheckSeedingStandings(season, models.League{}, roster, []models.Match{match}, map[string][]models.Score{"m1": scores}, nil)
+	if err != nil || !check.Ready {
+		t.Errorf("check = %+v, err = %v, want a weekly points season ready", check, err)
+	}
+}
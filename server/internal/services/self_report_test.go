@@ -0,0 +1,88 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestSelfReportScore(t *testing.T) {
+	course := absentPreviewCourse()
+	course.ID = "c1"
+	match := models.Match{ID: "m1", LeagueID: "l1", CourseID: "c1", PlayerAID: "pA", PlayerAName: "Alice", PlayerBID: "pB", Status: "scheduled"}
+	seasonPlayers := map[string]models.SeasonPlayer{
+		"pA": {PlayerID: "pA", CurrentHandicapIndex: 12.4},
+		"pB": {PlayerID: "pB", PlayerName: "Bob", ProvisionalHandicap: 6.0},
+	}
+	league := models.League{}
+	season := models.Season{}
+
+	// First submitter: the score is built but the match waits for the opponent
+	first, err := SelfReportScore(season, league, match, course, seasonPlayers, nil, "pA",
+		ScoreCard{HoleScores: []int{5, 4, 6, 5, 4, 4, 6, 5, 5}}, models.MatchDay{PCC: 1}, false)
+	if err != nil {
+		t.Fatalf("first submitter: unexpected error: %v", err)
+	}
+	if first.Completed || first.Match.Status != "scheduled" || first.Match.PlayerAPoints != 0 {
+		t.Errorf("first submitter: match = %+v, want it still scheduled with no points", first.Match)
+	}
+	score := first.Score
+	if score.PlayerID != "pA" || score.PlayerName != "Alice" || score.LeagueID != "l1" || score.ID != "" || score.GrossScore != 44 {
+		t.Errorf("first score = %+v, want a new 44 for Alice in l1", score)
+	}
+	if score.HandicapIndex != 12.4 {
+		t.Errorf("first score index = %.1f, want the season index 12.4", score.HandicapIndex)
+	}
+	// The match day's playing conditions adjustment is applied to the differential
+	if want := CalculateLeagueDifferential(score, course, 1, DefaultStandardSlope); score.HandicapDifferential != want {
+		t.Errorf("differential = %.2f, want %.2f with PCC 1", score.HandicapDifferential, want)
+	}
+
+	// Second submitter: the opponent's saved score completes the match
+	saved := score
+	saved.ID = "s1"
+	existing := map[string]models.Score{"pA": saved}
+	second, err := SelfReportScore(season, league, match, course, seasonPlayers, existing, "pB",
+		ScoreCard{HoleScores: []int{4, 3, 5, 4, 5, 3, 5, 4, 4}}, models.MatchDay{PCC: 1}, false)
+	if err != nil {
+		t.Fatalf("second submitter: unexpected error: %v", err)
+	}
+	if !second.Completed || second.Match.Status != "completed" {
+		t.Fatalf("second submitter: match = %+v, want it completed", second.Match)
+	}
+	if second.Score.PlayerName != "Bob" || second.Score.HandicapIndex != 6.0 {
+		t.Errorf("second score = %s at %.1f, want Bob at his provisional 6.0", second.Score.PlayerName, second.Score.HandicapIndex)
+	}
+
+	handicapA, handicapB := MatchHandicaps(match, course, league, MatchPlayNet, 12.4, 6.0)
+	wantA, wantB, _ := ScoreMatchPointsWithRules(season, DefaultMatchPointRules, saved, second.Score, handicapA.Strokes, handicapB.Strokes)
+	if second.Match.PlayerAPoints != wantA || second.Match.PlayerBPoints != wantB || wantA+wantB != 22 {
+		t.Errorf("points = %d-%d, want %d-%d", second.Match.PlayerAPoints, second.Match.PlayerBPoints, wantA, wantB)
+	}
+
+	// A correction keeps the existing score's ID
+	existing["pB"] = models.Score{ID: "s2", PlayerID: "pB", HandicapIndex: 6.0}
+	corrected, err := SelfReportScore(season, league, match, course, seasonPlayers, existing, "pB",
+		ScoreCard{HoleScores: []int{4, 3, 5, 4, 5, 3, 5, 4, 5}}, models.MatchDay{PCC: 1}, false)
+	if err != nil || corrected.Score.ID != "s2" {
+		t.Errorf("correction: id = %q, err = %v, want s2", corrected.Score.ID, err)
+	}
+}
+
+func TestSelfReportScoreRejectsOutsider(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+
+	_, err := SelfReportScore(models.Season{}, models.League{}, match, course, nil, nil, "pC",
+		ScoreCard{HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}, models.MatchDay{}, false)
+	if !errors.Is(err, ErrNotMatchParticipant) {
+		t.Errorf("err = %v, want ErrNotMatchParticipant", err)
+	}
+
+	_, err = SelfReportScore(models.Season{}, models.League{}, match, course, nil, nil, "pA",
+		ScoreCard{HoleScores: []int{4, 3, 5}}, models.MatchDay{}, false)
+	if err == nil {
+		t.Error("expected an error for a card that doesn't match the course")
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+
+	"golf-league-manager/internal/models"
+)
+
+// MatchOutcome is the strokes and points a match produces for a given pair of handicaps
+type MatchOutcome struct {
+	HandicapIndexA   float64 `json:"handicapIndexA"`
+	HandicapIndexB   float64 `json:"handicapIndexB"`
+	PlayingHandicapA int     `json:"playingHandicapA"`
+	PlayingHandicapB int     `json:"playingHandicapB"`
+	StrokesA         []int   `json:"strokesA"`
+	StrokesB         []int   `json:"strokesB"`
+	PointsA          int     `json:"pointsA"`
+	PointsB          int     `json:"pointsB"`
+}
+
+// MatchSimulation compares a played match with the same gross scores replayed at a
+// hypothetical handicap index for one player
+type MatchSimulation struct {
+	MatchID           string       `json:"matchId"`
+	PlayerID          string       `json:"playerId"`
+	HypotheticalIndex float64      `json:"hypotheticalIndex"`
+	Actual            MatchOutcome `json:"actual"`
+	Simulated         MatchOutcome `json:"simulated"`
+}
+
+// SimulateMatch replays a match's stored hole scores with playerID carrying hypotheticalIndex
+// instead of the index recorded on their score. The opponent keeps their recorded index.
+// Actual is recomputed from the recorded indexes the same way, so the two outcomes differ only
+// by the hypothetical index. Matches with an absent player cannot be simulated, since absent
+// scores are themselves derived from the handicap. Points follow the league's match point
+// values and the season's rules, as in score entry. basis is the match day's match play basis;
+// on a gross day the index changes nothing, since nobody receives strokes.
+func SimulateMatch(match models.Match, scoreA, scoreB models.Score, course models.Course, league models.League, season models.Season, basis string, playerID string, hypotheticalIndex float64) (MatchSimulation, error) {
+	if playerID != match.PlayerAID && playerID != match.PlayerBID {
+		return MatchSimulation{}, fmt.Errorf("player %s is not in match %s", playerID, match.ID)
+	}
+	if scoreA.PlayerAbsent || scoreB.PlayerAbsent {
+		return MatchSimulation{}, fmt.Errorf("match %s has an absent player and cannot be simulated", match.ID)
+	}
+	if len(scoreA.HoleScores) == 0 || len(scoreA.HoleScores) != len(scoreB.HoleScores) {
+		return MatchSimulation{}, fmt.Errorf("match %s does not have complete hole scores for both players", match.ID)
+	}
+
+	indexA, indexB := scoreA.HandicapIndex, scoreB.HandicapIndex
+	actual, err := playMatch(match, scoreA, scoreB, course, league, season, basis, indexA, indexB)
+	if err != nil {
+		return MatchSimulation{}, err
+	}
+
+	if playerID == match.PlayerAID {
+		indexA = hypotheticalIndex
+	} else {
+		indexB = hypotheticalIndex
+	}
+	simulated, err := playMatch(match, scoreA, scoreB, course, league, season, basis, indexA, indexB)
+	if err != nil {
+		return MatchSimulation{}, err
+	}
+
+	return MatchSimulation{
+		MatchID:           match.ID,
+		PlayerID:          playerID,
+		HypotheticalIndex: hypotheticalIndex,
+		Actual:            actual,
+		Simulated:         simulated,
+	}, nil
+}
+
+// playMatch scores a match the way score entry does for the given handicap indexes
+func playMatch(match models.Match, scoreA, scoreB models.Score, course models.Course, league models.League, season models.Season, basis string, indexA, indexB float64) (MatchOutcome, error) {
+	_, playingHCA := CalculateCourseAndPlayingHandicapForLeague(indexA, course, league)
+	_, playingHCB := CalculateCourseAndPlayingHandicapForLeague(indexB, course, league)
+	strokes := AssignStrokesForBasis(basis, match.PlayerAID, playingHCA, match.PlayerBID, playingHCB, course)
+
+	outcome := MatchOutcome{
+		HandicapIndexA:   indexA,
+		HandicapIndexB:   indexB,
+		PlayingHandicapA: playingHCA,
+		PlayingHandicapB: playingHCB,
+		StrokesA:         strokes[match.PlayerAID],
+		StrokesB:         strokes[match.PlayerBID],
+	}
+	var err error
+	outcome.PointsA, outcome.PointsB, err = ScoreMatchPointsWithRules(season, LeagueMatchPointRules(league), scoreA, scoreB, outcome.StrokesA, outcome.StrokesB)
+	return outcome, err
+}
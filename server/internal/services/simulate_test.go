@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func sumStrokes(strokes []int) int {
+	total := 0
+	for _, s := range strokes {
+		total += s
+	}
+	return total
+}
+
+func TestSimulateMatchLowerIndex(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	// Player A shoots bogey on every hole, player B shoots par
+	scoreA := models.Score{PlayerID: "pA", HandicapIndex: 14.2, HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}}
+	scoreB := models.Score{PlayerID: "pB", HandicapIndex: 2.0, HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+
+	sim, err := SimulateMatch(match, scoreA, scoreB, course, models.League{}, models.Season{}, MatchPlayNet, "pA", 8.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Playing handicaps 14 and 2: A gets 12 strokes, nets par or better everywhere and wins
+	if got := sumStrokes(sim.Actual.StrokesA); got != 12 {
+		t.Errorf("actual strokes for A = %d, want 12", got)
+	}
+	if sim.Actual.PointsA != 16 || sim.Actual.PointsB != 6 {
+		t.Errorf("actual points = %d-%d, want 16-6", sim.Actual.PointsA, sim.Actual.PointsB)
+	}
+
+	// At 8.0 A plays off 8 and gets only 6 strokes, so the same round loses
+	if got := sumStrokes(sim.Simulated.StrokesA); got != 6 {
+		t.Errorf("simulated strokes for A = %d, want 6", got)
+	}
+	if sim.Simulated.PointsA != 6 || sim.Simulated.PointsB != 16 {
+		t.Errorf("simulated points = %d-%d, want 6-16", sim.Simulated.PointsA, sim.Simulated.PointsB)
+	}
+
+	// The opponent keeps the index recorded on their score
+	if sim.Simulated.HandicapIndexB != 2.0 || sim.Simulated.HandicapIndexA != 8.0 {
+		t.Errorf("simulated indexes = %.1f/%.1f, want 8.0/2.0", sim.Simulated.HandicapIndexA, sim.Simulated.HandicapIndexB)
+	}
+	if sumStrokes(sim.Simulated.StrokesB) != 0 {
+		t.Errorf("simulated strokes for B = %v, want none", sim.Simulated.StrokesB)
+	}
+}
+
+func TestSimulateMatchRecordedIndexReproducesActual(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	scoreA := models.Score{PlayerID: "pA", HandicapIndex: 14.2, HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}}
+	scoreB := models.Score{PlayerID: "pB", HandicapIndex: 2.0, HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+
+	sim, err := SimulateMatch(match, scoreA, scoreB, course, models.League{}, models.Season{}, MatchPlayNet, "pB", 2.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sim.Simulated.PointsA != sim.Actual.PointsA || sim.Simulated.PointsB != sim.Actual.PointsB {
+		t.Errorf("simulated %d-%d differs from actual %d-%d at the recorded index",
+			sim.Simulated.PointsA, sim.Simulated.PointsB, sim.Actual.PointsA, sim.Actual.PointsB)
+	}
+}
+
+func TestSimulateMatchRejected(t *testing.T) {
+	course := absentPreviewCourse()
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	scoreA := models.Score{PlayerID: "pA", HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5}}
+	scoreB := models.Score{PlayerID: "pB", HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4}}
+
+	if _, err := SimulateMatch(match, scoreA, scoreB, course, models.League{}, models.Season{}, MatchPlayNet, "pC", 5.0); err == nil {
+		t.Error("expected an error for a player not in the match")
+	}
+
+	absent := scoreB
+	absent.PlayerAbsent = true
+	if _, err := SimulateMatch(match, scoreA, absent, course, models.League{}, models.Season{}, MatchPlayNet, "pA", 5.0); err == nil {
+		t.Error("expected an error for a match with an absent player")
+	}
+}
+
+func TestSimulateMatchLeagueRules(t *testing.T) {
+	course := absentPreviewCourse()
+	course.HolePars = append(course.HolePars, course.HolePars...)
+	course.HoleHandicaps = []int{5, 17, 1, 9, 13, 15, 3, 7, 11, 6, 18, 2, 10, 14, 16, 4, 8, 12}
+	course.Par = 72
+	perHole, overall := 4, 6
+	league := models.League{MatchPointsPerHole: perHole, MatchOverallPoints: &overall}
+	match := models.Match{ID: "m1", PlayerAID: "pA", PlayerBID: "pB"}
+	scoreA := models.Score{PlayerID: "pA", HandicapIndex: 14.2, HoleScores: []int{5, 4, 6, 5, 5, 4, 6, 5, 5, 5, 4, 6, 5, 5, 4, 6, 5, 5}}
+	scoreB := models.Score{PlayerID: "pB", HandicapIndex: 2.0, HoleScores: []int{4, 3, 5, 4, 4, 3, 5, 4, 4, 4, 3, 5, 4, 4, 3, 5, 4, 4}}
+
+	sim, err := SimulateMatch(match, scoreA, scoreB, course, league, models.Season{}, MatchPlayNet, "pA", 8.0)
+	if err != nil {
+		t.Fatalf("unexpected error for an 18-hole match: %v", err)
+	}
+
+	// 18 holes at 4 points plus 6 overall
+	for name, outcome := range map[string]MatchOutcome{"actual": sim.Actual, "simulated": sim.Simulated} {
+		if total := outcome.PointsA + outcome.PointsB; total != 78 {
+			t.Errorf("%s points = %d-%d, want a total of 78", name, outcome.PointsA, outcome.PointsB)
+		}
+	}
+	if sim.Simulated.PointsA >= sim.Actual.PointsA {
+		t.Errorf("simulated points for A = %d, want fewer than the actual %d with fewer strokes", sim.Simulated.PointsA, sim.Actual.PointsA)
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"golf-league-manager/internal/models"
+)
+
+// StandingsEntry represents a single player's row in the standings
+type StandingsEntry struct {
+	Rank          int     `json:"rank"`
+	PlayerID      string  `json:"playerId"`
+	PlayerName    string  `json:"playerName"`
+	MatchesPlayed int     `json:"matchesPlayed"`
+	MatchesWon    int     `json:"matchesWon"`
+	MatchesLost   int     `json:"matchesLost"`
+	MatchesTied   int     `json:"matchesTied"`
+	TotalPoints   int     `json:"totalPoints"`
+	HandicapIndex float64 `json:"handicapIndex"`
+	Unranked      bool    `json:"unranked,omitempty"` // Played too few matches to qualify for the final standings
+}
+
+// SeasonStandings splits a season's standings into the players who qualified for a rank and
+// those who played too few matches to be ranked
+type SeasonStandings struct {
+	Standings []StandingsEntry `json:"standings"`
+	Unranked  []StandingsEntry `json:"unranked"`
+}
+
+// ComputeStandings tallies completed matches into ranked standings.
+// The roster supplies the players to rank (ID, name and index); match counters on the
+// roster entries are ignored. Matches with no points recorded or under dispute are
+// skipped, as are players not present in the roster.
+// Entries are ordered by total points, then matches won, then player name, and players
+// with equal points share a rank (1, 2, 2, 4).
+func ComputeStandings(roster []StandingsEntry, matches []models.Match) []StandingsEntry {
+	standingsMap := make(map[string]*StandingsEntry, len(roster))
+	for _, r := range roster {
+		standingsMap[r.PlayerID] = &StandingsEntry{
+			PlayerID:      r.PlayerID,
+			PlayerName:    r.PlayerName,
+			HandicapIndex: r.HandicapIndex,
+		}
+	}
+
+	for _, match := range matches {
+		if match.Status != "completed" || match.Disputed {
+			continue
+		}
+		if match.PlayerAPoints == 0 && match.PlayerBPoints == 0 {
+			continue
+		}
+
+		if entryA, ok := standingsMap[match.PlayerAID]; ok {
+			tallyMatch(entryA, match.PlayerAPoints, match.PlayerBPoints)
+		}
+		if entryB, ok := standingsMap[match.PlayerBID]; ok {
+			tallyMatch(entryB, match.PlayerBPoints, match.PlayerAPoints)
+		}
+	}
+
+	standings := make([]StandingsEntry, 0, len(standingsMap))
+	for _, entry := range standingsMap {
+		standings = append(standings, *entry)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].TotalPoints != standings[j].TotalPoints {
+			return standings[i].TotalPoints > standings[j].TotalPoints
+		}
+		if standings[i].MatchesWon != standings[j].MatchesWon {
+			return standings[i].MatchesWon > standings[j].MatchesWon
+		}
+		return standings[i].PlayerName < standings[j].PlayerName
+	})
+
+	rankStandings(standings)
+
+	return standings
+}
+
+// rankStandings assigns ranks to standings already in order, with players on equal points
+// sharing a rank
+func rankStandings(standings []StandingsEntry) {
+	for i := range standings {
+		if i > 0 && standings[i].TotalPoints == standings[i-1].TotalPoints {
+			standings[i].Rank = standings[i-1].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+}
+
+// ValidateMinMatchesForStandings checks a season's qualifying threshold for the final standings
+func ValidateMinMatchesForStandings(season models.Season) error {
+	if season.MinMatchesForStandings < 0 {
+		return fmt.Errorf("minMatchesForStandings cannot be negative")
+	}
+	return nil
+}
+
+// QualifyStandings separates players who played fewer than minMatches matches from the
+// ranked standings. Qualified players keep their order and are ranked again among
+// themselves; unranked players are flagged, keep their order and have no rank. A minMatches
+// of zero or less ranks everyone.
+func QualifyStandings(standings []StandingsEntry, minMatches int) SeasonStandings {
+	result := SeasonStandings{
+		Standings: make([]StandingsEntry, 0, len(standings)),
+		Unranked:  make([]StandingsEntry, 0),
+	}
+	for _, entry := range standings {
+		if entry.MatchesPlayed < minMatches {
+			entry.Rank = 0
+			entry.Unranked = true
+			result.Unranked = append(result.Unranked, entry)
+			continue
+		}
+		result.Standings = append(result.Standings, entry)
+	}
+	rankStandings(result.Standings)
+	return result
+}
+
+// tallyMatch records one match result on a standings entry
+func tallyMatch(entry *StandingsEntry, pointsFor, pointsAgainst int) {
+	entry.MatchesPlayed++
+	entry.TotalPoints += pointsFor
+	if pointsFor > pointsAgainst {
+		entry.MatchesWon++
+	} else if pointsFor < pointsAgainst {
+		entry.MatchesLost++
+	} else {
+		entry.MatchesTied++
+	}
+}
+
+// standingsCSVHeader is the header row for exported standings
+var standingsCSVHeader = []string{
+	"rank", "player", "matches_played", "matches_won", "matches_lost", "matches_tied", "points", "handicap_index",
+}
+
+// WriteStandingsCSV writes standings as CSV with a header row, preserving the given order.
+// Unranked players are written with an empty rank.
+func WriteStandingsCSV(w io.Writer, standings []StandingsEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(standingsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, entry := range standings {
+		rank := strconv.Itoa(entry.Rank)
+		if entry.Unranked {
+			rank = ""
+		}
+		record := []string{
+			rank,
+			entry.PlayerName,
+			strconv.Itoa(entry.MatchesPlayed),
+			strconv.Itoa(entry.MatchesWon),
+			strconv.Itoa(entry.MatchesLost),
+			strconv.Itoa(entry.MatchesTied),
+			strconv.Itoa(entry.TotalPoints),
+			strconv.FormatFloat(entry.HandicapIndex, 'f', 1, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func standingsFixture() ([]StandingsEntry, []models.Match) {
+	roster := []StandingsEntry{
+		{PlayerID: "p1", PlayerName: "Alice", HandicapIndex: 8.4},
+		{PlayerID: "p2", PlayerName: "Bob", HandicapIndex: 12.1},
+		{PlayerID: "p3", PlayerName: "Smith, \"Carl\"", HandicapIndex: 15.0},
+		{PlayerID: "p4", PlayerName: "Dana", HandicapIndex: 20.3},
+	}
+
+	matches := []models.Match{
+		{ID: "m1", PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 15, PlayerBPoints: 7},
+		{ID: "m2", PlayerAID: "p3", PlayerBID: "p4", Status: "completed", PlayerAPoints: 11, PlayerBPoints: 11},
+		{ID: "m3", PlayerAID: "p1", PlayerBID: "p3", Status: "completed", PlayerAPoints: 6, PlayerBPoints: 16},
+		{ID: "m4", PlayerAID: "p2", PlayerBID: "p4", Status: "completed", PlayerAPoints: 14, PlayerBPoints: 8},
+		// Scheduled match must not count
+		{ID: "m5", PlayerAID: "p1", PlayerBID: "p4", Status: "scheduled"},
+	}
+
+	return roster, matches
+}
+
+func TestComputeStandings(t *testing.T) {
+	roster, matches := standingsFixture()
+
+	standings := ComputeStandings(roster, matches)
+
+	// Totals: Carl 27 (W1 T1), Bob 21 (W1 L1), Alice 21 (W1 L1), Dana 19 (L1 T1)
+	// Bob and Alice are tied on points and wins, so name breaks the tie
+	wantOrder := []string{"p3", "p1", "p2", "p4"}
+	wantRanks := []int{1, 2, 2, 4}
+	wantPoints := []int{27, 21, 21, 19}
+
+	if len(standings) != len(wantOrder) {
+		t.Fatalf("got %d entries, want %d", len(standings), len(wantOrder))
+	}
+
+	for i, entry := range standings {
+		if entry.PlayerID != wantOrder[i] {
+			t.Errorf("position %d: got player %s, want %s", i, entry.PlayerID, wantOrder[i])
+		}
+		if entry.Rank != wantRanks[i] {
+			t.Errorf("position %d: got rank %d, want %d", i, entry.Rank, wantRanks[i])
+		}
+		if entry.TotalPoints != wantPoints[i] {
+			t.Errorf("position %d: got points %d, want %d", i, entry.TotalPoints, wantPoints[i])
+		}
+		if entry.MatchesPlayed != 2 {
+			t.Errorf("player %s: got %d matches played, want 2", entry.PlayerID, entry.MatchesPlayed)
+		}
+	}
+
+	carl := standings[0]
+	if carl.MatchesWon != 1 || carl.MatchesTied != 1 || carl.MatchesLost != 0 {
+		t.Errorf("Carl record = %d-%d-%d, want 1-0-1", carl.MatchesWon, carl.MatchesLost, carl.MatchesTied)
+	}
+	if carl.HandicapIndex != 15.0 {
+		t.Errorf("Carl index = %.1f, want 15.0", carl.HandicapIndex)
+	}
+}
+
+func TestWriteStandingsCSV(t *testing.T) {
+	roster, matches := standingsFixture()
+	standings := ComputeStandings(roster, matches)
+
+	var buf bytes.Buffer
+	if err := WriteStandingsCSV(&buf, standings); err != nil {
+		t.Fatalf("WriteStandingsCSV returned error: %v", err)
+	}
+
+	// Names containing commas and quotes must be escaped
+	if !bytes.Contains(buf.Bytes(), []byte(`"Smith, ""Carl"""`)) {
+		t.Errorf("expected escaped player name in CSV output, got:\n%s", buf.String())
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV output: %v", err)
+	}
+
+	if len(records) != len(standings)+1 {
+		t.Fatalf("got %d CSV records, want %d (header + rows)", len(records), len(standings)+1)
+	}
+
+	header := records[0]
+	for i, col := range standingsCSVHeader {
+		if header[i] != col {
+			t.Errorf("header column %d = %q, want %q", i, header[i], col)
+		}
+	}
+
+	// Rows must match the JSON standings in content and order
+	for i, entry := range standings {
+		row := records[i+1]
+		if row[0] != strconv.Itoa(entry.Rank) {
+			t.Errorf("row %d rank = %s, want %d", i, row[0], entry.Rank)
+		}
+		if row[1] != entry.PlayerName {
+			t.Errorf("row %d player = %q, want %q", i, row[1], entry.PlayerName)
+		}
+		if row[2] != strconv.Itoa(entry.MatchesPlayed) || row[3] != strconv.Itoa(entry.MatchesWon) ||
+			row[4] != strconv.Itoa(entry.MatchesLost) || row[5] != strconv.Itoa(entry.MatchesTied) {
+			t.Errorf("row %d record = %v, want %d/%d/%d/%d", i, row[2:6],
+				entry.MatchesPlayed, entry.MatchesWon, entry.MatchesLost, entry.MatchesTied)
+		}
+		if row[6] != strconv.Itoa(entry.TotalPoints) {
+			t.Errorf("row %d points = %s, want %d", i, row[6], entry.TotalPoints)
+		}
+		if row[7] != strconv.FormatFloat(entry.HandicapIndex, 'f', 1, 64) {
+			t.Errorf("row %d index = %s, want %.1f", i, row[7], entry.HandicapIndex)
+		}
+	}
+}
+
+func TestQualifyStandingsMinMatches(t *testing.T) {
+	roster := []StandingsEntry{
+		{PlayerID: "p1", PlayerName: "Alice"},
+		{PlayerID: "p2", PlayerName: "Bob"},
+		{PlayerID: "p3", PlayerName: "Carl"},
+	}
+
+	// Alice and Bob play all ten weeks; Carl only shows up for two and wins both big
+	var matches []models.Match
+	for week := 1; week <= 10; week++ {
+		id := "m" + strconv.Itoa(week)
+		matches = append(matches, models.Match{ID: id, PlayerAID: "p1", PlayerBID: "p2", Status: "completed", PlayerAPoints: 12, PlayerBPoints: 10})
+	}
+	for week := 1; week <= 2; week++ {
+		id := "c" + strconv.Itoa(week)
+		matches = append(matches, models.Match{ID: id, PlayerAID: "p3", PlayerBID: "p2", Status: "completed", PlayerAPoints: 22, PlayerBPoints: 0})
+	}
+
+	standings := ComputeStandings(roster, matches)
+
+	qualified := QualifyStandings(standings, 8)
+	if len(qualified.Standings) != 2 || len(qualified.Unranked) != 1 {
+		t.Fatalf("got %d ranked and %d unranked, want 2 and 1", len(qualified.Standings), len(qualified.Unranked))
+	}
+	carl := qualified.Unranked[0]
+	if carl.PlayerID != "p3" || !carl.Unranked || carl.Rank != 0 || carl.MatchesPlayed != 2 {
+		t.Errorf("unranked = %+v, want Carl flagged with no rank after 2 matches", carl)
+	}
+	if qualified.Standings[0].PlayerID != "p1" || qualified.Standings[0].Rank != 1 || qualified.Standings[1].Rank != 2 {
+		t.Errorf("ranked = %+v, want Alice 1st and Bob 2nd", qualified.Standings)
+	}
+	for _, entry := range qualified.Standings {
+		if entry.Unranked {
+			t.Errorf("%s should be ranked", entry.PlayerID)
+		}
+	}
+
+	everyone := QualifyStandings(standings, 0)
+	if len(everyone.Standings) != 3 || len(everyone.Unranked) != 0 || everyone.Standings[0].PlayerID != "p1" {
+		t.Errorf("no minimum: got %+v, want all three ranked", everyone)
+	}
+}
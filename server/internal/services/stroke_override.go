@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+// AuditActionStrokesOverridden is recorded when a match is played off strokes the players
+// agreed on instead of the computed allocation
+const AuditActionStrokesOverridden = "match_strokes_overridden"
+
+// MaxOverrideStrokesPerHole is the most strokes an override may give on a single hole
+const MaxOverrideStrokesPerHole = 2
+
+// ValidateStrokeOverride checks an agreed stroke allocation for a match, keyed by player ID
+// with the strokes received on each hole. Only the match's players may appear, each list must
+// cover every hole with 0 to MaxOverrideStrokesPerHole strokes, and as in any match only one
+// player may receive strokes. A player left out receives none, so {} plays the match even.
+func ValidateStrokeOverride(match models.Match, holes int, override map[string][]int) error {
+	receiving := 0
+	for playerID, strokes := range override {
+		if playerID != match.PlayerAID && playerID != match.PlayerBID {
+			return fmt.Errorf("player %s is not in match %s", playerID, match.ID)
+		}
+		if len(strokes) != holes {
+			return fmt.Errorf("strokes for player %s must cover %d holes, got %d", playerID, holes, len(strokes))
+		}
+		total := 0
+		for i, s := range strokes {
+			if s < 0 || s > MaxOverrideStrokesPerHole {
+				return fmt.Errorf("strokes for player %s on hole %d must be between 0 and %d, got %d", playerID, i+1, MaxOverrideStrokesPerHole, s)
+			}
+			total += s
+		}
+		if total > 0 {
+			receiving++
+		}
+	}
+	if receiving > 1 {
+		return fmt.Errorf("only one player may receive strokes in a match")
+	}
+	return nil
+}
+
+// ApplyStrokeOverride replaces both players' computed strokes with the agreed allocation.
+// Handicaps are left as they are, so net scores and differentials are unaffected; only the
+// match play result changes.
+func ApplyStrokeOverride(match models.Match, holes int, override map[string][]int, a, b *PlayerMatchHandicap) {
+	a.Strokes = overrideStrokes(override[match.PlayerAID], holes)
+	b.Strokes = overrideStrokes(override[match.PlayerBID], holes)
+}
+
+// overrideStrokes copies one player's agreed strokes, or none when they receive no strokes
+func overrideStrokes(strokes []int, holes int) []int {
+	result := make([]int, holes)
+	copy(result, strokes)
+	return result
+}
+
+// RecordStrokeOverride marks a match as played off an agreed allocation, so the second
+// player's card is scored off the same strokes as the first
+func RecordStrokeOverride(match models.Match, override map[string][]int) models.Match {
+	match.StrokesOverridden = true
+	match.StrokeOverride = make(map[string][]int, len(override))
+	for playerID, strokes := range override {
+		match.StrokeOverride[playerID] = append([]int(nil), strokes...)
+	}
+	return match
+}
+
+// StrokeOverrideAuditEntry records the computed and agreed strokes for a match; the caller
+// assigns the entry's ID
+func StrokeOverrideAuditEntry(match models.Match, computedA, computedB, agreedA, agreedB []int, actorID string, now time.Time) models.AuditEntry {
+	return models.AuditEntry{
+		LeagueID:   match.LeagueID,
+		Action:     AuditActionStrokesOverridden,
+		EntityType: "match",
+		EntityID:   match.ID,
+		ActorID:    actorID,
+		Before:     fmt.Sprintf("%s:%v %s:%v", match.PlayerAID, computedA, match.PlayerBID, computedB),
+		After:      fmt.Sprintf("%s:%v %s:%v", match.PlayerAID, agreedA, match.PlayerBID, agreedB),
+		CreatedAt:  now,
+	}
+}
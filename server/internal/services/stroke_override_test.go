@@ -0,0 +1,98 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestValidateStrokeOverride(t *testing.T) {
+	match := models.Match{ID: "m1", PlayerAID: "p1", PlayerBID: "p2"}
+	tests := []struct {
+		name     string
+		override map[string][]int
+		wantErr  bool
+	}{
+		{"play even", map[string][]int{}, false},
+		{"one player receives", map[string][]int{"p2": {1, 0, 1, 0, 0, 0, 1, 0, 0}}, false},
+		{"both listed, one receives", map[string][]int{"p1": make([]int, 9), "p2": {2, 0, 0, 0, 0, 0, 0, 0, 0}}, false},
+		{"unknown player", map[string][]int{"p3": make([]int, 9)}, true},
+		{"wrong hole count", map[string][]int{"p1": make([]int, 18)}, true},
+		{"negative strokes", map[string][]int{"p1": {-1, 0, 0, 0, 0, 0, 0, 0, 0}}, true},
+		{"too many on a hole", map[string][]int{"p1": {3, 0, 0, 0, 0, 0, 0, 0, 0}}, true},
+		{"both receive", map[string][]int{"p1": {1, 0, 0, 0, 0, 0, 0, 0, 0}, "p2": {0, 1, 0, 0, 0, 0, 0, 0, 0}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStrokeOverride(match, 9, tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStrokeOverrideChangesMatchPoints(t *testing.T) {
+	computed, scores := pointsAuditMatch(t, "m1")
+	byPlayer := map[string]models.Score{"p1": scores[0], "p2": scores[1]}
+
+	// The computed allocation gives p2 a stroke on the first hole; the players agree to play even
+	handicapA := PlayerMatchHandicap{Strokes: scores[0].MatchStrokes}
+	handicapB := PlayerMatchHandicap{Strokes: scores[1].MatchStrokes}
+	match := models.Match{ID: "m1", LeagueID: "l1", SeasonID: "s1", PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"}
+	override := map[string][]int{}
+	if err := ValidateStrokeOverride(match, 9, override); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ApplyStrokeOverride(match, 9, override, &handicapA, &handicapB)
+	if len(handicapB.Strokes) != 9 || handicapB.Strokes[0] != 0 {
+		t.Fatalf("p2 strokes = %v, want none", handicapB.Strokes)
+	}
+
+	even, ok, err := CompleteMatchWithRules(models.Season{}, DefaultMatchPointRules, match, byPlayer, handicapA.Strokes, handicapB.Strokes)
+	if err != nil || !ok {
+		t.Fatalf("failed to complete match: ok = %v, err = %v", ok, err)
+	}
+	if even.PlayerAPoints <= computed.PlayerAPoints {
+		t.Errorf("playing even gave p1 %d points, want more than the %d from the computed strokes", even.PlayerAPoints, computed.PlayerAPoints)
+	}
+}
+
+func TestRecordStrokeOverride(t *testing.T) {
+	match := models.Match{ID: "m1", LeagueID: "l1", PlayerAID: "p1", PlayerBID: "p2"}
+	agreed := []int{1, 0, 0, 0, 0, 0, 0, 0, 0}
+	recorded := RecordStrokeOverride(match, map[string][]int{"p2": agreed})
+	agreed[0] = 2
+
+	if !recorded.StrokesOverridden || recorded.StrokeOverride["p2"][0] != 1 {
+		t.Errorf("recorded = %+v, want the override copied onto the match", recorded)
+	}
+	if match.StrokesOverridden {
+		t.Error("input match should not be modified")
+	}
+
+	// A card entered later is scored off the recorded strokes
+	a := PlayerMatchHandicap{Strokes: make([]int, 9)}
+	b := PlayerMatchHandicap{Strokes: []int{0, 0, 1, 0, 0, 0, 0, 0, 0}}
+	ApplyStrokeOverride(recorded, 9, recorded.StrokeOverride, &a, &b)
+	if b.Strokes[0] != 1 || b.Strokes[2] != 0 {
+		t.Errorf("p2 strokes = %v, want the recorded override", b.Strokes)
+	}
+}
+
+func TestStrokeOverrideAuditEntry(t *testing.T) {
+	match := models.Match{ID: "m1", LeagueID: "l1", PlayerAID: "p1", PlayerBID: "p2"}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	computedB := []int{1, 0, 0}
+	entry := StrokeOverrideAuditEntry(match, make([]int, 3), computedB, make([]int, 3), make([]int, 3), "admin", now)
+
+	if entry.Action != AuditActionStrokesOverridden || entry.EntityType != "match" || entry.EntityID != "m1" ||
+		entry.LeagueID != "l1" || entry.ActorID != "admin" || !entry.CreatedAt.Equal(now) {
+		t.Errorf("entry = %+v, want a stroke override of m1 by admin", entry)
+	}
+	if !strings.Contains(entry.Before, "p2:[1 0 0]") || !strings.Contains(entry.After, "p2:[0 0 0]") {
+		t.Errorf("before = %q, after = %q, want p2's stroke removed", entry.Before, entry.After)
+	}
+}
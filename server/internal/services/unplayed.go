@@ -0,0 +1,43 @@
+package services
+
+import (
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// UnplayedMatches returns a season's matches that are still scheduled, oldest first, so admins
+// can chase them down. Matches on the same date are grouped by match day. Player names missing
+// from a match are filled in from the season's players.
+func UnplayedMatches(matches []models.Match, seasonPlayers []models.SeasonPlayer) []models.Match {
+	names := make(map[string]string, len(seasonPlayers))
+	for _, sp := range seasonPlayers {
+		names[sp.PlayerID] = sp.PlayerName
+	}
+
+	unplayed := make([]models.Match, 0)
+	for _, match := range matches {
+		if match.Status != "scheduled" {
+			continue
+		}
+		if match.PlayerAName == "" {
+			match.PlayerAName = names[match.PlayerAID]
+		}
+		if match.PlayerBName == "" {
+			match.PlayerBName = names[match.PlayerBID]
+		}
+		unplayed = append(unplayed, match)
+	}
+
+	sort.SliceStable(unplayed, func(i, j int) bool {
+		if !unplayed[i].MatchDate.Equal(unplayed[j].MatchDate) {
+			return unplayed[i].MatchDate.Before(unplayed[j].MatchDate)
+		}
+		if unplayed[i].MatchDayID != unplayed[j].MatchDayID {
+			return unplayed[i].MatchDayID < unplayed[j].MatchDayID
+		}
+		return unplayed[i].PlayerAName < unplayed[j].PlayerAName
+	})
+
+	return unplayed
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestUnplayedMatchesAcrossWeeks(t *testing.T) {
+	week1 := time.Date(2025, 5, 1, 18, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week1.AddDate(0, 0, 14)
+
+	seasonPlayers := []models.SeasonPlayer{
+		{PlayerID: "p1", PlayerName: "Alice"},
+		{PlayerID: "p2", PlayerName: "Bob"},
+		{PlayerID: "p3", PlayerName: "Cara"},
+		{PlayerID: "p4", PlayerName: "Dan"},
+	}
+	matches := []models.Match{
+		{ID: "m5", MatchDayID: "md3", MatchDate: week3, PlayerAID: "p1", PlayerBID: "p2", Status: "scheduled"},
+		{ID: "m1", MatchDayID: "md1", MatchDate: week1, PlayerAID: "p1", PlayerBID: "p2", Status: "completed"},
+		{ID: "m2", MatchDayID: "md1", MatchDate: week1, PlayerAID: "p3", PlayerAName: "Cara", PlayerBID: "p4", Status: "scheduled"},
+		{ID: "m3", MatchDayID: "md2", MatchDate: week2, PlayerAID: "p1", PlayerBID: "p3", Status: "completed"},
+		{ID: "m4", MatchDayID: "md2", MatchDate: week2, PlayerAID: "p2", PlayerBID: "p4", Status: "scheduled"},
+	}
+
+	unplayed := UnplayedMatches(matches, seasonPlayers)
+
+	wantIDs := []string{"m2", "m4", "m5"}
+	if len(unplayed) != len(wantIDs) {
+		t.Fatalf("got %d unplayed matches, want %d: %+v", len(unplayed), len(wantIDs), unplayed)
+	}
+	for i, id := range wantIDs {
+		if unplayed[i].ID != id {
+			t.Errorf("unplayed[%d] = %s, want %s", i, unplayed[i].ID, id)
+		}
+		if unplayed[i].Status != "scheduled" {
+			t.Errorf("%s: status = %s, want scheduled", unplayed[i].ID, unplayed[i].Status)
+		}
+	}
+	if unplayed[0].PlayerAName != "Cara" || unplayed[0].PlayerBName != "Dan" {
+		t.Errorf("m2 players = %q vs %q, want Cara vs Dan", unplayed[0].PlayerAName, unplayed[0].PlayerBName)
+	}
+	if unplayed[1].PlayerAName != "Bob" || unplayed[1].MatchDayID != "md2" || !unplayed[1].MatchDate.Equal(week2) {
+		t.Errorf("m4 = %+v, want Bob on md2 in week 2", unplayed[1])
+	}
+}
+
+func TestUnplayedMatchesAllPlayed(t *testing.T) {
+	matches := []models.Match{{ID: "m1", Status: "completed"}}
+	if unplayed := UnplayedMatches(matches, nil); unplayed == nil || len(unplayed) != 0 {
+		t.Errorf("unplayed = %v, want an empty list", unplayed)
+	}
+}
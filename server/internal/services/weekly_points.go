@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"golf-league-manager/internal/models"
+)
+
+// ValidateWeeklyPointsTable checks a season's position points table. An empty table is
+// accepted and leaves the season on match points.
+func ValidateWeeklyPointsTable(season models.Season) error {
+	for i, points := range season.WeeklyPointsTable {
+		if points < 0 {
+			return fmt.Errorf("weeklyPointsTable position %d cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// ComputeWeeklyPositionPoints ranks the players who played a match day by raw total net score,
+// lowest first, and awards each the table's points for their finishing position. Tied players
+// share the points of the positions they span, averaged and rounded half up, so two players tied
+// for first on a 10, 7 table get 9 each and the next player is third. Positions past the end of
+// the table score nothing. Absent players and scores from disputed matches are left out, as
+// disputed matches are in match point standings; every other player who played is in the
+// result, even with no points.
+func ComputeWeeklyPositionPoints(matchDayScores []models.Score, disputed map[string]bool, table []int) map[string]int {
+	played := make([]models.Score, 0, len(matchDayScores))
+	for _, score := range matchDayScores {
+		if !score.PlayerAbsent && !disputed[score.MatchID] {
+			played = append(played, score)
+		}
+	}
+	sort.SliceStable(played, func(i, j int) bool {
+		return played[i].NetScore < played[j].NetScore
+	})
+
+	positionPoints := func(position int) int {
+		if position < len(table) {
+			return table[position]
+		}
+		return 0
+	}
+
+	points := make(map[string]int, len(played))
+	for start := 0; start < len(played); {
+		end := start + 1
+		for end < len(played) && played[end].NetScore == played[start].NetScore {
+			end++
+		}
+		total := 0
+		for position := start; position < end; position++ {
+			total += positionPoints(position)
+		}
+		tied := end - start
+		share := (2*total + tied) / (2 * tied) // Average, rounded half up
+		for _, score := range played[start:end] {
+			points[score.PlayerID] = share
+		}
+		start = end
+	}
+	return points
+}
+
+// ComputePositionStandings sums each week's position points into ranked standings. The roster
+// supplies the players to rank as in ComputeStandings; a week a player has points for, even
+// zero, counts as played, and a week they finished on top of the field counts as won.
+func ComputePositionStandings(roster []StandingsEntry, weeks []map[string]int) []StandingsEntry {
+	standingsMap := make(map[string]*StandingsEntry, len(roster))
+	for _, r := range roster {
+		standingsMap[r.PlayerID] = &StandingsEntry{
+			PlayerID:      r.PlayerID,
+			PlayerName:    r.PlayerName,
+			HandicapIndex: r.HandicapIndex,
+		}
+	}
+
+	for _, week := range weeks {
+		best := -1
+		for _, points := range week {
+			if points > best {
+				best = points
+			}
+		}
+		for playerID, points := range week {
+			entry, ok := standingsMap[playerID]
+			if !ok {
+				continue
+			}
+			entry.MatchesPlayed++
+			entry.TotalPoints += points
+			if points == best && points > 0 {
+				entry.MatchesWon++
+			}
+		}
+	}
+
+	standings := make([]StandingsEntry, 0, len(standingsMap))
+	for _, entry := range standingsMap {
+		standings = append(standings, *entry)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].TotalPoints != standings[j].TotalPoints {
+			return standings[i].TotalPoints > standings[j].TotalPoints
+		}
+		if standings[i].MatchesWon != standings[j].MatchesWon {
+			return standings[i].MatchesWon > standings[j].MatchesWon
+		}
+		return standings[i].PlayerName < standings[j].PlayerName
+	})
+	rankStandings(standings)
+
+	return standings
+}
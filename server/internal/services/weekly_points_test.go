@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+
+	"golf-league-manager/internal/models"
+)
+
+func TestComputeWeeklyPositionPoints(t *testing.T) {
+	table := []int{10, 7, 5, 3}
+	scores := []models.Score{
+		{PlayerID: "p1", NetScore: 70},
+		{PlayerID: "p2", NetScore: 68},
+		{PlayerID: "p3", NetScore: 72},
+		{PlayerID: "p4", NetScore: 75},
+		{PlayerID: "p5", NetScore: 77},
+		{PlayerID: "p6", NetScore: 60, PlayerAbsent: true},
+	}
+
+	got := ComputeWeeklyPositionPoints(scores, nil, table)
+	want := map[string]int{"p2": 10, "p1": 7, "p3": 5, "p4": 3, "p5": 0}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for playerID, points := range want {
+		if got[playerID] != points {
+			t.Errorf("%s: got %d points, want %d", playerID, got[playerID], points)
+		}
+	}
+}
+
+func TestComputeWeeklyPositionPointsTiesSharePositions(t *testing.T) {
+	table := []int{10, 7, 5, 3}
+	tests := []struct {
+		name   string
+		scores []models.Score
+		want   map[string]int
+	}{
+		{
+			name: "two tied for first share first and second",
+			scores: []models.Score{
+				{PlayerID: "p1", NetScore: 68},
+				{PlayerID: "p2", NetScore: 68},
+				{PlayerID: "p3", NetScore: 71},
+			},
+			// (10+7)/2 = 8.5 rounds up; the next player is third
+			want: map[string]int{"p1": 9, "p2": 9, "p3": 5},
+		},
+		{
+			name: "three tied in the middle",
+			scores: []models.Score{
+				{PlayerID: "p1", NetScore: 66},
+				{PlayerID: "p2", NetScore: 70},
+				{PlayerID: "p3", NetScore: 70},
+				{PlayerID: "p4", NetScore: 70},
+			},
+			want: map[string]int{"p1": 10, "p2": 5, "p3": 5, "p4": 5},
+		},
+		{
+			name: "tie running past the table",
+			scores: []models.Score{
+				{PlayerID: "p1", NetScore: 66},
+				{PlayerID: "p2", NetScore: 67},
+				{PlayerID: "p3", NetScore: 68},
+				{PlayerID: "p4", NetScore: 69},
+				{PlayerID: "p5", NetScore: 69},
+			},
+			// Fourth and fifth share 3 and nothing
+			want: map[string]int{"p1": 10, "p2": 7, "p3": 5, "p4": 2, "p5": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeWeeklyPositionPoints(tt.scores, nil, table)
+			for playerID, points := range tt.want {
+				if got[playerID] != points {
+					t.Errorf("%s: got %d points, want %d", playerID, got[playerID], points)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeWeeklyPositionPointsSkipsDisputedMatches(t *testing.T) {
+	table := []int{10, 7, 5}
+	scores := []models.Score{
+		{PlayerID: "p1", MatchID: "m1", NetScore: 66},
+		{PlayerID: "p2", MatchID: "m1", NetScore: 70},
+		{PlayerID: "p3", MatchID: "m2", NetScore: 68},
+		{PlayerID: "p4", MatchID: "m2", NetScore: 71},
+	}
+
+	got := ComputeWeeklyPositionPoints(scores, map[string]bool{"m1": true}, table)
+	want := map[string]int{"p3": 10, "p4": 7}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for playerID, points := range want {
+		if got[playerID] != points {
+			t.Errorf("%s: got %d points, want %d", playerID, got[playerID], points)
+		}
+	}
+}
+
+func TestComputePositionStandings(t *testing.T) {
+	roster := []StandingsEntry{
+		{PlayerID: "p1", PlayerName: "Alice"},
+		{PlayerID: "p2", PlayerName: "Bob"},
+		{PlayerID: "p3", PlayerName: "Cara"},
+	}
+	weeks := []map[string]int{
+		{"p1": 10, "p2": 7, "p3": 5},
+		{"p1": 5, "p2": 10, "p9": 7},
+	}
+
+	standings := ComputePositionStandings(roster, weeks)
+	if standings[0].PlayerID != "p2" || standings[0].TotalPoints != 17 || standings[0].MatchesWon != 1 {
+		t.Errorf("leader = %+v, want Bob on 17 with a week won", standings[0])
+	}
+	if standings[1].PlayerID != "p1" || standings[1].TotalPoints != 15 || standings[1].MatchesPlayed != 2 {
+		t.Errorf("second = %+v, want Alice on 15 over 2 weeks", standings[1])
+	}
+	if standings[2].PlayerID != "p3" || standings[2].MatchesPlayed != 1 || standings[2].Rank != 3 {
+		t.Errorf("third = %+v, want Cara ranked 3rd with 1 week played", standings[2])
+	}
+}
+
+func TestValidateWeeklyPointsTable(t *testing.T) {
+	if err := ValidateWeeklyPointsTable(models.Season{WeeklyPointsTable: []int{10, 7, 5}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateWeeklyPointsTable(models.Season{WeeklyPointsTable: []int{10, -1}}); err == nil {
+		t.Error("expected an error for negative points")
+	}
+}